@@ -1,11 +1,15 @@
 package main
 
 import (
+	"context"
 	"os"
 	"peluqueria/database"
 	_ "peluqueria/docs"
 	"peluqueria/internal/models"
+	"peluqueria/internal/notifications"
 	"peluqueria/internal/routes"
+	"peluqueria/internal/services"
+	"peluqueria/internal/storage"
 	"peluqueria/logger"
 
 	"github.com/joho/godotenv"
@@ -57,10 +61,21 @@ func main() {
 	database.InitializeDatabase()
 	logger.Log.Info("Base de datos inicializada correctamente")
 
+	// Fotos de los turnos
+	services.UsePhotoStorage(storage.FromEnv())
+
 	e := echo.New()
+	// La IP del cliente es la de la conexión; X-Forwarded-For lo puede enviar cualquiera y
+	// permitiría saltear el límite de solicitudes de las rutas públicas
+	e.IPExtractor = echo.ExtractIPDirect()
 	routes.RegisterRoutes(e)
 	logger.Log.Info("Rutas registradas correctamente")
 
+	// Worker de recordatorios, vive mientras vive el servidor
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	services.StartNotificationWorker(ctx, notifications.FromEnv())
+
 	// Arrancar el servidor
 	port := ":8080"
 	logger.Log.Infof("Servidor iniciado en %s", port)
@@ -84,6 +99,42 @@ func runMigrations() {
 		&models.Permission{},
 		&models.RolePermission{},
 		&models.StockMovement{},
+		&models.Staff{},
+		&models.BusinessHour{},
+		&models.Closure{},
+		&models.AppointmentStatusHistory{},
+		&models.AppointmentSeries{},
+		&models.WaitlistEntry{},
+		&models.WaitlistMatch{},
+		&models.BookingConfirmation{},
+		&models.CalendarFeed{},
+		&models.Notification{},
+		&models.WalkIn{},
+		&models.WalkInSequence{},
+		&models.Resource{},
+		&models.ServiceResource{},
+		&models.AppointmentResource{},
+		&models.StaffShift{},
+		&models.StaffScheduleException{},
+		&models.TimeOff{},
+		&models.StaffService{},
+		&models.AppointmentNote{},
+		&models.ColorFormula{},
+		&models.AppointmentPhoto{},
+		&models.ServiceBundle{},
+		&models.ServiceBundleItem{},
+		&models.ServiceVariant{},
+		&models.ServiceOption{},
+		&models.AppointmentServiceOption{},
+		&models.PaymentMethod{},
+		&models.Payment{},
+		&models.Promotion{},
+		&models.PromotionUse{},
+		&models.Tip{},
+		&models.Receipt{},
+		&models.ReceiptSequence{},
+		&models.CashSession{},
+		&models.CashMovement{},
 	)
 	if err != nil {
 		logger.Log.Fatal("Error al ejecutar migraciones: ", err)
@@ -3,6 +3,7 @@ package database
 import (
 	"peluqueria/internal/models"
 	"peluqueria/logger"
+	"strings"
 
 	"golang.org/x/crypto/bcrypt"
 	"gorm.io/gorm"
@@ -15,6 +16,9 @@ func SeedDatabase(db *gorm.DB) error {
 	seedUsers(db)
 	seedPermissions(db)
 	seedRolePermissions(db)
+	seedBusinessHours(db)
+	seedPaymentMethods(db)
+	seedLegacyPayments(db)
 	logger.Log.Info("Seeders ejecutados con éxito")
 	return nil
 }
@@ -87,6 +91,19 @@ func seedPermissions(db *gorm.DB) {
 		{Name: "update_client", Description: "Editar clientes"},
 		{Name: "delete_client", Description: "Eliminar clientes"},
 		{Name: "restock_product", Description: "Reponer productos"},
+		{Name: "create_staff", Description: "Crear empleados"},
+		{Name: "update_staff", Description: "Editar empleados"},
+		{Name: "delete_staff", Description: "Eliminar empleados"},
+		{Name: "update_calendar", Description: "Editar horarios, feriados y cierres"},
+		{Name: "create_resource", Description: "Crear recursos"},
+		{Name: "update_resource", Description: "Editar recursos"},
+		{Name: "delete_resource", Description: "Eliminar recursos"},
+		{Name: "approve_time_off", Description: "Aprobar y rechazar licencias"},
+		{Name: "manage_payment_methods", Description: "Administrar medios de pago"},
+		{Name: "manage_promotions", Description: "Administrar promociones y cupones"},
+		{Name: "manage_cash_register", Description: "Abrir, cerrar y registrar movimientos de caja"},
+		{Name: "see_notifications", Description: "Ver notificaciones enviadas"},
+		{Name: "see_events", Description: "Ver eventos en vivo de turnos y stock"},
 	}
 
 	for _, permission := range permissions {
@@ -111,10 +128,13 @@ func seedRolePermissions(db *gorm.DB) {
 			"create_product", "update_product", "delete_product",
 			"create_user", "update_user", "delete_user",
 			"create_role", "update_role", "delete_role", "create_client", "update_client", "delete_client", "restock_product",
+			"create_staff", "update_staff", "delete_staff", "update_calendar",
+			"create_resource", "update_resource", "delete_resource", "approve_time_off",
+			"manage_payment_methods", "manage_promotions", "manage_cash_register", "see_notifications", "see_events",
 		},
 		"empleado": {
 			"create_appointment", "update_appointment",
-			"create_service", "update_service",
+			"create_service", "update_service", "see_events",
 		},
 	}
 
@@ -145,6 +165,100 @@ func seedRolePermissions(db *gorm.DB) {
 	}
 }
 
+// seedBusinessHours carga un horario de atención inicial (lunes a sábado de 9 a 20)
+// solo si todavía no hay franjas configuradas.
+func seedBusinessHours(db *gorm.DB) {
+	var count int64
+	if err := db.Model(&models.BusinessHour{}).Count(&count).Error; err != nil {
+		logger.Log.Error("Error al contar horarios de atención: ", err)
+		return
+	}
+	if count > 0 {
+		return
+	}
+
+	for weekday := 1; weekday <= 6; weekday++ {
+		businessHour := models.BusinessHour{Weekday: weekday, OpenTime: "09:00", CloseTime: "20:00"}
+		if err := db.Create(&businessHour).Error; err != nil {
+			logger.Log.Error("Error al crear horario de atención para el día ", weekday, ": ", err)
+		}
+	}
+	logger.Log.Info("Horario de atención inicial creado con éxito")
+}
+
+// seedPaymentMethods carga los medios de pago que el sistema usaba antes del catálogo, solo
+// si todavía no hay ninguno.
+func seedPaymentMethods(db *gorm.DB) {
+	var count int64
+	if err := db.Unscoped().Model(&models.PaymentMethod{}).Count(&count).Error; err != nil {
+		logger.Log.Error("Error al contar medios de pago: ", err)
+		return
+	}
+	if count > 0 {
+		return
+	}
+
+	methods := []models.PaymentMethod{
+		{Code: "efectivo", Name: "Efectivo", IsCash: true, Active: true},
+		{Code: "debito", Name: "Débito", Active: true},
+		{Code: "credito", Name: "Crédito", Active: true},
+		{Code: "transferencia", Name: "Transferencia", RequiresReference: true, Active: true},
+	}
+	for _, method := range methods {
+		if err := db.Create(&method).Error; err != nil {
+			logger.Log.Error("Error al crear medio de pago '", method.Code, "': ", err)
+		}
+	}
+	logger.Log.Info("Medios de pago iniciales creados con éxito")
+}
+
+// seedLegacyPayments registra como pago único los turnos finalizados antes del registro de
+// pagos, para que las estadísticas por medio de pago los incluyan. Los medios que no estén
+// en el catálogo se agregan inactivos.
+func seedLegacyPayments(db *gorm.DB) {
+	var appointments []models.Appointment
+	if err := db.
+		Where("status = ? AND payment_method <> ''", models.AppointmentStatusFinished).
+		Where("NOT EXISTS (SELECT 1 FROM payments WHERE payments.appointment_id = appointments.id)").
+		Find(&appointments).Error; err != nil {
+		logger.Log.Error("Error al buscar turnos sin pagos: ", err)
+		return
+	}
+
+	for _, appointment := range appointments {
+		code := strings.ToLower(strings.TrimSpace(appointment.PaymentMethod))
+		var method models.PaymentMethod
+		if err := db.Unscoped().Where("code = ?", code).First(&method).Error; err != nil {
+			method = models.PaymentMethod{Code: code, Name: appointment.PaymentMethod, Active: false}
+			if err := db.Create(&method).Error; err != nil {
+				logger.Log.Error("Error al crear medio de pago '", code, "': ", err)
+				continue
+			}
+		}
+
+		var total float64
+		if err := db.Model(&models.AppointmentService{}).
+			Select("COALESCE(SUM(price), 0)").
+			Where("appointment_id = ?", appointment.ID).
+			Scan(&total).Error; err != nil {
+			logger.Log.Error("Error al calcular total del turno ", appointment.ID, ": ", err)
+			continue
+		}
+
+		paidAt := appointment.UpdatedAt
+		if appointment.FinishedAt != nil {
+			paidAt = *appointment.FinishedAt
+		}
+		payment := models.Payment{AppointmentID: appointment.ID, MethodID: method.ID, Amount: total, PaidAt: paidAt}
+		if err := db.Create(&payment).Error; err != nil {
+			logger.Log.Error("Error al registrar pago del turno ", appointment.ID, ": ", err)
+		}
+	}
+	if len(appointments) > 0 {
+		logger.Log.Infof("Pagos registrados para %d turnos finalizados anteriores", len(appointments))
+	}
+}
+
 // HashPassword es una función auxiliar para encriptar contraseñas
 func HashPassword(pass string) string {
 	costo := 8
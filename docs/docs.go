@@ -15,24 +15,38 @@ const docTemplate = `{
     "host": "{{.Host}}",
     "basePath": "{{.BasePath}}",
     "paths": {
-        "/cliente": {
+        "/caja": {
             "get": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Devuelve una lista de todos los clientes registrados.",
+                "description": "Devuelve las sesiones de caja con sus totales por tipo de movimiento y la diferencia al cierre.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Clientes"
+                    "Caja"
+                ],
+                "summary": "Obtener sesiones de caja",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Desde (DD/MM/YYYY)",
+                        "name": "from",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Hasta (DD/MM/YYYY)",
+                        "name": "to",
+                        "in": "query"
+                    }
                 ],
-                "summary": "Obtener todos los clientes",
                 "responses": {
                     "200": {
-                        "description": "Lista de clientes encontrados",
+                        "description": "Sesiones obtenidas",
                         "schema": {
                             "allOf": [
                                 {
@@ -44,7 +58,7 @@ const docTemplate = `{
                                         "data": {
                                             "type": "array",
                                             "items": {
-                                                "$ref": "#/definitions/dtos.GetClientDto"
+                                                "$ref": "#/definitions/dtos.GetCashSessionDto"
                                             }
                                         }
                                     }
@@ -59,14 +73,16 @@ const docTemplate = `{
                         }
                     }
                 }
-            },
+            }
+        },
+        "/caja/abrir": {
             "post": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Crea un nuevo cliente en el sistema.",
+                "description": "Abre la caja del día con un fondo inicial. Solo puede haber una caja abierta.",
                 "consumes": [
                     "application/json"
                 ],
@@ -74,23 +90,23 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Clientes"
+                    "Caja"
                 ],
-                "summary": "Crear cliente",
+                "summary": "Abrir caja",
                 "parameters": [
                     {
-                        "description": "Datos del cliente",
+                        "description": "Fondo inicial",
                         "name": "request",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/dtos.ClientDTO"
+                            "$ref": "#/definitions/dtos.OpenCashSessionDto"
                         }
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Cliente creado exitosamente",
+                        "description": "Caja abierta",
                         "schema": {
                             "allOf": [
                                 {
@@ -100,7 +116,7 @@ const docTemplate = `{
                                     "type": "object",
                                     "properties": {
                                         "data": {
-                                            "type": "object"
+                                            "$ref": "#/definitions/dtos.GetCashSessionDto"
                                         }
                                     }
                                 }
@@ -108,7 +124,7 @@ const docTemplate = `{
                         }
                     },
                     "400": {
-                        "description": "Datos inválidos. Ejemplo: El cliente ya existe",
+                        "description": "Datos inválidos o ya hay una caja abierta",
                         "schema": {
                             "$ref": "#/definitions/dtos.ErrorResponse"
                         }
@@ -122,33 +138,24 @@ const docTemplate = `{
                 }
             }
         },
-        "/cliente/{id}": {
+        "/caja/actual": {
             "get": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Devuelve los datos de un cliente específico.",
+                "description": "Devuelve la caja abierta con sus movimientos y el efectivo esperado hasta el momento.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Clientes"
-                ],
-                "summary": "Obtener cliente por ID",
-                "parameters": [
-                    {
-                        "type": "integer",
-                        "description": "ID del cliente",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
-                    }
+                    "Caja"
                 ],
+                "summary": "Caja abierta",
                 "responses": {
                     "200": {
-                        "description": "Cliente encontrado",
+                        "description": "Caja obtenida",
                         "schema": {
                             "allOf": [
                                 {
@@ -158,34 +165,36 @@ const docTemplate = `{
                                     "type": "object",
                                     "properties": {
                                         "data": {
-                                            "$ref": "#/definitions/dtos.GetClientDto"
+                                            "$ref": "#/definitions/dtos.GetCashSessionDto"
                                         }
                                     }
                                 }
                             ]
                         }
                     },
-                    "400": {
-                        "description": "ID inválido",
+                    "404": {
+                        "description": "No hay una caja abierta",
                         "schema": {
                             "$ref": "#/definitions/dtos.ErrorResponse"
                         }
                     },
-                    "404": {
-                        "description": "Cliente no encontrado",
+                    "500": {
+                        "description": "Error interno del servidor",
                         "schema": {
                             "$ref": "#/definitions/dtos.ErrorResponse"
                         }
                     }
                 }
-            },
+            }
+        },
+        "/caja/cerrar": {
             "put": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Actualiza los datos de un cliente específico.",
+                "description": "Cierra la caja abierta con el efectivo contado y devuelve el reporte de cierre con el sobrante o faltante.",
                 "consumes": [
                     "application/json"
                 ],
@@ -193,30 +202,23 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Clientes"
+                    "Caja"
                 ],
-                "summary": "Actualizar cliente",
+                "summary": "Cerrar caja",
                 "parameters": [
                     {
-                        "type": "integer",
-                        "description": "ID del cliente",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
-                    },
-                    {
-                        "description": "Datos actualizados del cliente",
+                        "description": "Efectivo contado",
                         "name": "request",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/dtos.ClientDTO"
+                            "$ref": "#/definitions/dtos.CloseCashSessionDto"
                         }
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Cliente actualizado exitosamente",
+                        "description": "Caja cerrada",
                         "schema": {
                             "allOf": [
                                 {
@@ -226,7 +228,7 @@ const docTemplate = `{
                                     "type": "object",
                                     "properties": {
                                         "data": {
-                                            "type": "object"
+                                            "$ref": "#/definitions/dtos.GetCashSessionDto"
                                         }
                                     }
                                 }
@@ -234,7 +236,7 @@ const docTemplate = `{
                         }
                     },
                     "400": {
-                        "description": "Datos o ID inválidos",
+                        "description": "Datos inválidos o no hay una caja abierta",
                         "schema": {
                             "$ref": "#/definitions/dtos.ErrorResponse"
                         }
@@ -246,33 +248,40 @@ const docTemplate = `{
                         }
                     }
                 }
-            },
-            "delete": {
+            }
+        },
+        "/caja/movimientos": {
+            "post": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Elimina un cliente específico.",
+                "description": "Registra un ingreso, gasto o retiro de efectivo en la caja abierta. Los cobros y propinas en efectivo se registran al finalizar el turno.",
+                "consumes": [
+                    "application/json"
+                ],
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Clientes"
+                    "Caja"
                 ],
-                "summary": "Eliminar cliente",
+                "summary": "Registrar movimiento de caja",
                 "parameters": [
                     {
-                        "type": "integer",
-                        "description": "ID del cliente",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
+                        "description": "Movimiento",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dtos.CashMovementDto"
+                        }
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Cliente eliminado exitosamente",
+                        "description": "Movimiento registrado",
                         "schema": {
                             "allOf": [
                                 {
@@ -290,7 +299,7 @@ const docTemplate = `{
                         }
                     },
                     "400": {
-                        "description": "ID inválido",
+                        "description": "Datos inválidos o no hay una caja abierta",
                         "schema": {
                             "$ref": "#/definitions/dtos.ErrorResponse"
                         }
@@ -304,33 +313,33 @@ const docTemplate = `{
                 }
             }
         },
-        "/login": {
-            "post": {
-                "description": "Permite a un usuario autenticarse en el sistema.",
-                "consumes": [
-                    "application/json"
+        "/caja/{id}": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
                 ],
+                "description": "Devuelve una sesión con sus movimientos. Si está cerrada, es el reporte de cierre con el sobrante o faltante.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Autenticación"
+                    "Caja"
                 ],
-                "summary": "Iniciar sesión",
+                "summary": "Obtener sesión de caja por ID",
                 "parameters": [
                     {
-                        "description": "Datos de inicio de sesión",
-                        "name": "request",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/dtos.LoginDto"
-                        }
+                        "type": "integer",
+                        "description": "ID de la sesión",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Token de acceso",
+                        "description": "Sesión obtenida",
                         "schema": {
                             "allOf": [
                                 {
@@ -340,7 +349,7 @@ const docTemplate = `{
                                     "type": "object",
                                     "properties": {
                                         "data": {
-                                            "$ref": "#/definitions/dtos.LoginAnswerDto"
+                                            "$ref": "#/definitions/dtos.GetCashSessionDto"
                                         }
                                     }
                                 }
@@ -348,13 +357,19 @@ const docTemplate = `{
                         }
                     },
                     "400": {
-                        "description": "Datos inválidos. Ejemplo: El formato de los datos es incorrecto",
+                        "description": "ID inválido",
                         "schema": {
                             "$ref": "#/definitions/dtos.ErrorResponse"
                         }
                     },
-                    "401": {
-                        "description": "Credenciales inválidas. Ejemplo: Usuario o contraseña incorrectos",
+                    "404": {
+                        "description": "Sesión no encontrada",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Error interno del servidor",
                         "schema": {
                             "$ref": "#/definitions/dtos.ErrorResponse"
                         }
@@ -362,19 +377,24 @@ const docTemplate = `{
                 }
             }
         },
-        "/producto": {
+        "/calendario/cierres": {
             "get": {
-                "description": "Devuelve una lista de todos los productos registrados.",
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Devuelve los feriados y cierres registrados.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Productos"
+                    "Calendario"
                 ],
-                "summary": "Obtener todos los productos",
+                "summary": "Obtener feriados y cierres",
                 "responses": {
                     "200": {
-                        "description": "OK",
+                        "description": "Cierres obtenidos",
                         "schema": {
                             "allOf": [
                                 {
@@ -386,7 +406,7 @@ const docTemplate = `{
                                         "data": {
                                             "type": "array",
                                             "items": {
-                                                "$ref": "#/definitions/dtos.GetProductDto"
+                                                "$ref": "#/definitions/dtos.GetClosureDto"
                                             }
                                         }
                                     }
@@ -397,25 +417,18 @@ const docTemplate = `{
                     "500": {
                         "description": "Error interno del servidor",
                         "schema": {
-                            "allOf": [
-                                {
-                                    "$ref": "#/definitions/dtos.Response"
-                                },
-                                {
-                                    "type": "object",
-                                    "properties": {
-                                        "data": {
-                                            "type": "object"
-                                        }
-                                    }
-                                }
-                            ]
+                            "$ref": "#/definitions/dtos.ErrorResponse"
                         }
                     }
                 }
             },
             "post": {
-                "description": "Crea un nuevo producto en el sistema.",
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Registra un feriado o un cierre eventual del salón, de uno o más días.",
                 "consumes": [
                     "application/json"
                 ],
@@ -423,23 +436,23 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Productos"
+                    "Calendario"
                 ],
-                "summary": "Crear producto",
+                "summary": "Crear feriado o cierre",
                 "parameters": [
                     {
-                        "description": "Datos del producto",
-                        "name": "product",
+                        "description": "Datos del cierre",
+                        "name": "request",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/dtos.CreateProductDto"
+                            "$ref": "#/definitions/dtos.ClosureDto"
                         }
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "OK",
+                        "description": "Cierre creado",
                         "schema": {
                             "allOf": [
                                 {
@@ -459,64 +472,57 @@ const docTemplate = `{
                     "400": {
                         "description": "Datos inválidos",
                         "schema": {
-                            "allOf": [
-                                {
-                                    "$ref": "#/definitions/dtos.Response"
-                                },
-                                {
-                                    "type": "object",
-                                    "properties": {
-                                        "data": {
-                                            "type": "object"
-                                        }
-                                    }
-                                }
-                            ]
+                            "$ref": "#/definitions/dtos.ErrorResponse"
                         }
                     },
                     "500": {
                         "description": "Error interno del servidor",
                         "schema": {
-                            "allOf": [
-                                {
-                                    "$ref": "#/definitions/dtos.Response"
-                                },
-                                {
-                                    "type": "object",
-                                    "properties": {
-                                        "data": {
-                                            "type": "object"
-                                        }
-                                    }
-                                }
-                            ]
+                            "$ref": "#/definitions/dtos.ErrorResponse"
                         }
                     }
                 }
             }
         },
-        "/producto/{id}": {
-            "get": {
-                "description": "Devuelve los datos de un producto específico.",
+        "/calendario/cierres/{id}": {
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Modifica un feriado o cierre existente.",
+                "consumes": [
+                    "application/json"
+                ],
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Productos"
+                    "Calendario"
                 ],
-                "summary": "Obtener producto por ID",
+                "summary": "Actualizar feriado o cierre",
                 "parameters": [
                     {
                         "type": "integer",
-                        "description": "ID del producto",
+                        "description": "ID del cierre",
                         "name": "id",
                         "in": "path",
                         "required": true
+                    },
+                    {
+                        "description": "Datos del cierre",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dtos.ClosureDto"
+                        }
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "OK",
+                        "description": "Cierre actualizado",
                         "schema": {
                             "allOf": [
                                 {
@@ -526,7 +532,7 @@ const docTemplate = `{
                                     "type": "object",
                                     "properties": {
                                         "data": {
-                                            "$ref": "#/definitions/dtos.GetProductDto"
+                                            "type": "object"
                                         }
                                     }
                                 }
@@ -534,7 +540,45 @@ const docTemplate = `{
                         }
                     },
                     "400": {
-                        "description": "ID inválido",
+                        "description": "ID o datos inválidos",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Error interno del servidor",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Elimina un feriado o cierre.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Calendario"
+                ],
+                "summary": "Eliminar feriado o cierre",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "ID del cierre",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Cierre eliminado",
                         "schema": {
                             "allOf": [
                                 {
@@ -551,8 +595,39 @@ const docTemplate = `{
                             ]
                         }
                     },
-                    "404": {
-                        "description": "Producto no encontrado",
+                    "400": {
+                        "description": "ID inválido",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Error interno del servidor",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/calendario/horarios": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Devuelve las franjas de atención semanales del salón.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Calendario"
+                ],
+                "summary": "Obtener horarios de atención",
+                "responses": {
+                    "200": {
+                        "description": "Horarios obtenidos",
                         "schema": {
                             "allOf": [
                                 {
@@ -562,17 +637,31 @@ const docTemplate = `{
                                     "type": "object",
                                     "properties": {
                                         "data": {
-                                            "type": "object"
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/dtos.GetBusinessHourDto"
+                                            }
                                         }
                                     }
                                 }
                             ]
                         }
+                    },
+                    "500": {
+                        "description": "Error interno del servidor",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.ErrorResponse"
+                        }
                     }
                 }
             },
-            "put": {
-                "description": "Actualiza los datos de un producto específico.",
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Agrega una franja de atención para un día de la semana. Un día puede tener varias franjas (horario cortado).",
                 "consumes": [
                     "application/json"
                 ],
@@ -580,30 +669,23 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Productos"
+                    "Calendario"
                 ],
-                "summary": "Actualizar producto",
+                "summary": "Crear franja horaria",
                 "parameters": [
                     {
-                        "type": "integer",
-                        "description": "ID del producto",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
-                    },
-                    {
-                        "description": "Datos del producto",
-                        "name": "product",
+                        "description": "Datos de la franja",
+                        "name": "request",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/dtos.UpdateProductDto"
+                            "$ref": "#/definitions/dtos.BusinessHourDto"
                         }
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "OK",
+                        "description": "Franja horaria creada",
                         "schema": {
                             "allOf": [
                                 {
@@ -621,64 +703,59 @@ const docTemplate = `{
                         }
                     },
                     "400": {
-                        "description": "Datos o ID inválidos",
+                        "description": "Datos inválidos",
                         "schema": {
-                            "allOf": [
-                                {
-                                    "$ref": "#/definitions/dtos.Response"
-                                },
-                                {
-                                    "type": "object",
-                                    "properties": {
-                                        "data": {
-                                            "type": "object"
-                                        }
-                                    }
-                                }
-                            ]
+                            "$ref": "#/definitions/dtos.ErrorResponse"
                         }
                     },
                     "500": {
                         "description": "Error interno del servidor",
                         "schema": {
-                            "allOf": [
-                                {
-                                    "$ref": "#/definitions/dtos.Response"
-                                },
-                                {
-                                    "type": "object",
-                                    "properties": {
-                                        "data": {
-                                            "type": "object"
-                                        }
-                                    }
-                                }
-                            ]
+                            "$ref": "#/definitions/dtos.ErrorResponse"
                         }
                     }
                 }
-            },
-            "delete": {
-                "description": "Elimina un producto específico.",
+            }
+        },
+        "/calendario/horarios/{id}": {
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Modifica una franja de atención existente.",
+                "consumes": [
+                    "application/json"
+                ],
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Productos"
+                    "Calendario"
                 ],
-                "summary": "Eliminar producto",
+                "summary": "Actualizar franja horaria",
                 "parameters": [
                     {
                         "type": "integer",
-                        "description": "ID del producto",
+                        "description": "ID de la franja",
                         "name": "id",
                         "in": "path",
                         "required": true
+                    },
+                    {
+                        "description": "Datos de la franja",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dtos.BusinessHourDto"
+                        }
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "OK",
+                        "description": "Franja horaria actualizada",
                         "schema": {
                             "allOf": [
                                 {
@@ -696,78 +773,45 @@ const docTemplate = `{
                         }
                     },
                     "400": {
-                        "description": "ID inválido",
+                        "description": "ID o datos inválidos",
                         "schema": {
-                            "allOf": [
-                                {
-                                    "$ref": "#/definitions/dtos.Response"
-                                },
-                                {
-                                    "type": "object",
-                                    "properties": {
-                                        "data": {
-                                            "type": "object"
-                                        }
-                                    }
-                                }
-                            ]
+                            "$ref": "#/definitions/dtos.ErrorResponse"
                         }
                     },
                     "500": {
                         "description": "Error interno del servidor",
                         "schema": {
-                            "allOf": [
-                                {
-                                    "$ref": "#/definitions/dtos.Response"
-                                },
-                                {
-                                    "type": "object",
-                                    "properties": {
-                                        "data": {
-                                            "type": "object"
-                                        }
-                                    }
-                                }
-                            ]
+                            "$ref": "#/definitions/dtos.ErrorResponse"
                         }
                     }
                 }
-            }
-        },
-        "/producto/{id}/restock": {
-            "post": {
-                "description": "Agrega stock adicional a un producto existente.",
-                "consumes": [
-                    "application/json"
+            },
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
                 ],
+                "description": "Elimina una franja de atención.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Productos"
+                    "Calendario"
                 ],
-                "summary": "Reabastecer producto",
+                "summary": "Eliminar franja horaria",
                 "parameters": [
                     {
                         "type": "integer",
-                        "description": "ID del producto",
+                        "description": "ID de la franja",
                         "name": "id",
                         "in": "path",
                         "required": true
-                    },
-                    {
-                        "description": "Datos para el reestock",
-                        "name": "restock",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/dtos.RestockProductDto"
-                        }
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "OK",
+                        "description": "Franja horaria eliminada",
                         "schema": {
                             "allOf": [
                                 {
@@ -785,62 +829,38 @@ const docTemplate = `{
                         }
                     },
                     "400": {
-                        "description": "Datos o ID inválidos",
+                        "description": "ID inválido",
                         "schema": {
-                            "allOf": [
-                                {
-                                    "$ref": "#/definitions/dtos.Response"
-                                },
-                                {
-                                    "type": "object",
-                                    "properties": {
-                                        "data": {
-                                            "type": "object"
-                                        }
-                                    }
-                                }
-                            ]
+                            "$ref": "#/definitions/dtos.ErrorResponse"
                         }
                     },
                     "500": {
                         "description": "Error interno del servidor",
                         "schema": {
-                            "allOf": [
-                                {
-                                    "$ref": "#/definitions/dtos.Response"
-                                },
-                                {
-                                    "type": "object",
-                                    "properties": {
-                                        "data": {
-                                            "type": "object"
-                                        }
-                                    }
-                                }
-                            ]
+                            "$ref": "#/definitions/dtos.ErrorResponse"
                         }
                     }
                 }
             }
         },
-        "/rol": {
+        "/calendario/ical": {
             "get": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Devuelve una lista de todos los roles registrados en el sistema.",
+                "description": "Devuelve las URLs de suscripción vigentes.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Roles"
+                    "Calendario"
                 ],
-                "summary": "Obtener todos los roles",
+                "summary": "Obtener calendarios iCal",
                 "responses": {
                     "200": {
-                        "description": "Lista de roles encontrados",
+                        "description": "Calendarios obtenidos",
                         "schema": {
                             "allOf": [
                                 {
@@ -852,7 +872,7 @@ const docTemplate = `{
                                         "data": {
                                             "type": "array",
                                             "items": {
-                                                "$ref": "#/definitions/dtos.GetRoleDto"
+                                                "$ref": "#/definitions/dtos.GetCalendarFeedDto"
                                             }
                                         }
                                     }
@@ -874,7 +894,7 @@ const docTemplate = `{
                         "BearerAuth": []
                     }
                 ],
-                "description": "Crea un nuevo rol con permisos específicos.",
+                "description": "Genera la URL secreta de suscripción (.ics) de un estilista o, sin staff_id, de todo el salón. Si ya existía se reemplaza y la URL anterior deja de funcionar.",
                 "consumes": [
                     "application/json"
                 ],
@@ -882,23 +902,23 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Roles"
+                    "Calendario"
                 ],
-                "summary": "Crear rol",
+                "summary": "Generar calendario iCal",
                 "parameters": [
                     {
-                        "description": "Datos del nuevo rol",
+                        "description": "Estilista del calendario",
                         "name": "request",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/dtos.CreateRoleDto"
+                            "$ref": "#/definitions/dtos.CalendarFeedDto"
                         }
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Rol creado exitosamente",
+                        "description": "Calendario generado",
                         "schema": {
                             "allOf": [
                                 {
@@ -908,7 +928,7 @@ const docTemplate = `{
                                     "type": "object",
                                     "properties": {
                                         "data": {
-                                            "type": "object"
+                                            "$ref": "#/definitions/dtos.GetCalendarFeedDto"
                                         }
                                     }
                                 }
@@ -916,7 +936,7 @@ const docTemplate = `{
                         }
                     },
                     "400": {
-                        "description": "Datos inválidos. Ejemplo: El nombre del rol ya existe",
+                        "description": "Datos inválidos",
                         "schema": {
                             "$ref": "#/definitions/dtos.ErrorResponse"
                         }
@@ -930,25 +950,25 @@ const docTemplate = `{
                 }
             }
         },
-        "/rol/{id}": {
-            "get": {
+        "/calendario/ical/{id}": {
+            "delete": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Devuelve los datos de un rol específico.",
+                "description": "Elimina una URL de suscripción; los calendarios suscritos dejan de actualizarse.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Roles"
+                    "Calendario"
                 ],
-                "summary": "Obtener rol por ID",
+                "summary": "Revocar calendario iCal",
                 "parameters": [
                     {
                         "type": "integer",
-                        "description": "ID del rol",
+                        "description": "ID del calendario",
                         "name": "id",
                         "in": "path",
                         "required": true
@@ -956,7 +976,7 @@ const docTemplate = `{
                 ],
                 "responses": {
                     "200": {
-                        "description": "Rol encontrado",
+                        "description": "Calendario revocado",
                         "schema": {
                             "allOf": [
                                 {
@@ -966,7 +986,7 @@ const docTemplate = `{
                                     "type": "object",
                                     "properties": {
                                         "data": {
-                                            "$ref": "#/definitions/dtos.GetRoleDto"
+                                            "type": "object"
                                         }
                                     }
                                 }
@@ -979,52 +999,91 @@ const docTemplate = `{
                             "$ref": "#/definitions/dtos.ErrorResponse"
                         }
                     },
-                    "404": {
-                        "description": "Rol no encontrado",
+                    "500": {
+                        "description": "Error interno del servidor",
                         "schema": {
                             "$ref": "#/definitions/dtos.ErrorResponse"
                         }
                     }
                 }
-            },
-            "put": {
+            }
+        },
+        "/cliente": {
+            "get": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Actualiza los datos de un rol específico.",
-                "consumes": [
-                    "application/json"
-                ],
+                "description": "Devuelve una lista de todos los clientes registrados.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Roles"
+                    "Clientes"
                 ],
-                "summary": "Actualizar rol",
-                "parameters": [
-                    {
-                        "type": "integer",
-                        "description": "ID del rol",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
-                    },
-                    {
-                        "description": "Datos actualizados del rol",
-                        "name": "request",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/dtos.CreateRoleDto"
+                "summary": "Obtener todos los clientes",
+                "responses": {
+                    "200": {
+                        "description": "Lista de clientes encontrados",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/dtos.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/dtos.GetClientDto"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "500": {
+                        "description": "Error interno del servidor",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Crea un nuevo cliente en el sistema.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Clientes"
+                ],
+                "summary": "Crear cliente",
+                "parameters": [
+                    {
+                        "description": "Datos del cliente",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dtos.ClientDTO"
                         }
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Rol actualizado exitosamente",
+                        "description": "Cliente creado exitosamente",
                         "schema": {
                             "allOf": [
                                 {
@@ -1042,7 +1101,133 @@ const docTemplate = `{
                         }
                     },
                     "400": {
-                        "description": "Datos inválidos o ID inválido",
+                        "description": "Datos inválidos. Ejemplo: El cliente ya existe",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Error interno del servidor",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/cliente/{id}": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Devuelve los datos de un cliente específico.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Clientes"
+                ],
+                "summary": "Obtener cliente por ID",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "ID del cliente",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Cliente encontrado",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/dtos.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/dtos.GetClientDto"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "ID inválido",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Cliente no encontrado",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Actualiza los datos de un cliente específico.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Clientes"
+                ],
+                "summary": "Actualizar cliente",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "ID del cliente",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Datos actualizados del cliente",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dtos.ClientDTO"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Cliente actualizado exitosamente",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/dtos.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "object"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Datos o ID inválidos",
                         "schema": {
                             "$ref": "#/definitions/dtos.ErrorResponse"
                         }
@@ -1061,18 +1246,18 @@ const docTemplate = `{
                         "BearerAuth": []
                     }
                 ],
-                "description": "Elimina un rol específico.",
+                "description": "Elimina un cliente específico.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Roles"
+                    "Clientes"
                 ],
-                "summary": "Eliminar rol",
+                "summary": "Eliminar cliente",
                 "parameters": [
                     {
                         "type": "integer",
-                        "description": "ID del rol",
+                        "description": "ID del cliente",
                         "name": "id",
                         "in": "path",
                         "required": true
@@ -1080,7 +1265,7 @@ const docTemplate = `{
                 ],
                 "responses": {
                     "200": {
-                        "description": "Rol eliminado correctamente",
+                        "description": "Cliente eliminado exitosamente",
                         "schema": {
                             "allOf": [
                                 {
@@ -1112,24 +1297,32 @@ const docTemplate = `{
                 }
             }
         },
-        "/servicio": {
+        "/combo": {
             "get": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Devuelve una lista de todos los servicios registrados en el sistema.",
+                "description": "Devuelve los combos registrados, con sus servicios y el ahorro respecto de reservarlos sueltos.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Servicios"
+                    "Combos"
+                ],
+                "summary": "Obtener todos los combos",
+                "parameters": [
+                    {
+                        "type": "boolean",
+                        "description": "Solo combos activos",
+                        "name": "active",
+                        "in": "query"
+                    }
                 ],
-                "summary": "Obtener todos los servicios",
                 "responses": {
                     "200": {
-                        "description": "Servicios obtenidos",
+                        "description": "Combos obtenidos",
                         "schema": {
                             "allOf": [
                                 {
@@ -1141,7 +1334,7 @@ const docTemplate = `{
                                         "data": {
                                             "type": "array",
                                             "items": {
-                                                "$ref": "#/definitions/dtos.GetServiceDto"
+                                                "$ref": "#/definitions/dtos.GetServiceBundleDto"
                                             }
                                         }
                                     }
@@ -1163,7 +1356,7 @@ const docTemplate = `{
                         "BearerAuth": []
                     }
                 ],
-                "description": "Permite crear un nuevo servicio en el sistema.",
+                "description": "Crea un combo de servicios con precio y duración propios. El precio no puede superar la suma de los servicios con ninguno de los estilistas que los realizan; sin duración se usa la suma de los servicios.",
                 "consumes": [
                     "application/json"
                 ],
@@ -1171,23 +1364,23 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Servicios"
+                    "Combos"
                 ],
-                "summary": "Crear servicio",
+                "summary": "Crear combo",
                 "parameters": [
                     {
-                        "description": "Datos del servicio",
+                        "description": "Datos del combo",
                         "name": "request",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/dtos.ServiceDto"
+                            "$ref": "#/definitions/dtos.ServiceBundleDto"
                         }
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Servicio creado",
+                        "description": "Combo creado",
                         "schema": {
                             "allOf": [
                                 {
@@ -1219,25 +1412,25 @@ const docTemplate = `{
                 }
             }
         },
-        "/servicio/{id}": {
+        "/combo/{id}": {
             "get": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Devuelve los datos de un servicio específico.",
+                "description": "Devuelve los datos de un combo específico.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Servicios"
+                    "Combos"
                 ],
-                "summary": "Obtener servicio por ID",
+                "summary": "Obtener combo por ID",
                 "parameters": [
                     {
                         "type": "integer",
-                        "description": "ID del servicio",
+                        "description": "ID del combo",
                         "name": "id",
                         "in": "path",
                         "required": true
@@ -1245,7 +1438,7 @@ const docTemplate = `{
                 ],
                 "responses": {
                     "200": {
-                        "description": "Servicio obtenido",
+                        "description": "Combo obtenido",
                         "schema": {
                             "allOf": [
                                 {
@@ -1255,7 +1448,7 @@ const docTemplate = `{
                                     "type": "object",
                                     "properties": {
                                         "data": {
-                                            "$ref": "#/definitions/dtos.GetServiceDto"
+                                            "$ref": "#/definitions/dtos.GetServiceBundleDto"
                                         }
                                     }
                                 }
@@ -1268,8 +1461,8 @@ const docTemplate = `{
                             "$ref": "#/definitions/dtos.ErrorResponse"
                         }
                     },
-                    "500": {
-                        "description": "Error interno del servidor",
+                    "404": {
+                        "description": "Combo no encontrado",
                         "schema": {
                             "$ref": "#/definitions/dtos.ErrorResponse"
                         }
@@ -1282,7 +1475,7 @@ const docTemplate = `{
                         "BearerAuth": []
                     }
                 ],
-                "description": "Permite actualizar los datos de un servicio existente.",
+                "description": "Actualiza un combo. Los turnos ya agendados conservan los precios y tiempos con los que se reservaron.",
                 "consumes": [
                     "application/json"
                 ],
@@ -1290,30 +1483,30 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Servicios"
+                    "Combos"
                 ],
-                "summary": "Actualizar servicio",
+                "summary": "Actualizar combo",
                 "parameters": [
                     {
                         "type": "integer",
-                        "description": "ID del servicio",
+                        "description": "ID del combo",
                         "name": "id",
                         "in": "path",
                         "required": true
                     },
                     {
-                        "description": "Datos del servicio",
+                        "description": "Datos del combo",
                         "name": "request",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/dtos.ServiceDto"
+                            "$ref": "#/definitions/dtos.ServiceBundleDto"
                         }
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Servicio actualizado",
+                        "description": "Combo actualizado",
                         "schema": {
                             "allOf": [
                                 {
@@ -1350,18 +1543,18 @@ const docTemplate = `{
                         "BearerAuth": []
                     }
                 ],
-                "description": "Permite eliminar un servicio específico.",
+                "description": "Da de baja un combo. Los turnos que lo usaron lo siguen mostrando.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Servicios"
+                    "Combos"
                 ],
-                "summary": "Eliminar servicio",
+                "summary": "Eliminar combo",
                 "parameters": [
                     {
                         "type": "integer",
-                        "description": "ID del servicio",
+                        "description": "ID del combo",
                         "name": "id",
                         "in": "path",
                         "required": true
@@ -1369,7 +1562,7 @@ const docTemplate = `{
                 ],
                 "responses": {
                     "200": {
-                        "description": "Servicio eliminado",
+                        "description": "Combo eliminado",
                         "schema": {
                             "allOf": [
                                 {
@@ -1401,41 +1594,24 @@ const docTemplate = `{
                 }
             }
         },
-        "/stock-movements": {
+        "/empleado": {
             "get": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Devuelve una lista de todos los movimientos de stock, filtrados opcionalmente por tipo y mes.",
-                "consumes": [
-                    "application/json"
-                ],
+                "description": "Devuelve una lista de todos los empleados registrados.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Movimientos de Stock"
-                ],
-                "summary": "Obtener movimientos de stock",
-                "parameters": [
-                    {
-                        "type": "string",
-                        "description": "Filtrar por tipo de movimiento ('entry' para entradas, 'exit' para salidas)",
-                        "name": "type",
-                        "in": "query"
-                    },
-                    {
-                        "type": "string",
-                        "description": "Filtrar por mes (formato YYYY-MM)",
-                        "name": "month",
-                        "in": "query"
-                    }
+                    "Empleados"
                 ],
+                "summary": "Obtener todos los empleados",
                 "responses": {
                     "200": {
-                        "description": "Movimientos de stock obtenidos",
+                        "description": "Empleados obtenidos",
                         "schema": {
                             "allOf": [
                                 {
@@ -1447,7 +1623,7 @@ const docTemplate = `{
                                         "data": {
                                             "type": "array",
                                             "items": {
-                                                "$ref": "#/definitions/dtos.StockMovementDto"
+                                                "$ref": "#/definitions/dtos.GetStaffDto"
                                             }
                                         }
                                     }
@@ -1456,34 +1632,20 @@ const docTemplate = `{
                         }
                     },
                     "500": {
-                        "description": "Error al obtener movimientos de stock",
+                        "description": "Error interno del servidor",
                         "schema": {
-                            "allOf": [
-                                {
-                                    "$ref": "#/definitions/dtos.Response"
-                                },
-                                {
-                                    "type": "object",
-                                    "properties": {
-                                        "data": {
-                                            "type": "object"
-                                        }
-                                    }
-                                }
-                            ]
+                            "$ref": "#/definitions/dtos.ErrorResponse"
                         }
                     }
                 }
-            }
-        },
-        "/stock-movements/product/{id}": {
-            "get": {
+            },
+            "post": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Devuelve los movimientos de stock asociados a un producto específico, filtrados opcionalmente por tipo y mes.",
+                "description": "Registra un nuevo empleado (estilista), opcionalmente vinculado a un usuario.",
                 "consumes": [
                     "application/json"
                 ],
@@ -1491,12 +1653,23 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Movimientos de Stock"
+                    "Empleados"
+                ],
+                "summary": "Crear empleado",
+                "parameters": [
+                    {
+                        "description": "Datos del empleado",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dtos.StaffDto"
+                        }
+                    }
                 ],
-                "summary": "Obtener movimientos de stock por producto",
                 "responses": {
                     "200": {
-                        "description": "Movimientos de stock obtenidos por producto",
+                        "description": "Empleado creado exitosamente",
                         "schema": {
                             "allOf": [
                                 {
@@ -1506,10 +1679,7 @@ const docTemplate = `{
                                     "type": "object",
                                     "properties": {
                                         "data": {
-                                            "type": "array",
-                                            "items": {
-                                                "$ref": "#/definitions/dtos.StockMovementDto"
-                                            }
+                                            "type": "object"
                                         }
                                     }
                                 }
@@ -1517,88 +1687,59 @@ const docTemplate = `{
                         }
                     },
                     "400": {
-                        "description": "ID del producto inválido",
+                        "description": "Datos inválidos",
                         "schema": {
-                            "allOf": [
-                                {
-                                    "$ref": "#/definitions/dtos.Response"
-                                },
-                                {
-                                    "type": "object",
-                                    "properties": {
-                                        "data": {
-                                            "type": "object"
-                                        }
-                                    }
-                                }
-                            ]
+                            "$ref": "#/definitions/dtos.ErrorResponse"
                         }
                     },
                     "500": {
-                        "description": "Error al obtener movimientos de stock por producto",
+                        "description": "Error interno del servidor",
                         "schema": {
-                            "allOf": [
-                                {
-                                    "$ref": "#/definitions/dtos.Response"
-                                },
-                                {
-                                    "type": "object",
-                                    "properties": {
-                                        "data": {
-                                            "type": "object"
-                                        }
-                                    }
-                                }
-                            ]
+                            "$ref": "#/definitions/dtos.ErrorResponse"
                         }
                     }
                 }
             }
         },
-        "/turno": {
-            "get": {
+        "/empleado/excepciones/{id}": {
+            "put": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Devuelve una lista de todos los turnos registrados, con la posibilidad de aplicar filtros.",
+                "description": "Modifica una excepción de horario y devuelve los turnos ya agendados que quedan fuera del nuevo horario.",
+                "consumes": [
+                    "application/json"
+                ],
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Turnos"
+                    "Horarios de empleados"
                 ],
-                "summary": "Obtener todos los turnos",
+                "summary": "Actualizar excepción de horario",
                 "parameters": [
                     {
-                        "type": "string",
-                        "description": "ID del cliente (opcional)",
-                        "name": "client_id",
-                        "in": "query"
-                    },
-                    {
-                        "type": "string",
-                        "description": "Estado del turno (opcional)",
-                        "name": "status",
-                        "in": "query"
-                    },
-                    {
-                        "type": "string",
-                        "description": "Fecha de inicio (opcional), formato: YYYY-MM-DD",
-                        "name": "start_date",
-                        "in": "query"
+                        "type": "integer",
+                        "description": "ID de la excepción",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
                     },
                     {
-                        "type": "string",
-                        "description": "Fecha de fin (opcional), formato: YYYY-MM-DD",
-                        "name": "end_date",
-                        "in": "query"
+                        "description": "Datos de la excepción",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dtos.StaffScheduleExceptionDto"
+                        }
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Turnos obtenidos",
+                        "description": "Excepción actualizada",
                         "schema": {
                             "allOf": [
                                 {
@@ -1608,21 +1749,53 @@ const docTemplate = `{
                                     "type": "object",
                                     "properties": {
                                         "data": {
-                                            "type": "array",
-                                            "items": {
-                                                "$ref": "#/definitions/dtos.AllAppointmentDto"
-                                            }
-                                        },
-                                        "message": {
-                                            "type": "string"
+                                            "$ref": "#/definitions/dtos.GetStaffScheduleExceptionDto"
                                         }
                                     }
                                 }
                             ]
                         }
                     },
+                    "400": {
+                        "description": "ID o datos inválidos",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.ErrorResponse"
+                        }
+                    },
                     "500": {
                         "description": "Error interno del servidor",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Elimina una excepción; el empleado vuelve a su horario semanal ese día.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Horarios de empleados"
+                ],
+                "summary": "Eliminar excepción de horario",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "ID de la excepción",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Excepción eliminada",
                         "schema": {
                             "allOf": [
                                 {
@@ -1633,24 +1806,35 @@ const docTemplate = `{
                                     "properties": {
                                         "data": {
                                             "type": "object"
-                                        },
-                                        "message": {
-                                            "type": "string"
                                         }
                                     }
                                 }
                             ]
                         }
+                    },
+                    "400": {
+                        "description": "ID inválido",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Error interno del servidor",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.ErrorResponse"
+                        }
                     }
                 }
-            },
-            "post": {
+            }
+        },
+        "/empleado/horarios/{id}": {
+            "put": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Permite crear un nuevo turno en el sistema.",
+                "description": "Modifica una franja semanal de un empleado.",
                 "consumes": [
                     "application/json"
                 ],
@@ -1658,23 +1842,30 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Turnos"
+                    "Horarios de empleados"
                 ],
-                "summary": "Crear turno",
+                "summary": "Actualizar franja de trabajo",
                 "parameters": [
                     {
-                        "description": "Datos del turno",
+                        "type": "integer",
+                        "description": "ID de la franja",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Datos de la franja",
                         "name": "request",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/dtos.CreateAppointmentDto"
+                            "$ref": "#/definitions/dtos.StaffShiftDto"
                         }
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Turno creado con éxito",
+                        "description": "Franja actualizada",
                         "schema": {
                             "allOf": [
                                 {
@@ -1685,9 +1876,6 @@ const docTemplate = `{
                                     "properties": {
                                         "data": {
                                             "type": "object"
-                                        },
-                                        "message": {
-                                            "type": "string"
                                         }
                                     }
                                 }
@@ -1695,28 +1883,45 @@ const docTemplate = `{
                         }
                     },
                     "400": {
-                        "description": "Datos inválidos",
+                        "description": "ID o datos inválidos",
                         "schema": {
-                            "allOf": [
-                                {
-                                    "$ref": "#/definitions/dtos.Response"
-                                },
-                                {
-                                    "type": "object",
-                                    "properties": {
-                                        "data": {
-                                            "type": "object"
-                                        },
-                                        "message": {
-                                            "type": "string"
-                                        }
-                                    }
-                                }
-                            ]
+                            "$ref": "#/definitions/dtos.ErrorResponse"
                         }
                     },
                     "500": {
                         "description": "Error interno del servidor",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Elimina una franja semanal de un empleado.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Horarios de empleados"
+                ],
+                "summary": "Eliminar franja de trabajo",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "ID de la franja",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Franja eliminada",
                         "schema": {
                             "allOf": [
                                 {
@@ -1727,37 +1932,46 @@ const docTemplate = `{
                                     "properties": {
                                         "data": {
                                             "type": "object"
-                                        },
-                                        "message": {
-                                            "type": "string"
                                         }
                                     }
                                 }
                             ]
                         }
+                    },
+                    "400": {
+                        "description": "ID inválido",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Error interno del servidor",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.ErrorResponse"
+                        }
                     }
                 }
             }
         },
-        "/turno/{id}": {
+        "/empleado/{id}": {
             "get": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Devuelve los datos de un turno específico.",
+                "description": "Devuelve los datos de un empleado específico.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Turnos"
+                    "Empleados"
                 ],
-                "summary": "Obtener turno por ID",
+                "summary": "Obtener empleado por ID",
                 "parameters": [
                     {
                         "type": "integer",
-                        "description": "ID del turno",
+                        "description": "ID del empleado",
                         "name": "id",
                         "in": "path",
                         "required": true
@@ -1765,7 +1979,7 @@ const docTemplate = `{
                 ],
                 "responses": {
                     "200": {
-                        "description": "Turno obtenido con éxito",
+                        "description": "Empleado encontrado",
                         "schema": {
                             "allOf": [
                                 {
@@ -1775,10 +1989,7 @@ const docTemplate = `{
                                     "type": "object",
                                     "properties": {
                                         "data": {
-                                            "$ref": "#/definitions/dtos.AppointmentByIDDto"
-                                        },
-                                        "message": {
-                                            "type": "string"
+                                            "$ref": "#/definitions/dtos.GetStaffDto"
                                         }
                                     }
                                 }
@@ -1788,43 +1999,13 @@ const docTemplate = `{
                     "400": {
                         "description": "ID inválido",
                         "schema": {
-                            "allOf": [
-                                {
-                                    "$ref": "#/definitions/dtos.Response"
-                                },
-                                {
-                                    "type": "object",
-                                    "properties": {
-                                        "data": {
-                                            "type": "object"
-                                        },
-                                        "message": {
-                                            "type": "string"
-                                        }
-                                    }
-                                }
-                            ]
+                            "$ref": "#/definitions/dtos.ErrorResponse"
                         }
                     },
-                    "500": {
-                        "description": "Error interno del servidor",
+                    "404": {
+                        "description": "Empleado no encontrado",
                         "schema": {
-                            "allOf": [
-                                {
-                                    "$ref": "#/definitions/dtos.Response"
-                                },
-                                {
-                                    "type": "object",
-                                    "properties": {
-                                        "data": {
-                                            "type": "object"
-                                        },
-                                        "message": {
-                                            "type": "string"
-                                        }
-                                    }
-                                }
-                            ]
+                            "$ref": "#/definitions/dtos.ErrorResponse"
                         }
                     }
                 }
@@ -1835,7 +2016,7 @@ const docTemplate = `{
                         "BearerAuth": []
                     }
                 ],
-                "description": "Permite actualizar un turno existente en el sistema.",
+                "description": "Actualiza los datos de un empleado específico.",
                 "consumes": [
                     "application/json"
                 ],
@@ -1843,30 +2024,30 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Turnos"
+                    "Empleados"
                 ],
-                "summary": "Actualizar turno",
+                "summary": "Actualizar empleado",
                 "parameters": [
                     {
                         "type": "integer",
-                        "description": "ID del turno",
+                        "description": "ID del empleado",
                         "name": "id",
                         "in": "path",
                         "required": true
                     },
                     {
-                        "description": "Datos actualizados del turno",
+                        "description": "Datos del empleado",
                         "name": "request",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/dtos.CreateAppointmentDto"
+                            "$ref": "#/definitions/dtos.StaffDto"
                         }
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Turno actualizado con éxito",
+                        "description": "Empleado actualizado",
                         "schema": {
                             "allOf": [
                                 {
@@ -1877,9 +2058,6 @@ const docTemplate = `{
                                     "properties": {
                                         "data": {
                                             "type": "object"
-                                        },
-                                        "message": {
-                                            "type": "string"
                                         }
                                     }
                                 }
@@ -1887,45 +2065,15 @@ const docTemplate = `{
                         }
                     },
                     "400": {
-                        "description": "Datos o ID inválidos",
+                        "description": "ID o datos inválidos",
                         "schema": {
-                            "allOf": [
-                                {
-                                    "$ref": "#/definitions/dtos.Response"
-                                },
-                                {
-                                    "type": "object",
-                                    "properties": {
-                                        "data": {
-                                            "type": "object"
-                                        },
-                                        "message": {
-                                            "type": "string"
-                                        }
-                                    }
-                                }
-                            ]
+                            "$ref": "#/definitions/dtos.ErrorResponse"
                         }
                     },
                     "500": {
                         "description": "Error interno del servidor",
                         "schema": {
-                            "allOf": [
-                                {
-                                    "$ref": "#/definitions/dtos.Response"
-                                },
-                                {
-                                    "type": "object",
-                                    "properties": {
-                                        "data": {
-                                            "type": "object"
-                                        },
-                                        "message": {
-                                            "type": "string"
-                                        }
-                                    }
-                                }
-                            ]
+                            "$ref": "#/definitions/dtos.ErrorResponse"
                         }
                     }
                 }
@@ -1936,18 +2084,18 @@ const docTemplate = `{
                         "BearerAuth": []
                     }
                 ],
-                "description": "Permite eliminar un turno del sistema.",
+                "description": "Elimina un empleado sin turnos asignados.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Turnos"
+                    "Empleados"
                 ],
-                "summary": "Eliminar turno",
+                "summary": "Eliminar empleado",
                 "parameters": [
                     {
                         "type": "integer",
-                        "description": "ID del turno",
+                        "description": "ID del empleado",
                         "name": "id",
                         "in": "path",
                         "required": true
@@ -1955,7 +2103,7 @@ const docTemplate = `{
                 ],
                 "responses": {
                     "200": {
-                        "description": "Turno eliminado con éxito",
+                        "description": "Empleado eliminado",
                         "schema": {
                             "allOf": [
                                 {
@@ -1966,9 +2114,6 @@ const docTemplate = `{
                                     "properties": {
                                         "data": {
                                             "type": "object"
-                                        },
-                                        "message": {
-                                            "type": "string"
                                         }
                                     }
                                 }
@@ -1978,87 +2123,63 @@ const docTemplate = `{
                     "400": {
                         "description": "ID inválido",
                         "schema": {
-                            "allOf": [
-                                {
-                                    "$ref": "#/definitions/dtos.Response"
-                                },
-                                {
-                                    "type": "object",
-                                    "properties": {
-                                        "data": {
-                                            "type": "object"
-                                        },
-                                        "message": {
-                                            "type": "string"
-                                        }
-                                    }
-                                }
-                            ]
+                            "$ref": "#/definitions/dtos.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Empleado no encontrado",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.ErrorResponse"
                         }
                     },
                     "500": {
                         "description": "Error interno del servidor",
                         "schema": {
-                            "allOf": [
-                                {
-                                    "$ref": "#/definitions/dtos.Response"
-                                },
-                                {
-                                    "type": "object",
-                                    "properties": {
-                                        "data": {
-                                            "type": "object"
-                                        },
-                                        "message": {
-                                            "type": "string"
-                                        }
-                                    }
-                                }
-                            ]
+                            "$ref": "#/definitions/dtos.ErrorResponse"
                         }
                     }
                 }
             }
         },
-        "/turno/{id}/finalizar": {
-            "put": {
+        "/empleado/{id}/excepciones": {
+            "get": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Permite finalizar un turno, registrando productos utilizados y método de pago.",
-                "consumes": [
-                    "application/json"
-                ],
+                "description": "Devuelve los cambios de horario y francos puntuales del empleado, opcionalmente entre dos fechas.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Turnos"
+                    "Horarios de empleados"
                 ],
-                "summary": "Finalizar turno",
+                "summary": "Obtener excepciones de horario de un empleado",
                 "parameters": [
                     {
                         "type": "integer",
-                        "description": "ID del turno",
+                        "description": "ID del empleado",
                         "name": "id",
                         "in": "path",
                         "required": true
                     },
                     {
-                        "description": "Datos para finalizar el turno",
-                        "name": "request",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/dtos.FinalizeAppointmentDto"
-                        }
+                        "type": "string",
+                        "description": "Desde (DD/MM/YYYY)",
+                        "name": "from",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Hasta (DD/MM/YYYY)",
+                        "name": "to",
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Turno finalizado con éxito",
+                        "description": "Excepciones obtenidas",
                         "schema": {
                             "allOf": [
                                 {
@@ -2068,10 +2189,10 @@ const docTemplate = `{
                                     "type": "object",
                                     "properties": {
                                         "data": {
-                                            "type": "object"
-                                        },
-                                        "message": {
-                                            "type": "string"
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/dtos.GetStaffScheduleExceptionDto"
+                                            }
                                         }
                                     }
                                 }
@@ -2079,58 +2200,26 @@ const docTemplate = `{
                         }
                     },
                     "400": {
-                        "description": "Datos o ID inválidos",
+                        "description": "ID o fechas inválidos",
                         "schema": {
-                            "allOf": [
-                                {
-                                    "$ref": "#/definitions/dtos.Response"
-                                },
-                                {
-                                    "type": "object",
-                                    "properties": {
-                                        "data": {
-                                            "type": "object"
-                                        },
-                                        "message": {
-                                            "type": "string"
-                                        }
-                                    }
-                                }
-                            ]
+                            "$ref": "#/definitions/dtos.ErrorResponse"
                         }
                     },
                     "500": {
                         "description": "Error interno del servidor",
                         "schema": {
-                            "allOf": [
-                                {
-                                    "$ref": "#/definitions/dtos.Response"
-                                },
-                                {
-                                    "type": "object",
-                                    "properties": {
-                                        "data": {
-                                            "type": "object"
-                                        },
-                                        "message": {
-                                            "type": "string"
-                                        }
-                                    }
-                                }
-                            ]
+                            "$ref": "#/definitions/dtos.ErrorResponse"
                         }
                     }
                 }
-            }
-        },
-        "/turno/{id}/products": {
-            "put": {
+            },
+            "post": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Permite actualizar los productos utilizados en un turno finalizado.",
+                "description": "Reemplaza el horario semanal del empleado en una fecha. Sin horario, el empleado no trabaja ese día. Devuelve los turnos ya agendados que quedan fuera del nuevo horario.",
                 "consumes": [
                     "application/json"
                 ],
@@ -2138,30 +2227,30 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Turnos"
+                    "Horarios de empleados"
                 ],
-                "summary": "Actualizar productos del turno",
+                "summary": "Crear excepción de horario",
                 "parameters": [
                     {
                         "type": "integer",
-                        "description": "ID del turno",
+                        "description": "ID del empleado",
                         "name": "id",
                         "in": "path",
                         "required": true
                     },
                     {
-                        "description": "Datos de productos",
+                        "description": "Datos de la excepción",
                         "name": "request",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/dtos.UpdateAppointmentProductsDto"
+                            "$ref": "#/definitions/dtos.StaffScheduleExceptionDto"
                         }
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Productos actualizados",
+                        "description": "Excepción creada",
                         "schema": {
                             "allOf": [
                                 {
@@ -2171,10 +2260,7 @@ const docTemplate = `{
                                     "type": "object",
                                     "properties": {
                                         "data": {
-                                            "type": "object"
-                                        },
-                                        "message": {
-                                            "type": "string"
+                                            "$ref": "#/definitions/dtos.GetStaffScheduleExceptionDto"
                                         }
                                     }
                                 }
@@ -2182,68 +2268,47 @@ const docTemplate = `{
                         }
                     },
                     "400": {
-                        "description": "Datos inválidos",
+                        "description": "ID o datos inválidos",
                         "schema": {
-                            "allOf": [
-                                {
-                                    "$ref": "#/definitions/dtos.Response"
-                                },
-                                {
-                                    "type": "object",
-                                    "properties": {
-                                        "data": {
-                                            "type": "object"
-                                        },
-                                        "message": {
-                                            "type": "string"
-                                        }
-                                    }
-                                }
-                            ]
+                            "$ref": "#/definitions/dtos.ErrorResponse"
                         }
                     },
                     "500": {
                         "description": "Error interno del servidor",
                         "schema": {
-                            "allOf": [
-                                {
-                                    "$ref": "#/definitions/dtos.Response"
-                                },
-                                {
-                                    "type": "object",
-                                    "properties": {
-                                        "data": {
-                                            "type": "object"
-                                        },
-                                        "message": {
-                                            "type": "string"
-                                        }
-                                    }
-                                }
-                            ]
+                            "$ref": "#/definitions/dtos.ErrorResponse"
                         }
                     }
                 }
             }
         },
-        "/usuarios": {
+        "/empleado/{id}/horarios": {
             "get": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Devuelve una lista de todos los usuarios registrados.",
+                "description": "Devuelve el horario semanal del empleado. Sin franjas cargadas trabaja en todo el horario del salón.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Usuarios"
+                    "Horarios de empleados"
                 ],
-                "summary": "Obtener todos los usuarios",
-                "responses": {
-                    "200": {
-                        "description": "Usuarios encontrados",
+                "summary": "Obtener franjas de trabajo de un empleado",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "ID del empleado",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Franjas obtenidas",
                         "schema": {
                             "allOf": [
                                 {
@@ -2255,7 +2320,7 @@ const docTemplate = `{
                                         "data": {
                                             "type": "array",
                                             "items": {
-                                                "$ref": "#/definitions/dtos.GetUserDto"
+                                                "$ref": "#/definitions/dtos.GetStaffShiftDto"
                                             }
                                         }
                                     }
@@ -2263,8 +2328,14 @@ const docTemplate = `{
                             ]
                         }
                     },
+                    "400": {
+                        "description": "ID inválido",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.ErrorResponse"
+                        }
+                    },
                     "500": {
-                        "description": "Error interno del servidor. Ejemplo: No se pudo obtener la lista de usuarios",
+                        "description": "Error interno del servidor",
                         "schema": {
                             "$ref": "#/definitions/dtos.ErrorResponse"
                         }
@@ -2277,7 +2348,7 @@ const docTemplate = `{
                         "BearerAuth": []
                     }
                 ],
-                "description": "Crea un nuevo usuario en el sistema.",
+                "description": "Agrega una franja semanal al horario del empleado. Un día puede tener varias franjas (turno cortado).",
                 "consumes": [
                     "application/json"
                 ],
@@ -2285,23 +2356,30 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Usuarios"
+                    "Horarios de empleados"
                 ],
-                "summary": "Crear usuario",
+                "summary": "Crear franja de trabajo",
                 "parameters": [
                     {
-                        "description": "Datos del usuario",
-                        "name": "user",
+                        "type": "integer",
+                        "description": "ID del empleado",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Datos de la franja",
+                        "name": "request",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/dtos.UserDto"
+                            "$ref": "#/definitions/dtos.StaffShiftDto"
                         }
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Usuario creado exitosamente",
+                        "description": "Franja creada",
                         "schema": {
                             "allOf": [
                                 {
@@ -2311,7 +2389,7 @@ const docTemplate = `{
                                     "type": "object",
                                     "properties": {
                                         "data": {
-                                            "type": "string"
+                                            "type": "object"
                                         }
                                     }
                                 }
@@ -2319,13 +2397,13 @@ const docTemplate = `{
                         }
                     },
                     "400": {
-                        "description": "Datos inválidos. Ejemplo: El nombre de usuario ya existe",
+                        "description": "ID o datos inválidos",
                         "schema": {
                             "$ref": "#/definitions/dtos.ErrorResponse"
                         }
                     },
                     "500": {
-                        "description": "Error interno del servidor. Ejemplo: No se pudo conectar a la base de datos",
+                        "description": "Error interno del servidor",
                         "schema": {
                             "$ref": "#/definitions/dtos.ErrorResponse"
                         }
@@ -2333,25 +2411,25 @@ const docTemplate = `{
                 }
             }
         },
-        "/usuarios/{id}": {
+        "/empleado/{id}/servicios": {
             "get": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Devuelve los datos de un usuario específico.",
+                "description": "Devuelve los servicios que realiza el empleado con su precio y tiempo efectivos. Sin servicios cargados, el empleado realiza todos con los valores de cada servicio.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Usuarios"
+                    "Empleados"
                 ],
-                "summary": "Obtener usuario por ID",
+                "summary": "Obtener servicios de un empleado",
                 "parameters": [
                     {
                         "type": "integer",
-                        "description": "ID del usuario",
+                        "description": "ID del empleado",
                         "name": "id",
                         "in": "path",
                         "required": true
@@ -2359,7 +2437,7 @@ const docTemplate = `{
                 ],
                 "responses": {
                     "200": {
-                        "description": "Usuario encontrado",
+                        "description": "Servicios del empleado obtenidos",
                         "schema": {
                             "allOf": [
                                 {
@@ -2369,7 +2447,10 @@ const docTemplate = `{
                                     "type": "object",
                                     "properties": {
                                         "data": {
-                                            "$ref": "#/definitions/dtos.GetUserDto"
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/dtos.GetStaffSkillDto"
+                                            }
                                         }
                                     }
                                 }
@@ -2377,26 +2458,28 @@ const docTemplate = `{
                         }
                     },
                     "400": {
-                        "description": "ID inválido. Ejemplo: El ID proporcionado no es válido",
+                        "description": "ID inválido",
                         "schema": {
                             "$ref": "#/definitions/dtos.ErrorResponse"
                         }
                     },
-                    "404": {
-                        "description": "Usuario no encontrado. Ejemplo: El usuario solicitado no existe",
+                    "500": {
+                        "description": "Error interno del servidor",
                         "schema": {
                             "$ref": "#/definitions/dtos.ErrorResponse"
                         }
                     }
                 }
-            },
+            }
+        },
+        "/empleado/{id}/servicios/{serviceId}": {
             "put": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Actualiza los datos de un usuario específico.",
+                "description": "Habilita al empleado para realizar el servicio, opcionalmente con precio y tiempo activo propios. Si ya estaba asignado, actualiza esos valores.",
                 "consumes": [
                     "application/json"
                 ],
@@ -2404,30 +2487,36 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Usuarios"
+                    "Empleados"
                 ],
-                "summary": "Actualizar usuario",
+                "summary": "Asignar servicio a un empleado",
                 "parameters": [
                     {
                         "type": "integer",
-                        "description": "ID del usuario",
+                        "description": "ID del empleado",
                         "name": "id",
                         "in": "path",
                         "required": true
                     },
                     {
-                        "description": "Datos del usuario",
-                        "name": "user",
+                        "type": "integer",
+                        "description": "ID del servicio",
+                        "name": "serviceId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Precio y tiempo propios del empleado",
+                        "name": "request",
                         "in": "body",
-                        "required": true,
                         "schema": {
-                            "$ref": "#/definitions/dtos.UserDto"
+                            "$ref": "#/definitions/dtos.StaffSkillDto"
                         }
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Usuario actualizado correctamente",
+                        "description": "Servicio asignado",
                         "schema": {
                             "allOf": [
                                 {
@@ -2437,7 +2526,7 @@ const docTemplate = `{
                                     "type": "object",
                                     "properties": {
                                         "data": {
-                                            "type": "string"
+                                            "type": "object"
                                         }
                                     }
                                 }
@@ -2445,13 +2534,13 @@ const docTemplate = `{
                         }
                     },
                     "400": {
-                        "description": "Datos o ID inválidos. Ejemplo: Los datos enviados son inválidos",
+                        "description": "ID o datos inválidos",
                         "schema": {
                             "$ref": "#/definitions/dtos.ErrorResponse"
                         }
                     },
                     "500": {
-                        "description": "Error interno del servidor. Ejemplo: No se pudo actualizar el usuario",
+                        "description": "Error interno del servidor",
                         "schema": {
                             "$ref": "#/definitions/dtos.ErrorResponse"
                         }
@@ -2464,26 +2553,33 @@ const docTemplate = `{
                         "BearerAuth": []
                     }
                 ],
-                "description": "Elimina un usuario específico.",
+                "description": "Deja de ofrecer el servicio con este empleado. Los turnos ya agendados conservan su precio.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Usuarios"
+                    "Empleados"
                 ],
-                "summary": "Eliminar usuario",
+                "summary": "Quitar servicio a un empleado",
                 "parameters": [
                     {
                         "type": "integer",
-                        "description": "ID del usuario",
+                        "description": "ID del empleado",
                         "name": "id",
                         "in": "path",
                         "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "ID del servicio",
+                        "name": "serviceId",
+                        "in": "path",
+                        "required": true
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Usuario eliminado correctamente",
+                        "description": "Servicio quitado",
                         "schema": {
                             "allOf": [
                                 {
@@ -2493,7 +2589,7 @@ const docTemplate = `{
                                     "type": "object",
                                     "properties": {
                                         "data": {
-                                            "type": "string"
+                                            "type": "object"
                                         }
                                     }
                                 }
@@ -2501,191 +2597,8834 @@ const docTemplate = `{
                         }
                     },
                     "400": {
-                        "description": "ID inválido. Ejemplo: El ID proporcionado no es válido",
+                        "description": "ID inválido",
                         "schema": {
                             "$ref": "#/definitions/dtos.ErrorResponse"
                         }
                     },
                     "500": {
-                        "description": "Error interno del servidor. Ejemplo: No se pudo eliminar el usuario",
+                        "description": "Error interno del servidor",
                         "schema": {
                             "$ref": "#/definitions/dtos.ErrorResponse"
                         }
                     }
                 }
             }
-        }
-    },
-    "definitions": {
-        "dtos.AllAppointmentDto": {
-            "type": "object",
-            "properties": {
-                "appointment_date": {
-                    "type": "string",
-                    "example": "12/01/2025 15:30"
-                },
-                "client_id": {
-                    "type": "integer",
-                    "example": 1
-                },
-                "client_name": {
-                    "type": "string",
-                    "example": "Juan Pérez"
-                },
-                "estimated_time_minutes": {
-                    "type": "integer",
-                    "example": 60
-                },
-                "id": {
-                    "type": "integer",
-                    "example": 1
-                },
-                "status": {
-                    "type": "string",
-                    "example": "pendiente"
-                }
-            }
         },
-        "dtos.AppointmentByIDDto": {
-            "type": "object",
-            "properties": {
-                "appointment_date": {
-                    "type": "string",
-                    "example": "12/01/2025 15:30"
-                },
-                "client_id": {
-                    "type": "integer",
-                    "example": 1
-                },
-                "client_name": {
-                    "type": "string",
-                    "example": "Juan Pérez"
-                },
-                "created_at": {
-                    "type": "string",
-                    "example": "2025-01-08T10:00:00Z"
-                },
-                "id": {
-                    "type": "integer",
-                    "example": 1
-                },
-                "products": {
-                    "type": "array",
-                    "items": {
-                        "$ref": "#/definitions/dtos.AppointmentProductDto"
+        "/eventos": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
                     }
-                },
-                "services": {
-                    "type": "array",
-                    "items": {
-                        "$ref": "#/definitions/dtos.AppointmentServiceDto"
+                ],
+                "description": "Flujo Server-Sent Events con los cambios de turnos (appointment.created, appointment.updated, appointment.finalized, appointment.deleted), de stock (stock.changed) y las coincidencias de la lista de espera (waitlist.match_found). Como EventSource no permite enviar headers, el token también puede ir en el parámetro access_token.",
+                "produces": [
+                    "text/event-stream"
+                ],
+                "tags": [
+                    "Eventos"
+                ],
+                "summary": "Eventos en vivo",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Prefijos de tipos de evento separados por coma (ej: appointment,stock)",
+                        "name": "types",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Token JWT, si no se envía el header Authorization",
+                        "name": "access_token",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Flujo de eventos",
+                        "schema": {
+                            "$ref": "#/definitions/events.Event"
+                        }
+                    },
+                    "401": {
+                        "description": "Token inválido",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/dtos.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "object"
+                                        },
+                                        "message": {
+                                            "type": "string"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
                     }
-                },
-                "status": {
-                    "type": "string",
-                    "example": "pendiente"
-                },
-                "updated_at": {
-                    "type": "string",
-                    "example": "2025-01-08T12:00:00Z"
-                }
-            }
-        },
-        "dtos.AppointmentProductDto": {
-            "type": "object",
-            "properties": {
-                "name": {
-                    "type": "string",
-                    "example": "Gel fijador"
-                },
-                "product_id": {
-                    "type": "integer",
-                    "example": 1
-                },
-                "quantity": {
-                    "type": "number",
-                    "example": 1
-                },
-                "unit": {
-                    "description": "Unidad del producto",
-                    "type": "string",
-                    "example": "unidad"
-                }
-            }
-        },
-        "dtos.AppointmentServiceDto": {
-            "type": "object",
-            "properties": {
-                "estimated_time_minutes": {
-                    "type": "integer",
-                    "example": 30
-                },
-                "price": {
-                    "type": "number",
-                    "example": 1500
-                },
-                "service_id": {
-                    "type": "integer",
-                    "example": 1
-                },
-                "service_name": {
-                    "type": "string",
-                    "example": "Corte de cabello"
                 }
             }
         },
-        "dtos.ClientAppointmentDto": {
+        "/fila": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Devuelve la fila del día en orden, con la espera estimada de cada ticket según los turnos en curso y la duración de los servicios.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Fila"
+                ],
+                "summary": "Obtener fila",
+                "responses": {
+                    "200": {
+                        "description": "Fila obtenida",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/dtos.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/dtos.GetWalkInDto"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "500": {
+                        "description": "Error interno del servidor",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Agrega a la fila a un cliente que llegó sin turno. Si no se indica client_id se busca por teléfono o se crea con el nombre.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Fila"
+                ],
+                "summary": "Sacar ticket",
+                "parameters": [
+                    {
+                        "description": "Datos del ticket",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dtos.WalkInDto"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Ticket creado",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/dtos.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/dtos.GetWalkInDto"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Datos inválidos",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Error interno del servidor",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/fila/siguiente": {
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Llama al primer ticket en espera. Con staff_id solo considera los tickets sin preferencia o que prefieren a ese estilista.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Fila"
+                ],
+                "summary": "Llamar al siguiente",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "ID del estilista que llama",
+                        "name": "staff_id",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Ticket llamado",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/dtos.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/dtos.GetWalkInDto"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "ID inválido o no hay clientes esperando",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/fila/{id}/atender": {
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Convierte el ticket en un turno para ahora con sus servicios y lo marca en curso.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Fila"
+                ],
+                "summary": "Atender ticket",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "ID del ticket",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Estilista que atiende",
+                        "name": "request",
+                        "in": "body",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.ServeWalkInDto"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Ticket atendido",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/dtos.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/dtos.GetWalkInDto"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "ID inválido, ticket ya atendido o fuera del horario de atención",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "El estilista está ocupado o no quedan recursos libres",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/dtos.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/dtos.AppointmentConflictDto"
+                                        },
+                                        "message": {
+                                            "type": "string"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "500": {
+                        "description": "Error interno del servidor",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/fila/{id}/posicion": {
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Mueve un ticket a otra posición de la fila (1 es el primero).",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Fila"
+                ],
+                "summary": "Reordenar ticket",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "ID del ticket",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Nueva posición",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dtos.WalkInPositionDto"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Fila reordenada",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/dtos.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "object"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "ID o posición inválidos",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/fila/{id}/saltear": {
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Saca de la fila a un ticket que no respondió al llamado.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Fila"
+                ],
+                "summary": "Saltear ticket",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "ID del ticket",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Ticket salteado",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/dtos.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "object"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "ID inválido o el ticket no está en la fila",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/ical/{token}": {
+            "get": {
+                "description": "Devuelve el calendario en formato iCalendar (.ics). El token de la URL es la única autenticación.",
+                "produces": [
+                    "text/calendar"
+                ],
+                "tags": [
+                    "Calendario"
+                ],
+                "summary": "Suscripción iCal",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Token del calendario, con o sin extensión .ics",
+                        "name": "token",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Calendario iCal",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "404": {
+                        "description": "Calendario no encontrado",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Error interno del servidor",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/licencia": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Devuelve las licencias, opcionalmente filtradas por empleado y estado.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Licencias"
+                ],
+                "summary": "Obtener licencias",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "ID del empleado",
+                        "name": "staff_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Estado: pendiente, aprobada o rechazada",
+                        "name": "status",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Licencias obtenidas",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/dtos.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/dtos.GetTimeOffDto"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "500": {
+                        "description": "Error interno del servidor",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Registra una solicitud de licencia (vacaciones, enfermedad u otro) pendiente de aprobación. Devuelve los turnos del empleado que caen dentro de ella. Sin el permiso approve_time_off solo se pueden pedir licencias para el empleado vinculado al usuario.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Licencias"
+                ],
+                "summary": "Solicitar licencia",
+                "parameters": [
+                    {
+                        "description": "Datos de la licencia",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dtos.TimeOffDto"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Licencia solicitada",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/dtos.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/dtos.GetTimeOffDto"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Datos inválidos",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "La licencia es de otro empleado",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Error interno del servidor",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/licencia/{id}": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Devuelve una licencia y los turnos del empleado que caen dentro de ella.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Licencias"
+                ],
+                "summary": "Obtener licencia por ID",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "ID de la licencia",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Licencia encontrada",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/dtos.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/dtos.GetTimeOffDto"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "ID inválido",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Licencia no encontrada",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Modifica una licencia que todavía está pendiente de aprobación. Sin el permiso approve_time_off solo se pueden modificar las licencias del empleado vinculado al usuario.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Licencias"
+                ],
+                "summary": "Actualizar licencia",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "ID de la licencia",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Datos de la licencia",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dtos.TimeOffDto"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Licencia actualizada",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/dtos.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "object"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "ID o datos inválidos",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "La licencia es de otro empleado",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Error interno del servidor",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Elimina una licencia; si estaba aprobada el empleado vuelve a estar disponible esos días.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Licencias"
+                ],
+                "summary": "Eliminar licencia",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "ID de la licencia",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Licencia eliminada",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/dtos.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "object"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "ID inválido",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Error interno del servidor",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/licencia/{id}/aprobar": {
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Aprueba una licencia pendiente. El empleado deja de ofrecerse en la agenda esos días y se devuelven los turnos ya agendados que quedan en conflicto.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Licencias"
+                ],
+                "summary": "Aprobar licencia",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "ID de la licencia",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Nota de la revisión",
+                        "name": "request",
+                        "in": "body",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.ReviewTimeOffDto"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Licencia aprobada",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/dtos.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/dtos.GetTimeOffDto"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "ID inválido",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Error interno del servidor",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/licencia/{id}/rechazar": {
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Rechaza una licencia pendiente.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Licencias"
+                ],
+                "summary": "Rechazar licencia",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "ID de la licencia",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Nota de la revisión",
+                        "name": "request",
+                        "in": "body",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.ReviewTimeOffDto"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Licencia rechazada",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/dtos.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/dtos.GetTimeOffDto"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "ID inválido",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Error interno del servidor",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/lista-espera": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Devuelve las entradas de la lista de espera, opcionalmente filtradas por estado.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Lista de espera"
+                ],
+                "summary": "Obtener lista de espera",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Estado (activa, atendida, cancelada)",
+                        "name": "status",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Lista de espera obtenida",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/dtos.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/dtos.GetWaitlistEntryDto"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "500": {
+                        "description": "Error interno del servidor",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Registra un cliente que quiere un turno en un rango de fechas y horario preferido.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Lista de espera"
+                ],
+                "summary": "Agregar a la lista de espera",
+                "parameters": [
+                    {
+                        "description": "Datos de la entrada",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dtos.WaitlistEntryDto"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Cliente agregado a la lista de espera",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/dtos.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "object"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Datos inválidos",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Error interno del servidor",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/lista-espera/coincidencias": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Devuelve los horarios liberados por cancelaciones o cambios junto con los clientes de la lista de espera a los que les sirven.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Lista de espera"
+                ],
+                "summary": "Candidatos para horarios liberados",
+                "responses": {
+                    "200": {
+                        "description": "Candidatos obtenidos",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/dtos.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/dtos.WaitlistMatchDto"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "500": {
+                        "description": "Error interno del servidor",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/lista-espera/coincidencias/{id}/descartar": {
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Marca un candidato de la lista de espera como descartado para que no se vuelva a mostrar.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Lista de espera"
+                ],
+                "summary": "Descartar candidato",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "ID del candidato",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Candidato descartado",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/dtos.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "object"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "ID inválido",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Error interno del servidor",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/lista-espera/{id}": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Devuelve los datos de una entrada específica de la lista de espera.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Lista de espera"
+                ],
+                "summary": "Obtener entrada de la lista de espera",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "ID de la entrada",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Entrada encontrada",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/dtos.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/dtos.GetWaitlistEntryDto"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "ID inválido",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Entrada no encontrada",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Actualiza los datos o el estado de una entrada de la lista de espera.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Lista de espera"
+                ],
+                "summary": "Actualizar entrada de la lista de espera",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "ID de la entrada",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Datos de la entrada",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dtos.WaitlistEntryDto"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Entrada actualizada",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/dtos.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "object"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "ID o datos inválidos",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Error interno del servidor",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Quita una entrada de la lista de espera.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Lista de espera"
+                ],
+                "summary": "Eliminar entrada de la lista de espera",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "ID de la entrada",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Entrada eliminada",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/dtos.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "object"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "ID inválido",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Error interno del servidor",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/login": {
+            "post": {
+                "description": "Permite a un usuario autenticarse en el sistema.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Autenticación"
+                ],
+                "summary": "Iniciar sesión",
+                "parameters": [
+                    {
+                        "description": "Datos de inicio de sesión",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dtos.LoginDto"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Token de acceso",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/dtos.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/dtos.LoginAnswerDto"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Datos inválidos. Ejemplo: El formato de los datos es incorrecto",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Credenciales inválidas. Ejemplo: Usuario o contraseña incorrectos",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/medio-pago": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Devuelve el catálogo de medios de pago.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Medios de pago"
+                ],
+                "summary": "Obtener medios de pago",
+                "parameters": [
+                    {
+                        "type": "boolean",
+                        "description": "Solo medios activos",
+                        "name": "active",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Medios de pago obtenidos",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/dtos.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/dtos.GetPaymentMethodDto"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "500": {
+                        "description": "Error interno del servidor",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Agrega un medio de pago al catálogo.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Medios de pago"
+                ],
+                "summary": "Crear medio de pago",
+                "parameters": [
+                    {
+                        "description": "Datos del medio de pago",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dtos.PaymentMethodDto"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Medio de pago creado",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/dtos.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "object"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Datos inválidos",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Error interno del servidor",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/medio-pago/{id}": {
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Actualiza nombre y configuración de un medio de pago. El código no se puede modificar.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Medios de pago"
+                ],
+                "summary": "Actualizar medio de pago",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "ID del medio de pago",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Datos del medio de pago",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dtos.PaymentMethodDto"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Medio de pago actualizado",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/dtos.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "object"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "ID o datos inválidos",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Medio de pago no encontrado",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Error interno del servidor",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Da de baja un medio de pago. Los pagos ya registrados lo conservan.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Medios de pago"
+                ],
+                "summary": "Eliminar medio de pago",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "ID del medio de pago",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Medio de pago eliminado",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/dtos.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "object"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "ID inválido",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Medio de pago no encontrado",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Error interno del servidor",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/notificaciones": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Devuelve la bandeja de salida de notificaciones con su estado de entrega.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Notificaciones"
+                ],
+                "summary": "Obtener notificaciones",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Estado (pendiente, enviada, fallida, cancelada)",
+                        "name": "status",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "ID del turno",
+                        "name": "appointment_id",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Notificaciones obtenidas",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/dtos.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/dtos.NotificationDto"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "500": {
+                        "description": "Error interno del servidor",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/notificaciones/{id}/reintentar": {
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Vuelve a poner en cola una notificación fallida.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Notificaciones"
+                ],
+                "summary": "Reintentar notificación",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "ID de la notificación",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Notificación en cola",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/dtos.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "object"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "ID inválido o la notificación no está fallida",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/producto": {
+            "get": {
+                "description": "Devuelve una lista de todos los productos registrados.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Productos"
+                ],
+                "summary": "Obtener todos los productos",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/dtos.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/dtos.GetProductDto"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "500": {
+                        "description": "Error interno del servidor",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/dtos.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "object"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            },
+            "post": {
+                "description": "Crea un nuevo producto en el sistema.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Productos"
+                ],
+                "summary": "Crear producto",
+                "parameters": [
+                    {
+                        "description": "Datos del producto",
+                        "name": "product",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dtos.CreateProductDto"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/dtos.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "object"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Datos inválidos",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/dtos.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "object"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "500": {
+                        "description": "Error interno del servidor",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/dtos.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "object"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            }
+        },
+        "/producto/{id}": {
+            "get": {
+                "description": "Devuelve los datos de un producto específico.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Productos"
+                ],
+                "summary": "Obtener producto por ID",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "ID del producto",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/dtos.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/dtos.GetProductDto"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "ID inválido",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/dtos.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "object"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "404": {
+                        "description": "Producto no encontrado",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/dtos.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "object"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            },
+            "put": {
+                "description": "Actualiza los datos de un producto específico.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Productos"
+                ],
+                "summary": "Actualizar producto",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "ID del producto",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Datos del producto",
+                        "name": "product",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dtos.UpdateProductDto"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/dtos.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "object"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Datos o ID inválidos",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/dtos.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "object"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "500": {
+                        "description": "Error interno del servidor",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/dtos.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "object"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "description": "Elimina un producto específico.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Productos"
+                ],
+                "summary": "Eliminar producto",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "ID del producto",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/dtos.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "object"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "ID inválido",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/dtos.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "object"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "500": {
+                        "description": "Error interno del servidor",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/dtos.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "object"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            }
+        },
+        "/producto/{id}/restock": {
+            "post": {
+                "description": "Agrega stock adicional a un producto existente.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Productos"
+                ],
+                "summary": "Reabastecer producto",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "ID del producto",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Datos para el reestock",
+                        "name": "restock",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dtos.RestockProductDto"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/dtos.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "object"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Datos o ID inválidos",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/dtos.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "object"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "500": {
+                        "description": "Error interno del servidor",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/dtos.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "object"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            }
+        },
+        "/promocion": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Devuelve las promociones y cupones, con la cantidad de veces que se aplicó cada uno.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Promociones"
+                ],
+                "summary": "Obtener todas las promociones",
+                "parameters": [
+                    {
+                        "type": "boolean",
+                        "description": "Solo promociones activas",
+                        "name": "active",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Promociones obtenidas",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/dtos.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/dtos.GetPromotionDto"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "500": {
+                        "description": "Error interno del servidor",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Crea una promoción de porcentaje o monto fijo para todo el turno, un servicio o una categoría. Con código funciona como cupón.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Promociones"
+                ],
+                "summary": "Crear promoción",
+                "parameters": [
+                    {
+                        "description": "Datos de la promoción",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dtos.PromotionDto"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Promoción creada",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/dtos.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "object"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Datos inválidos",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Error interno del servidor",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/promocion/{id}": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Devuelve una promoción con la cantidad de veces que se aplicó.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Promociones"
+                ],
+                "summary": "Obtener promoción por ID",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "ID de la promoción",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Promoción obtenida",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/dtos.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/dtos.GetPromotionDto"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "ID inválido",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Promoción no encontrada",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Error interno del servidor",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Actualiza una promoción. Los turnos ya finalizados conservan el descuento con el que se cobraron.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Promociones"
+                ],
+                "summary": "Actualizar promoción",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "ID de la promoción",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Datos de la promoción",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dtos.PromotionDto"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Promoción actualizada",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/dtos.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "object"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "ID o datos inválidos",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Promoción no encontrada",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Error interno del servidor",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Da de baja una promoción. Los turnos en que se aplicó conservan el descuento.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Promociones"
+                ],
+                "summary": "Eliminar promoción",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "ID de la promoción",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Promoción eliminada",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/dtos.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "object"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "ID inválido",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Promoción no encontrada",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Error interno del servidor",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/public/combos": {
+            "get": {
+                "description": "Devuelve los combos activos que se pueden reservar desde la web. No requiere autenticación.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Reservas online"
+                ],
+                "summary": "Combos reservables",
+                "responses": {
+                    "200": {
+                        "description": "Combos obtenidos",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/dtos.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/dtos.GetServiceBundleDto"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "429": {
+                        "description": "Demasiadas solicitudes",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Error interno del servidor",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/public/disponibilidad": {
+            "get": {
+                "description": "Devuelve los horarios libres de un día para los servicios elegidos. No requiere autenticación.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Reservas online"
+                ],
+                "summary": "Disponibilidad para reservas online",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Día a consultar, formato: DD/MM/YYYY",
+                        "name": "date",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "integer"
+                        },
+                        "collectionFormat": "multi",
+                        "description": "IDs de los servicios (se puede repetir o separar por comas)",
+                        "name": "service_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "integer"
+                        },
+                        "collectionFormat": "multi",
+                        "description": "IDs de los combos (se puede repetir o separar por comas)",
+                        "name": "bundle_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "integer"
+                        },
+                        "collectionFormat": "multi",
+                        "description": "IDs de las variantes elegidas (ej: largo del pelo)",
+                        "name": "variant_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "integer"
+                        },
+                        "collectionFormat": "multi",
+                        "description": "IDs de los adicionales elegidos",
+                        "name": "option_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "ID del estilista (opcional)",
+                        "name": "staff_id",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Disponibilidad obtenida",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/dtos.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/dtos.AvailabilityDto"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Parámetros inválidos",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.ErrorResponse"
+                        }
+                    },
+                    "429": {
+                        "description": "Demasiadas solicitudes",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Error interno del servidor",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/public/empleados": {
+            "get": {
+                "description": "Devuelve los estilistas activos que se pueden elegir al reservar. No requiere autenticación.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Reservas online"
+                ],
+                "summary": "Estilistas reservables",
+                "responses": {
+                    "200": {
+                        "description": "Estilistas obtenidos",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/dtos.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/dtos.PublicStaffDto"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "429": {
+                        "description": "Demasiadas solicitudes",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Error interno del servidor",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/public/servicios": {
+            "get": {
+                "description": "Devuelve los servicios que se pueden reservar desde la web. No requiere autenticación.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Reservas online"
+                ],
+                "summary": "Servicios reservables",
+                "responses": {
+                    "200": {
+                        "description": "Servicios obtenidos",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/dtos.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/dtos.GetServiceDto"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "429": {
+                        "description": "Demasiadas solicitudes",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Error interno del servidor",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/public/turno": {
+            "post": {
+                "description": "Registra una reserva desde la web. El código de confirmación se envía por email o al teléfono, nunca en la respuesta. Si el teléfono ya es de un cliente, el turno pasa a ese cliente al validar el código y el código se envía a sus datos de contacto cargados. El turno queda en estado \"solicitado\" hasta que el cliente valide el código y el salón lo confirme. No requiere autenticación.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Reservas online"
+                ],
+                "summary": "Solicitar turno",
+                "parameters": [
+                    {
+                        "description": "Datos de la reserva",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dtos.PublicBookingDto"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Reserva registrada",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/dtos.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/dtos.PublicBookingResultDto"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Datos inválidos, fuera del horario de atención o del estilista, o salón cerrado",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "El horario ya no está disponible",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.ErrorResponse"
+                        }
+                    },
+                    "429": {
+                        "description": "Demasiadas solicitudes",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Error interno del servidor",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/public/turno/{id}/confirmar": {
+            "post": {
+                "description": "Valida el código de un solo uso de una reserva online. Una vez validado, el salón puede confirmar el turno. No requiere autenticación.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Reservas online"
+                ],
+                "summary": "Validar código de reserva",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "ID del turno",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Código de confirmación",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dtos.ConfirmPublicBookingDto"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Código validado",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/dtos.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "object"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Código inválido o vencido",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.ErrorResponse"
+                        }
+                    },
+                    "429": {
+                        "description": "Demasiadas solicitudes",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Error interno del servidor",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/recurso": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Devuelve los recursos del salón, opcionalmente filtrados por tipo.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Recursos"
+                ],
+                "summary": "Obtener todos los recursos",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Tipo de recurso (ej: lavacabezas)",
+                        "name": "type",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Recursos obtenidos",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/dtos.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/dtos.GetResourceDto"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "500": {
+                        "description": "Error interno del servidor",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Registra un recurso físico del salón (sillón, lavacabezas) que los servicios ocupan al agendarse.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Recursos"
+                ],
+                "summary": "Crear recurso",
+                "parameters": [
+                    {
+                        "description": "Datos del recurso",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dtos.ResourceDto"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Recurso creado exitosamente",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/dtos.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "object"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Datos inválidos",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Error interno del servidor",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/recurso/{id}": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Devuelve los datos de un recurso específico.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Recursos"
+                ],
+                "summary": "Obtener recurso por ID",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "ID del recurso",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Recurso encontrado",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/dtos.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/dtos.GetResourceDto"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "ID inválido",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Recurso no encontrado",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Error interno del servidor",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Actualiza un recurso. No se puede desactivar ni cambiar de tipo si tiene turnos futuros asignados.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Recursos"
+                ],
+                "summary": "Actualizar recurso",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "ID del recurso",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Datos del recurso",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dtos.ResourceDto"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Recurso actualizado",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/dtos.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "object"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "ID o datos inválidos, o recurso con turnos asignados",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Recurso no encontrado",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Error interno del servidor",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Elimina un recurso sin turnos futuros asignados.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Recursos"
+                ],
+                "summary": "Eliminar recurso",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "ID del recurso",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Recurso eliminado",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/dtos.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "object"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "ID inválido o recurso con turnos asignados",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Recurso no encontrado",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Error interno del servidor",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/rol": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Devuelve una lista de todos los roles registrados en el sistema.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Roles"
+                ],
+                "summary": "Obtener todos los roles",
+                "responses": {
+                    "200": {
+                        "description": "Lista de roles encontrados",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/dtos.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/dtos.GetRoleDto"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "500": {
+                        "description": "Error interno del servidor",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Crea un nuevo rol con permisos específicos.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Roles"
+                ],
+                "summary": "Crear rol",
+                "parameters": [
+                    {
+                        "description": "Datos del nuevo rol",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dtos.CreateRoleDto"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Rol creado exitosamente",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/dtos.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "object"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Datos inválidos. Ejemplo: El nombre del rol ya existe",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Error interno del servidor",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/rol/{id}": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Devuelve los datos de un rol específico.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Roles"
+                ],
+                "summary": "Obtener rol por ID",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "ID del rol",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Rol encontrado",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/dtos.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/dtos.GetRoleDto"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "ID inválido",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Rol no encontrado",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Actualiza los datos de un rol específico.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Roles"
+                ],
+                "summary": "Actualizar rol",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "ID del rol",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Datos actualizados del rol",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dtos.CreateRoleDto"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Rol actualizado exitosamente",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/dtos.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "object"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Datos inválidos o ID inválido",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Error interno del servidor",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Elimina un rol específico.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Roles"
+                ],
+                "summary": "Eliminar rol",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "ID del rol",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Rol eliminado correctamente",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/dtos.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "object"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "ID inválido",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Error interno del servidor",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/servicio": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Devuelve una lista de todos los servicios registrados en el sistema.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Servicios"
+                ],
+                "summary": "Obtener todos los servicios",
+                "responses": {
+                    "200": {
+                        "description": "Servicios obtenidos",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/dtos.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/dtos.GetServiceDto"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "500": {
+                        "description": "Error interno del servidor",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Permite crear un nuevo servicio en el sistema.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Servicios"
+                ],
+                "summary": "Crear servicio",
+                "parameters": [
+                    {
+                        "description": "Datos del servicio",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dtos.ServiceDto"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Servicio creado",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/dtos.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "object"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Datos inválidos",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Error interno del servidor",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/servicio/{id}": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Devuelve los datos de un servicio específico.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Servicios"
+                ],
+                "summary": "Obtener servicio por ID",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "ID del servicio",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Servicio obtenido",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/dtos.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/dtos.GetServiceDto"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "ID inválido",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Error interno del servidor",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Permite actualizar los datos de un servicio existente.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Servicios"
+                ],
+                "summary": "Actualizar servicio",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "ID del servicio",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Datos del servicio",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dtos.ServiceDto"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Servicio actualizado",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/dtos.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "object"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "ID o datos inválidos",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Error interno del servidor",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Permite eliminar un servicio específico.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Servicios"
+                ],
+                "summary": "Eliminar servicio",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "ID del servicio",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Servicio eliminado",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/dtos.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "object"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "ID inválido",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Error interno del servidor",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/stock-movements": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Devuelve una lista de todos los movimientos de stock, filtrados opcionalmente por tipo y mes.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Movimientos de Stock"
+                ],
+                "summary": "Obtener movimientos de stock",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Filtrar por tipo de movimiento ('entry' para entradas, 'exit' para salidas)",
+                        "name": "type",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Filtrar por mes (formato YYYY-MM)",
+                        "name": "month",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Movimientos de stock obtenidos",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/dtos.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/dtos.StockMovementDto"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "500": {
+                        "description": "Error al obtener movimientos de stock",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/dtos.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "object"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            }
+        },
+        "/stock-movements/product/{id}": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Devuelve los movimientos de stock asociados a un producto específico, filtrados opcionalmente por tipo y mes.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Movimientos de Stock"
+                ],
+                "summary": "Obtener movimientos de stock por producto",
+                "responses": {
+                    "200": {
+                        "description": "Movimientos de stock obtenidos por producto",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/dtos.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/dtos.StockMovementDto"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "ID del producto inválido",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/dtos.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "object"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "500": {
+                        "description": "Error al obtener movimientos de stock por producto",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/dtos.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "object"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            }
+        },
+        "/turno": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Devuelve una lista de todos los turnos registrados, con la posibilidad de aplicar filtros.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Turnos"
+                ],
+                "summary": "Obtener todos los turnos",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "ID del cliente (opcional)",
+                        "name": "client_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "ID del estilista (opcional)",
+                        "name": "staff_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Estado del turno (opcional): solicitado, pendiente, confirmado, en_curso, finalizado, cancelado, ausente",
+                        "name": "status",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Fecha de inicio (opcional), formato: YYYY-MM-DD",
+                        "name": "start_date",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Fecha de fin (opcional), formato: YYYY-MM-DD",
+                        "name": "end_date",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Turnos obtenidos",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/dtos.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/dtos.AllAppointmentDto"
+                                            }
+                                        },
+                                        "message": {
+                                            "type": "string"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "500": {
+                        "description": "Error interno del servidor",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/dtos.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "object"
+                                        },
+                                        "message": {
+                                            "type": "string"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Permite crear un nuevo turno en el sistema. Si se indica una regla de repetición se crea una serie y se informan las fechas que no pudieron agendarse. Los combos agregan sus servicios con el descuento repartido entre ellos.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Turnos"
+                ],
+                "summary": "Crear turno",
+                "parameters": [
+                    {
+                        "description": "Datos del turno",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dtos.CreateAppointmentDto"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Turno creado con éxito",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/dtos.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/dtos.CreateAppointmentResultDto"
+                                        },
+                                        "message": {
+                                            "type": "string"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Datos inválidos, fuera del horario de atención o del estilista, o salón cerrado",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/dtos.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "object"
+                                        },
+                                        "message": {
+                                            "type": "string"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "409": {
+                        "description": "El turno se superpone con otro o no quedan recursos libres",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/dtos.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/dtos.AppointmentConflictDto"
+                                        },
+                                        "message": {
+                                            "type": "string"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "500": {
+                        "description": "Error interno del servidor",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/dtos.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "object"
+                                        },
+                                        "message": {
+                                            "type": "string"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            }
+        },
+        "/turno/disponibilidad": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Devuelve los horarios libres de un día según la duración de los servicios y combos solicitados y la agenda de los estilistas.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Turnos"
+                ],
+                "summary": "Consultar disponibilidad",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Día a consultar, formato: DD/MM/YYYY",
+                        "name": "date",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "integer"
+                        },
+                        "collectionFormat": "multi",
+                        "description": "IDs de los servicios (se puede repetir o separar por comas)",
+                        "name": "service_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "integer"
+                        },
+                        "collectionFormat": "multi",
+                        "description": "IDs de los combos (se puede repetir o separar por comas)",
+                        "name": "bundle_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "integer"
+                        },
+                        "collectionFormat": "multi",
+                        "description": "IDs de las variantes elegidas (ej: largo del pelo)",
+                        "name": "variant_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "integer"
+                        },
+                        "collectionFormat": "multi",
+                        "description": "IDs de los adicionales elegidos",
+                        "name": "option_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "ID del estilista (opcional)",
+                        "name": "staff_id",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Disponibilidad obtenida",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/dtos.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/dtos.AvailabilityDto"
+                                        },
+                                        "message": {
+                                            "type": "string"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Parámetros inválidos",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/dtos.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "object"
+                                        },
+                                        "message": {
+                                            "type": "string"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "500": {
+                        "description": "Error interno del servidor",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/dtos.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "object"
+                                        },
+                                        "message": {
+                                            "type": "string"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            }
+        },
+        "/turno/formulas/{id}": {
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Ficha del turno"
+                ],
+                "summary": "Actualizar fórmula de color",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "ID de la fórmula",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Datos de la fórmula",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dtos.ColorFormulaDto"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Fórmula actualizada",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/dtos.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "object"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "ID o datos inválidos",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Error interno del servidor",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Ficha del turno"
+                ],
+                "summary": "Eliminar fórmula de color",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "ID de la fórmula",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Fórmula eliminada",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/dtos.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "object"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "ID inválido",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Error interno del servidor",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/turno/fotos/{id}": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "produces": [
+                    "image/jpeg",
+                    "image/png"
+                ],
+                "tags": [
+                    "Ficha del turno"
+                ],
+                "summary": "Descargar foto del turno",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "ID de la foto",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Imagen",
+                        "schema": {
+                            "type": "file"
+                        }
+                    },
+                    "400": {
+                        "description": "ID inválido",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Foto no encontrada",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Ficha del turno"
+                ],
+                "summary": "Eliminar foto del turno",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "ID de la foto",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Foto eliminada",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/dtos.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "object"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "ID inválido",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Error interno del servidor",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/turno/fotos/{id}/miniatura": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "produces": [
+                    "image/jpeg"
+                ],
+                "tags": [
+                    "Ficha del turno"
+                ],
+                "summary": "Descargar miniatura de la foto",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "ID de la foto",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Miniatura JPEG",
+                        "schema": {
+                            "type": "file"
+                        }
+                    },
+                    "400": {
+                        "description": "ID inválido",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Foto no encontrada",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/turno/notas/{id}": {
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Ficha del turno"
+                ],
+                "summary": "Actualizar nota del turno",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "ID de la nota",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Nuevo texto",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dtos.AppointmentNoteDto"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Nota actualizada",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/dtos.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "object"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "ID o datos inválidos",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Error interno del servidor",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Ficha del turno"
+                ],
+                "summary": "Eliminar nota del turno",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "ID de la nota",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Nota eliminada",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/dtos.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "object"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "ID inválido",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Error interno del servidor",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/turno/sala": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Devuelve el estado del día en curso: turnos que atiende cada estilista y sus sillones, próximo turno, atraso en minutos respecto de la agenda y clientes que llegaron y esperan.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Turnos"
+                ],
+                "summary": "Estado de la sala",
+                "responses": {
+                    "200": {
+                        "description": "Estado de la sala obtenido",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/dtos.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/dtos.FloorStatusDto"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "500": {
+                        "description": "Error interno del servidor",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/dtos.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "object"
+                                        },
+                                        "message": {
+                                            "type": "string"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            }
+        },
+        "/turno/{id}": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Devuelve los datos de un turno específico.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Turnos"
+                ],
+                "summary": "Obtener turno por ID",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "ID del turno",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Turno obtenido con éxito",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/dtos.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/dtos.AppointmentByIDDto"
+                                        },
+                                        "message": {
+                                            "type": "string"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "ID inválido",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/dtos.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "object"
+                                        },
+                                        "message": {
+                                            "type": "string"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "500": {
+                        "description": "Error interno del servidor",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/dtos.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "object"
+                                        },
+                                        "message": {
+                                            "type": "string"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            },
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Permite actualizar un turno existente en el sistema.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Turnos"
+                ],
+                "summary": "Actualizar turno",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "ID del turno",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Alcance si el turno pertenece a una serie: este (por defecto), siguientes, todos",
+                        "name": "scope",
+                        "in": "query"
+                    },
+                    {
+                        "description": "Datos actualizados del turno",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dtos.CreateAppointmentDto"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Turno actualizado con éxito",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/dtos.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "object"
+                                        },
+                                        "message": {
+                                            "type": "string"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Datos o ID inválidos, fuera del horario de atención o del estilista, o salón cerrado",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/dtos.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "object"
+                                        },
+                                        "message": {
+                                            "type": "string"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "409": {
+                        "description": "El turno se superpone con otro o no quedan recursos libres",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/dtos.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/dtos.AppointmentConflictDto"
+                                        },
+                                        "message": {
+                                            "type": "string"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "500": {
+                        "description": "Error interno del servidor",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/dtos.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "object"
+                                        },
+                                        "message": {
+                                            "type": "string"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Permite eliminar un turno del sistema.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Turnos"
+                ],
+                "summary": "Eliminar turno",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "ID del turno",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Turno eliminado con éxito",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/dtos.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "object"
+                                        },
+                                        "message": {
+                                            "type": "string"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "ID inválido",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/dtos.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "object"
+                                        },
+                                        "message": {
+                                            "type": "string"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "500": {
+                        "description": "Error interno del servidor",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/dtos.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "object"
+                                        },
+                                        "message": {
+                                            "type": "string"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            }
+        },
+        "/turno/{id}/ausente": {
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Marca que el cliente no se presentó al turno.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Turnos"
+                ],
+                "summary": "Marcar ausencia",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "ID del turno",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Observación (opcional)",
+                        "name": "request",
+                        "in": "body",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.ChangeAppointmentStatusDto"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Ausencia registrada",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/dtos.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "object"
+                                        },
+                                        "message": {
+                                            "type": "string"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "ID inválido o transición no permitida",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/dtos.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "object"
+                                        },
+                                        "message": {
+                                            "type": "string"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "500": {
+                        "description": "Error interno del servidor",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/dtos.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "object"
+                                        },
+                                        "message": {
+                                            "type": "string"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            }
+        },
+        "/turno/{id}/cancelar": {
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Cancela un turno indicando el motivo. El horario queda libre en la agenda.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Turnos"
+                ],
+                "summary": "Cancelar turno",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "ID del turno",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Alcance si el turno pertenece a una serie: este (por defecto), siguientes, todos",
+                        "name": "scope",
+                        "in": "query"
+                    },
+                    {
+                        "description": "Motivo de la cancelación",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dtos.ChangeAppointmentStatusDto"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Turno cancelado",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/dtos.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "object"
+                                        },
+                                        "message": {
+                                            "type": "string"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Datos inválidos o transición no permitida",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/dtos.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "object"
+                                        },
+                                        "message": {
+                                            "type": "string"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "500": {
+                        "description": "Error interno del servidor",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/dtos.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "object"
+                                        },
+                                        "message": {
+                                            "type": "string"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            }
+        },
+        "/turno/{id}/comprobante": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Devuelve el comprobante de un turno finalizado, en PDF o en texto para impresoras térmicas de 80mm. El número es correlativo y se mantiene al reimprimirlo. El encabezado se toma de SALON_NAME, SALON_ADDRESS, SALON_PHONE y SALON_TAX_ID.",
+                "produces": [
+                    "application/pdf",
+                    "text/plain"
+                ],
+                "tags": [
+                    "Turnos"
+                ],
+                "summary": "Comprobante del turno",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "ID del turno",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "pdf (por defecto) o texto",
+                        "name": "format",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Comprobante",
+                        "schema": {
+                            "type": "file"
+                        }
+                    },
+                    "400": {
+                        "description": "ID o formato inválido, o turno no finalizado",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/dtos.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "object"
+                                        },
+                                        "message": {
+                                            "type": "string"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "404": {
+                        "description": "Turno no encontrado",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/dtos.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "object"
+                                        },
+                                        "message": {
+                                            "type": "string"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "500": {
+                        "description": "Error interno del servidor",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/dtos.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "object"
+                                        },
+                                        "message": {
+                                            "type": "string"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            }
+        },
+        "/turno/{id}/confirmar": {
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Marca un turno pendiente como confirmado.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Turnos"
+                ],
+                "summary": "Confirmar turno",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "ID del turno",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Turno confirmado",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/dtos.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "object"
+                                        },
+                                        "message": {
+                                            "type": "string"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "ID inválido o transición no permitida",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/dtos.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "object"
+                                        },
+                                        "message": {
+                                            "type": "string"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "500": {
+                        "description": "Error interno del servidor",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/dtos.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "object"
+                                        },
+                                        "message": {
+                                            "type": "string"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            }
+        },
+        "/turno/{id}/finalizar": {
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Permite finalizar un turno, registrando productos utilizados y los pagos. Las promociones y cupones indicados se descuentan de cada servicio alcanzado. Los pagos pueden combinar varios medios y deben sumar el total con descuentos. Las propinas se registran aparte, por estilista. Cobrar en efectivo requiere una caja abierta. Solo se finalizan turnos confirmados o en curso: un turno pendiente debe confirmarse o iniciarse antes.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Turnos"
+                ],
+                "summary": "Finalizar turno",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "ID del turno",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Datos para finalizar el turno",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dtos.FinalizeAppointmentDto"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Turno finalizado con éxito",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/dtos.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "object"
+                                        },
+                                        "message": {
+                                            "type": "string"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Datos o ID inválidos, turno que no está confirmado ni en curso, promoción no aplicable, pagos que no cubren el total o efectivo sin caja abierta",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/dtos.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "object"
+                                        },
+                                        "message": {
+                                            "type": "string"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "500": {
+                        "description": "Error interno del servidor",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/dtos.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "object"
+                                        },
+                                        "message": {
+                                            "type": "string"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            }
+        },
+        "/turno/{id}/formulas": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Registra un componente de la mezcla de color aplicada: marca, tono, volumen del oxidante, gramos y tiempo de exposición. Una mezcla de varios tonos se carga como varias fórmulas.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Ficha del turno"
+                ],
+                "summary": "Agregar fórmula de color al turno",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "ID del turno",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Datos de la fórmula",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dtos.ColorFormulaDto"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Fórmula creada",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/dtos.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/dtos.GetColorFormulaDto"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "ID o datos inválidos",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Error interno del servidor",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/turno/{id}/fotos": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Sube una foto del antes o el después. Se aceptan JPEG o PNG de hasta PHOTO_MAX_MB (10 MB por defecto); el tipo se verifica por el contenido. Se genera una miniatura JPEG.",
+                "consumes": [
+                    "multipart/form-data"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Ficha del turno"
+                ],
+                "summary": "Subir foto del turno",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "ID del turno",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "file",
+                        "description": "Imagen",
+                        "name": "file",
+                        "in": "formData",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "antes o despues",
+                        "name": "kind",
+                        "in": "formData",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Descripción",
+                        "name": "caption",
+                        "in": "formData"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Foto subida",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/dtos.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/dtos.GetAppointmentPhotoDto"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "ID inválido, archivo faltante o foto no aceptada",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.ErrorResponse"
+                        }
+                    },
+                    "413": {
+                        "description": "La foto supera el tamaño permitido",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Error interno del servidor",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/turno/{id}/historial": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Devuelve los cambios de estado del turno, con quién y cuándo los realizó.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Turnos"
+                ],
+                "summary": "Historial de estados del turno",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "ID del turno",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Historial obtenido",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/dtos.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/dtos.AppointmentStatusHistoryDto"
+                                            }
+                                        },
+                                        "message": {
+                                            "type": "string"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "ID inválido",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/dtos.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "object"
+                                        },
+                                        "message": {
+                                            "type": "string"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "500": {
+                        "description": "Error interno del servidor",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/dtos.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "object"
+                                        },
+                                        "message": {
+                                            "type": "string"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            }
+        },
+        "/turno/{id}/iniciar": {
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Marca un turno como en curso.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Turnos"
+                ],
+                "summary": "Iniciar turno",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "ID del turno",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Turno iniciado",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/dtos.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "object"
+                                        },
+                                        "message": {
+                                            "type": "string"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "ID inválido o transición no permitida",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/dtos.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "object"
+                                        },
+                                        "message": {
+                                            "type": "string"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "500": {
+                        "description": "Error interno del servidor",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/dtos.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "object"
+                                        },
+                                        "message": {
+                                            "type": "string"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            }
+        },
+        "/turno/{id}/llegada": {
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Marca que el cliente de un turno pendiente o confirmado llegó al salón. Al iniciar el turno sin llegada registrada, se toma la hora de inicio.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Turnos"
+                ],
+                "summary": "Registrar llegada del cliente",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "ID del turno",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Llegada registrada",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/dtos.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "object"
+                                        },
+                                        "message": {
+                                            "type": "string"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "ID inválido, llegada ya registrada o estado no permitido",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/dtos.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "object"
+                                        },
+                                        "message": {
+                                            "type": "string"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "500": {
+                        "description": "Error interno del servidor",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/dtos.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "object"
+                                        },
+                                        "message": {
+                                            "type": "string"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            }
+        },
+        "/turno/{id}/notas": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Registra una nota libre del estilista sobre lo realizado en el turno.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Ficha del turno"
+                ],
+                "summary": "Agregar nota al turno",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "ID del turno",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Texto de la nota",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dtos.AppointmentNoteDto"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Nota creada",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/dtos.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/dtos.GetAppointmentNoteDto"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "ID o datos inválidos",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Error interno del servidor",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/turno/{id}/products": {
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Permite actualizar los productos utilizados en un turno finalizado.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Turnos"
+                ],
+                "summary": "Actualizar productos del turno",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "ID del turno",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Datos de productos",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dtos.UpdateAppointmentProductsDto"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Productos actualizados",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/dtos.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "object"
+                                        },
+                                        "message": {
+                                            "type": "string"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Datos inválidos",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/dtos.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "object"
+                                        },
+                                        "message": {
+                                            "type": "string"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "500": {
+                        "description": "Error interno del servidor",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/dtos.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "object"
+                                        },
+                                        "message": {
+                                            "type": "string"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            }
+        },
+        "/usuarios": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Devuelve una lista de todos los usuarios registrados.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Usuarios"
+                ],
+                "summary": "Obtener todos los usuarios",
+                "responses": {
+                    "200": {
+                        "description": "Usuarios encontrados",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/dtos.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/dtos.GetUserDto"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "500": {
+                        "description": "Error interno del servidor. Ejemplo: No se pudo obtener la lista de usuarios",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Crea un nuevo usuario en el sistema.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Usuarios"
+                ],
+                "summary": "Crear usuario",
+                "parameters": [
+                    {
+                        "description": "Datos del usuario",
+                        "name": "user",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dtos.UserDto"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Usuario creado exitosamente",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/dtos.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "string"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Datos inválidos. Ejemplo: El nombre de usuario ya existe",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Error interno del servidor. Ejemplo: No se pudo conectar a la base de datos",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/usuarios/{id}": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Devuelve los datos de un usuario específico.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Usuarios"
+                ],
+                "summary": "Obtener usuario por ID",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "ID del usuario",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Usuario encontrado",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/dtos.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/dtos.GetUserDto"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "ID inválido. Ejemplo: El ID proporcionado no es válido",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Usuario no encontrado. Ejemplo: El usuario solicitado no existe",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Actualiza los datos de un usuario específico.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Usuarios"
+                ],
+                "summary": "Actualizar usuario",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "ID del usuario",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Datos del usuario",
+                        "name": "user",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dtos.UserDto"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Usuario actualizado correctamente",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/dtos.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "string"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Datos o ID inválidos. Ejemplo: Los datos enviados son inválidos",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Error interno del servidor. Ejemplo: No se pudo actualizar el usuario",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Elimina un usuario específico.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Usuarios"
+                ],
+                "summary": "Eliminar usuario",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "ID del usuario",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Usuario eliminado correctamente",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/dtos.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "string"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "ID inválido. Ejemplo: El ID proporcionado no es válido",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Error interno del servidor. Ejemplo: No se pudo eliminar el usuario",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        }
+    },
+    "definitions": {
+        "dtos.AllAppointmentDto": {
+            "type": "object",
+            "properties": {
+                "appointment_date": {
+                    "type": "string",
+                    "example": "12/01/2025 15:30"
+                },
+                "client_id": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "client_name": {
+                    "type": "string",
+                    "example": "Juan Pérez"
+                },
+                "duration_override": {
+                    "type": "integer",
+                    "example": 90
+                },
+                "estimated_time_minutes": {
+                    "type": "integer",
+                    "example": 60
+                },
+                "id": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "series_id": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "staff_id": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "staff_name": {
+                    "type": "string",
+                    "example": "Laura Gómez"
+                },
+                "status": {
+                    "type": "string",
+                    "example": "pendiente"
+                }
+            }
+        },
+        "dtos.AppointmentByIDDto": {
+            "type": "object",
+            "properties": {
+                "actual_minutes": {
+                    "description": "Duración real, si empezó y terminó",
+                    "type": "integer",
+                    "example": 65
+                },
+                "appointment_date": {
+                    "type": "string",
+                    "example": "12/01/2025 15:30"
+                },
+                "cancellation_reason": {
+                    "type": "string",
+                    "example": ""
+                },
+                "checked_in_at": {
+                    "description": "Llegada del cliente; vacío si no llegó",
+                    "type": "string",
+                    "example": "12/01/2025 15:25"
+                },
+                "client_id": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "client_name": {
+                    "type": "string",
+                    "example": "Juan Pérez"
+                },
+                "color_formulas": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dtos.GetColorFormulaDto"
+                    }
+                },
+                "created_at": {
+                    "type": "string",
+                    "example": "2025-01-08T10:00:00Z"
+                },
+                "discount": {
+                    "description": "Descuentos de promociones, ya restados del total",
+                    "type": "number",
+                    "example": 3000
+                },
+                "duration_override": {
+                    "type": "integer",
+                    "example": 90
+                },
+                "estimated_time_minutes": {
+                    "type": "integer",
+                    "example": 60
+                },
+                "finished_at": {
+                    "description": "Fin real; vacío si no terminó",
+                    "type": "string",
+                    "example": "12/01/2025 16:40"
+                },
+                "id": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "notes": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dtos.GetAppointmentNoteDto"
+                    }
+                },
+                "payment_method": {
+                    "type": "string",
+                    "example": "mixto"
+                },
+                "payments": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dtos.GetPaymentDto"
+                    }
+                },
+                "photos": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dtos.GetAppointmentPhotoDto"
+                    }
+                },
+                "products": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dtos.AppointmentProductDto"
+                    }
+                },
+                "resources": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dtos.AppointmentResourceDto"
+                    }
+                },
+                "series_id": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "services": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dtos.AppointmentServiceDto"
+                    }
+                },
+                "staff_id": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "staff_name": {
+                    "type": "string",
+                    "example": "Laura Gómez"
+                },
+                "started_at": {
+                    "description": "Inicio real; vacío si no empezó",
+                    "type": "string",
+                    "example": "12/01/2025 15:35"
+                },
+                "status": {
+                    "type": "string",
+                    "example": "pendiente"
+                },
+                "tips": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dtos.GetTipDto"
+                    }
+                },
+                "total": {
+                    "description": "Suma de los servicios",
+                    "type": "number",
+                    "example": 25000
+                },
+                "updated_at": {
+                    "type": "string",
+                    "example": "2025-01-08T12:00:00Z"
+                }
+            }
+        },
+        "dtos.AppointmentConflictDto": {
+            "type": "object",
+            "properties": {
+                "conflicting_appointment_id": {
+                    "type": "integer",
+                    "example": 12
+                }
+            }
+        },
+        "dtos.AppointmentNoteDto": {
+            "type": "object",
+            "properties": {
+                "text": {
+                    "type": "string",
+                    "example": "Se emparejó el largo y se marcó la nuca más corta"
+                }
+            }
+        },
+        "dtos.AppointmentProductDto": {
+            "type": "object",
+            "properties": {
+                "name": {
+                    "type": "string",
+                    "example": "Gel fijador"
+                },
+                "product_id": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "quantity": {
+                    "type": "number",
+                    "example": 1
+                },
+                "unit": {
+                    "description": "Unidad del producto",
+                    "type": "string",
+                    "example": "unidad"
+                }
+            }
+        },
+        "dtos.AppointmentResourceDto": {
+            "type": "object",
+            "properties": {
+                "end": {
+                    "type": "string",
+                    "example": "15:40"
+                },
+                "resource_id": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "resource_name": {
+                    "type": "string",
+                    "example": "Lavacabezas 1"
+                },
+                "resource_type": {
+                    "type": "string",
+                    "example": "lavacabezas"
+                },
+                "start": {
+                    "type": "string",
+                    "example": "15:30"
+                }
+            }
+        },
+        "dtos.AppointmentServiceDto": {
+            "type": "object",
+            "properties": {
+                "bundle_id": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "bundle_name": {
+                    "type": "string",
+                    "example": "Corte + color"
+                },
+                "discount": {
+                    "description": "Descuento de la promoción, ya restado del precio",
+                    "type": "number",
+                    "example": 300
+                },
+                "estimated_time_minutes": {
+                    "type": "integer",
+                    "example": 30
+                },
+                "list_price": {
+                    "description": "Precio sin el descuento del combo",
+                    "type": "number",
+                    "example": 1800
+                },
+                "options": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dtos.AppointmentServiceOptionDto"
+                    }
+                },
+                "price": {
+                    "description": "Precio cobrado",
+                    "type": "number",
+                    "example": 1500
+                },
+                "promotion_id": {
+                    "type": "integer",
+                    "example": 2
+                },
+                "promotion_name": {
+                    "type": "string",
+                    "example": "Martes de color"
+                },
+                "service_id": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "service_name": {
+                    "type": "string",
+                    "example": "Corte de cabello"
+                },
+                "staff_id": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "staff_name": {
+                    "type": "string",
+                    "example": "Laura Gómez"
+                },
+                "variant_id": {
+                    "type": "integer",
+                    "example": 3
+                },
+                "variant_name": {
+                    "type": "string",
+                    "example": "Pelo largo"
+                }
+            }
+        },
+        "dtos.AppointmentServiceOptionDto": {
+            "type": "object",
+            "properties": {
+                "extra_minutes": {
+                    "type": "integer",
+                    "example": 10
+                },
+                "name": {
+                    "type": "string",
+                    "example": "Matizador"
+                },
+                "option_id": {
+                    "type": "integer",
+                    "example": 5
+                },
+                "price": {
+                    "type": "number",
+                    "example": 3000
+                }
+            }
+        },
+        "dtos.AppointmentStatusHistoryDto": {
+            "type": "object",
+            "properties": {
+                "created_at": {
+                    "type": "string",
+                    "example": "12/01/2025 15:30"
+                },
+                "from_status": {
+                    "type": "string",
+                    "example": "pendiente"
+                },
+                "reason": {
+                    "type": "string",
+                    "example": ""
+                },
+                "to_status": {
+                    "type": "string",
+                    "example": "confirmado"
+                },
+                "user_id": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "username": {
+                    "type": "string",
+                    "example": "admin"
+                }
+            }
+        },
+        "dtos.AvailabilityDto": {
+            "type": "object",
+            "properties": {
+                "closed": {
+                    "description": "El salón no abre ese día",
+                    "type": "boolean",
+                    "example": false
+                },
+                "closed_reason": {
+                    "description": "Motivo del cierre, si corresponde",
+                    "type": "string",
+                    "example": ""
+                },
+                "date": {
+                    "type": "string",
+                    "example": "12/01/2025"
+                },
+                "estimated_time_minutes": {
+                    "type": "integer",
+                    "example": 60
+                },
+                "slot_minutes": {
+                    "type": "integer",
+                    "example": 15
+                },
+                "slots": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dtos.AvailableSlotDto"
+                    }
+                }
+            }
+        },
+        "dtos.AvailableSlotDto": {
+            "type": "object",
+            "properties": {
+                "staff_ids": {
+                    "description": "Estilistas libres en ese horario",
+                    "type": "array",
+                    "items": {
+                        "type": "integer"
+                    },
+                    "example": [
+                        1,
+                        2
+                    ]
+                },
+                "time": {
+                    "type": "string",
+                    "example": "15:30"
+                }
+            }
+        },
+        "dtos.BusinessHourDto": {
+            "type": "object",
+            "properties": {
+                "close_time": {
+                    "description": "Formato: HH:MM",
+                    "type": "string",
+                    "example": "13:00"
+                },
+                "open_time": {
+                    "description": "Formato: HH:MM",
+                    "type": "string",
+                    "example": "09:00"
+                },
+                "weekday": {
+                    "description": "0 = domingo ... 6 = sábado",
+                    "type": "integer",
+                    "example": 1
+                }
+            }
+        },
+        "dtos.CalendarFeedDto": {
+            "type": "object",
+            "properties": {
+                "staff_id": {
+                    "description": "Vacío para el calendario de todo el salón",
+                    "type": "integer",
+                    "example": 1
+                }
+            }
+        },
+        "dtos.CashMovementDto": {
+            "type": "object",
+            "properties": {
+                "amount": {
+                    "type": "number",
+                    "example": 3500
+                },
+                "description": {
+                    "description": "Obligatoria para gastos y retiros",
+                    "type": "string",
+                    "example": "Artículos de limpieza"
+                },
+                "type": {
+                    "description": "\"ingreso\", \"gasto\" o \"retiro\"",
+                    "type": "string",
+                    "example": "gasto"
+                }
+            }
+        },
+        "dtos.CashTotalDto": {
+            "type": "object",
+            "properties": {
+                "amount": {
+                    "description": "Negativo para salidas",
+                    "type": "number",
+                    "example": 62000
+                },
+                "count": {
+                    "type": "integer",
+                    "example": 18
+                },
+                "type": {
+                    "type": "string",
+                    "example": "pago"
+                }
+            }
+        },
+        "dtos.ChangeAppointmentStatusDto": {
+            "type": "object",
+            "properties": {
+                "reason": {
+                    "description": "Obligatorio al cancelar",
+                    "type": "string",
+                    "example": "El cliente avisó que no puede asistir"
+                }
+            }
+        },
+        "dtos.ClientAppointmentDto": {
+            "type": "object",
+            "properties": {
+                "appointment_date": {
+                    "type": "string",
+                    "example": "30/09/2002 16:30"
+                },
+                "color_formulas": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dtos.GetColorFormulaDto"
+                    }
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "notes": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dtos.GetAppointmentNoteDto"
+                    }
+                },
+                "photos": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dtos.GetAppointmentPhotoDto"
+                    }
+                },
+                "services": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "Corte de cabello",
+                        "Color"
+                    ]
+                },
+                "staff_name": {
+                    "type": "string",
+                    "example": "Laura Gómez"
+                },
+                "status": {
+                    "type": "string",
+                    "example": "finalizado"
+                }
+            }
+        },
+        "dtos.ClientDTO": {
+            "type": "object",
+            "properties": {
+                "email": {
+                    "type": "string",
+                    "example": "example@gmail.com"
+                },
+                "last_name": {
+                    "type": "string",
+                    "example": "Garcia Mendez"
+                },
+                "name": {
+                    "type": "string",
+                    "example": "Valentino"
+                },
+                "phone": {
+                    "type": "string",
+                    "example": "343534345"
+                }
+            }
+        },
+        "dtos.CloseCashSessionDto": {
+            "type": "object",
+            "properties": {
+                "counted_amount": {
+                    "description": "Efectivo contado al cerrar",
+                    "type": "number",
+                    "example": 84500
+                },
+                "notes": {
+                    "type": "string",
+                    "example": "Sin novedades"
+                }
+            }
+        },
+        "dtos.ClosureDto": {
+            "type": "object",
+            "properties": {
+                "end_date": {
+                    "description": "Formato: DD/MM/YYYY (opcional, por defecto igual a start_date)",
+                    "type": "string",
+                    "example": "25/12/2025"
+                },
+                "kind": {
+                    "description": "\"feriado\" o \"cierre\"",
+                    "type": "string",
+                    "example": "feriado"
+                },
+                "reason": {
+                    "type": "string",
+                    "example": "Navidad"
+                },
+                "recurring": {
+                    "description": "Se repite todos los años",
+                    "type": "boolean",
+                    "example": true
+                },
+                "start_date": {
+                    "description": "Formato: DD/MM/YYYY",
+                    "type": "string",
+                    "example": "25/12/2025"
+                }
+            }
+        },
+        "dtos.ColorFormulaDto": {
+            "type": "object",
+            "properties": {
+                "brand": {
+                    "type": "string",
+                    "example": "Igora Royal"
+                },
+                "developer_volume": {
+                    "description": "0, 10, 20, 30 o 40",
+                    "type": "integer",
+                    "example": 20
+                },
+                "grams": {
+                    "type": "number",
+                    "example": 30
+                },
+                "notes": {
+                    "type": "string",
+                    "example": "Mezcla 1:1"
+                },
+                "processing_minutes": {
+                    "type": "integer",
+                    "example": 35
+                },
+                "shade": {
+                    "type": "string",
+                    "example": "6-0"
+                },
+                "zone": {
+                    "description": "Opcional",
+                    "type": "string",
+                    "example": "raíz"
+                }
+            }
+        },
+        "dtos.ConfirmPublicBookingDto": {
+            "type": "object",
+            "properties": {
+                "code": {
+                    "type": "string",
+                    "example": "483920"
+                }
+            }
+        },
+        "dtos.CreateAppointmentDto": {
+            "type": "object",
+            "properties": {
+                "appointment_date": {
+                    "description": "Formato: HH:MM DD/MM/YYYY",
+                    "type": "string",
+                    "example": "15:30 12/01/2025"
+                },
+                "bundle_id": {
+                    "description": "IDs de los combos; se agregan sus servicios con el descuento repartido",
+                    "type": "array",
+                    "items": {
+                        "type": "integer"
+                    },
+                    "example": [
+                        1
+                    ]
+                },
+                "client_id": {
+                    "description": "ID del cliente",
+                    "type": "integer",
+                    "example": 1
+                },
+                "duration_override": {
+                    "description": "Duración manual en minutos (opcional); 0 al actualizar vuelve a la de los servicios",
+                    "type": "integer",
+                    "example": 90
+                },
+                "option_id": {
+                    "description": "Adicionales elegidos; misma regla que las variantes",
+                    "type": "array",
+                    "items": {
+                        "type": "integer"
+                    },
+                    "example": [
+                        5
+                    ]
+                },
+                "recurrence": {
+                    "description": "Regla de repetición (opcional, solo al crear)",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/dtos.RecurrenceDto"
+                        }
+                    ]
+                },
+                "service_id": {
+                    "description": "IDs de los servicios asociados",
+                    "type": "array",
+                    "items": {
+                        "type": "integer"
+                    },
+                    "example": [
+                        1,
+                        2
+                    ]
+                },
+                "staff_id": {
+                    "description": "ID del estilista (opcional)",
+                    "type": "integer",
+                    "example": 1
+                },
+                "variant_id": {
+                    "description": "Variantes elegidas; se aplican a la primera línea de su servicio o agregan una",
+                    "type": "array",
+                    "items": {
+                        "type": "integer"
+                    },
+                    "example": [
+                        3
+                    ]
+                }
+            }
+        },
+        "dtos.CreateAppointmentResultDto": {
+            "type": "object",
+            "properties": {
+                "appointment_ids": {
+                    "type": "array",
+                    "items": {
+                        "type": "integer"
+                    },
+                    "example": [
+                        1,
+                        2,
+                        3
+                    ]
+                },
+                "conflicts": {
+                    "description": "Ocurrencias que no pudieron agendarse",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dtos.SeriesConflictDto"
+                    }
+                },
+                "series_id": {
+                    "type": "integer",
+                    "example": 1
+                }
+            }
+        },
+        "dtos.CreateProductDto": {
+            "type": "object",
+            "properties": {
+                "brand": {
+                    "type": "string",
+                    "example": "Head \u0026 Shoulders"
+                },
+                "low_stock_alert": {
+                    "type": "number",
+                    "example": 100
+                },
+                "name": {
+                    "type": "string",
+                    "example": "Shampoo Anticaspa"
+                },
+                "package_count": {
+                    "type": "number",
+                    "example": 32
+                },
+                "unit": {
+                    "type": "string",
+                    "example": "ml"
+                },
+                "unit_per_package": {
+                    "type": "number",
+                    "example": 500
+                },
+                "unity_price": {
+                    "type": "number",
+                    "example": 10000
+                }
+            }
+        },
+        "dtos.CreateRoleDto": {
+            "type": "object",
+            "properties": {
+                "name": {
+                    "type": "string",
+                    "example": "empleado"
+                },
+                "permission_names": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "create_user",
+                        " delete_user"
+                    ]
+                }
+            }
+        },
+        "dtos.ErrorResponse": {
+            "type": "object",
+            "properties": {
+                "message": {
+                    "description": "Detalle del error",
+                    "type": "string",
+                    "example": "Descripción del error"
+                },
+                "status": {
+                    "description": "Siempre \"error\"",
+                    "type": "string",
+                    "example": "error"
+                }
+            }
+        },
+        "dtos.FinalizeAppointmentDto": {
+            "type": "object",
+            "properties": {
+                "coupon_code": {
+                    "description": "Cupones presentados por el cliente",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "COLOR20"
+                    ]
+                },
+                "payment_method": {
+                    "description": "Un único pago por el total; se usa si no se envían pagos",
+                    "type": "string",
+                    "example": "efectivo"
+                },
+                "payments": {
+                    "description": "Pagos del turno; deben sumar el total",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dtos.PaymentDto"
+                    }
+                },
+                "products": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dtos.FinalizeAppointmentProductDto"
+                    }
+                },
+                "promotion_id": {
+                    "description": "Promociones sin código a aplicar",
+                    "type": "array",
+                    "items": {
+                        "type": "integer"
+                    },
+                    "example": [
+                        2
+                    ]
+                },
+                "tips": {
+                    "description": "Propinas; no forman parte del total",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dtos.TipDto"
+                    }
+                }
+            }
+        },
+        "dtos.FinalizeAppointmentProductDto": {
+            "type": "object",
+            "properties": {
+                "product_id": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "quantity": {
+                    "type": "number",
+                    "example": 2
+                }
+            }
+        },
+        "dtos.FloorAppointmentDto": {
+            "type": "object",
+            "properties": {
+                "appointment_date": {
+                    "type": "string",
+                    "example": "15:30"
+                },
+                "appointment_id": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "checked_in_at": {
+                    "type": "string",
+                    "example": "15:25"
+                },
+                "client_name": {
+                    "type": "string",
+                    "example": "Juan Pérez"
+                },
+                "delay_minutes": {
+                    "description": "Atraso respecto del horario agendado",
+                    "type": "integer",
+                    "example": 5
+                },
+                "expected_end": {
+                    "description": "Fin previsto según el inicio real, o el agendado si no empezó",
+                    "type": "string",
+                    "example": "16:35"
+                },
+                "resources": {
+                    "description": "Sillones y equipos asignados",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "Sillón 1"
+                    ]
+                },
+                "services": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "Corte de cabello",
+                        "Color"
+                    ]
+                },
+                "started_at": {
+                    "type": "string",
+                    "example": "15:35"
+                },
+                "status": {
+                    "type": "string",
+                    "example": "en_curso"
+                }
+            }
+        },
+        "dtos.FloorStatusDto": {
+            "type": "object",
+            "properties": {
+                "date": {
+                    "type": "string",
+                    "example": "12/01/2025"
+                },
+                "stylists": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dtos.FloorStylistDto"
+                    }
+                },
+                "time": {
+                    "type": "string",
+                    "example": "15:40"
+                },
+                "unassigned": {
+                    "description": "Turnos en curso sin estilista asignado",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dtos.FloorAppointmentDto"
+                    }
+                },
+                "waiting": {
+                    "description": "Clientes que llegaron y aún no fueron atendidos",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dtos.FloorWaitingDto"
+                    }
+                }
+            }
+        },
+        "dtos.FloorStylistDto": {
+            "type": "object",
+            "properties": {
+                "busy": {
+                    "description": "Tiene turnos en curso",
+                    "type": "boolean",
+                    "example": true
+                },
+                "delay_minutes": {
+                    "type": "integer",
+                    "example": 5
+                },
+                "in_service": {
+                    "description": "Turnos en curso (puede haber más de uno durante una espera)",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dtos.FloorAppointmentDto"
+                    }
+                },
+                "next": {
+                    "description": "Próximo turno del día sin empezar",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/dtos.FloorAppointmentDto"
+                        }
+                    ]
+                },
+                "staff_id": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "staff_name": {
+                    "type": "string",
+                    "example": "Laura Gómez"
+                }
+            }
+        },
+        "dtos.FloorWaitingDto": {
+            "type": "object",
+            "properties": {
+                "appointment_date": {
+                    "type": "string",
+                    "example": "15:45"
+                },
+                "appointment_id": {
+                    "type": "integer",
+                    "example": 2
+                },
+                "checked_in_at": {
+                    "type": "string",
+                    "example": "15:38"
+                },
+                "client_name": {
+                    "type": "string",
+                    "example": "Ana López"
+                },
+                "staff_id": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "staff_name": {
+                    "type": "string",
+                    "example": "Laura Gómez"
+                },
+                "waiting_minutes": {
+                    "type": "integer",
+                    "example": 2
+                }
+            }
+        },
+        "dtos.GetAppointmentNoteDto": {
+            "type": "object",
+            "properties": {
+                "appointment_id": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "author": {
+                    "type": "string",
+                    "example": "laura"
+                },
+                "author_id": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "created_at": {
+                    "type": "string",
+                    "example": "12/01/2025 16:40"
+                },
+                "id": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "text": {
+                    "type": "string",
+                    "example": "Se emparejó el largo y se marcó la nuca más corta"
+                },
+                "updated_at": {
+                    "type": "string",
+                    "example": "12/01/2025 16:45"
+                }
+            }
+        },
+        "dtos.GetAppointmentPhotoDto": {
+            "type": "object",
+            "properties": {
+                "appointment_id": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "caption": {
+                    "type": "string",
+                    "example": "Balayage terminado"
+                },
+                "content_type": {
+                    "type": "string",
+                    "example": "image/jpeg"
+                },
+                "created_at": {
+                    "type": "string",
+                    "example": "12/01/2025 16:40"
+                },
+                "height": {
+                    "type": "integer",
+                    "example": 1080
+                },
+                "id": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "kind": {
+                    "description": "antes o despues",
+                    "type": "string",
+                    "example": "despues"
+                },
+                "size": {
+                    "type": "integer",
+                    "example": 845213
+                },
+                "thumbnail_url": {
+                    "type": "string",
+                    "example": "/api/v1/turno/fotos/1/miniatura"
+                },
+                "uploaded_by": {
+                    "type": "string",
+                    "example": "laura"
+                },
+                "url": {
+                    "type": "string",
+                    "example": "/api/v1/turno/fotos/1"
+                },
+                "width": {
+                    "type": "integer",
+                    "example": 1920
+                }
+            }
+        },
+        "dtos.GetBusinessHourDto": {
+            "type": "object",
+            "properties": {
+                "close_time": {
+                    "type": "string",
+                    "example": "13:00"
+                },
+                "id": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "open_time": {
+                    "type": "string",
+                    "example": "09:00"
+                },
+                "weekday": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "weekday_name": {
+                    "type": "string",
+                    "example": "lunes"
+                }
+            }
+        },
+        "dtos.GetCalendarFeedDto": {
+            "type": "object",
+            "properties": {
+                "created_at": {
+                    "type": "string",
+                    "example": "10/01/2025 12:30"
+                },
+                "id": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "staff_id": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "staff_name": {
+                    "type": "string",
+                    "example": "Laura Gómez"
+                },
+                "url": {
+                    "type": "string",
+                    "example": "/api/v1/ical/3f9a...c1.ics"
+                }
+            }
+        },
+        "dtos.GetCashMovementDto": {
+            "type": "object",
+            "properties": {
+                "amount": {
+                    "description": "Negativo para salidas",
+                    "type": "number",
+                    "example": 7500
+                },
+                "appointment_id": {
+                    "type": "integer",
+                    "example": 12
+                },
+                "created_at": {
+                    "type": "string",
+                    "example": "12/01/2025 16:40"
+                },
+                "description": {
+                    "type": "string",
+                    "example": "Cobro turno ID 12"
+                },
+                "id": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "type": {
+                    "type": "string",
+                    "example": "pago"
+                },
+                "username": {
+                    "type": "string",
+                    "example": "admin"
+                }
+            }
+        },
+        "dtos.GetCashSessionDto": {
+            "type": "object",
+            "properties": {
+                "closed_at": {
+                    "type": "string",
+                    "example": "12/01/2025 20:15"
+                },
+                "closed_by": {
+                    "type": "string",
+                    "example": "admin"
+                },
+                "counted_amount": {
+                    "description": "Vacío mientras está abierta",
+                    "type": "number",
+                    "example": 84500
+                },
+                "difference": {
+                    "description": "Contado menos esperado",
+                    "type": "number",
+                    "example": -500
+                },
+                "expected_amount": {
+                    "description": "Fondo más movimientos",
+                    "type": "number",
+                    "example": 85000
+                },
+                "id": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "movements": {
+                    "description": "Solo en el detalle de la sesión",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dtos.GetCashMovementDto"
+                    }
+                },
+                "notes": {
+                    "type": "string",
+                    "example": "Sin novedades"
+                },
+                "opened_at": {
+                    "type": "string",
+                    "example": "12/01/2025 09:00"
+                },
+                "opened_by": {
+                    "type": "string",
+                    "example": "admin"
+                },
+                "opening_float": {
+                    "type": "number",
+                    "example": 20000
+                },
+                "result": {
+                    "description": "\"sobrante\", \"faltante\" o \"sin diferencia\" al cerrar",
+                    "type": "string",
+                    "example": "faltante"
+                },
+                "status": {
+                    "description": "\"abierta\" o \"cerrada\"",
+                    "type": "string",
+                    "example": "cerrada"
+                },
+                "totals": {
+                    "description": "Suma por tipo de movimiento",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dtos.CashTotalDto"
+                    }
+                }
+            }
+        },
+        "dtos.GetClientDto": {
+            "type": "object",
+            "properties": {
+                "appointments": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dtos.ClientAppointmentDto"
+                    }
+                },
+                "email": {
+                    "type": "string",
+                    "example": "example@gmail.com"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "last_name": {
+                    "type": "string",
+                    "example": "Garcia Mendez"
+                },
+                "name": {
+                    "type": "string",
+                    "example": "Valentino"
+                },
+                "phone": {
+                    "type": "string",
+                    "example": "343534345"
+                }
+            }
+        },
+        "dtos.GetClosureDto": {
+            "type": "object",
+            "properties": {
+                "end_date": {
+                    "type": "string",
+                    "example": "25/12/2025"
+                },
+                "id": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "kind": {
+                    "type": "string",
+                    "example": "feriado"
+                },
+                "reason": {
+                    "type": "string",
+                    "example": "Navidad"
+                },
+                "recurring": {
+                    "type": "boolean",
+                    "example": true
+                },
+                "start_date": {
+                    "type": "string",
+                    "example": "25/12/2025"
+                }
+            }
+        },
+        "dtos.GetColorFormulaDto": {
+            "type": "object",
+            "properties": {
+                "appointment_id": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "author": {
+                    "type": "string",
+                    "example": "laura"
+                },
+                "brand": {
+                    "type": "string",
+                    "example": "Igora Royal"
+                },
+                "created_at": {
+                    "type": "string",
+                    "example": "12/01/2025 16:40"
+                },
+                "developer_volume": {
+                    "type": "integer",
+                    "example": 20
+                },
+                "grams": {
+                    "type": "number",
+                    "example": 30
+                },
+                "id": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "notes": {
+                    "type": "string",
+                    "example": "Mezcla 1:1"
+                },
+                "processing_minutes": {
+                    "type": "integer",
+                    "example": 35
+                },
+                "shade": {
+                    "type": "string",
+                    "example": "6-0"
+                },
+                "zone": {
+                    "type": "string",
+                    "example": "raíz"
+                }
+            }
+        },
+        "dtos.GetPaymentDto": {
+            "type": "object",
+            "properties": {
+                "amount": {
+                    "type": "number",
+                    "example": 7500
+                },
+                "id": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "method": {
+                    "type": "string",
+                    "example": "efectivo"
+                },
+                "method_name": {
+                    "type": "string",
+                    "example": "Efectivo"
+                },
+                "paid_at": {
+                    "type": "string",
+                    "example": "12/01/2025 16:40"
+                },
+                "reference": {
+                    "type": "string",
+                    "example": "TRX-88231"
+                },
+                "user_id": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "username": {
+                    "type": "string",
+                    "example": "admin"
+                }
+            }
+        },
+        "dtos.GetPaymentMethodDto": {
+            "type": "object",
+            "properties": {
+                "active": {
+                    "type": "boolean",
+                    "example": true
+                },
+                "code": {
+                    "type": "string",
+                    "example": "efectivo"
+                },
+                "id": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "is_cash": {
+                    "type": "boolean",
+                    "example": true
+                },
+                "name": {
+                    "type": "string",
+                    "example": "Efectivo"
+                },
+                "requires_reference": {
+                    "type": "boolean",
+                    "example": false
+                }
+            }
+        },
+        "dtos.GetProductDto": {
+            "type": "object",
+            "properties": {
+                "brand": {
+                    "type": "string",
+                    "example": "Head \u0026 Shoulders"
+                },
+                "id": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "name": {
+                    "type": "string",
+                    "example": "Shampoo Anticaspa"
+                },
+                "quantity": {
+                    "type": "number",
+                    "example": 400
+                },
+                "unit": {
+                    "type": "string",
+                    "example": "ml"
+                }
+            }
+        },
+        "dtos.GetPromotionDto": {
+            "type": "object",
+            "properties": {
+                "active": {
+                    "type": "boolean",
+                    "example": true
+                },
+                "category": {
+                    "type": "string",
+                    "example": "color"
+                },
+                "code": {
+                    "type": "string",
+                    "example": "COLOR20"
+                },
+                "description": {
+                    "type": "string",
+                    "example": "20% en servicios de color los martes"
+                },
+                "discount_type": {
+                    "type": "string",
+                    "example": "porcentaje"
+                },
+                "ends_on": {
+                    "type": "string",
+                    "example": "31/03/2025"
+                },
+                "id": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "max_uses": {
+                    "type": "integer",
+                    "example": 100
+                },
+                "max_uses_per_client": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "name": {
+                    "type": "string",
+                    "example": "Martes de color"
+                },
+                "scope": {
+                    "type": "string",
+                    "example": "categoria"
+                },
+                "service_id": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "service_name": {
+                    "type": "string",
+                    "example": "Tintura"
+                },
+                "starts_on": {
+                    "type": "string",
+                    "example": "01/03/2025"
+                },
+                "uses": {
+                    "description": "Veces que se aplicó",
+                    "type": "integer",
+                    "example": 12
+                },
+                "value": {
+                    "type": "number",
+                    "example": 20
+                }
+            }
+        },
+        "dtos.GetResourceDto": {
+            "type": "object",
+            "properties": {
+                "active": {
+                    "type": "boolean",
+                    "example": true
+                },
+                "id": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "name": {
+                    "type": "string",
+                    "example": "Lavacabezas 1"
+                },
+                "type": {
+                    "type": "string",
+                    "example": "lavacabezas"
+                }
+            }
+        },
+        "dtos.GetRoleDto": {
+            "type": "object",
+            "properties": {
+                "id": {
+                    "type": "integer"
+                },
+                "name": {
+                    "type": "string",
+                    "example": "empleado"
+                },
+                "permissions": {
+                    "description": "Solo los nombres de los permisos",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "create_user",
+                        " delete_user"
+                    ]
+                }
+            }
+        },
+        "dtos.GetServiceBundleDto": {
+            "type": "object",
+            "properties": {
+                "active": {
+                    "type": "boolean",
+                    "example": true
+                },
+                "description": {
+                    "type": "string",
+                    "example": "Corte, tintura y peinado"
+                },
+                "duration_minutes": {
+                    "type": "integer",
+                    "example": 150
+                },
+                "id": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "list_price": {
+                    "description": "Suma de los precios de los servicios",
+                    "type": "number",
+                    "example": 30000
+                },
+                "name": {
+                    "type": "string",
+                    "example": "Corte + color"
+                },
+                "price": {
+                    "type": "number",
+                    "example": 25000
+                },
+                "savings": {
+                    "type": "number",
+                    "example": 5000
+                },
+                "services": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dtos.ServiceBundleItemDto"
+                    }
+                }
+            }
+        },
+        "dtos.GetServiceDto": {
+            "type": "object",
+            "properties": {
+                "buffer_time_minutes": {
+                    "type": "integer",
+                    "example": 10
+                },
+                "category": {
+                    "type": "string",
+                    "example": "corte"
+                },
+                "description": {
+                    "type": "string",
+                    "example": "Corte de pelo clasico"
+                },
+                "estimated_time_minutes": {
+                    "type": "integer",
+                    "example": 90
+                },
+                "id": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "name": {
+                    "type": "string",
+                    "example": "Corte de pelo"
+                },
+                "options": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dtos.GetServiceOptionDto"
+                    }
+                },
+                "price": {
+                    "type": "number",
+                    "example": 10000
+                },
+                "processing_time_minutes": {
+                    "type": "integer",
+                    "example": 30
+                },
+                "resources": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dtos.ServiceResourceDto"
+                    }
+                },
+                "total_time_minutes": {
+                    "description": "Activo + espera + limpieza",
+                    "type": "integer",
+                    "example": 130
+                },
+                "variants": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dtos.GetServiceVariantDto"
+                    }
+                }
+            }
+        },
+        "dtos.GetServiceOptionDto": {
+            "type": "object",
+            "properties": {
+                "extra_minutes": {
+                    "type": "integer",
+                    "example": 10
+                },
+                "id": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "name": {
+                    "type": "string",
+                    "example": "Matizador"
+                },
+                "price": {
+                    "type": "number",
+                    "example": 3000
+                }
+            }
+        },
+        "dtos.GetServiceVariantDto": {
+            "type": "object",
+            "properties": {
+                "estimated_time_minutes": {
+                    "type": "integer",
+                    "example": 45
+                },
+                "id": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "name": {
+                    "type": "string",
+                    "example": "Pelo largo"
+                },
+                "price": {
+                    "type": "number",
+                    "example": 14000
+                }
+            }
+        },
+        "dtos.GetStaffDto": {
+            "type": "object",
+            "properties": {
+                "active": {
+                    "type": "boolean",
+                    "example": true
+                },
+                "id": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "last_name": {
+                    "type": "string",
+                    "example": "Gómez"
+                },
+                "name": {
+                    "type": "string",
+                    "example": "Laura"
+                },
+                "phone": {
+                    "type": "string",
+                    "example": "343534345"
+                },
+                "user_id": {
+                    "type": "integer",
+                    "example": 2
+                },
+                "username": {
+                    "type": "string",
+                    "example": "laura"
+                }
+            }
+        },
+        "dtos.GetStaffScheduleExceptionDto": {
+            "type": "object",
+            "properties": {
+                "conflicts": {
+                    "description": "Turnos ya agendados que quedan fuera del horario",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dtos.StaffScheduleConflictDto"
+                    }
+                },
+                "date": {
+                    "type": "string",
+                    "example": "20/01/2025"
+                },
+                "end_time": {
+                    "type": "string",
+                    "example": "16:00"
+                },
+                "id": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "reason": {
+                    "type": "string",
+                    "example": "Trámite personal"
+                },
+                "staff_id": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "start_time": {
+                    "type": "string",
+                    "example": "12:00"
+                }
+            }
+        },
+        "dtos.GetStaffShiftDto": {
+            "type": "object",
+            "properties": {
+                "end_time": {
+                    "type": "string",
+                    "example": "17:00"
+                },
+                "id": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "staff_id": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "start_time": {
+                    "type": "string",
+                    "example": "09:00"
+                },
+                "weekday": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "weekday_name": {
+                    "type": "string",
+                    "example": "lunes"
+                }
+            }
+        },
+        "dtos.GetStaffSkillDto": {
+            "type": "object",
+            "properties": {
+                "duration_override": {
+                    "description": "Vacío si usa el tiempo del servicio",
+                    "type": "integer",
+                    "example": 25
+                },
+                "estimated_time_minutes": {
+                    "description": "Tiempo activo efectivo para el estilista",
+                    "type": "integer",
+                    "example": 25
+                },
+                "price": {
+                    "description": "Precio efectivo para el estilista",
+                    "type": "number",
+                    "example": 15000
+                },
+                "price_override": {
+                    "description": "Vacío si usa el precio del servicio",
+                    "type": "number",
+                    "example": 15000
+                },
+                "service_id": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "service_name": {
+                    "type": "string",
+                    "example": "Corte de pelo"
+                }
+            }
+        },
+        "dtos.GetTimeOffDto": {
+            "type": "object",
+            "properties": {
+                "conflicts": {
+                    "description": "Turnos ya agendados dentro de la licencia",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dtos.StaffScheduleConflictDto"
+                    }
+                },
+                "end_date": {
+                    "type": "string",
+                    "example": "14/02/2025"
+                },
+                "id": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "kind": {
+                    "type": "string",
+                    "example": "vacaciones"
+                },
+                "reason": {
+                    "type": "string",
+                    "example": "Vacaciones de verano"
+                },
+                "review_note": {
+                    "type": "string",
+                    "example": "Aprobado, cubrir con Laura"
+                },
+                "reviewed_at": {
+                    "type": "string",
+                    "example": "20/01/2025 10:00"
+                },
+                "staff_id": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "staff_name": {
+                    "type": "string",
+                    "example": "Laura Gómez"
+                },
+                "start_date": {
+                    "type": "string",
+                    "example": "03/02/2025"
+                },
+                "status": {
+                    "type": "string",
+                    "example": "pendiente"
+                }
+            }
+        },
+        "dtos.GetTipDto": {
             "type": "object",
             "properties": {
-                "appointment_date": {
+                "amount": {
+                    "type": "number",
+                    "example": 2000
+                },
+                "id": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "method": {
+                    "type": "string",
+                    "example": "efectivo"
+                },
+                "method_name": {
+                    "type": "string",
+                    "example": "Efectivo"
+                },
+                "paid_at": {
+                    "type": "string",
+                    "example": "12/01/2025 16:40"
+                },
+                "staff_id": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "staff_name": {
+                    "type": "string",
+                    "example": "Laura Gómez"
+                }
+            }
+        },
+        "dtos.GetUserDto": {
+            "type": "object",
+            "properties": {
+                "id": {
+                    "description": "ID del usuario",
+                    "type": "integer",
+                    "example": 1
+                },
+                "role_name": {
+                    "description": "Nombre del rol del usuario",
+                    "type": "string",
+                    "example": "Administrador"
+                },
+                "username": {
+                    "description": "Nombre de usuario",
+                    "type": "string",
+                    "example": "admin"
+                }
+            }
+        },
+        "dtos.GetWaitlistEntryDto": {
+            "type": "object",
+            "properties": {
+                "client_id": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "client_name": {
+                    "type": "string",
+                    "example": "Juan Pérez"
+                },
+                "client_phone": {
+                    "type": "string",
+                    "example": "343534345"
+                },
+                "desired_from": {
+                    "type": "string",
+                    "example": "10/01/2025"
+                },
+                "desired_to": {
+                    "type": "string",
+                    "example": "15/01/2025"
+                },
+                "id": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "notes": {
+                    "type": "string",
+                    "example": "Prefiere la tarde"
+                },
+                "preferred_end_time": {
+                    "type": "string",
+                    "example": "18:00"
+                },
+                "preferred_start_time": {
+                    "type": "string",
+                    "example": "14:00"
+                },
+                "services": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dtos.AppointmentServiceDto"
+                    }
+                },
+                "staff_id": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "staff_name": {
+                    "type": "string",
+                    "example": "Laura Gómez"
+                },
+                "status": {
+                    "type": "string",
+                    "example": "activa"
+                }
+            }
+        },
+        "dtos.GetWalkInDto": {
+            "type": "object",
+            "properties": {
+                "appointment_id": {
+                    "type": "integer",
+                    "example": 12
+                },
+                "client_id": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "client_name": {
+                    "type": "string",
+                    "example": "Juan Pérez"
+                },
+                "created_at": {
+                    "type": "string",
+                    "example": "10/01/2025 15:30"
+                },
+                "estimated_wait_minutes": {
+                    "type": "integer",
+                    "example": 25
+                },
+                "id": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "position": {
+                    "type": "integer",
+                    "example": 2
+                },
+                "services": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dtos.AppointmentServiceDto"
+                    }
+                },
+                "staff_id": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "staff_name": {
+                    "type": "string",
+                    "example": "Laura Gómez"
+                },
+                "status": {
+                    "type": "string",
+                    "example": "esperando"
+                },
+                "ticket_number": {
+                    "type": "integer",
+                    "example": 7
+                }
+            }
+        },
+        "dtos.LoginAnswerDto": {
+            "type": "object",
+            "properties": {
+                "token": {
+                    "type": "string",
+                    "example": "eyJhbGciOiJIUzI1NiIsInR5cCI6IkpXVCJ9.eyJleHAiOjE3MzYzODMzMzcsImlhdCI6MTczNjI5NjkzNywicm"
+                },
+                "username": {
+                    "type": "string",
+                    "example": "admin"
+                }
+            }
+        },
+        "dtos.LoginDto": {
+            "type": "object",
+            "properties": {
+                "password": {
+                    "type": "string"
+                },
+                "username": {
+                    "type": "string"
+                }
+            }
+        },
+        "dtos.NotificationDto": {
+            "type": "object",
+            "properties": {
+                "appointment_id": {
+                    "type": "integer",
+                    "example": 12
+                },
+                "attempts": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "channel": {
+                    "type": "string",
+                    "example": "email"
+                },
+                "id": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "kind": {
+                    "type": "string",
+                    "example": "recordatorio_24h"
+                },
+                "last_error": {
+                    "type": "string",
+                    "example": ""
+                },
+                "next_attempt_at": {
+                    "type": "string",
+                    "example": "10/01/2025 15:30"
+                },
+                "recipient": {
+                    "type": "string",
+                    "example": "juan@mail.com"
+                },
+                "sent_at": {
+                    "type": "string",
+                    "example": "10/01/2025 15:30"
+                },
+                "status": {
+                    "type": "string",
+                    "example": "enviada"
+                },
+                "subject": {
+                    "type": "string",
+                    "example": "Recordatorio de turno"
+                }
+            }
+        },
+        "dtos.OpenCashSessionDto": {
+            "type": "object",
+            "properties": {
+                "notes": {
+                    "type": "string",
+                    "example": "Turno mañana"
+                },
+                "opening_float": {
+                    "description": "Fondo inicial de la caja",
+                    "type": "number",
+                    "example": 20000
+                }
+            }
+        },
+        "dtos.PaymentDto": {
+            "type": "object",
+            "properties": {
+                "amount": {
+                    "type": "number",
+                    "example": 7500
+                },
+                "method": {
+                    "description": "Código del medio de pago",
+                    "type": "string",
+                    "example": "efectivo"
+                },
+                "reference": {
+                    "description": "Opcional salvo que el medio lo exija",
+                    "type": "string",
+                    "example": "TRX-88231"
+                }
+            }
+        },
+        "dtos.PaymentMethodDto": {
+            "type": "object",
+            "properties": {
+                "active": {
+                    "description": "Opcional, por defecto activo",
+                    "type": "boolean",
+                    "example": true
+                },
+                "code": {
+                    "description": "Identificador único; no se puede cambiar",
+                    "type": "string",
+                    "example": "mercadopago"
+                },
+                "is_cash": {
+                    "description": "Entra a la caja del salón",
+                    "type": "boolean",
+                    "example": false
+                },
+                "name": {
+                    "type": "string",
+                    "example": "Mercado Pago"
+                },
+                "requires_reference": {
+                    "description": "Exige número de operación al cobrar",
+                    "type": "boolean",
+                    "example": true
+                }
+            }
+        },
+        "dtos.PromotionDto": {
+            "type": "object",
+            "properties": {
+                "active": {
+                    "description": "Opcional, por defecto activa",
+                    "type": "boolean",
+                    "example": true
+                },
+                "category": {
+                    "description": "Obligatoria si el alcance es \"categoria\"",
+                    "type": "string",
+                    "example": "color"
+                },
+                "code": {
+                    "description": "Código del cupón (opcional); sin código la promoción se aplica por ID",
+                    "type": "string",
+                    "example": "COLOR20"
+                },
+                "description": {
+                    "type": "string",
+                    "example": "20% en servicios de color los martes"
+                },
+                "discount_type": {
+                    "description": "\"porcentaje\" o \"monto\"",
+                    "type": "string",
+                    "example": "porcentaje"
+                },
+                "ends_on": {
+                    "description": "Formato: DD/MM/YYYY (opcional)",
+                    "type": "string",
+                    "example": "31/03/2025"
+                },
+                "max_uses": {
+                    "description": "Usos totales (opcional); 0 al actualizar quita el límite",
+                    "type": "integer",
+                    "example": 100
+                },
+                "max_uses_per_client": {
+                    "description": "Usos por cliente (opcional); 0 al actualizar quita el límite",
+                    "type": "integer",
+                    "example": 1
+                },
+                "name": {
                     "type": "string",
-                    "example": "30/09/2002 16:30"
+                    "example": "Martes de color"
                 },
-                "id": {
-                    "type": "integer"
+                "scope": {
+                    "description": "\"turno\", \"servicio\" o \"categoria\"",
+                    "type": "string",
+                    "example": "categoria"
                 },
-                "status": {
+                "service_id": {
+                    "description": "Obligatorio si el alcance es \"servicio\"",
+                    "type": "integer",
+                    "example": 1
+                },
+                "starts_on": {
+                    "description": "Formato: DD/MM/YYYY (opcional)",
                     "type": "string",
-                    "example": "finalizado"
+                    "example": "01/03/2025"
+                },
+                "value": {
+                    "description": "Porcentaje (hasta 100) o monto fijo",
+                    "type": "number",
+                    "example": 20
                 }
             }
         },
-        "dtos.ClientDTO": {
+        "dtos.PublicBookingDto": {
             "type": "object",
             "properties": {
+                "appointment_date": {
+                    "description": "Formato: HH:MM DD/MM/YYYY",
+                    "type": "string",
+                    "example": "15:30 10/01/2025"
+                },
+                "bundle_id": {
+                    "description": "Combos (opcional)",
+                    "type": "array",
+                    "items": {
+                        "type": "integer"
+                    },
+                    "example": [
+                        1
+                    ]
+                },
                 "email": {
                     "type": "string",
-                    "example": "example@gmail.com"
+                    "example": "juan@mail.com"
                 },
                 "last_name": {
                     "type": "string",
-                    "example": "Garcia Mendez"
+                    "example": "Pérez"
                 },
                 "name": {
                     "type": "string",
-                    "example": "Valentino"
+                    "example": "Juan"
+                },
+                "option_id": {
+                    "description": "Adicionales (opcional)",
+                    "type": "array",
+                    "items": {
+                        "type": "integer"
+                    },
+                    "example": [
+                        5
+                    ]
                 },
                 "phone": {
                     "type": "string",
-                    "example": "343534345"
-                }
-            }
-        },
-        "dtos.CreateAppointmentDto": {
-            "type": "object",
-            "properties": {
-                "appointment_date": {
-                    "description": "Formato: HH:MM DD/MM/YYYY",
-                    "type": "string",
-                    "example": "15:30 12/01/2025"
-                },
-                "client_id": {
-                    "description": "ID del cliente",
-                    "type": "integer",
-                    "example": 1
+                    "example": "3435343450"
                 },
                 "service_id": {
-                    "description": "IDs de los servicios asociados",
                     "type": "array",
                     "items": {
                         "type": "integer"
@@ -2694,314 +11433,467 @@ const docTemplate = `{
                         1,
                         2
                     ]
+                },
+                "staff_id": {
+                    "description": "Opcional",
+                    "type": "integer",
+                    "example": 1
+                },
+                "variant_id": {
+                    "description": "Variantes de los servicios (opcional)",
+                    "type": "array",
+                    "items": {
+                        "type": "integer"
+                    },
+                    "example": [
+                        3
+                    ]
                 }
             }
         },
-        "dtos.CreateProductDto": {
+        "dtos.PublicBookingResultDto": {
             "type": "object",
             "properties": {
-                "brand": {
+                "appointment_id": {
+                    "type": "integer",
+                    "example": 12
+                },
+                "expires_at": {
+                    "description": "Vencimiento del código enviado al cliente",
                     "type": "string",
-                    "example": "Head \u0026 Shoulders"
+                    "example": "10/01/2025 12:30"
                 },
-                "low_stock_alert": {
-                    "type": "number",
-                    "example": 100
+                "status": {
+                    "type": "string",
+                    "example": "solicitado"
+                }
+            }
+        },
+        "dtos.PublicStaffDto": {
+            "type": "object",
+            "properties": {
+                "id": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "last_name": {
+                    "type": "string",
+                    "example": "Gómez"
                 },
                 "name": {
                     "type": "string",
-                    "example": "Shampoo Anticaspa"
+                    "example": "Laura"
+                }
+            }
+        },
+        "dtos.RecurrenceDto": {
+            "type": "object",
+            "properties": {
+                "count": {
+                    "description": "Cantidad de turnos (opcional si se indica until)",
+                    "type": "integer",
+                    "example": 6
+                },
+                "frequency": {
+                    "description": "\"semanal\" (cada N semanas) o \"mensual\" (mismo día de la semana del mes)",
+                    "type": "string",
+                    "example": "semanal"
+                },
+                "interval": {
+                    "description": "Cada cuántas semanas o meses",
+                    "type": "integer",
+                    "example": 3
+                },
+                "until": {
+                    "description": "Fecha límite DD/MM/YYYY (opcional si se indica count)",
+                    "type": "string",
+                    "example": "30/06/2025"
+                }
+            }
+        },
+        "dtos.ResourceDto": {
+            "type": "object",
+            "properties": {
+                "active": {
+                    "type": "boolean",
+                    "example": true
+                },
+                "name": {
+                    "type": "string",
+                    "example": "Lavacabezas 1"
+                },
+                "type": {
+                    "description": "Los recursos del mismo tipo son intercambiables",
+                    "type": "string",
+                    "example": "lavacabezas"
+                }
+            }
+        },
+        "dtos.Response": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "description": "Datos adicionales"
+                },
+                "message": {
+                    "description": "Mensaje descriptivo",
+                    "type": "string",
+                    "example": "Operación realizada con éxito"
                 },
+                "status": {
+                    "description": "Ejemplo de estado para éxito o error",
+                    "type": "string",
+                    "example": "ok"
+                }
+            }
+        },
+        "dtos.RestockProductDto": {
+            "type": "object",
+            "properties": {
                 "package_count": {
                     "type": "number",
                     "example": 32
                 },
-                "unit": {
+                "reason": {
+                    "description": "Razón del movimiento (opcional)",
                     "type": "string",
-                    "example": "ml"
+                    "example": "Reinventario"
                 },
                 "unit_per_package": {
                     "type": "number",
                     "example": 500
                 },
                 "unity_price": {
+                    "description": "Precio unitario",
                     "type": "number",
                     "example": 10000
                 }
             }
         },
-        "dtos.CreateRoleDto": {
+        "dtos.ReviewTimeOffDto": {
             "type": "object",
             "properties": {
-                "name": {
+                "note": {
                     "type": "string",
-                    "example": "empleado"
-                },
-                "permission_names": {
-                    "type": "array",
-                    "items": {
-                        "type": "string"
-                    },
-                    "example": [
-                        "create_user",
-                        " delete_user"
-                    ]
+                    "example": "Aprobado, cubrir con Laura"
                 }
             }
         },
-        "dtos.ErrorResponse": {
+        "dtos.SeriesConflictDto": {
             "type": "object",
             "properties": {
-                "message": {
-                    "description": "Detalle del error",
+                "appointment_date": {
                     "type": "string",
-                    "example": "Descripción del error"
+                    "example": "02/02/2025 15:30"
                 },
-                "status": {
-                    "description": "Siempre \"error\"",
+                "conflicting_appointment_id": {
+                    "type": "integer",
+                    "example": 12
+                },
+                "reason": {
                     "type": "string",
-                    "example": "error"
+                    "example": "el turno se superpone con el turno ID 12"
                 }
             }
         },
-        "dtos.FinalizeAppointmentDto": {
+        "dtos.ServeWalkInDto": {
             "type": "object",
             "properties": {
-                "payment_method": {
+                "staff_id": {
+                    "description": "Si se omite se usa el estilista preferido",
+                    "type": "integer",
+                    "example": 1
+                }
+            }
+        },
+        "dtos.ServiceBundleDto": {
+            "type": "object",
+            "properties": {
+                "active": {
+                    "description": "Opcional, por defecto activo",
+                    "type": "boolean",
+                    "example": true
+                },
+                "description": {
                     "type": "string",
-                    "example": "tarjeta"
+                    "example": "Corte, tintura y peinado"
                 },
-                "products": {
+                "duration_minutes": {
+                    "description": "Duración total; 0 usa la suma de los servicios",
+                    "type": "integer",
+                    "example": 150
+                },
+                "name": {
+                    "type": "string",
+                    "example": "Corte + color"
+                },
+                "price": {
+                    "description": "No puede superar la suma de los servicios",
+                    "type": "number",
+                    "example": 25000
+                },
+                "service_ids": {
+                    "description": "En el orden en que se realizan; al actualizar, omitirla los conserva",
                     "type": "array",
                     "items": {
-                        "$ref": "#/definitions/dtos.FinalizeAppointmentProductDto"
-                    }
+                        "type": "integer"
+                    },
+                    "example": [
+                        1,
+                        2,
+                        3
+                    ]
                 }
             }
         },
-        "dtos.FinalizeAppointmentProductDto": {
+        "dtos.ServiceBundleItemDto": {
             "type": "object",
             "properties": {
-                "product_id": {
+                "price": {
+                    "type": "number",
+                    "example": 10000
+                },
+                "service_id": {
                     "type": "integer",
                     "example": 1
                 },
-                "quantity": {
-                    "type": "number",
-                    "example": 2
+                "service_name": {
+                    "type": "string",
+                    "example": "Corte de pelo"
+                },
+                "total_time_minutes": {
+                    "type": "integer",
+                    "example": 40
                 }
             }
         },
-        "dtos.GetClientDto": {
+        "dtos.ServiceDto": {
             "type": "object",
             "properties": {
-                "appointments": {
-                    "type": "array",
-                    "items": {
-                        "$ref": "#/definitions/dtos.ClientAppointmentDto"
-                    }
+                "buffer_time_minutes": {
+                    "description": "Limpieza posterior",
+                    "type": "integer",
+                    "example": 10
                 },
-                "email": {
+                "category": {
+                    "description": "Agrupa servicios para las promociones",
                     "type": "string",
-                    "example": "example@gmail.com"
+                    "example": "corte"
                 },
-                "id": {
-                    "type": "integer"
-                },
-                "last_name": {
+                "description": {
                     "type": "string",
-                    "example": "Garcia Mendez"
+                    "example": "Corte de pelo clasico"
+                },
+                "estimated_time_hours": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "estimated_time_minutes": {
+                    "type": "integer",
+                    "example": 30
                 },
                 "name": {
                     "type": "string",
-                    "example": "Valentino"
+                    "example": "Corte de pelo"
                 },
-                "phone": {
-                    "type": "string",
-                    "example": "343534345"
+                "options": {
+                    "description": "Adicionales; misma regla que las variantes",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dtos.ServiceOptionDto"
+                    }
+                },
+                "price": {
+                    "type": "number",
+                    "example": 10000
+                },
+                "processing_time_minutes": {
+                    "description": "Tiempo de espera en que el estilista queda libre",
+                    "type": "integer",
+                    "example": 30
+                },
+                "resources": {
+                    "description": "Recursos que ocupa; al actualizar, una lista vacía los quita y omitirla los conserva",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dtos.ServiceResourceDto"
+                    }
+                },
+                "variants": {
+                    "description": "Variantes de precio; al actualizar, las que no se envían se dan de baja y omitirla las conserva",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dtos.ServiceVariantDto"
+                    }
                 }
             }
         },
-        "dtos.GetProductDto": {
-            "type": "object",
-            "properties": {
-                "brand": {
-                    "type": "string",
-                    "example": "Head \u0026 Shoulders"
+        "dtos.ServiceOptionDto": {
+            "type": "object",
+            "properties": {
+                "extra_minutes": {
+                    "type": "integer",
+                    "example": 10
                 },
                 "id": {
+                    "description": "Al actualizar, ID del adicional a modificar; 0 crea uno nuevo",
                     "type": "integer",
-                    "example": 1
+                    "example": 0
                 },
                 "name": {
                     "type": "string",
-                    "example": "Shampoo Anticaspa"
+                    "example": "Matizador"
                 },
-                "quantity": {
+                "price": {
                     "type": "number",
-                    "example": 400
-                },
-                "unit": {
-                    "type": "string",
-                    "example": "ml"
+                    "example": 3000
                 }
             }
         },
-        "dtos.GetRoleDto": {
+        "dtos.ServiceResourceDto": {
             "type": "object",
             "properties": {
-                "id": {
-                    "type": "integer"
+                "duration_minutes": {
+                    "type": "integer",
+                    "example": 10
                 },
-                "name": {
-                    "type": "string",
-                    "example": "empleado"
+                "offset_minutes": {
+                    "description": "Minutos desde el inicio del servicio",
+                    "type": "integer",
+                    "example": 0
                 },
-                "permissions": {
-                    "description": "Solo los nombres de los permisos",
-                    "type": "array",
-                    "items": {
-                        "type": "string"
-                    },
-                    "example": [
-                        "create_user",
-                        " delete_user"
-                    ]
+                "resource_type": {
+                    "type": "string",
+                    "example": "lavacabezas"
                 }
             }
         },
-        "dtos.GetServiceDto": {
+        "dtos.ServiceVariantDto": {
             "type": "object",
             "properties": {
-                "description": {
-                    "type": "string",
-                    "example": "Corte de pelo clasico"
-                },
                 "estimated_time_minutes": {
+                    "description": "Opcional; vacío usa el del servicio",
                     "type": "integer",
-                    "example": 90
+                    "example": 45
                 },
                 "id": {
+                    "description": "Al actualizar, ID de la variante a modificar; 0 crea una nueva",
                     "type": "integer",
-                    "example": 1
+                    "example": 0
                 },
                 "name": {
                     "type": "string",
-                    "example": "Corte de pelo"
+                    "example": "Pelo largo"
                 },
                 "price": {
                     "type": "number",
-                    "example": 10000
+                    "example": 14000
                 }
             }
         },
-        "dtos.GetUserDto": {
+        "dtos.StaffDto": {
             "type": "object",
             "properties": {
-                "id": {
-                    "description": "ID del usuario",
-                    "type": "integer",
-                    "example": 1
+                "active": {
+                    "type": "boolean",
+                    "example": true
                 },
-                "role_name": {
-                    "description": "Nombre del rol del usuario",
+                "last_name": {
                     "type": "string",
-                    "example": "Administrador"
+                    "example": "Gómez"
                 },
-                "username": {
-                    "description": "Nombre de usuario",
-                    "type": "string",
-                    "example": "admin"
-                }
-            }
-        },
-        "dtos.LoginAnswerDto": {
-            "type": "object",
-            "properties": {
-                "token": {
+                "name": {
                     "type": "string",
-                    "example": "eyJhbGciOiJIUzI1NiIsInR5cCI6IkpXVCJ9.eyJleHAiOjE3MzYzODMzMzcsImlhdCI6MTczNjI5NjkzNywicm"
+                    "example": "Laura"
                 },
-                "username": {
+                "phone": {
                     "type": "string",
-                    "example": "admin"
-                }
-            }
-        },
-        "dtos.LoginDto": {
-            "type": "object",
-            "properties": {
-                "password": {
-                    "type": "string"
+                    "example": "343534345"
                 },
-                "username": {
-                    "type": "string"
+                "user_id": {
+                    "description": "Usuario de login asociado (opcional)",
+                    "type": "integer",
+                    "example": 2
                 }
             }
         },
-        "dtos.Response": {
+        "dtos.StaffScheduleConflictDto": {
             "type": "object",
             "properties": {
-                "data": {
-                    "description": "Datos adicionales"
+                "appointment_date": {
+                    "type": "string",
+                    "example": "05/02/2025 15:30"
                 },
-                "message": {
-                    "description": "Mensaje descriptivo",
+                "appointment_id": {
+                    "type": "integer",
+                    "example": 12
+                },
+                "client_name": {
                     "type": "string",
-                    "example": "Operación realizada con éxito"
+                    "example": "Juan Pérez"
                 },
                 "status": {
-                    "description": "Ejemplo de estado para éxito o error",
                     "type": "string",
-                    "example": "ok"
+                    "example": "confirmado"
                 }
             }
         },
-        "dtos.RestockProductDto": {
+        "dtos.StaffScheduleExceptionDto": {
             "type": "object",
             "properties": {
-                "package_count": {
-                    "type": "number",
-                    "example": 32
+                "date": {
+                    "description": "Formato: DD/MM/YYYY",
+                    "type": "string",
+                    "example": "20/01/2025"
                 },
-                "reason": {
-                    "description": "Razón del movimiento (opcional)",
+                "end_time": {
+                    "description": "Formato: HH:MM, vacío si no trabaja ese día",
                     "type": "string",
-                    "example": "Reinventario"
+                    "example": "16:00"
                 },
-                "unit_per_package": {
-                    "type": "number",
-                    "example": 500
+                "reason": {
+                    "type": "string",
+                    "example": "Trámite personal"
                 },
-                "unity_price": {
-                    "description": "Precio unitario",
-                    "type": "number",
-                    "example": 10000
+                "start_time": {
+                    "description": "Formato: HH:MM, vacío si no trabaja ese día",
+                    "type": "string",
+                    "example": "12:00"
                 }
             }
         },
-        "dtos.ServiceDto": {
+        "dtos.StaffShiftDto": {
             "type": "object",
             "properties": {
-                "description": {
+                "end_time": {
+                    "description": "Formato: HH:MM",
                     "type": "string",
-                    "example": "Corte de pelo clasico"
+                    "example": "17:00"
                 },
-                "estimated_time_hours": {
+                "start_time": {
+                    "description": "Formato: HH:MM",
+                    "type": "string",
+                    "example": "09:00"
+                },
+                "weekday": {
+                    "description": "0 = domingo ... 6 = sábado",
                     "type": "integer",
                     "example": 1
-                },
+                }
+            }
+        },
+        "dtos.StaffSkillDto": {
+            "type": "object",
+            "properties": {
                 "estimated_time_minutes": {
+                    "description": "Tiempo activo propio del estilista (opcional)",
                     "type": "integer",
-                    "example": 30
-                },
-                "name": {
-                    "type": "string",
-                    "example": "Corte de pelo"
+                    "example": 25
                 },
                 "price": {
+                    "description": "Precio propio del estilista (opcional)",
                     "type": "number",
-                    "example": 10000
+                    "example": 15000
                 }
             }
         },
@@ -3057,6 +11949,53 @@ const docTemplate = `{
                 }
             }
         },
+        "dtos.TimeOffDto": {
+            "type": "object",
+            "properties": {
+                "end_date": {
+                    "description": "Formato: DD/MM/YYYY (opcional, por defecto igual a start_date)",
+                    "type": "string",
+                    "example": "14/02/2025"
+                },
+                "kind": {
+                    "description": "vacaciones, enfermedad u otro",
+                    "type": "string",
+                    "example": "vacaciones"
+                },
+                "reason": {
+                    "type": "string",
+                    "example": "Vacaciones de verano"
+                },
+                "staff_id": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "start_date": {
+                    "description": "Formato: DD/MM/YYYY",
+                    "type": "string",
+                    "example": "03/02/2025"
+                }
+            }
+        },
+        "dtos.TipDto": {
+            "type": "object",
+            "properties": {
+                "amount": {
+                    "type": "number",
+                    "example": 2000
+                },
+                "method": {
+                    "description": "Código del medio de pago",
+                    "type": "string",
+                    "example": "efectivo"
+                },
+                "staff_id": {
+                    "description": "Opcional; por defecto el estilista del turno",
+                    "type": "integer",
+                    "example": 1
+                }
+            }
+        },
         "dtos.UpdateAppointmentProductsDto": {
             "type": "object",
             "properties": {
@@ -3108,6 +12047,144 @@ const docTemplate = `{
                     "example": "nuevo_usuario"
                 }
             }
+        },
+        "dtos.WaitlistEntryDto": {
+            "type": "object",
+            "properties": {
+                "client_id": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "desired_from": {
+                    "description": "Formato: DD/MM/YYYY",
+                    "type": "string",
+                    "example": "10/01/2025"
+                },
+                "desired_to": {
+                    "description": "Formato: DD/MM/YYYY",
+                    "type": "string",
+                    "example": "15/01/2025"
+                },
+                "notes": {
+                    "type": "string",
+                    "example": "Prefiere la tarde"
+                },
+                "preferred_end_time": {
+                    "type": "string",
+                    "example": "18:00"
+                },
+                "preferred_start_time": {
+                    "type": "string",
+                    "example": "14:00"
+                },
+                "service_id": {
+                    "description": "Servicios deseados",
+                    "type": "array",
+                    "items": {
+                        "type": "integer"
+                    },
+                    "example": [
+                        1,
+                        2
+                    ]
+                },
+                "staff_id": {
+                    "description": "Estilista preferido (opcional)",
+                    "type": "integer",
+                    "example": 1
+                },
+                "status": {
+                    "description": "Solo al actualizar: activa, atendida, cancelada",
+                    "type": "string",
+                    "example": "activa"
+                }
+            }
+        },
+        "dtos.WaitlistMatchDto": {
+            "type": "object",
+            "properties": {
+                "entry": {
+                    "$ref": "#/definitions/dtos.GetWaitlistEntryDto"
+                },
+                "freed_appointment_id": {
+                    "type": "integer",
+                    "example": 12
+                },
+                "id": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "slot_end": {
+                    "type": "string",
+                    "example": "12/01/2025 16:30"
+                },
+                "slot_start": {
+                    "type": "string",
+                    "example": "12/01/2025 15:30"
+                },
+                "staff_id": {
+                    "type": "integer",
+                    "example": 1
+                }
+            }
+        },
+        "dtos.WalkInDto": {
+            "type": "object",
+            "properties": {
+                "client_id": {
+                    "description": "Opcional si se indica el nombre",
+                    "type": "integer",
+                    "example": 1
+                },
+                "last_name": {
+                    "type": "string",
+                    "example": "Pérez"
+                },
+                "name": {
+                    "type": "string",
+                    "example": "Juan"
+                },
+                "phone": {
+                    "type": "string",
+                    "example": "3435343450"
+                },
+                "service_id": {
+                    "type": "array",
+                    "items": {
+                        "type": "integer"
+                    },
+                    "example": [
+                        1,
+                        2
+                    ]
+                },
+                "staff_id": {
+                    "description": "Estilista preferido (opcional)",
+                    "type": "integer",
+                    "example": 1
+                }
+            }
+        },
+        "dtos.WalkInPositionDto": {
+            "type": "object",
+            "properties": {
+                "position": {
+                    "type": "integer",
+                    "example": 1
+                }
+            }
+        },
+        "events.Event": {
+            "type": "object",
+            "properties": {
+                "data": {},
+                "occurred_at": {
+                    "type": "string"
+                },
+                "type": {
+                    "type": "string"
+                }
+            }
         }
     }
 }`
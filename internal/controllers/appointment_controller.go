@@ -1,24 +1,28 @@
 package controllers
 
 import (
+	"errors"
+	"fmt"
 	"net/http"
 	"peluqueria/internal/dtos"
 	"peluqueria/internal/helpers"
 	"peluqueria/internal/services"
 	"peluqueria/logger"
 	"strconv"
+	"strings"
 
 	"github.com/labstack/echo/v4"
 )
 
 // @Summary Crear turno
-// @Description Permite crear un nuevo turno en el sistema.
+// @Description Permite crear un nuevo turno en el sistema. Si se indica una regla de repetición se crea una serie y se informan las fechas que no pudieron agendarse. Los combos agregan sus servicios con el descuento repartido entre ellos.
 // @Tags Turnos
 // @Accept json
 // @Produce json
 // @Param request body dtos.CreateAppointmentDto true "Datos del turno"
-// @Success 200 {object} dtos.Response{message=string,data=nil} "Turno creado con éxito"
-// @Failure 400 {object} dtos.Response{message=string,data=nil} "Datos inválidos"
+// @Success 200 {object} dtos.Response{message=string,data=dtos.CreateAppointmentResultDto} "Turno creado con éxito"
+// @Failure 400 {object} dtos.Response{message=string,data=nil} "Datos inválidos, fuera del horario de atención o del estilista, o salón cerrado"
+// @Failure 409 {object} dtos.Response{message=string,data=dtos.AppointmentConflictDto} "El turno se superpone con otro o no quedan recursos libres"
 // @Failure 500 {object} dtos.Response{message=string,data=nil} "Error interno del servidor"
 // @Router /turno [post]
 // @Security BearerAuth
@@ -29,13 +33,17 @@ func CreateAppointment(c echo.Context) error {
 		return helpers.RespondError(c, http.StatusBadRequest, "Los datos enviados son inválidos: "+err.Error())
 	}
 
-	if err := services.CreateAppointment(appointment); err != nil {
+	result, err := services.CreateAppointment(appointment, helpers.CurrentUserID(c))
+	if err != nil {
 		logger.Log.Error("[AppointmentController][CreateAppointment] Error al crear turno: ", err)
-		return helpers.RespondError(c, http.StatusInternalServerError, "No se pudo crear el turno: "+err.Error())
+		return respondScheduleError(c, "No se pudo crear el turno: ", err)
 	}
 
 	logger.Log.Info("[AppointmentController][CreateAppointment] Turno creado exitosamente")
-	return helpers.RespondSuccess(c, "Turno creado con éxito", nil)
+	if len(result.Conflicts) > 0 {
+		return helpers.RespondSuccess(c, "Serie creada con conflictos en algunas fechas", result)
+	}
+	return helpers.RespondSuccess(c, "Turno creado con éxito", result)
 }
 
 // @Summary Obtener todos los turnos
@@ -43,7 +51,8 @@ func CreateAppointment(c echo.Context) error {
 // @Tags Turnos
 // @Produce json
 // @Param client_id query string false "ID del cliente (opcional)"
-// @Param status query string false "Estado del turno (opcional)"
+// @Param staff_id query string false "ID del estilista (opcional)"
+// @Param status query string false "Estado del turno (opcional): solicitado, pendiente, confirmado, en_curso, finalizado, cancelado, ausente"
 // @Param start_date query string false "Fecha de inicio (opcional), formato: YYYY-MM-DD"
 // @Param end_date query string false "Fecha de fin (opcional), formato: YYYY-MM-DD"
 // @Success 200 {object} dtos.Response{message=string,data=[]dtos.AllAppointmentDto} "Turnos obtenidos"
@@ -52,11 +61,12 @@ func CreateAppointment(c echo.Context) error {
 // @Security BearerAuth
 func GetAllAppointments(c echo.Context) error {
 	clientID := c.QueryParam("client_id")
+	staffID := c.QueryParam("staff_id")
 	status := c.QueryParam("status")
 	startDate := c.QueryParam("start_date")
 	endDate := c.QueryParam("end_date")
 
-	appointments, err := services.GetAllAppointments(clientID, status, startDate, endDate)
+	appointments, err := services.GetAllAppointments(clientID, staffID, status, startDate, endDate)
 	if err != nil {
 		logger.Log.Error("[AppointmentController][GetAllAppointments] Error al obtener turnos: ", err)
 		return helpers.RespondError(c, http.StatusInternalServerError, err.Error())
@@ -65,6 +75,96 @@ func GetAllAppointments(c echo.Context) error {
 	return helpers.RespondSuccess(c, "Turnos obtenidos", appointments)
 }
 
+// @Summary Consultar disponibilidad
+// @Description Devuelve los horarios libres de un día según la duración de los servicios y combos solicitados y la agenda de los estilistas.
+// @Tags Turnos
+// @Produce json
+// @Param date query string true "Día a consultar, formato: DD/MM/YYYY"
+// @Param service_id query []int false "IDs de los servicios (se puede repetir o separar por comas)" collectionFormat(multi)
+// @Param bundle_id query []int false "IDs de los combos (se puede repetir o separar por comas)" collectionFormat(multi)
+// @Param variant_id query []int false "IDs de las variantes elegidas (ej: largo del pelo)" collectionFormat(multi)
+// @Param option_id query []int false "IDs de los adicionales elegidos" collectionFormat(multi)
+// @Param staff_id query int false "ID del estilista (opcional)"
+// @Success 200 {object} dtos.Response{message=string,data=dtos.AvailabilityDto} "Disponibilidad obtenida"
+// @Failure 400 {object} dtos.Response{message=string,data=nil} "Parámetros inválidos"
+// @Failure 500 {object} dtos.Response{message=string,data=nil} "Error interno del servidor"
+// @Router /turno/disponibilidad [get]
+// @Security BearerAuth
+func GetAvailability(c echo.Context) error {
+	date, request, staffID, err := parseAvailabilityParams(c)
+	if err != nil {
+		logger.Log.Warn("[AppointmentController][GetAvailability] Error: ", err)
+		return helpers.RespondError(c, http.StatusBadRequest, err.Error())
+	}
+
+	availability, err := services.GetAvailability(date, request, staffID)
+	if err != nil {
+		logger.Log.Error("[AppointmentController][GetAvailability] Error al obtener disponibilidad: ", err)
+		return respondAvailabilityError(c, err)
+	}
+
+	return helpers.RespondSuccess(c, "Disponibilidad obtenida", availability)
+}
+
+// respondAvailabilityError responde 400 a las consultas de disponibilidad inválidas y 500 al
+// resto.
+func respondAvailabilityError(c echo.Context, err error) error {
+	if errors.Is(err, services.ErrInvalidAvailabilityRequest) || errors.Is(err, services.ErrInvalidSelection) ||
+		errors.Is(err, services.ErrServiceNotOffered) {
+		return helpers.RespondError(c, http.StatusBadRequest, "No se pudo obtener la disponibilidad: "+err.Error())
+	}
+	return helpers.RespondError(c, http.StatusInternalServerError, "No se pudo obtener la disponibilidad: "+err.Error())
+}
+
+// Lee los parámetros de consulta de disponibilidad: date, service_id, bundle_id, variant_id
+// y option_id (repetidos o separados por comas) y staff_id opcional.
+func parseAvailabilityParams(c echo.Context) (string, dtos.ServiceSelectionDto, uint, error) {
+	var request dtos.ServiceSelectionDto
+	date := c.QueryParam("date")
+	if date == "" {
+		return "", request, 0, errors.New("el parámetro 'date' es obligatorio")
+	}
+
+	var err error
+	if request.ServiceIDs, err = parseQueryIDs(c, "service_id"); err != nil {
+		return "", request, 0, errors.New("el ID de servicio es inválido")
+	}
+	if request.BundleIDs, err = parseQueryIDs(c, "bundle_id"); err != nil {
+		return "", request, 0, errors.New("el ID de combo es inválido")
+	}
+	if request.VariantIDs, err = parseQueryIDs(c, "variant_id"); err != nil {
+		return "", request, 0, errors.New("el ID de variante es inválido")
+	}
+	if request.OptionIDs, err = parseQueryIDs(c, "option_id"); err != nil {
+		return "", request, 0, errors.New("el ID de adicional es inválido")
+	}
+
+	var staffID uint64
+	if staffParam := c.QueryParam("staff_id"); staffParam != "" {
+		staffID, err = strconv.ParseUint(staffParam, 10, 32)
+		if err != nil {
+			return "", request, 0, errors.New("el ID del estilista es inválido")
+		}
+	}
+
+	return date, request, uint(staffID), nil
+}
+
+// parseQueryIDs lee un parámetro de IDs que puede repetirse o venir separado por comas.
+func parseQueryIDs(c echo.Context, name string) ([]uint, error) {
+	var ids []uint
+	for _, param := range c.QueryParams()[name] {
+		for _, value := range strings.Split(param, ",") {
+			id, err := strconv.ParseUint(strings.TrimSpace(value), 10, 32)
+			if err != nil {
+				return nil, err
+			}
+			ids = append(ids, uint(id))
+		}
+	}
+	return ids, nil
+}
+
 // @Summary Obtener turno por ID
 // @Description Devuelve los datos de un turno específico.
 // @Tags Turnos
@@ -99,9 +199,11 @@ func GetAppointmentByID(c echo.Context) error {
 // @Accept json
 // @Produce json
 // @Param id path int true "ID del turno"
+// @Param scope query string false "Alcance si el turno pertenece a una serie: este (por defecto), siguientes, todos"
 // @Param request body dtos.CreateAppointmentDto true "Datos actualizados del turno"
 // @Success 200 {object} dtos.Response{message=string,data=nil} "Turno actualizado con éxito"
-// @Failure 400 {object} dtos.Response{message=string,data=nil} "Datos o ID inválidos"
+// @Failure 400 {object} dtos.Response{message=string,data=nil} "Datos o ID inválidos, fuera del horario de atención o del estilista, o salón cerrado"
+// @Failure 409 {object} dtos.Response{message=string,data=dtos.AppointmentConflictDto} "El turno se superpone con otro o no quedan recursos libres"
 // @Failure 500 {object} dtos.Response{message=string,data=nil} "Error interno del servidor"
 // @Router /turno/{id} [put]
 // @Security BearerAuth
@@ -119,9 +221,9 @@ func UpdateAppointment(c echo.Context) error {
 		return helpers.RespondError(c, http.StatusBadRequest, "Los datos enviados son inválidos")
 	}
 
-	if err := services.UpdateAppointment(uint(appointmentID), appointmentDto); err != nil {
+	if err := services.UpdateAppointment(uint(appointmentID), appointmentDto, c.QueryParam("scope")); err != nil {
 		logger.Log.Error("[AppointmentController][UpdateAppointment] Error al actualizar turno con ID: ", appointmentID, " - ", err)
-		return helpers.RespondError(c, http.StatusInternalServerError, "No se pudo actualizar el turno")
+		return respondScheduleError(c, "No se pudo actualizar el turno: ", err)
 	}
 
 	logger.Log.Infof("[AppointmentController][UpdateAppointment] Turno actualizado con ID: %d", appointmentID)
@@ -164,14 +266,14 @@ func UpdateAppointmentProducts(c echo.Context) error {
 }
 
 // @Summary Finalizar turno
-// @Description Permite finalizar un turno, registrando productos utilizados y método de pago.
+// @Description Permite finalizar un turno, registrando productos utilizados y los pagos. Las promociones y cupones indicados se descuentan de cada servicio alcanzado. Los pagos pueden combinar varios medios y deben sumar el total con descuentos. Las propinas se registran aparte, por estilista. Cobrar en efectivo requiere una caja abierta. Solo se finalizan turnos confirmados o en curso: un turno pendiente debe confirmarse o iniciarse antes.
 // @Tags Turnos
 // @Accept json
 // @Produce json
 // @Param id path int true "ID del turno"
 // @Param request body dtos.FinalizeAppointmentDto true "Datos para finalizar el turno"
 // @Success 200 {object} dtos.Response{message=string,data=nil} "Turno finalizado con éxito"
-// @Failure 400 {object} dtos.Response{message=string,data=nil} "Datos o ID inválidos"
+// @Failure 400 {object} dtos.Response{message=string,data=nil} "Datos o ID inválidos, turno que no está confirmado ni en curso, promoción no aplicable, pagos que no cubren el total o efectivo sin caja abierta"
 // @Failure 500 {object} dtos.Response{message=string,data=nil} "Error interno del servidor"
 // @Router /turno/{id}/finalizar [put]
 // @Security BearerAuth
@@ -189,15 +291,209 @@ func FinalizeAppointment(c echo.Context) error {
 		return helpers.RespondError(c, http.StatusBadRequest, "Los datos enviados son inválidos")
 	}
 
-	if err := services.FinalizeAppointment(uint(appointmentID), finalizeDto); err != nil {
+	if err := services.FinalizeAppointment(uint(appointmentID), finalizeDto, helpers.CurrentUserID(c)); err != nil {
 		logger.Log.Error("[AppointmentController][FinalizeAppointment] Error al finalizar turno con ID: ", appointmentID, " - ", err)
-		return helpers.RespondError(c, http.StatusInternalServerError, "No se pudo finalizar el turno: "+err.Error())
+		if errors.Is(err, services.ErrInvalidPayment) || errors.Is(err, services.ErrInvalidPromotion) {
+			return helpers.RespondError(c, http.StatusBadRequest, "No se pudo finalizar el turno: "+err.Error())
+		}
+		return respondStatusError(c, "No se pudo finalizar el turno: ", err)
 	}
 
 	logger.Log.Infof("[AppointmentController][FinalizeAppointment] Turno finalizado con éxito: ID %d", appointmentID)
 	return helpers.RespondSuccess(c, "Turno finalizado con éxito", nil)
 }
 
+// @Summary Confirmar turno
+// @Description Marca un turno pendiente como confirmado.
+// @Tags Turnos
+// @Produce json
+// @Param id path int true "ID del turno"
+// @Success 200 {object} dtos.Response{message=string,data=nil} "Turno confirmado"
+// @Failure 400 {object} dtos.Response{message=string,data=nil} "ID inválido o transición no permitida"
+// @Failure 500 {object} dtos.Response{message=string,data=nil} "Error interno del servidor"
+// @Router /turno/{id}/confirmar [put]
+// @Security BearerAuth
+func ConfirmAppointment(c echo.Context) error {
+	appointmentID, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		logger.Log.Warn("[AppointmentController][ConfirmAppointment] Error: ID inválido")
+		return helpers.RespondError(c, http.StatusBadRequest, "El ID del turno es inválido")
+	}
+
+	if err := services.ConfirmAppointment(uint(appointmentID), helpers.CurrentUserID(c)); err != nil {
+		logger.Log.Error("[AppointmentController][ConfirmAppointment] Error al confirmar turno con ID: ", appointmentID, " - ", err)
+		return respondStatusError(c, "No se pudo confirmar el turno: ", err)
+	}
+
+	logger.Log.Infof("[AppointmentController][ConfirmAppointment] Turno confirmado: ID %d", appointmentID)
+	return helpers.RespondSuccess(c, "Turno confirmado", nil)
+}
+
+// @Summary Iniciar turno
+// @Description Marca un turno como en curso.
+// @Tags Turnos
+// @Produce json
+// @Param id path int true "ID del turno"
+// @Success 200 {object} dtos.Response{message=string,data=nil} "Turno iniciado"
+// @Failure 400 {object} dtos.Response{message=string,data=nil} "ID inválido o transición no permitida"
+// @Failure 500 {object} dtos.Response{message=string,data=nil} "Error interno del servidor"
+// @Router /turno/{id}/iniciar [put]
+// @Security BearerAuth
+func StartAppointment(c echo.Context) error {
+	appointmentID, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		logger.Log.Warn("[AppointmentController][StartAppointment] Error: ID inválido")
+		return helpers.RespondError(c, http.StatusBadRequest, "El ID del turno es inválido")
+	}
+
+	if err := services.StartAppointment(uint(appointmentID), helpers.CurrentUserID(c)); err != nil {
+		logger.Log.Error("[AppointmentController][StartAppointment] Error al iniciar turno con ID: ", appointmentID, " - ", err)
+		return respondStatusError(c, "No se pudo iniciar el turno: ", err)
+	}
+
+	logger.Log.Infof("[AppointmentController][StartAppointment] Turno iniciado: ID %d", appointmentID)
+	return helpers.RespondSuccess(c, "Turno iniciado", nil)
+}
+
+// @Summary Registrar llegada del cliente
+// @Description Marca que el cliente de un turno pendiente o confirmado llegó al salón. Al iniciar el turno sin llegada registrada, se toma la hora de inicio.
+// @Tags Turnos
+// @Produce json
+// @Param id path int true "ID del turno"
+// @Success 200 {object} dtos.Response{message=string,data=nil} "Llegada registrada"
+// @Failure 400 {object} dtos.Response{message=string,data=nil} "ID inválido, llegada ya registrada o estado no permitido"
+// @Failure 500 {object} dtos.Response{message=string,data=nil} "Error interno del servidor"
+// @Router /turno/{id}/llegada [put]
+// @Security BearerAuth
+func CheckInAppointment(c echo.Context) error {
+	appointmentID, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		logger.Log.Warn("[AppointmentController][CheckInAppointment] Error: ID inválido")
+		return helpers.RespondError(c, http.StatusBadRequest, "El ID del turno es inválido")
+	}
+
+	if err := services.CheckInAppointment(uint(appointmentID), helpers.CurrentUserID(c)); err != nil {
+		logger.Log.Error("[AppointmentController][CheckInAppointment] Error al registrar llegada del turno con ID: ", appointmentID, " - ", err)
+		return respondStatusError(c, "No se pudo registrar la llegada: ", err)
+	}
+
+	logger.Log.Infof("[AppointmentController][CheckInAppointment] Llegada registrada: turno ID %d", appointmentID)
+	return helpers.RespondSuccess(c, "Llegada registrada", nil)
+}
+
+// @Summary Estado de la sala
+// @Description Devuelve el estado del día en curso: turnos que atiende cada estilista y sus sillones, próximo turno, atraso en minutos respecto de la agenda y clientes que llegaron y esperan.
+// @Tags Turnos
+// @Produce json
+// @Success 200 {object} dtos.Response{data=dtos.FloorStatusDto} "Estado de la sala obtenido"
+// @Failure 500 {object} dtos.Response{message=string,data=nil} "Error interno del servidor"
+// @Router /turno/sala [get]
+// @Security BearerAuth
+func GetFloorStatus(c echo.Context) error {
+	status, err := services.GetFloorStatus()
+	if err != nil {
+		logger.Log.Error("[AppointmentController][GetFloorStatus] Error al obtener estado de la sala: ", err)
+		return helpers.RespondError(c, http.StatusInternalServerError, err.Error())
+	}
+
+	return helpers.RespondSuccess(c, "Estado de la sala obtenido", status)
+}
+
+// @Summary Cancelar turno
+// @Description Cancela un turno indicando el motivo. El horario queda libre en la agenda.
+// @Tags Turnos
+// @Accept json
+// @Produce json
+// @Param id path int true "ID del turno"
+// @Param scope query string false "Alcance si el turno pertenece a una serie: este (por defecto), siguientes, todos"
+// @Param request body dtos.ChangeAppointmentStatusDto true "Motivo de la cancelación"
+// @Success 200 {object} dtos.Response{message=string,data=nil} "Turno cancelado"
+// @Failure 400 {object} dtos.Response{message=string,data=nil} "Datos inválidos o transición no permitida"
+// @Failure 500 {object} dtos.Response{message=string,data=nil} "Error interno del servidor"
+// @Router /turno/{id}/cancelar [put]
+// @Security BearerAuth
+func CancelAppointment(c echo.Context) error {
+	appointmentID, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		logger.Log.Warn("[AppointmentController][CancelAppointment] Error: ID inválido")
+		return helpers.RespondError(c, http.StatusBadRequest, "El ID del turno es inválido")
+	}
+
+	var statusDto dtos.ChangeAppointmentStatusDto
+	if err := c.Bind(&statusDto); err != nil {
+		logger.Log.Warn("[AppointmentController][CancelAppointment] Error: datos inválidos")
+		return helpers.RespondError(c, http.StatusBadRequest, "Los datos enviados son inválidos")
+	}
+
+	if err := services.CancelAppointment(uint(appointmentID), statusDto, helpers.CurrentUserID(c), c.QueryParam("scope")); err != nil {
+		logger.Log.Error("[AppointmentController][CancelAppointment] Error al cancelar turno con ID: ", appointmentID, " - ", err)
+		return respondStatusError(c, "No se pudo cancelar el turno: ", err)
+	}
+
+	logger.Log.Infof("[AppointmentController][CancelAppointment] Turno cancelado: ID %d", appointmentID)
+	return helpers.RespondSuccess(c, "Turno cancelado", nil)
+}
+
+// @Summary Marcar ausencia
+// @Description Marca que el cliente no se presentó al turno.
+// @Tags Turnos
+// @Accept json
+// @Produce json
+// @Param id path int true "ID del turno"
+// @Param request body dtos.ChangeAppointmentStatusDto false "Observación (opcional)"
+// @Success 200 {object} dtos.Response{message=string,data=nil} "Ausencia registrada"
+// @Failure 400 {object} dtos.Response{message=string,data=nil} "ID inválido o transición no permitida"
+// @Failure 500 {object} dtos.Response{message=string,data=nil} "Error interno del servidor"
+// @Router /turno/{id}/ausente [put]
+// @Security BearerAuth
+func MarkAppointmentNoShow(c echo.Context) error {
+	appointmentID, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		logger.Log.Warn("[AppointmentController][MarkAppointmentNoShow] Error: ID inválido")
+		return helpers.RespondError(c, http.StatusBadRequest, "El ID del turno es inválido")
+	}
+
+	var statusDto dtos.ChangeAppointmentStatusDto
+	if err := c.Bind(&statusDto); err != nil {
+		logger.Log.Warn("[AppointmentController][MarkAppointmentNoShow] Error: datos inválidos")
+		return helpers.RespondError(c, http.StatusBadRequest, "Los datos enviados son inválidos")
+	}
+
+	if err := services.MarkAppointmentNoShow(uint(appointmentID), statusDto, helpers.CurrentUserID(c)); err != nil {
+		logger.Log.Error("[AppointmentController][MarkAppointmentNoShow] Error al marcar ausencia en turno con ID: ", appointmentID, " - ", err)
+		return respondStatusError(c, "No se pudo registrar la ausencia: ", err)
+	}
+
+	logger.Log.Infof("[AppointmentController][MarkAppointmentNoShow] Ausencia registrada: ID %d", appointmentID)
+	return helpers.RespondSuccess(c, "Ausencia registrada", nil)
+}
+
+// @Summary Historial de estados del turno
+// @Description Devuelve los cambios de estado del turno, con quién y cuándo los realizó.
+// @Tags Turnos
+// @Produce json
+// @Param id path int true "ID del turno"
+// @Success 200 {object} dtos.Response{message=string,data=[]dtos.AppointmentStatusHistoryDto} "Historial obtenido"
+// @Failure 400 {object} dtos.Response{message=string,data=nil} "ID inválido"
+// @Failure 500 {object} dtos.Response{message=string,data=nil} "Error interno del servidor"
+// @Router /turno/{id}/historial [get]
+// @Security BearerAuth
+func GetAppointmentStatusHistory(c echo.Context) error {
+	appointmentID, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		logger.Log.Warn("[AppointmentController][GetAppointmentStatusHistory] Error: ID inválido")
+		return helpers.RespondError(c, http.StatusBadRequest, "El ID del turno es inválido")
+	}
+
+	history, err := services.GetAppointmentStatusHistory(uint(appointmentID))
+	if err != nil {
+		logger.Log.Error("[AppointmentController][GetAppointmentStatusHistory] Error al obtener historial del turno con ID: ", appointmentID, " - ", err)
+		return helpers.RespondError(c, http.StatusInternalServerError, err.Error())
+	}
+
+	return helpers.RespondSuccess(c, "Historial obtenido", history)
+}
+
 // @Summary Eliminar turno
 // @Description Permite eliminar un turno del sistema.
 // @Tags Turnos
@@ -224,3 +520,82 @@ func DeleteAppointment(c echo.Context) error {
 	logger.Log.Infof("[AppointmentController][DeleteAppointment] Turno eliminado con éxito: ID %d", appointmentID)
 	return helpers.RespondSuccess(c, "Turno eliminado con éxito", nil)
 }
+
+// Genera la respuesta de error al guardar un turno: 409 si choca con otro turno o no quedan
+// recursos, 400 si cae fuera de la agenda o el estilista no hace el servicio y 500 al resto
+func respondScheduleError(c echo.Context, message string, err error) error {
+	var conflictErr *services.AppointmentConflictError
+	if errors.As(err, &conflictErr) {
+		return respondAppointmentConflict(c, conflictErr)
+	}
+	if errors.Is(err, services.ErrResourceUnavailable) {
+		return helpers.RespondError(c, http.StatusConflict, "No se pudo guardar el turno: "+err.Error())
+	}
+	if errors.Is(err, services.ErrSalonClosed) || errors.Is(err, services.ErrOutsideBusinessHours) || errors.Is(err, services.ErrStaffNotWorking) ||
+		errors.Is(err, services.ErrServiceNotOffered) || errors.Is(err, services.ErrInvalidStatusTransition) {
+		return helpers.RespondError(c, http.StatusBadRequest, err.Error())
+	}
+	return helpers.RespondError(c, http.StatusInternalServerError, message+err.Error())
+}
+
+// Genera la respuesta 409 con el ID del turno en conflicto
+func respondAppointmentConflict(c echo.Context, conflictErr *services.AppointmentConflictError) error {
+	return helpers.RespondErrorWithData(c, http.StatusConflict, "No se pudo guardar el turno: "+conflictErr.Error(), dtos.AppointmentConflictDto{
+		ConflictingAppointmentID: conflictErr.AppointmentID,
+	})
+}
+
+// @Summary Comprobante del turno
+// @Description Devuelve el comprobante de un turno finalizado, en PDF o en texto para impresoras térmicas de 80mm. El número es correlativo y se mantiene al reimprimirlo. El encabezado se toma de SALON_NAME, SALON_ADDRESS, SALON_PHONE y SALON_TAX_ID.
+// @Tags Turnos
+// @Produce application/pdf
+// @Produce text/plain
+// @Param id path int true "ID del turno"
+// @Param format query string false "pdf (por defecto) o texto"
+// @Success 200 {file} file "Comprobante"
+// @Failure 400 {object} dtos.Response{message=string,data=nil} "ID o formato inválido, o turno no finalizado"
+// @Failure 404 {object} dtos.Response{message=string,data=nil} "Turno no encontrado"
+// @Failure 500 {object} dtos.Response{message=string,data=nil} "Error interno del servidor"
+// @Router /turno/{id}/comprobante [get]
+// @Security BearerAuth
+func GetAppointmentReceipt(c echo.Context) error {
+	id := c.Param("id")
+	logger.Log.Infof("[AppointmentController][GetAppointmentReceipt] Obteniendo comprobante del turno con ID: %s", id)
+	appointmentID, err := strconv.ParseUint(id, 10, 32)
+	if err != nil {
+		logger.Log.Warn("[AppointmentController][GetAppointmentReceipt] ID inválido")
+		return helpers.RespondError(c, http.StatusBadRequest, "ID inválido")
+	}
+
+	format := c.QueryParam("format")
+	if format == "" {
+		format = services.ReceiptFormatPDF
+	}
+	if format != services.ReceiptFormatPDF && format != services.ReceiptFormatText {
+		logger.Log.Warnf("[AppointmentController][GetAppointmentReceipt] Formato inválido: %s", format)
+		return helpers.RespondError(c, http.StatusBadRequest, "Formato inválido, debe ser 'pdf' o 'texto'")
+	}
+
+	content, number, err := services.GetAppointmentReceipt(uint(appointmentID), format)
+	if err != nil {
+		logger.Log.Error("[AppointmentController][GetAppointmentReceipt] Error al generar comprobante: ", err)
+		if errors.Is(err, services.ErrAppointmentNotFound) {
+			return helpers.RespondError(c, http.StatusNotFound, err.Error())
+		}
+		return respondStatusError(c, "No se pudo generar el comprobante: ", err)
+	}
+
+	if format == services.ReceiptFormatText {
+		return c.Blob(http.StatusOK, "text/plain; charset=utf-8", content)
+	}
+	c.Response().Header().Set(echo.HeaderContentDisposition, fmt.Sprintf("inline; filename=\"comprobante-%s.pdf\"", number))
+	return c.Blob(http.StatusOK, "application/pdf", content)
+}
+
+// Genera la respuesta de error de un cambio de estado: 400 si la transición no está permitida
+func respondStatusError(c echo.Context, message string, err error) error {
+	if errors.Is(err, services.ErrInvalidStatusTransition) {
+		return helpers.RespondError(c, http.StatusBadRequest, message+err.Error())
+	}
+	return helpers.RespondError(c, http.StatusInternalServerError, message+err.Error())
+}
@@ -0,0 +1,312 @@
+package controllers
+
+import (
+	"errors"
+	"net/http"
+	"peluqueria/internal/dtos"
+	"peluqueria/internal/helpers"
+	"peluqueria/internal/services"
+	"peluqueria/logger"
+	"strconv"
+
+	"github.com/labstack/echo/v4"
+)
+
+// @Summary Agregar nota al turno
+// @Description Registra una nota libre del estilista sobre lo realizado en el turno.
+// @Tags Ficha del turno
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "ID del turno"
+// @Param request body dtos.AppointmentNoteDto true "Texto de la nota"
+// @Success 200 {object} dtos.Response{data=dtos.GetAppointmentNoteDto} "Nota creada"
+// @Failure 400 {object} dtos.ErrorResponse "ID o datos inválidos"
+// @Failure 500 {object} dtos.ErrorResponse "Error interno del servidor"
+// @Router /turno/{id}/notas [post]
+func CreateAppointmentNote(c echo.Context) error {
+	appointmentID, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		logger.Log.Warn("[AppointmentRecordController][CreateAppointmentNote] ID inválido")
+		return helpers.RespondError(c, http.StatusBadRequest, "ID inválido")
+	}
+
+	var noteDto dtos.AppointmentNoteDto
+	if err := c.Bind(&noteDto); err != nil {
+		logger.Log.Warn("[AppointmentRecordController][CreateAppointmentNote] Error: datos inválidos")
+		return helpers.RespondError(c, http.StatusBadRequest, "Datos inválidos")
+	}
+
+	note, err := services.CreateAppointmentNote(uint(appointmentID), noteDto, helpers.CurrentUserID(c))
+	if err != nil {
+		logger.Log.Error("[AppointmentRecordController][CreateAppointmentNote] Error al crear nota: ", err)
+		return helpers.RespondError(c, http.StatusInternalServerError, err.Error())
+	}
+
+	return helpers.RespondSuccess(c, "Nota creada", note)
+}
+
+// @Summary Actualizar nota del turno
+// @Tags Ficha del turno
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "ID de la nota"
+// @Param request body dtos.AppointmentNoteDto true "Nuevo texto"
+// @Success 200 {object} dtos.Response{data=nil} "Nota actualizada"
+// @Failure 400 {object} dtos.ErrorResponse "ID o datos inválidos"
+// @Failure 500 {object} dtos.ErrorResponse "Error interno del servidor"
+// @Router /turno/notas/{id} [put]
+func UpdateAppointmentNote(c echo.Context) error {
+	noteID, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		logger.Log.Warn("[AppointmentRecordController][UpdateAppointmentNote] ID inválido")
+		return helpers.RespondError(c, http.StatusBadRequest, "ID inválido")
+	}
+
+	var noteDto dtos.AppointmentNoteDto
+	if err := c.Bind(&noteDto); err != nil {
+		logger.Log.Warn("[AppointmentRecordController][UpdateAppointmentNote] Error: datos inválidos")
+		return helpers.RespondError(c, http.StatusBadRequest, "Datos inválidos")
+	}
+
+	if err := services.UpdateAppointmentNote(uint(noteID), noteDto); err != nil {
+		logger.Log.Error("[AppointmentRecordController][UpdateAppointmentNote] Error al actualizar nota: ", err)
+		return helpers.RespondError(c, http.StatusInternalServerError, err.Error())
+	}
+
+	return helpers.RespondSuccess(c, "Nota actualizada", nil)
+}
+
+// @Summary Eliminar nota del turno
+// @Tags Ficha del turno
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "ID de la nota"
+// @Success 200 {object} dtos.Response{data=nil} "Nota eliminada"
+// @Failure 400 {object} dtos.ErrorResponse "ID inválido"
+// @Failure 500 {object} dtos.ErrorResponse "Error interno del servidor"
+// @Router /turno/notas/{id} [delete]
+func DeleteAppointmentNote(c echo.Context) error {
+	noteID, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		logger.Log.Warn("[AppointmentRecordController][DeleteAppointmentNote] ID inválido")
+		return helpers.RespondError(c, http.StatusBadRequest, "ID inválido")
+	}
+
+	if err := services.DeleteAppointmentNote(uint(noteID)); err != nil {
+		logger.Log.Error("[AppointmentRecordController][DeleteAppointmentNote] Error al eliminar nota: ", err)
+		return helpers.RespondError(c, http.StatusInternalServerError, err.Error())
+	}
+
+	return helpers.RespondSuccess(c, "Nota eliminada", nil)
+}
+
+// @Summary Agregar fórmula de color al turno
+// @Description Registra un componente de la mezcla de color aplicada: marca, tono, volumen del oxidante, gramos y tiempo de exposición. Una mezcla de varios tonos se carga como varias fórmulas.
+// @Tags Ficha del turno
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "ID del turno"
+// @Param request body dtos.ColorFormulaDto true "Datos de la fórmula"
+// @Success 200 {object} dtos.Response{data=dtos.GetColorFormulaDto} "Fórmula creada"
+// @Failure 400 {object} dtos.ErrorResponse "ID o datos inválidos"
+// @Failure 500 {object} dtos.ErrorResponse "Error interno del servidor"
+// @Router /turno/{id}/formulas [post]
+func CreateColorFormula(c echo.Context) error {
+	appointmentID, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		logger.Log.Warn("[AppointmentRecordController][CreateColorFormula] ID inválido")
+		return helpers.RespondError(c, http.StatusBadRequest, "ID inválido")
+	}
+
+	var formulaDto dtos.ColorFormulaDto
+	if err := c.Bind(&formulaDto); err != nil {
+		logger.Log.Warn("[AppointmentRecordController][CreateColorFormula] Error: datos inválidos")
+		return helpers.RespondError(c, http.StatusBadRequest, "Datos inválidos")
+	}
+
+	formula, err := services.CreateColorFormula(uint(appointmentID), formulaDto, helpers.CurrentUserID(c))
+	if err != nil {
+		logger.Log.Error("[AppointmentRecordController][CreateColorFormula] Error al crear fórmula: ", err)
+		return helpers.RespondError(c, http.StatusInternalServerError, err.Error())
+	}
+
+	return helpers.RespondSuccess(c, "Fórmula de color creada", formula)
+}
+
+// @Summary Actualizar fórmula de color
+// @Tags Ficha del turno
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "ID de la fórmula"
+// @Param request body dtos.ColorFormulaDto true "Datos de la fórmula"
+// @Success 200 {object} dtos.Response{data=nil} "Fórmula actualizada"
+// @Failure 400 {object} dtos.ErrorResponse "ID o datos inválidos"
+// @Failure 500 {object} dtos.ErrorResponse "Error interno del servidor"
+// @Router /turno/formulas/{id} [put]
+func UpdateColorFormula(c echo.Context) error {
+	formulaID, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		logger.Log.Warn("[AppointmentRecordController][UpdateColorFormula] ID inválido")
+		return helpers.RespondError(c, http.StatusBadRequest, "ID inválido")
+	}
+
+	var formulaDto dtos.ColorFormulaDto
+	if err := c.Bind(&formulaDto); err != nil {
+		logger.Log.Warn("[AppointmentRecordController][UpdateColorFormula] Error: datos inválidos")
+		return helpers.RespondError(c, http.StatusBadRequest, "Datos inválidos")
+	}
+
+	if err := services.UpdateColorFormula(uint(formulaID), formulaDto); err != nil {
+		logger.Log.Error("[AppointmentRecordController][UpdateColorFormula] Error al actualizar fórmula: ", err)
+		return helpers.RespondError(c, http.StatusInternalServerError, err.Error())
+	}
+
+	return helpers.RespondSuccess(c, "Fórmula de color actualizada", nil)
+}
+
+// @Summary Eliminar fórmula de color
+// @Tags Ficha del turno
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "ID de la fórmula"
+// @Success 200 {object} dtos.Response{data=nil} "Fórmula eliminada"
+// @Failure 400 {object} dtos.ErrorResponse "ID inválido"
+// @Failure 500 {object} dtos.ErrorResponse "Error interno del servidor"
+// @Router /turno/formulas/{id} [delete]
+func DeleteColorFormula(c echo.Context) error {
+	formulaID, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		logger.Log.Warn("[AppointmentRecordController][DeleteColorFormula] ID inválido")
+		return helpers.RespondError(c, http.StatusBadRequest, "ID inválido")
+	}
+
+	if err := services.DeleteColorFormula(uint(formulaID)); err != nil {
+		logger.Log.Error("[AppointmentRecordController][DeleteColorFormula] Error al eliminar fórmula: ", err)
+		return helpers.RespondError(c, http.StatusInternalServerError, err.Error())
+	}
+
+	return helpers.RespondSuccess(c, "Fórmula de color eliminada", nil)
+}
+
+// @Summary Subir foto del turno
+// @Description Sube una foto del antes o el después. Se aceptan JPEG o PNG de hasta PHOTO_MAX_MB (10 MB por defecto); el tipo se verifica por el contenido. Se genera una miniatura JPEG.
+// @Tags Ficha del turno
+// @Accept multipart/form-data
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "ID del turno"
+// @Param file formData file true "Imagen"
+// @Param kind formData string true "antes o despues"
+// @Param caption formData string false "Descripción"
+// @Success 200 {object} dtos.Response{data=dtos.GetAppointmentPhotoDto} "Foto subida"
+// @Failure 400 {object} dtos.ErrorResponse "ID inválido, archivo faltante o foto no aceptada"
+// @Failure 413 {object} dtos.ErrorResponse "La foto supera el tamaño permitido"
+// @Failure 500 {object} dtos.ErrorResponse "Error interno del servidor"
+// @Router /turno/{id}/fotos [post]
+func UploadAppointmentPhoto(c echo.Context) error {
+	appointmentID, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		logger.Log.Warn("[AppointmentRecordController][UploadAppointmentPhoto] ID inválido")
+		return helpers.RespondError(c, http.StatusBadRequest, "ID inválido")
+	}
+
+	fileHeader, err := c.FormFile("file")
+	if err != nil {
+		var tooLarge *http.MaxBytesError
+		if errors.As(err, &tooLarge) {
+			logger.Log.Warn("[AppointmentRecordController][UploadAppointmentPhoto] Archivo demasiado grande: ", err)
+			return helpers.RespondError(c, http.StatusRequestEntityTooLarge, "La foto supera el tamaño permitido")
+		}
+		logger.Log.Warn("[AppointmentRecordController][UploadAppointmentPhoto] Archivo faltante: ", err)
+		return helpers.RespondError(c, http.StatusBadRequest, "El archivo es obligatorio")
+	}
+	file, err := fileHeader.Open()
+	if err != nil {
+		logger.Log.Error("[AppointmentRecordController][UploadAppointmentPhoto] Error al abrir archivo: ", err)
+		return helpers.RespondError(c, http.StatusBadRequest, "No se pudo leer el archivo")
+	}
+	defer file.Close()
+
+	photo, err := services.UploadAppointmentPhoto(uint(appointmentID), c.FormValue("kind"), c.FormValue("caption"), file, helpers.CurrentUserID(c))
+	if err != nil {
+		logger.Log.Error("[AppointmentRecordController][UploadAppointmentPhoto] Error al subir foto: ", err)
+		if errors.Is(err, services.ErrInvalidPhoto) {
+			return helpers.RespondError(c, http.StatusBadRequest, err.Error())
+		}
+		return helpers.RespondError(c, http.StatusInternalServerError, err.Error())
+	}
+
+	return helpers.RespondSuccess(c, "Foto subida", photo)
+}
+
+// @Summary Descargar foto del turno
+// @Tags Ficha del turno
+// @Produce image/jpeg,image/png
+// @Security BearerAuth
+// @Param id path int true "ID de la foto"
+// @Success 200 {file} file "Imagen"
+// @Failure 400 {object} dtos.ErrorResponse "ID inválido"
+// @Failure 404 {object} dtos.ErrorResponse "Foto no encontrada"
+// @Router /turno/fotos/{id} [get]
+func GetAppointmentPhoto(c echo.Context) error {
+	return streamAppointmentPhoto(c, false)
+}
+
+// @Summary Descargar miniatura de la foto
+// @Tags Ficha del turno
+// @Produce image/jpeg
+// @Security BearerAuth
+// @Param id path int true "ID de la foto"
+// @Success 200 {file} file "Miniatura JPEG"
+// @Failure 400 {object} dtos.ErrorResponse "ID inválido"
+// @Failure 404 {object} dtos.ErrorResponse "Foto no encontrada"
+// @Router /turno/fotos/{id}/miniatura [get]
+func GetAppointmentPhotoThumbnail(c echo.Context) error {
+	return streamAppointmentPhoto(c, true)
+}
+
+func streamAppointmentPhoto(c echo.Context, thumbnail bool) error {
+	photoID, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		logger.Log.Warn("[AppointmentRecordController][streamAppointmentPhoto] ID inválido")
+		return helpers.RespondError(c, http.StatusBadRequest, "ID inválido")
+	}
+
+	file, contentType, err := services.OpenAppointmentPhoto(uint(photoID), thumbnail)
+	if err != nil {
+		logger.Log.Warn("[AppointmentRecordController][streamAppointmentPhoto] Error al abrir foto: ", err)
+		return helpers.RespondError(c, http.StatusNotFound, err.Error())
+	}
+	defer file.Close()
+
+	c.Response().Header().Set(echo.HeaderCacheControl, "private, max-age=86400")
+	return c.Stream(http.StatusOK, contentType, file)
+}
+
+// @Summary Eliminar foto del turno
+// @Tags Ficha del turno
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "ID de la foto"
+// @Success 200 {object} dtos.Response{data=nil} "Foto eliminada"
+// @Failure 400 {object} dtos.ErrorResponse "ID inválido"
+// @Failure 500 {object} dtos.ErrorResponse "Error interno del servidor"
+// @Router /turno/fotos/{id} [delete]
+func DeleteAppointmentPhoto(c echo.Context) error {
+	photoID, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		logger.Log.Warn("[AppointmentRecordController][DeleteAppointmentPhoto] ID inválido")
+		return helpers.RespondError(c, http.StatusBadRequest, "ID inválido")
+	}
+
+	if err := services.DeleteAppointmentPhoto(uint(photoID)); err != nil {
+		logger.Log.Error("[AppointmentRecordController][DeleteAppointmentPhoto] Error al eliminar foto: ", err)
+		return helpers.RespondError(c, http.StatusInternalServerError, err.Error())
+	}
+
+	return helpers.RespondSuccess(c, "Foto eliminada", nil)
+}
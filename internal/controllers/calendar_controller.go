@@ -0,0 +1,218 @@
+package controllers
+
+import (
+	"net/http"
+	"peluqueria/internal/dtos"
+	"peluqueria/internal/helpers"
+	"peluqueria/internal/services"
+	"peluqueria/logger"
+	"strconv"
+
+	"github.com/labstack/echo/v4"
+)
+
+// @Summary Crear franja horaria
+// @Description Agrega una franja de atención para un día de la semana. Un día puede tener varias franjas (horario cortado).
+// @Tags Calendario
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param request body dtos.BusinessHourDto true "Datos de la franja"
+// @Success 200 {object} dtos.Response{data=nil} "Franja horaria creada"
+// @Failure 400 {object} dtos.ErrorResponse "Datos inválidos"
+// @Failure 500 {object} dtos.ErrorResponse "Error interno del servidor"
+// @Router /calendario/horarios [post]
+func CreateBusinessHour(c echo.Context) error {
+	logger.Log.Info("[CalendarController][CreateBusinessHour] Intentando crear franja horaria")
+	var dto dtos.BusinessHourDto
+	if err := c.Bind(&dto); err != nil {
+		logger.Log.Warn("[CalendarController][CreateBusinessHour] Error: datos inválidos")
+		return helpers.RespondError(c, http.StatusBadRequest, "Datos inválidos")
+	}
+
+	if err := services.CreateBusinessHour(dto); err != nil {
+		logger.Log.Error("[CalendarController][CreateBusinessHour] Error al crear franja horaria: ", err)
+		return helpers.RespondError(c, http.StatusInternalServerError, err.Error())
+	}
+
+	return helpers.RespondSuccess(c, "Franja horaria creada", nil)
+}
+
+// @Summary Obtener horarios de atención
+// @Description Devuelve las franjas de atención semanales del salón.
+// @Tags Calendario
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {object} dtos.Response{data=[]dtos.GetBusinessHourDto} "Horarios obtenidos"
+// @Failure 500 {object} dtos.ErrorResponse "Error interno del servidor"
+// @Router /calendario/horarios [get]
+func GetAllBusinessHours(c echo.Context) error {
+	businessHours, err := services.GetAllBusinessHours()
+	if err != nil {
+		logger.Log.Error("[CalendarController][GetAllBusinessHours] Error al obtener horarios: ", err)
+		return helpers.RespondError(c, http.StatusInternalServerError, err.Error())
+	}
+
+	return helpers.RespondSuccess(c, "Horarios obtenidos", businessHours)
+}
+
+// @Summary Actualizar franja horaria
+// @Description Modifica una franja de atención existente.
+// @Tags Calendario
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "ID de la franja"
+// @Param request body dtos.BusinessHourDto true "Datos de la franja"
+// @Success 200 {object} dtos.Response{data=nil} "Franja horaria actualizada"
+// @Failure 400 {object} dtos.ErrorResponse "ID o datos inválidos"
+// @Failure 500 {object} dtos.ErrorResponse "Error interno del servidor"
+// @Router /calendario/horarios/{id} [put]
+func UpdateBusinessHour(c echo.Context) error {
+	businessHourID, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		logger.Log.Warn("[CalendarController][UpdateBusinessHour] ID inválido")
+		return helpers.RespondError(c, http.StatusBadRequest, "ID inválido")
+	}
+
+	var dto dtos.BusinessHourDto
+	if err := c.Bind(&dto); err != nil {
+		logger.Log.Warn("[CalendarController][UpdateBusinessHour] Error: datos inválidos")
+		return helpers.RespondError(c, http.StatusBadRequest, "Datos inválidos")
+	}
+
+	if err := services.UpdateBusinessHour(uint(businessHourID), dto); err != nil {
+		logger.Log.Error("[CalendarController][UpdateBusinessHour] Error al actualizar franja horaria: ", err)
+		return helpers.RespondError(c, http.StatusInternalServerError, err.Error())
+	}
+
+	return helpers.RespondSuccess(c, "Franja horaria actualizada", nil)
+}
+
+// @Summary Eliminar franja horaria
+// @Description Elimina una franja de atención.
+// @Tags Calendario
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "ID de la franja"
+// @Success 200 {object} dtos.Response{data=nil} "Franja horaria eliminada"
+// @Failure 400 {object} dtos.ErrorResponse "ID inválido"
+// @Failure 500 {object} dtos.ErrorResponse "Error interno del servidor"
+// @Router /calendario/horarios/{id} [delete]
+func DeleteBusinessHour(c echo.Context) error {
+	businessHourID, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		logger.Log.Warn("[CalendarController][DeleteBusinessHour] ID inválido")
+		return helpers.RespondError(c, http.StatusBadRequest, "ID inválido")
+	}
+
+	if err := services.DeleteBusinessHour(uint(businessHourID)); err != nil {
+		logger.Log.Error("[CalendarController][DeleteBusinessHour] Error al eliminar franja horaria: ", err)
+		return helpers.RespondError(c, http.StatusInternalServerError, err.Error())
+	}
+
+	return helpers.RespondSuccess(c, "Franja horaria eliminada", nil)
+}
+
+// @Summary Crear feriado o cierre
+// @Description Registra un feriado o un cierre eventual del salón, de uno o más días.
+// @Tags Calendario
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param request body dtos.ClosureDto true "Datos del cierre"
+// @Success 200 {object} dtos.Response{data=nil} "Cierre creado"
+// @Failure 400 {object} dtos.ErrorResponse "Datos inválidos"
+// @Failure 500 {object} dtos.ErrorResponse "Error interno del servidor"
+// @Router /calendario/cierres [post]
+func CreateClosure(c echo.Context) error {
+	logger.Log.Info("[CalendarController][CreateClosure] Intentando crear cierre")
+	var dto dtos.ClosureDto
+	if err := c.Bind(&dto); err != nil {
+		logger.Log.Warn("[CalendarController][CreateClosure] Error: datos inválidos")
+		return helpers.RespondError(c, http.StatusBadRequest, "Datos inválidos")
+	}
+
+	if err := services.CreateClosure(dto); err != nil {
+		logger.Log.Error("[CalendarController][CreateClosure] Error al crear cierre: ", err)
+		return helpers.RespondError(c, http.StatusInternalServerError, err.Error())
+	}
+
+	return helpers.RespondSuccess(c, "Cierre creado", nil)
+}
+
+// @Summary Obtener feriados y cierres
+// @Description Devuelve los feriados y cierres registrados.
+// @Tags Calendario
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {object} dtos.Response{data=[]dtos.GetClosureDto} "Cierres obtenidos"
+// @Failure 500 {object} dtos.ErrorResponse "Error interno del servidor"
+// @Router /calendario/cierres [get]
+func GetAllClosures(c echo.Context) error {
+	closures, err := services.GetAllClosures()
+	if err != nil {
+		logger.Log.Error("[CalendarController][GetAllClosures] Error al obtener cierres: ", err)
+		return helpers.RespondError(c, http.StatusInternalServerError, err.Error())
+	}
+
+	return helpers.RespondSuccess(c, "Cierres obtenidos", closures)
+}
+
+// @Summary Actualizar feriado o cierre
+// @Description Modifica un feriado o cierre existente.
+// @Tags Calendario
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "ID del cierre"
+// @Param request body dtos.ClosureDto true "Datos del cierre"
+// @Success 200 {object} dtos.Response{data=nil} "Cierre actualizado"
+// @Failure 400 {object} dtos.ErrorResponse "ID o datos inválidos"
+// @Failure 500 {object} dtos.ErrorResponse "Error interno del servidor"
+// @Router /calendario/cierres/{id} [put]
+func UpdateClosure(c echo.Context) error {
+	closureID, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		logger.Log.Warn("[CalendarController][UpdateClosure] ID inválido")
+		return helpers.RespondError(c, http.StatusBadRequest, "ID inválido")
+	}
+
+	var dto dtos.ClosureDto
+	if err := c.Bind(&dto); err != nil {
+		logger.Log.Warn("[CalendarController][UpdateClosure] Error: datos inválidos")
+		return helpers.RespondError(c, http.StatusBadRequest, "Datos inválidos")
+	}
+
+	if err := services.UpdateClosure(uint(closureID), dto); err != nil {
+		logger.Log.Error("[CalendarController][UpdateClosure] Error al actualizar cierre: ", err)
+		return helpers.RespondError(c, http.StatusInternalServerError, err.Error())
+	}
+
+	return helpers.RespondSuccess(c, "Cierre actualizado", nil)
+}
+
+// @Summary Eliminar feriado o cierre
+// @Description Elimina un feriado o cierre.
+// @Tags Calendario
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "ID del cierre"
+// @Success 200 {object} dtos.Response{data=nil} "Cierre eliminado"
+// @Failure 400 {object} dtos.ErrorResponse "ID inválido"
+// @Failure 500 {object} dtos.ErrorResponse "Error interno del servidor"
+// @Router /calendario/cierres/{id} [delete]
+func DeleteClosure(c echo.Context) error {
+	closureID, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		logger.Log.Warn("[CalendarController][DeleteClosure] ID inválido")
+		return helpers.RespondError(c, http.StatusBadRequest, "ID inválido")
+	}
+
+	if err := services.DeleteClosure(uint(closureID)); err != nil {
+		logger.Log.Error("[CalendarController][DeleteClosure] Error al eliminar cierre: ", err)
+		return helpers.RespondError(c, http.StatusInternalServerError, err.Error())
+	}
+
+	return helpers.RespondSuccess(c, "Cierre eliminado", nil)
+}
@@ -0,0 +1,186 @@
+package controllers
+
+import (
+	"errors"
+	"net/http"
+	"peluqueria/internal/dtos"
+	"peluqueria/internal/helpers"
+	"peluqueria/internal/services"
+	"peluqueria/logger"
+	"strconv"
+
+	"github.com/labstack/echo/v4"
+)
+
+// @Summary Abrir caja
+// @Description Abre la caja del día con un fondo inicial. Solo puede haber una caja abierta.
+// @Tags Caja
+// @Accept json
+// @Produce json
+// @Param request body dtos.OpenCashSessionDto true "Fondo inicial"
+// @Success 200 {object} dtos.Response{data=dtos.GetCashSessionDto} "Caja abierta"
+// @Failure 400 {object} dtos.ErrorResponse "Datos inválidos o ya hay una caja abierta"
+// @Failure 500 {object} dtos.ErrorResponse "Error interno del servidor"
+// @Router /caja/abrir [post]
+// @Security BearerAuth
+func OpenCashSession(c echo.Context) error {
+	logger.Log.Info("[CashRegisterController][OpenCashSession] Abriendo caja")
+
+	var openDto dtos.OpenCashSessionDto
+	if err := c.Bind(&openDto); err != nil {
+		logger.Log.Warn("[CashRegisterController][OpenCashSession] Error: datos inválidos")
+		return helpers.RespondError(c, http.StatusBadRequest, "Datos inválidos")
+	}
+
+	session, err := services.OpenCashSession(openDto, helpers.CurrentUserID(c))
+	if err != nil {
+		logger.Log.Error("[CashRegisterController][OpenCashSession] Error al abrir caja: ", err)
+		return respondCashError(c, err)
+	}
+
+	return helpers.RespondSuccess(c, "Caja abierta", session)
+}
+
+// @Summary Caja abierta
+// @Description Devuelve la caja abierta con sus movimientos y el efectivo esperado hasta el momento.
+// @Tags Caja
+// @Produce json
+// @Success 200 {object} dtos.Response{data=dtos.GetCashSessionDto} "Caja obtenida"
+// @Failure 404 {object} dtos.ErrorResponse "No hay una caja abierta"
+// @Failure 500 {object} dtos.ErrorResponse "Error interno del servidor"
+// @Router /caja/actual [get]
+// @Security BearerAuth
+func GetCurrentCashSession(c echo.Context) error {
+	logger.Log.Info("[CashRegisterController][GetCurrentCashSession] Obteniendo caja abierta")
+
+	session, err := services.GetCurrentCashSession()
+	if err != nil {
+		if errors.Is(err, services.ErrNoOpenCashSession) {
+			return helpers.RespondError(c, http.StatusNotFound, err.Error())
+		}
+		logger.Log.Error("[CashRegisterController][GetCurrentCashSession] Error al obtener caja: ", err)
+		return helpers.RespondError(c, http.StatusInternalServerError, err.Error())
+	}
+
+	return helpers.RespondSuccess(c, "Caja obtenida", session)
+}
+
+// @Summary Obtener sesiones de caja
+// @Description Devuelve las sesiones de caja con sus totales por tipo de movimiento y la diferencia al cierre.
+// @Tags Caja
+// @Produce json
+// @Param from query string false "Desde (DD/MM/YYYY)"
+// @Param to query string false "Hasta (DD/MM/YYYY)"
+// @Success 200 {object} dtos.Response{data=[]dtos.GetCashSessionDto} "Sesiones obtenidas"
+// @Failure 500 {object} dtos.ErrorResponse "Error interno del servidor"
+// @Router /caja [get]
+// @Security BearerAuth
+func GetAllCashSessions(c echo.Context) error {
+	logger.Log.Info("[CashRegisterController][GetAllCashSessions] Obteniendo sesiones de caja")
+
+	sessions, err := services.GetAllCashSessions(c.QueryParam("from"), c.QueryParam("to"))
+	if err != nil {
+		logger.Log.Error("[CashRegisterController][GetAllCashSessions] Error al obtener sesiones: ", err)
+		return helpers.RespondError(c, http.StatusInternalServerError, err.Error())
+	}
+
+	return helpers.RespondSuccess(c, "Sesiones de caja obtenidas", sessions)
+}
+
+// @Summary Obtener sesión de caja por ID
+// @Description Devuelve una sesión con sus movimientos. Si está cerrada, es el reporte de cierre con el sobrante o faltante.
+// @Tags Caja
+// @Produce json
+// @Param id path int true "ID de la sesión"
+// @Success 200 {object} dtos.Response{data=dtos.GetCashSessionDto} "Sesión obtenida"
+// @Failure 400 {object} dtos.ErrorResponse "ID inválido"
+// @Failure 404 {object} dtos.ErrorResponse "Sesión no encontrada"
+// @Failure 500 {object} dtos.ErrorResponse "Error interno del servidor"
+// @Router /caja/{id} [get]
+// @Security BearerAuth
+func GetCashSessionByID(c echo.Context) error {
+	id := c.Param("id")
+	logger.Log.Infof("[CashRegisterController][GetCashSessionByID] Obteniendo sesión de caja con ID: %s", id)
+	sessionID, err := strconv.ParseUint(id, 10, 32)
+	if err != nil {
+		logger.Log.Warn("[CashRegisterController][GetCashSessionByID] ID inválido")
+		return helpers.RespondError(c, http.StatusBadRequest, "ID inválido")
+	}
+
+	session, err := services.GetCashSessionByID(uint(sessionID))
+	if err != nil {
+		logger.Log.Error("[CashRegisterController][GetCashSessionByID] Error al obtener sesión: ", err)
+		return respondCashError(c, err)
+	}
+
+	return helpers.RespondSuccess(c, "Sesión de caja obtenida", session)
+}
+
+// @Summary Registrar movimiento de caja
+// @Description Registra un ingreso, gasto o retiro de efectivo en la caja abierta. Los cobros y propinas en efectivo se registran al finalizar el turno.
+// @Tags Caja
+// @Accept json
+// @Produce json
+// @Param request body dtos.CashMovementDto true "Movimiento"
+// @Success 200 {object} dtos.Response{data=nil} "Movimiento registrado"
+// @Failure 400 {object} dtos.ErrorResponse "Datos inválidos o no hay una caja abierta"
+// @Failure 500 {object} dtos.ErrorResponse "Error interno del servidor"
+// @Router /caja/movimientos [post]
+// @Security BearerAuth
+func RecordCashMovement(c echo.Context) error {
+	logger.Log.Info("[CashRegisterController][RecordCashMovement] Registrando movimiento de caja")
+
+	var movementDto dtos.CashMovementDto
+	if err := c.Bind(&movementDto); err != nil {
+		logger.Log.Warn("[CashRegisterController][RecordCashMovement] Error: datos inválidos")
+		return helpers.RespondError(c, http.StatusBadRequest, "Datos inválidos")
+	}
+
+	if err := services.RecordCashMovement(movementDto, helpers.CurrentUserID(c)); err != nil {
+		logger.Log.Error("[CashRegisterController][RecordCashMovement] Error al registrar movimiento: ", err)
+		return respondCashError(c, err)
+	}
+
+	return helpers.RespondSuccess(c, "Movimiento registrado", nil)
+}
+
+// @Summary Cerrar caja
+// @Description Cierra la caja abierta con el efectivo contado y devuelve el reporte de cierre con el sobrante o faltante.
+// @Tags Caja
+// @Accept json
+// @Produce json
+// @Param request body dtos.CloseCashSessionDto true "Efectivo contado"
+// @Success 200 {object} dtos.Response{data=dtos.GetCashSessionDto} "Caja cerrada"
+// @Failure 400 {object} dtos.ErrorResponse "Datos inválidos o no hay una caja abierta"
+// @Failure 500 {object} dtos.ErrorResponse "Error interno del servidor"
+// @Router /caja/cerrar [put]
+// @Security BearerAuth
+func CloseCashSession(c echo.Context) error {
+	logger.Log.Info("[CashRegisterController][CloseCashSession] Cerrando caja")
+
+	var closeDto dtos.CloseCashSessionDto
+	if err := c.Bind(&closeDto); err != nil {
+		logger.Log.Warn("[CashRegisterController][CloseCashSession] Error: datos inválidos")
+		return helpers.RespondError(c, http.StatusBadRequest, "Datos inválidos")
+	}
+
+	session, err := services.CloseCashSession(closeDto, helpers.CurrentUserID(c))
+	if err != nil {
+		logger.Log.Error("[CashRegisterController][CloseCashSession] Error al cerrar caja: ", err)
+		return respondCashError(c, err)
+	}
+
+	return helpers.RespondSuccess(c, "Caja cerrada", session)
+}
+
+// respondCashError responde 400 a las operaciones de caja no permitidas, 404 a las sesiones
+// inexistentes y 500 al resto.
+func respondCashError(c echo.Context, err error) error {
+	if errors.Is(err, services.ErrInvalidCashOperation) || errors.Is(err, services.ErrNoOpenCashSession) {
+		return helpers.RespondError(c, http.StatusBadRequest, err.Error())
+	}
+	if errors.Is(err, services.ErrCashSessionNotFound) {
+		return helpers.RespondError(c, http.StatusNotFound, err.Error())
+	}
+	return helpers.RespondError(c, http.StatusInternalServerError, err.Error())
+}
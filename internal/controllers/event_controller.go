@@ -0,0 +1,90 @@
+package controllers
+
+import (
+	"encoding/json"
+	"fmt"
+	"peluqueria/internal/events"
+	"peluqueria/logger"
+	"strings"
+	"time"
+
+	"github.com/labstack/echo/v4"
+)
+
+// Intervalo de los comentarios que mantienen viva la conexión a través de proxies.
+const eventsHeartbeat = 25 * time.Second
+
+// @Summary Eventos en vivo
+// @Description Flujo Server-Sent Events con los cambios de turnos (appointment.created, appointment.updated, appointment.finalized, appointment.deleted), de stock (stock.changed) y las coincidencias de la lista de espera (waitlist.match_found). Como EventSource no permite enviar headers, el token también puede ir en el parámetro access_token.
+// @Tags Eventos
+// @Produce text/event-stream
+// @Param types query string false "Prefijos de tipos de evento separados por coma (ej: appointment,stock)"
+// @Param access_token query string false "Token JWT, si no se envía el header Authorization"
+// @Success 200 {object} events.Event "Flujo de eventos"
+// @Failure 401 {object} dtos.Response{message=string,data=nil} "Token inválido"
+// @Router /eventos [get]
+// @Security BearerAuth
+func StreamEvents(c echo.Context) error {
+	var prefixes []string
+	for _, prefix := range strings.Split(c.QueryParam("types"), ",") {
+		if prefix = strings.TrimSpace(prefix); prefix != "" {
+			prefixes = append(prefixes, prefix)
+		}
+	}
+
+	stream, unsubscribe := events.Subscribe(64)
+	defer unsubscribe()
+
+	res := c.Response()
+	res.Header().Set(echo.HeaderContentType, "text/event-stream")
+	res.Header().Set(echo.HeaderCacheControl, "no-cache")
+	res.Header().Set(echo.HeaderConnection, "keep-alive")
+	res.Header().Set("X-Accel-Buffering", "no")
+	res.WriteHeader(200)
+	fmt.Fprint(res, "retry: 5000\n\n")
+	res.Flush()
+
+	logger.Log.Infof("[EventController][StreamEvents] Cliente conectado al flujo de eventos (usuario ID %v)", c.Get("user_id"))
+	defer logger.Log.Infof("[EventController][StreamEvents] Cliente desconectado del flujo de eventos (usuario ID %v)", c.Get("user_id"))
+
+	heartbeat := time.NewTicker(eventsHeartbeat)
+	defer heartbeat.Stop()
+
+	var sequence uint64
+	for {
+		select {
+		case <-c.Request().Context().Done():
+			return nil
+		case <-heartbeat.C:
+			fmt.Fprint(res, ": ping\n\n")
+			res.Flush()
+		case event, ok := <-stream:
+			if !ok {
+				return nil
+			}
+			if !matchesEventPrefix(event.Type, prefixes) {
+				continue
+			}
+			data, err := json.Marshal(event)
+			if err != nil {
+				logger.Log.Error("[EventController][StreamEvents] Error al serializar evento: ", err)
+				continue
+			}
+			sequence++
+			fmt.Fprintf(res, "id: %d\nevent: %s\ndata: %s\n\n", sequence, event.Type, data)
+			res.Flush()
+		}
+	}
+}
+
+func matchesEventPrefix(eventType string, prefixes []string) bool {
+	if len(prefixes) == 0 {
+		return true
+	}
+	for _, prefix := range prefixes {
+		if strings.HasPrefix(eventType, prefix) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,122 @@
+package controllers
+
+import (
+	"errors"
+	"net/http"
+	"peluqueria/internal/dtos"
+	"peluqueria/internal/helpers"
+	"peluqueria/internal/services"
+	"peluqueria/logger"
+	"strconv"
+	"strings"
+
+	"github.com/labstack/echo/v4"
+)
+
+// @Summary Generar calendario iCal
+// @Description Genera la URL secreta de suscripción (.ics) de un estilista o, sin staff_id, de todo el salón. Si ya existía se reemplaza y la URL anterior deja de funcionar.
+// @Tags Calendario
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param request body dtos.CalendarFeedDto true "Estilista del calendario"
+// @Success 200 {object} dtos.Response{data=dtos.GetCalendarFeedDto} "Calendario generado"
+// @Failure 400 {object} dtos.ErrorResponse "Datos inválidos"
+// @Failure 500 {object} dtos.ErrorResponse "Error interno del servidor"
+// @Router /calendario/ical [post]
+func CreateCalendarFeed(c echo.Context) error {
+	logger.Log.Info("[ICalController][CreateCalendarFeed] Intentando generar calendario")
+	var feedDto dtos.CalendarFeedDto
+	if err := c.Bind(&feedDto); err != nil {
+		logger.Log.Warn("[ICalController][CreateCalendarFeed] Error al generar calendario: datos inválidos")
+		return helpers.RespondError(c, http.StatusBadRequest, "Datos inválidos")
+	}
+
+	feed, err := services.CreateCalendarFeed(feedDto)
+	if err != nil {
+		logger.Log.Error("[ICalController][CreateCalendarFeed] Error al generar calendario: ", err)
+		return helpers.RespondError(c, http.StatusInternalServerError, err.Error())
+	}
+
+	feed.URL = absoluteURL(c, feed.URL)
+	return helpers.RespondSuccess(c, "Calendario generado", feed)
+}
+
+// @Summary Obtener calendarios iCal
+// @Description Devuelve las URLs de suscripción vigentes.
+// @Tags Calendario
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {object} dtos.Response{data=[]dtos.GetCalendarFeedDto} "Calendarios obtenidos"
+// @Failure 500 {object} dtos.ErrorResponse "Error interno del servidor"
+// @Router /calendario/ical [get]
+func GetAllCalendarFeeds(c echo.Context) error {
+	logger.Log.Info("[ICalController][GetAllCalendarFeeds] Obteniendo calendarios")
+	feeds, err := services.GetAllCalendarFeeds()
+	if err != nil {
+		logger.Log.Error("[ICalController][GetAllCalendarFeeds] Error al obtener calendarios: ", err)
+		return helpers.RespondError(c, http.StatusInternalServerError, err.Error())
+	}
+
+	for i := range feeds {
+		feeds[i].URL = absoluteURL(c, feeds[i].URL)
+	}
+	return helpers.RespondSuccess(c, "Calendarios obtenidos", feeds)
+}
+
+// @Summary Revocar calendario iCal
+// @Description Elimina una URL de suscripción; los calendarios suscritos dejan de actualizarse.
+// @Tags Calendario
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "ID del calendario"
+// @Success 200 {object} dtos.Response{data=nil} "Calendario revocado"
+// @Failure 400 {object} dtos.ErrorResponse "ID inválido"
+// @Failure 500 {object} dtos.ErrorResponse "Error interno del servidor"
+// @Router /calendario/ical/{id} [delete]
+func DeleteCalendarFeed(c echo.Context) error {
+	id := c.Param("id")
+	logger.Log.Infof("[ICalController][DeleteCalendarFeed] Intentando revocar calendario con ID: %s", id)
+	feedID, err := strconv.ParseUint(id, 10, 32)
+	if err != nil {
+		logger.Log.Warn("[ICalController][DeleteCalendarFeed] ID inválido")
+		return helpers.RespondError(c, http.StatusBadRequest, "ID inválido")
+	}
+
+	if err := services.DeleteCalendarFeed(uint(feedID)); err != nil {
+		logger.Log.Error("[ICalController][DeleteCalendarFeed] Error al revocar calendario: ", err)
+		return helpers.RespondError(c, http.StatusInternalServerError, err.Error())
+	}
+
+	logger.Log.Infof("[ICalController][DeleteCalendarFeed] Calendario revocado: ID %d", feedID)
+	return helpers.RespondSuccess(c, "Calendario revocado", nil)
+}
+
+// @Summary Suscripción iCal
+// @Description Devuelve el calendario en formato iCalendar (.ics). El token de la URL es la única autenticación.
+// @Tags Calendario
+// @Produce text/calendar
+// @Param token path string true "Token del calendario, con o sin extensión .ics"
+// @Success 200 {string} string "Calendario iCal"
+// @Failure 404 {object} dtos.ErrorResponse "Calendario no encontrado"
+// @Failure 500 {object} dtos.ErrorResponse "Error interno del servidor"
+// @Router /ical/{token} [get]
+func GetCalendarFeedICS(c echo.Context) error {
+	token := strings.TrimSuffix(c.Param("token"), ".ics")
+
+	ics, err := services.GetCalendarFeedICS(token)
+	if err != nil {
+		if errors.Is(err, services.ErrCalendarFeedNotFound) {
+			return helpers.RespondError(c, http.StatusNotFound, err.Error())
+		}
+		logger.Log.Error("[ICalController][GetCalendarFeedICS] Error al generar calendario: ", err)
+		return helpers.RespondError(c, http.StatusInternalServerError, err.Error())
+	}
+
+	return c.Blob(http.StatusOK, "text/calendar; charset=utf-8", []byte(ics))
+}
+
+// Completa una ruta con el esquema y el host de la solicitud
+func absoluteURL(c echo.Context, path string) string {
+	return c.Scheme() + "://" + c.Request().Host + path
+}
@@ -0,0 +1,56 @@
+package controllers
+
+import (
+	"net/http"
+	"peluqueria/internal/helpers"
+	"peluqueria/internal/services"
+	"peluqueria/logger"
+	"strconv"
+
+	"github.com/labstack/echo/v4"
+)
+
+// @Summary Obtener notificaciones
+// @Description Devuelve la bandeja de salida de notificaciones con su estado de entrega.
+// @Tags Notificaciones
+// @Produce json
+// @Security BearerAuth
+// @Param status query string false "Estado (pendiente, enviada, fallida, cancelada)"
+// @Param appointment_id query int false "ID del turno"
+// @Success 200 {object} dtos.Response{data=[]dtos.NotificationDto} "Notificaciones obtenidas"
+// @Failure 500 {object} dtos.ErrorResponse "Error interno del servidor"
+// @Router /notificaciones [get]
+func GetNotifications(c echo.Context) error {
+	logger.Log.Info("[NotificationController][GetNotifications] Obteniendo notificaciones")
+	list, err := services.GetNotifications(c.QueryParam("status"), c.QueryParam("appointment_id"))
+	if err != nil {
+		logger.Log.Error("[NotificationController][GetNotifications] Error al obtener notificaciones: ", err)
+		return helpers.RespondError(c, http.StatusInternalServerError, err.Error())
+	}
+	return helpers.RespondSuccess(c, "Notificaciones obtenidas", list)
+}
+
+// @Summary Reintentar notificación
+// @Description Vuelve a poner en cola una notificación fallida.
+// @Tags Notificaciones
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "ID de la notificación"
+// @Success 200 {object} dtos.Response{data=nil} "Notificación en cola"
+// @Failure 400 {object} dtos.ErrorResponse "ID inválido o la notificación no está fallida"
+// @Router /notificaciones/{id}/reintentar [put]
+func RetryNotification(c echo.Context) error {
+	id := c.Param("id")
+	logger.Log.Infof("[NotificationController][RetryNotification] Intentando reintentar notificación con ID: %s", id)
+	notificationID, err := strconv.ParseUint(id, 10, 32)
+	if err != nil {
+		logger.Log.Warn("[NotificationController][RetryNotification] ID inválido")
+		return helpers.RespondError(c, http.StatusBadRequest, "ID inválido")
+	}
+
+	if err := services.RetryNotification(uint(notificationID)); err != nil {
+		logger.Log.Error("[NotificationController][RetryNotification] Error al reintentar notificación: ", err)
+		return helpers.RespondError(c, http.StatusBadRequest, err.Error())
+	}
+	return helpers.RespondSuccess(c, "Notificación en cola", nil)
+}
@@ -0,0 +1,142 @@
+package controllers
+
+import (
+	"errors"
+	"net/http"
+	"peluqueria/internal/dtos"
+	"peluqueria/internal/helpers"
+	"peluqueria/internal/services"
+	"peluqueria/logger"
+	"strconv"
+
+	"github.com/labstack/echo/v4"
+)
+
+// @Summary Crear medio de pago
+// @Description Agrega un medio de pago al catálogo.
+// @Tags Medios de pago
+// @Accept json
+// @Produce json
+// @Param request body dtos.PaymentMethodDto true "Datos del medio de pago"
+// @Success 200 {object} dtos.Response{data=nil} "Medio de pago creado"
+// @Failure 400 {object} dtos.ErrorResponse "Datos inválidos"
+// @Failure 500 {object} dtos.ErrorResponse "Error interno del servidor"
+// @Router /medio-pago [post]
+// @Security BearerAuth
+func CreatePaymentMethod(c echo.Context) error {
+	logger.Log.Info("[PaymentController][CreatePaymentMethod] Intentando crear medio de pago")
+
+	var methodDto dtos.PaymentMethodDto
+	if err := c.Bind(&methodDto); err != nil {
+		logger.Log.Warn("[PaymentController][CreatePaymentMethod] Error: datos inválidos")
+		return helpers.RespondError(c, http.StatusBadRequest, "Datos inválidos")
+	}
+
+	if err := services.CreatePaymentMethod(methodDto); err != nil {
+		logger.Log.Error("[PaymentController][CreatePaymentMethod] Error al crear medio de pago: ", err)
+		return respondPaymentMethodError(c, err)
+	}
+
+	logger.Log.Infof("[PaymentController][CreatePaymentMethod] Medio de pago creado: %s", methodDto.Code)
+	return helpers.RespondSuccess(c, "Medio de pago creado", nil)
+}
+
+// @Summary Obtener medios de pago
+// @Description Devuelve el catálogo de medios de pago.
+// @Tags Medios de pago
+// @Produce json
+// @Param active query bool false "Solo medios activos"
+// @Success 200 {object} dtos.Response{data=[]dtos.GetPaymentMethodDto} "Medios de pago obtenidos"
+// @Failure 500 {object} dtos.ErrorResponse "Error interno del servidor"
+// @Router /medio-pago [get]
+// @Security BearerAuth
+func GetAllPaymentMethods(c echo.Context) error {
+	logger.Log.Info("[PaymentController][GetAllPaymentMethods] Obteniendo medios de pago")
+
+	onlyActive, _ := strconv.ParseBool(c.QueryParam("active"))
+	methods, err := services.GetAllPaymentMethods(onlyActive)
+	if err != nil {
+		logger.Log.Error("[PaymentController][GetAllPaymentMethods] Error al obtener medios de pago: ", err)
+		return helpers.RespondError(c, http.StatusInternalServerError, err.Error())
+	}
+
+	return helpers.RespondSuccess(c, "Medios de pago obtenidos", methods)
+}
+
+// @Summary Actualizar medio de pago
+// @Description Actualiza nombre y configuración de un medio de pago. El código no se puede modificar.
+// @Tags Medios de pago
+// @Accept json
+// @Produce json
+// @Param id path int true "ID del medio de pago"
+// @Param request body dtos.PaymentMethodDto true "Datos del medio de pago"
+// @Success 200 {object} dtos.Response{data=nil} "Medio de pago actualizado"
+// @Failure 400 {object} dtos.ErrorResponse "ID o datos inválidos"
+// @Failure 404 {object} dtos.ErrorResponse "Medio de pago no encontrado"
+// @Failure 500 {object} dtos.ErrorResponse "Error interno del servidor"
+// @Router /medio-pago/{id} [put]
+// @Security BearerAuth
+func UpdatePaymentMethod(c echo.Context) error {
+	id := c.Param("id")
+	logger.Log.Infof("[PaymentController][UpdatePaymentMethod] Actualizando medio de pago con ID: %s", id)
+	methodID, err := strconv.ParseUint(id, 10, 32)
+	if err != nil {
+		logger.Log.Warn("[PaymentController][UpdatePaymentMethod] ID inválido")
+		return helpers.RespondError(c, http.StatusBadRequest, "ID inválido")
+	}
+
+	var methodDto dtos.PaymentMethodDto
+	if err := c.Bind(&methodDto); err != nil {
+		logger.Log.Warn("[PaymentController][UpdatePaymentMethod] Error: datos inválidos")
+		return helpers.RespondError(c, http.StatusBadRequest, "Datos inválidos")
+	}
+
+	if err := services.UpdatePaymentMethod(uint(methodID), methodDto); err != nil {
+		logger.Log.Error("[PaymentController][UpdatePaymentMethod] Error al actualizar medio de pago: ", err)
+		return respondPaymentMethodError(c, err)
+	}
+
+	logger.Log.Infof("[PaymentController][UpdatePaymentMethod] Medio de pago actualizado: ID %d", methodID)
+	return helpers.RespondSuccess(c, "Medio de pago actualizado", nil)
+}
+
+// @Summary Eliminar medio de pago
+// @Description Da de baja un medio de pago. Los pagos ya registrados lo conservan.
+// @Tags Medios de pago
+// @Produce json
+// @Param id path int true "ID del medio de pago"
+// @Success 200 {object} dtos.Response{data=nil} "Medio de pago eliminado"
+// @Failure 400 {object} dtos.ErrorResponse "ID inválido"
+// @Failure 404 {object} dtos.ErrorResponse "Medio de pago no encontrado"
+// @Failure 500 {object} dtos.ErrorResponse "Error interno del servidor"
+// @Router /medio-pago/{id} [delete]
+// @Security BearerAuth
+func DeletePaymentMethod(c echo.Context) error {
+	id := c.Param("id")
+	logger.Log.Infof("[PaymentController][DeletePaymentMethod] Eliminando medio de pago con ID: %s", id)
+	methodID, err := strconv.ParseUint(id, 10, 32)
+	if err != nil {
+		logger.Log.Warn("[PaymentController][DeletePaymentMethod] ID inválido")
+		return helpers.RespondError(c, http.StatusBadRequest, "ID inválido")
+	}
+
+	if err := services.DeletePaymentMethod(uint(methodID)); err != nil {
+		logger.Log.Error("[PaymentController][DeletePaymentMethod] Error al eliminar medio de pago: ", err)
+		return respondPaymentMethodError(c, err)
+	}
+
+	logger.Log.Infof("[PaymentController][DeletePaymentMethod] Medio de pago eliminado: ID %d", methodID)
+	return helpers.RespondSuccess(c, "Medio de pago eliminado", nil)
+}
+
+// respondPaymentMethodError responde 400 a los datos inválidos, 404 a los medios de pago
+// inexistentes y 500 al resto.
+func respondPaymentMethodError(c echo.Context, err error) error {
+	if errors.Is(err, services.ErrInvalidPaymentMethod) {
+		return helpers.RespondError(c, http.StatusBadRequest, err.Error())
+	}
+	if errors.Is(err, services.ErrPaymentMethodNotFound) {
+		return helpers.RespondError(c, http.StatusNotFound, err.Error())
+	}
+	return helpers.RespondError(c, http.StatusInternalServerError, err.Error())
+}
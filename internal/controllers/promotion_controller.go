@@ -0,0 +1,171 @@
+package controllers
+
+import (
+	"errors"
+	"net/http"
+	"peluqueria/internal/dtos"
+	"peluqueria/internal/helpers"
+	"peluqueria/internal/services"
+	"peluqueria/logger"
+	"strconv"
+
+	"github.com/labstack/echo/v4"
+)
+
+// @Summary Crear promoción
+// @Description Crea una promoción de porcentaje o monto fijo para todo el turno, un servicio o una categoría. Con código funciona como cupón.
+// @Tags Promociones
+// @Accept json
+// @Produce json
+// @Param request body dtos.PromotionDto true "Datos de la promoción"
+// @Success 200 {object} dtos.Response{data=nil} "Promoción creada"
+// @Failure 400 {object} dtos.ErrorResponse "Datos inválidos"
+// @Failure 500 {object} dtos.ErrorResponse "Error interno del servidor"
+// @Router /promocion [post]
+// @Security BearerAuth
+func CreatePromotion(c echo.Context) error {
+	logger.Log.Info("[PromotionController][CreatePromotion] Iniciando creación de promoción")
+
+	var promotionDto dtos.PromotionDto
+	if err := c.Bind(&promotionDto); err != nil {
+		logger.Log.Warn("[PromotionController][CreatePromotion] Error al parsear datos: datos inválidos")
+		return helpers.RespondError(c, http.StatusBadRequest, "Datos inválidos")
+	}
+
+	if err := services.CreatePromotion(promotionDto); err != nil {
+		logger.Log.Error("[PromotionController][CreatePromotion] Error al crear promoción: ", err)
+		return respondPromotionError(c, err)
+	}
+
+	logger.Log.Infof("[PromotionController][CreatePromotion] Promoción creada con éxito: %s", promotionDto.Name)
+	return helpers.RespondSuccess(c, "Promoción creada", nil)
+}
+
+// @Summary Obtener todas las promociones
+// @Description Devuelve las promociones y cupones, con la cantidad de veces que se aplicó cada uno.
+// @Tags Promociones
+// @Produce json
+// @Param active query bool false "Solo promociones activas"
+// @Success 200 {object} dtos.Response{data=[]dtos.GetPromotionDto} "Promociones obtenidas"
+// @Failure 500 {object} dtos.ErrorResponse "Error interno del servidor"
+// @Router /promocion [get]
+// @Security BearerAuth
+func GetAllPromotions(c echo.Context) error {
+	logger.Log.Info("[PromotionController][GetAllPromotions] Obteniendo lista de promociones")
+
+	onlyActive, _ := strconv.ParseBool(c.QueryParam("active"))
+	promotions, err := services.GetAllPromotions(onlyActive)
+	if err != nil {
+		logger.Log.Error("[PromotionController][GetAllPromotions] Error al obtener promociones: ", err)
+		return helpers.RespondError(c, http.StatusInternalServerError, err.Error())
+	}
+
+	return helpers.RespondSuccess(c, "Promociones obtenidas", promotions)
+}
+
+// @Summary Obtener promoción por ID
+// @Description Devuelve una promoción con la cantidad de veces que se aplicó.
+// @Tags Promociones
+// @Produce json
+// @Param id path int true "ID de la promoción"
+// @Success 200 {object} dtos.Response{data=dtos.GetPromotionDto} "Promoción obtenida"
+// @Failure 400 {object} dtos.ErrorResponse "ID inválido"
+// @Failure 404 {object} dtos.ErrorResponse "Promoción no encontrada"
+// @Failure 500 {object} dtos.ErrorResponse "Error interno del servidor"
+// @Router /promocion/{id} [get]
+// @Security BearerAuth
+func GetPromotionByID(c echo.Context) error {
+	id := c.Param("id")
+	logger.Log.Infof("[PromotionController][GetPromotionByID] Obteniendo promoción con ID: %s", id)
+	promotionID, err := strconv.ParseUint(id, 10, 32)
+	if err != nil {
+		logger.Log.Warn("[PromotionController][GetPromotionByID] ID inválido")
+		return helpers.RespondError(c, http.StatusBadRequest, "ID inválido")
+	}
+
+	promotion, err := services.GetPromotionByID(uint(promotionID))
+	if err != nil {
+		logger.Log.Error("[PromotionController][GetPromotionByID] Error al obtener promoción: ", err)
+		return respondPromotionError(c, err)
+	}
+
+	return helpers.RespondSuccess(c, "Promoción obtenida", promotion)
+}
+
+// @Summary Actualizar promoción
+// @Description Actualiza una promoción. Los turnos ya finalizados conservan el descuento con el que se cobraron.
+// @Tags Promociones
+// @Accept json
+// @Produce json
+// @Param id path int true "ID de la promoción"
+// @Param request body dtos.PromotionDto true "Datos de la promoción"
+// @Success 200 {object} dtos.Response{data=nil} "Promoción actualizada"
+// @Failure 400 {object} dtos.ErrorResponse "ID o datos inválidos"
+// @Failure 404 {object} dtos.ErrorResponse "Promoción no encontrada"
+// @Failure 500 {object} dtos.ErrorResponse "Error interno del servidor"
+// @Router /promocion/{id} [put]
+// @Security BearerAuth
+func UpdatePromotion(c echo.Context) error {
+	id := c.Param("id")
+	logger.Log.Infof("[PromotionController][UpdatePromotion] Actualizando promoción con ID: %s", id)
+	promotionID, err := strconv.ParseUint(id, 10, 32)
+	if err != nil {
+		logger.Log.Warn("[PromotionController][UpdatePromotion] ID inválido")
+		return helpers.RespondError(c, http.StatusBadRequest, "ID inválido")
+	}
+
+	var promotionDto dtos.PromotionDto
+	if err := c.Bind(&promotionDto); err != nil {
+		logger.Log.Warn("[PromotionController][UpdatePromotion] Error al parsear datos: datos inválidos")
+		return helpers.RespondError(c, http.StatusBadRequest, "Datos inválidos")
+	}
+
+	if err := services.UpdatePromotion(uint(promotionID), promotionDto); err != nil {
+		logger.Log.Error("[PromotionController][UpdatePromotion] Error al actualizar promoción: ", err)
+		return respondPromotionError(c, err)
+	}
+
+	logger.Log.Infof("[PromotionController][UpdatePromotion] Promoción actualizada: ID %d", promotionID)
+	return helpers.RespondSuccess(c, "Promoción actualizada", nil)
+}
+
+// @Summary Eliminar promoción
+// @Description Da de baja una promoción. Los turnos en que se aplicó conservan el descuento.
+// @Tags Promociones
+// @Produce json
+// @Param id path int true "ID de la promoción"
+// @Success 200 {object} dtos.Response{data=nil} "Promoción eliminada"
+// @Failure 400 {object} dtos.ErrorResponse "ID inválido"
+// @Failure 404 {object} dtos.ErrorResponse "Promoción no encontrada"
+// @Failure 500 {object} dtos.ErrorResponse "Error interno del servidor"
+// @Router /promocion/{id} [delete]
+// @Security BearerAuth
+func DeletePromotion(c echo.Context) error {
+	id := c.Param("id")
+	logger.Log.Infof("[PromotionController][DeletePromotion] Eliminando promoción con ID: %s", id)
+	promotionID, err := strconv.ParseUint(id, 10, 32)
+	if err != nil {
+		logger.Log.Warn("[PromotionController][DeletePromotion] ID inválido")
+		return helpers.RespondError(c, http.StatusBadRequest, "ID inválido")
+	}
+
+	if err := services.DeletePromotion(uint(promotionID)); err != nil {
+		logger.Log.Error("[PromotionController][DeletePromotion] Error al eliminar promoción: ", err)
+		return respondPromotionError(c, err)
+	}
+
+	logger.Log.Infof("[PromotionController][DeletePromotion] Promoción eliminada: ID %d", promotionID)
+	return helpers.RespondSuccess(c, "Promoción eliminada", nil)
+}
+
+// respondPromotionError responde 400 a los datos inválidos, 404 a las promociones
+// inexistentes y 500 al resto.
+func respondPromotionError(c echo.Context, err error) error {
+	if errors.Is(err, services.ErrInvalidPromotion) {
+		return helpers.RespondError(c, http.StatusBadRequest, err.Error())
+	}
+	if errors.Is(err, services.ErrPromotionNotFound) {
+		return helpers.RespondError(c, http.StatusNotFound, err.Error())
+	}
+	return helpers.RespondError(c, http.StatusInternalServerError, err.Error())
+}
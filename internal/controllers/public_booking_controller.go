@@ -0,0 +1,168 @@
+package controllers
+
+import (
+	"errors"
+	"net/http"
+	"peluqueria/internal/dtos"
+	"peluqueria/internal/helpers"
+	"peluqueria/internal/services"
+	"peluqueria/logger"
+	"strconv"
+
+	"github.com/labstack/echo/v4"
+)
+
+// @Summary Servicios reservables
+// @Description Devuelve los servicios que se pueden reservar desde la web. No requiere autenticación.
+// @Tags Reservas online
+// @Produce json
+// @Success 200 {object} dtos.Response{data=[]dtos.GetServiceDto} "Servicios obtenidos"
+// @Failure 429 {object} dtos.ErrorResponse "Demasiadas solicitudes"
+// @Failure 500 {object} dtos.ErrorResponse "Error interno del servidor"
+// @Router /public/servicios [get]
+func GetPublicServices(c echo.Context) error {
+	logger.Log.Info("[PublicBookingController][GetPublicServices] Obteniendo servicios")
+	servicesList, err := services.GetAllServices()
+	if err != nil {
+		logger.Log.Error("[PublicBookingController][GetPublicServices] Error al obtener servicios: ", err)
+		return helpers.RespondError(c, http.StatusInternalServerError, err.Error())
+	}
+	return helpers.RespondSuccess(c, "Servicios obtenidos", servicesList)
+}
+
+// @Summary Combos reservables
+// @Description Devuelve los combos activos que se pueden reservar desde la web. No requiere autenticación.
+// @Tags Reservas online
+// @Produce json
+// @Success 200 {object} dtos.Response{data=[]dtos.GetServiceBundleDto} "Combos obtenidos"
+// @Failure 429 {object} dtos.ErrorResponse "Demasiadas solicitudes"
+// @Failure 500 {object} dtos.ErrorResponse "Error interno del servidor"
+// @Router /public/combos [get]
+func GetPublicServiceBundles(c echo.Context) error {
+	logger.Log.Info("[PublicBookingController][GetPublicServiceBundles] Obteniendo combos")
+	bundles, err := services.GetAllServiceBundles(true)
+	if err != nil {
+		logger.Log.Error("[PublicBookingController][GetPublicServiceBundles] Error al obtener combos: ", err)
+		return helpers.RespondError(c, http.StatusInternalServerError, err.Error())
+	}
+	return helpers.RespondSuccess(c, "Combos obtenidos", bundles)
+}
+
+// @Summary Estilistas reservables
+// @Description Devuelve los estilistas activos que se pueden elegir al reservar. No requiere autenticación.
+// @Tags Reservas online
+// @Produce json
+// @Success 200 {object} dtos.Response{data=[]dtos.PublicStaffDto} "Estilistas obtenidos"
+// @Failure 429 {object} dtos.ErrorResponse "Demasiadas solicitudes"
+// @Failure 500 {object} dtos.ErrorResponse "Error interno del servidor"
+// @Router /public/empleados [get]
+func GetPublicStaff(c echo.Context) error {
+	logger.Log.Info("[PublicBookingController][GetPublicStaff] Obteniendo estilistas")
+	staff, err := services.GetPublicStaff()
+	if err != nil {
+		logger.Log.Error("[PublicBookingController][GetPublicStaff] Error al obtener estilistas: ", err)
+		return helpers.RespondError(c, http.StatusInternalServerError, err.Error())
+	}
+	return helpers.RespondSuccess(c, "Estilistas obtenidos", staff)
+}
+
+// @Summary Disponibilidad para reservas online
+// @Description Devuelve los horarios libres de un día para los servicios elegidos. No requiere autenticación.
+// @Tags Reservas online
+// @Produce json
+// @Param date query string true "Día a consultar, formato: DD/MM/YYYY"
+// @Param service_id query []int false "IDs de los servicios (se puede repetir o separar por comas)" collectionFormat(multi)
+// @Param bundle_id query []int false "IDs de los combos (se puede repetir o separar por comas)" collectionFormat(multi)
+// @Param variant_id query []int false "IDs de las variantes elegidas (ej: largo del pelo)" collectionFormat(multi)
+// @Param option_id query []int false "IDs de los adicionales elegidos" collectionFormat(multi)
+// @Param staff_id query int false "ID del estilista (opcional)"
+// @Success 200 {object} dtos.Response{data=dtos.AvailabilityDto} "Disponibilidad obtenida"
+// @Failure 400 {object} dtos.ErrorResponse "Parámetros inválidos"
+// @Failure 429 {object} dtos.ErrorResponse "Demasiadas solicitudes"
+// @Failure 500 {object} dtos.ErrorResponse "Error interno del servidor"
+// @Router /public/disponibilidad [get]
+func GetPublicAvailability(c echo.Context) error {
+	date, request, staffID, err := parseAvailabilityParams(c)
+	if err != nil {
+		logger.Log.Warn("[PublicBookingController][GetPublicAvailability] Error: ", err)
+		return helpers.RespondError(c, http.StatusBadRequest, err.Error())
+	}
+
+	availability, err := services.GetAvailability(date, request, staffID)
+	if err != nil {
+		logger.Log.Error("[PublicBookingController][GetPublicAvailability] Error al obtener disponibilidad: ", err)
+		return respondAvailabilityError(c, err)
+	}
+	return helpers.RespondSuccess(c, "Disponibilidad obtenida", availability)
+}
+
+// @Summary Solicitar turno
+// @Description Registra una reserva desde la web. El código de confirmación se envía por email o al teléfono, nunca en la respuesta. Si el teléfono ya es de un cliente, el turno pasa a ese cliente al validar el código y el código se envía a sus datos de contacto cargados. El turno queda en estado "solicitado" hasta que el cliente valide el código y el salón lo confirme. No requiere autenticación.
+// @Tags Reservas online
+// @Accept json
+// @Produce json
+// @Param request body dtos.PublicBookingDto true "Datos de la reserva"
+// @Success 200 {object} dtos.Response{data=dtos.PublicBookingResultDto} "Reserva registrada"
+// @Failure 400 {object} dtos.ErrorResponse "Datos inválidos, fuera del horario de atención o del estilista, o salón cerrado"
+// @Failure 409 {object} dtos.ErrorResponse "El horario ya no está disponible"
+// @Failure 429 {object} dtos.ErrorResponse "Demasiadas solicitudes"
+// @Failure 500 {object} dtos.ErrorResponse "Error interno del servidor"
+// @Router /public/turno [post]
+func RequestPublicBooking(c echo.Context) error {
+	var bookingDto dtos.PublicBookingDto
+	if err := c.Bind(&bookingDto); err != nil {
+		logger.Log.Warn("[PublicBookingController][RequestPublicBooking] Error: datos inválidos")
+		return helpers.RespondError(c, http.StatusBadRequest, "Datos inválidos")
+	}
+
+	result, err := services.RequestPublicBooking(bookingDto)
+	if err != nil {
+		logger.Log.Error("[PublicBookingController][RequestPublicBooking] Error al registrar reserva: ", err)
+		// Desde la web no se muestra con qué turno se superpone
+		var conflictErr *services.AppointmentConflictError
+		if errors.As(err, &conflictErr) || errors.Is(err, services.ErrResourceUnavailable) {
+			return helpers.RespondError(c, http.StatusConflict, "El horario elegido ya no está disponible")
+		}
+		return respondScheduleError(c, "No se pudo registrar la reserva: ", err)
+	}
+
+	logger.Log.Infof("[PublicBookingController][RequestPublicBooking] Reserva registrada: turno ID %d", result.AppointmentID)
+	return helpers.RespondSuccess(c, "Reserva registrada, valide el código recibido para completarla", result)
+}
+
+// @Summary Validar código de reserva
+// @Description Valida el código de un solo uso de una reserva online. Una vez validado, el salón puede confirmar el turno. No requiere autenticación.
+// @Tags Reservas online
+// @Accept json
+// @Produce json
+// @Param id path int true "ID del turno"
+// @Param request body dtos.ConfirmPublicBookingDto true "Código de confirmación"
+// @Success 200 {object} dtos.Response{data=nil} "Código validado"
+// @Failure 400 {object} dtos.ErrorResponse "Código inválido o vencido"
+// @Failure 429 {object} dtos.ErrorResponse "Demasiadas solicitudes"
+// @Failure 500 {object} dtos.ErrorResponse "Error interno del servidor"
+// @Router /public/turno/{id}/confirmar [post]
+func ConfirmPublicBooking(c echo.Context) error {
+	appointmentID, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		logger.Log.Warn("[PublicBookingController][ConfirmPublicBooking] Error: ID inválido")
+		return helpers.RespondError(c, http.StatusBadRequest, "ID inválido")
+	}
+
+	var confirmDto dtos.ConfirmPublicBookingDto
+	if err := c.Bind(&confirmDto); err != nil {
+		logger.Log.Warn("[PublicBookingController][ConfirmPublicBooking] Error: datos inválidos")
+		return helpers.RespondError(c, http.StatusBadRequest, "Datos inválidos")
+	}
+
+	if err := services.ConfirmPublicBooking(uint(appointmentID), confirmDto.Code); err != nil {
+		if errors.Is(err, services.ErrInvalidBookingCode) {
+			return helpers.RespondError(c, http.StatusBadRequest, err.Error())
+		}
+		logger.Log.Error("[PublicBookingController][ConfirmPublicBooking] Error al validar código: ", err)
+		return helpers.RespondError(c, http.StatusInternalServerError, err.Error())
+	}
+
+	logger.Log.Infof("[PublicBookingController][ConfirmPublicBooking] Código validado para turno ID %d", appointmentID)
+	return helpers.RespondSuccess(c, "Código validado, el salón confirmará su turno", nil)
+}
@@ -0,0 +1,170 @@
+package controllers
+
+import (
+	"errors"
+	"net/http"
+	"peluqueria/internal/dtos"
+	"peluqueria/internal/helpers"
+	"peluqueria/internal/services"
+	"peluqueria/logger"
+	"strconv"
+
+	"github.com/labstack/echo/v4"
+)
+
+// @Summary Crear recurso
+// @Description Registra un recurso físico del salón (sillón, lavacabezas) que los servicios ocupan al agendarse.
+// @Tags Recursos
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param request body dtos.ResourceDto true "Datos del recurso"
+// @Success 200 {object} dtos.Response{data=nil} "Recurso creado exitosamente"
+// @Failure 400 {object} dtos.ErrorResponse "Datos inválidos"
+// @Failure 500 {object} dtos.ErrorResponse "Error interno del servidor"
+// @Router /recurso [post]
+func CreateResource(c echo.Context) error {
+	logger.Log.Info("[ResourceController][CreateResource] Intentando crear recurso")
+	var resourceDto dtos.ResourceDto
+	if err := c.Bind(&resourceDto); err != nil {
+		logger.Log.Warn("[ResourceController][CreateResource] Error al crear recurso: datos inválidos")
+		return helpers.RespondError(c, http.StatusBadRequest, "Datos inválidos")
+	}
+
+	if err := services.CreateResource(resourceDto); err != nil {
+		logger.Log.Error("[ResourceController][CreateResource] Error al crear recurso: ", err)
+		return respondResourceError(c, err)
+	}
+
+	logger.Log.Infof("[ResourceController][CreateResource] Recurso creado: %s", resourceDto.Name)
+	return helpers.RespondSuccess(c, "Recurso creado exitosamente", nil)
+}
+
+// @Summary Obtener todos los recursos
+// @Description Devuelve los recursos del salón, opcionalmente filtrados por tipo.
+// @Tags Recursos
+// @Produce json
+// @Security BearerAuth
+// @Param type query string false "Tipo de recurso (ej: lavacabezas)"
+// @Success 200 {object} dtos.Response{data=[]dtos.GetResourceDto} "Recursos obtenidos"
+// @Failure 500 {object} dtos.ErrorResponse "Error interno del servidor"
+// @Router /recurso [get]
+func GetAllResources(c echo.Context) error {
+	logger.Log.Info("[ResourceController][GetAllResources] Obteniendo recursos")
+	resources, err := services.GetAllResources(c.QueryParam("type"))
+	if err != nil {
+		logger.Log.Error("[ResourceController][GetAllResources] Error al obtener recursos: ", err)
+		return helpers.RespondError(c, http.StatusInternalServerError, err.Error())
+	}
+
+	logger.Log.Infof("[ResourceController][GetAllResources] Recursos obtenidos: %d", len(resources))
+	return helpers.RespondSuccess(c, "Recursos obtenidos", resources)
+}
+
+// @Summary Obtener recurso por ID
+// @Description Devuelve los datos de un recurso específico.
+// @Tags Recursos
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "ID del recurso"
+// @Success 200 {object} dtos.Response{data=dtos.GetResourceDto} "Recurso encontrado"
+// @Failure 400 {object} dtos.ErrorResponse "ID inválido"
+// @Failure 404 {object} dtos.ErrorResponse "Recurso no encontrado"
+// @Failure 500 {object} dtos.ErrorResponse "Error interno del servidor"
+// @Router /recurso/{id} [get]
+func GetResourceByID(c echo.Context) error {
+	id := c.Param("id")
+	logger.Log.Infof("[ResourceController][GetResourceByID] Intentando obtener recurso con ID: %s", id)
+	resourceID, err := strconv.ParseUint(id, 10, 32)
+	if err != nil {
+		logger.Log.Warn("[ResourceController][GetResourceByID] ID inválido")
+		return helpers.RespondError(c, http.StatusBadRequest, "ID inválido")
+	}
+
+	resource, err := services.GetResourceByID(uint(resourceID))
+	if err != nil {
+		logger.Log.Error("[ResourceController][GetResourceByID] Error al obtener recurso: ", err)
+		return respondResourceError(c, err)
+	}
+
+	logger.Log.Infof("[ResourceController][GetResourceByID] Recurso obtenido: ID %d", resourceID)
+	return helpers.RespondSuccess(c, "Recurso encontrado", resource)
+}
+
+// @Summary Actualizar recurso
+// @Description Actualiza un recurso. No se puede desactivar ni cambiar de tipo si tiene turnos futuros asignados.
+// @Tags Recursos
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "ID del recurso"
+// @Param request body dtos.ResourceDto true "Datos del recurso"
+// @Success 200 {object} dtos.Response{data=nil} "Recurso actualizado"
+// @Failure 400 {object} dtos.ErrorResponse "ID o datos inválidos, o recurso con turnos asignados"
+// @Failure 404 {object} dtos.ErrorResponse "Recurso no encontrado"
+// @Failure 500 {object} dtos.ErrorResponse "Error interno del servidor"
+// @Router /recurso/{id} [put]
+func UpdateResource(c echo.Context) error {
+	id := c.Param("id")
+	logger.Log.Infof("[ResourceController][UpdateResource] Intentando actualizar recurso con ID: %s", id)
+	resourceID, err := strconv.ParseUint(id, 10, 32)
+	if err != nil {
+		logger.Log.Warn("[ResourceController][UpdateResource] ID inválido")
+		return helpers.RespondError(c, http.StatusBadRequest, "ID inválido")
+	}
+
+	var resourceDto dtos.ResourceDto
+	if err := c.Bind(&resourceDto); err != nil {
+		logger.Log.Warn("[ResourceController][UpdateResource] Error al actualizar recurso: datos inválidos")
+		return helpers.RespondError(c, http.StatusBadRequest, "Datos inválidos")
+	}
+
+	if err := services.UpdateResource(uint(resourceID), resourceDto); err != nil {
+		logger.Log.Error("[ResourceController][UpdateResource] Error al actualizar recurso: ", err)
+		return respondResourceError(c, err)
+	}
+
+	logger.Log.Infof("[ResourceController][UpdateResource] Recurso actualizado: ID %d", resourceID)
+	return helpers.RespondSuccess(c, "Recurso actualizado", nil)
+}
+
+// @Summary Eliminar recurso
+// @Description Elimina un recurso sin turnos futuros asignados.
+// @Tags Recursos
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "ID del recurso"
+// @Success 200 {object} dtos.Response{data=nil} "Recurso eliminado"
+// @Failure 400 {object} dtos.ErrorResponse "ID inválido o recurso con turnos asignados"
+// @Failure 404 {object} dtos.ErrorResponse "Recurso no encontrado"
+// @Failure 500 {object} dtos.ErrorResponse "Error interno del servidor"
+// @Router /recurso/{id} [delete]
+func DeleteResource(c echo.Context) error {
+	id := c.Param("id")
+	logger.Log.Infof("[ResourceController][DeleteResource] Intentando eliminar recurso con ID: %s", id)
+	resourceID, err := strconv.ParseUint(id, 10, 32)
+	if err != nil {
+		logger.Log.Warn("[ResourceController][DeleteResource] ID inválido")
+		return helpers.RespondError(c, http.StatusBadRequest, "ID inválido")
+	}
+
+	if err := services.DeleteResource(uint(resourceID)); err != nil {
+		logger.Log.Error("[ResourceController][DeleteResource] Error al eliminar recurso: ", err)
+		return respondResourceError(c, err)
+	}
+
+	logger.Log.Infof("[ResourceController][DeleteResource] Recurso eliminado: ID %d", resourceID)
+	return helpers.RespondSuccess(c, "Recurso eliminado", nil)
+}
+
+// respondResourceError responde 400 a los datos inválidos, 404 a los recursos inexistentes y
+// 500 al resto.
+func respondResourceError(c echo.Context, err error) error {
+	if errors.Is(err, services.ErrInvalidResource) {
+		return helpers.RespondError(c, http.StatusBadRequest, err.Error())
+	}
+	if errors.Is(err, services.ErrResourceNotFound) {
+		return helpers.RespondError(c, http.StatusNotFound, err.Error())
+	}
+	return helpers.RespondError(c, http.StatusInternalServerError, err.Error())
+}
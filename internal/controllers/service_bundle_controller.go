@@ -0,0 +1,155 @@
+package controllers
+
+import (
+	"net/http"
+	"peluqueria/internal/dtos"
+	"peluqueria/internal/helpers"
+	"peluqueria/internal/services"
+	"peluqueria/logger"
+	"strconv"
+
+	"github.com/labstack/echo/v4"
+)
+
+// @Summary Crear combo
+// @Description Crea un combo de servicios con precio y duración propios. El precio no puede superar la suma de los servicios con ninguno de los estilistas que los realizan; sin duración se usa la suma de los servicios.
+// @Tags Combos
+// @Accept json
+// @Produce json
+// @Param request body dtos.ServiceBundleDto true "Datos del combo"
+// @Success 200 {object} dtos.Response{data=nil} "Combo creado"
+// @Failure 400 {object} dtos.ErrorResponse "Datos inválidos"
+// @Failure 500 {object} dtos.ErrorResponse "Error interno del servidor"
+// @Router /combo [post]
+// @Security BearerAuth
+func CreateServiceBundle(c echo.Context) error {
+	logger.Log.Info("[ServiceBundleController][CreateServiceBundle] Iniciando creación de combo")
+
+	var bundleDto dtos.ServiceBundleDto
+	if err := c.Bind(&bundleDto); err != nil {
+		logger.Log.Warn("[ServiceBundleController][CreateServiceBundle] Error al parsear datos: datos inválidos")
+		return helpers.RespondError(c, http.StatusBadRequest, "Datos inválidos")
+	}
+
+	if err := services.CreateServiceBundle(bundleDto); err != nil {
+		logger.Log.Error("[ServiceBundleController][CreateServiceBundle] Error al crear combo: ", err)
+		return helpers.RespondError(c, http.StatusInternalServerError, err.Error())
+	}
+
+	logger.Log.Infof("[ServiceBundleController][CreateServiceBundle] Combo creado con éxito: %s", bundleDto.Name)
+	return helpers.RespondSuccess(c, "Combo creado", nil)
+}
+
+// @Summary Obtener todos los combos
+// @Description Devuelve los combos registrados, con sus servicios y el ahorro respecto de reservarlos sueltos.
+// @Tags Combos
+// @Produce json
+// @Param active query bool false "Solo combos activos"
+// @Success 200 {object} dtos.Response{data=[]dtos.GetServiceBundleDto} "Combos obtenidos"
+// @Failure 500 {object} dtos.ErrorResponse "Error interno del servidor"
+// @Router /combo [get]
+// @Security BearerAuth
+func GetAllServiceBundles(c echo.Context) error {
+	logger.Log.Info("[ServiceBundleController][GetAllServiceBundles] Obteniendo lista de combos")
+
+	onlyActive, _ := strconv.ParseBool(c.QueryParam("active"))
+	bundles, err := services.GetAllServiceBundles(onlyActive)
+	if err != nil {
+		logger.Log.Error("[ServiceBundleController][GetAllServiceBundles] Error al obtener combos: ", err)
+		return helpers.RespondError(c, http.StatusInternalServerError, err.Error())
+	}
+
+	return helpers.RespondSuccess(c, "Combos obtenidos", bundles)
+}
+
+// @Summary Obtener combo por ID
+// @Description Devuelve los datos de un combo específico.
+// @Tags Combos
+// @Produce json
+// @Param id path int true "ID del combo"
+// @Success 200 {object} dtos.Response{data=dtos.GetServiceBundleDto} "Combo obtenido"
+// @Failure 400 {object} dtos.ErrorResponse "ID inválido"
+// @Failure 404 {object} dtos.ErrorResponse "Combo no encontrado"
+// @Router /combo/{id} [get]
+// @Security BearerAuth
+func GetServiceBundleByID(c echo.Context) error {
+	id := c.Param("id")
+	logger.Log.Infof("[ServiceBundleController][GetServiceBundleByID] Obteniendo combo con ID: %s", id)
+	bundleID, err := strconv.ParseUint(id, 10, 32)
+	if err != nil {
+		logger.Log.Warn("[ServiceBundleController][GetServiceBundleByID] ID inválido")
+		return helpers.RespondError(c, http.StatusBadRequest, "ID inválido")
+	}
+
+	bundle, err := services.GetServiceBundleByID(uint(bundleID))
+	if err != nil {
+		logger.Log.Error("[ServiceBundleController][GetServiceBundleByID] Error al obtener combo: ", err)
+		return helpers.RespondError(c, http.StatusNotFound, err.Error())
+	}
+
+	return helpers.RespondSuccess(c, "Combo obtenido", bundle)
+}
+
+// @Summary Actualizar combo
+// @Description Actualiza un combo. Los turnos ya agendados conservan los precios y tiempos con los que se reservaron.
+// @Tags Combos
+// @Accept json
+// @Produce json
+// @Param id path int true "ID del combo"
+// @Param request body dtos.ServiceBundleDto true "Datos del combo"
+// @Success 200 {object} dtos.Response{data=nil} "Combo actualizado"
+// @Failure 400 {object} dtos.ErrorResponse "ID o datos inválidos"
+// @Failure 500 {object} dtos.ErrorResponse "Error interno del servidor"
+// @Router /combo/{id} [put]
+// @Security BearerAuth
+func UpdateServiceBundle(c echo.Context) error {
+	id := c.Param("id")
+	logger.Log.Infof("[ServiceBundleController][UpdateServiceBundle] Actualizando combo con ID: %s", id)
+	bundleID, err := strconv.ParseUint(id, 10, 32)
+	if err != nil {
+		logger.Log.Warn("[ServiceBundleController][UpdateServiceBundle] ID inválido")
+		return helpers.RespondError(c, http.StatusBadRequest, "ID inválido")
+	}
+
+	var bundleDto dtos.ServiceBundleDto
+	if err := c.Bind(&bundleDto); err != nil {
+		logger.Log.Warn("[ServiceBundleController][UpdateServiceBundle] Error al parsear datos: datos inválidos")
+		return helpers.RespondError(c, http.StatusBadRequest, "Datos inválidos")
+	}
+
+	if err := services.UpdateServiceBundle(uint(bundleID), bundleDto); err != nil {
+		logger.Log.Error("[ServiceBundleController][UpdateServiceBundle] Error al actualizar combo: ", err)
+		return helpers.RespondError(c, http.StatusInternalServerError, err.Error())
+	}
+
+	logger.Log.Infof("[ServiceBundleController][UpdateServiceBundle] Combo actualizado: ID %d", bundleID)
+	return helpers.RespondSuccess(c, "Combo actualizado", nil)
+}
+
+// @Summary Eliminar combo
+// @Description Da de baja un combo. Los turnos que lo usaron lo siguen mostrando.
+// @Tags Combos
+// @Produce json
+// @Param id path int true "ID del combo"
+// @Success 200 {object} dtos.Response{data=nil} "Combo eliminado"
+// @Failure 400 {object} dtos.ErrorResponse "ID inválido"
+// @Failure 500 {object} dtos.ErrorResponse "Error interno del servidor"
+// @Router /combo/{id} [delete]
+// @Security BearerAuth
+func DeleteServiceBundle(c echo.Context) error {
+	id := c.Param("id")
+	logger.Log.Infof("[ServiceBundleController][DeleteServiceBundle] Eliminando combo con ID: %s", id)
+	bundleID, err := strconv.ParseUint(id, 10, 32)
+	if err != nil {
+		logger.Log.Warn("[ServiceBundleController][DeleteServiceBundle] ID inválido")
+		return helpers.RespondError(c, http.StatusBadRequest, "ID inválido")
+	}
+
+	if err := services.DeleteServiceBundle(uint(bundleID)); err != nil {
+		logger.Log.Error("[ServiceBundleController][DeleteServiceBundle] Error al eliminar combo: ", err)
+		return helpers.RespondError(c, http.StatusInternalServerError, err.Error())
+	}
+
+	logger.Log.Infof("[ServiceBundleController][DeleteServiceBundle] Combo eliminado: ID %d", bundleID)
+	return helpers.RespondSuccess(c, "Combo eliminado", nil)
+}
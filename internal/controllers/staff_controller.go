@@ -0,0 +1,259 @@
+package controllers
+
+import (
+	"errors"
+	"net/http"
+	"peluqueria/internal/dtos"
+	"peluqueria/internal/helpers"
+	"peluqueria/internal/services"
+	"peluqueria/logger"
+	"strconv"
+
+	"github.com/labstack/echo/v4"
+)
+
+// @Summary Crear empleado
+// @Description Registra un nuevo empleado (estilista), opcionalmente vinculado a un usuario.
+// @Tags Empleados
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param request body dtos.StaffDto true "Datos del empleado"
+// @Success 200 {object} dtos.Response{data=nil} "Empleado creado exitosamente"
+// @Failure 400 {object} dtos.ErrorResponse "Datos inválidos"
+// @Failure 500 {object} dtos.ErrorResponse "Error interno del servidor"
+// @Router /empleado [post]
+func CreateStaff(c echo.Context) error {
+	logger.Log.Info("[StaffController][CreateStaff] Intentando crear empleado")
+	var staffDto dtos.StaffDto
+	if err := c.Bind(&staffDto); err != nil {
+		logger.Log.Warn("[StaffController][CreateStaff] Error al crear empleado: datos inválidos")
+		return helpers.RespondError(c, http.StatusBadRequest, "Datos inválidos")
+	}
+
+	if err := services.CreateStaff(staffDto); err != nil {
+		logger.Log.Error("[StaffController][CreateStaff] Error al crear empleado: ", err)
+		return helpers.RespondError(c, http.StatusInternalServerError, err.Error())
+	}
+
+	logger.Log.Infof("[StaffController][CreateStaff] Empleado creado: %s", staffDto.Name)
+	return helpers.RespondSuccess(c, "Empleado creado exitosamente", nil)
+}
+
+// @Summary Obtener todos los empleados
+// @Description Devuelve una lista de todos los empleados registrados.
+// @Tags Empleados
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {object} dtos.Response{data=[]dtos.GetStaffDto} "Empleados obtenidos"
+// @Failure 500 {object} dtos.ErrorResponse "Error interno del servidor"
+// @Router /empleado [get]
+func GetAllStaff(c echo.Context) error {
+	logger.Log.Info("[StaffController][GetAllStaff] Obteniendo empleados")
+	staff, err := services.GetAllStaff()
+	if err != nil {
+		logger.Log.Error("[StaffController][GetAllStaff] Error al obtener empleados: ", err)
+		return helpers.RespondError(c, http.StatusInternalServerError, err.Error())
+	}
+
+	logger.Log.Infof("[StaffController][GetAllStaff] Empleados obtenidos: %d", len(staff))
+	return helpers.RespondSuccess(c, "Empleados obtenidos", staff)
+}
+
+// @Summary Obtener empleado por ID
+// @Description Devuelve los datos de un empleado específico.
+// @Tags Empleados
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "ID del empleado"
+// @Success 200 {object} dtos.Response{data=dtos.GetStaffDto} "Empleado encontrado"
+// @Failure 400 {object} dtos.ErrorResponse "ID inválido"
+// @Failure 404 {object} dtos.ErrorResponse "Empleado no encontrado"
+// @Router /empleado/{id} [get]
+func GetStaffByID(c echo.Context) error {
+	id := c.Param("id")
+	logger.Log.Infof("[StaffController][GetStaffByID] Intentando obtener empleado con ID: %s", id)
+	staffID, err := strconv.ParseUint(id, 10, 32)
+	if err != nil {
+		logger.Log.Warn("[StaffController][GetStaffByID] ID inválido")
+		return helpers.RespondError(c, http.StatusBadRequest, "ID inválido")
+	}
+
+	staff, err := services.GetStaffByID(uint(staffID))
+	if err != nil {
+		logger.Log.Error("[StaffController][GetStaffByID] Error al obtener empleado: ", err)
+		return helpers.RespondError(c, http.StatusNotFound, err.Error())
+	}
+
+	logger.Log.Infof("[StaffController][GetStaffByID] Empleado obtenido: ID %d", staffID)
+	return helpers.RespondSuccess(c, "Empleado encontrado", staff)
+}
+
+// @Summary Actualizar empleado
+// @Description Actualiza los datos de un empleado específico.
+// @Tags Empleados
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "ID del empleado"
+// @Param request body dtos.StaffDto true "Datos del empleado"
+// @Success 200 {object} dtos.Response{data=nil} "Empleado actualizado"
+// @Failure 400 {object} dtos.ErrorResponse "ID o datos inválidos"
+// @Failure 500 {object} dtos.ErrorResponse "Error interno del servidor"
+// @Router /empleado/{id} [put]
+func UpdateStaff(c echo.Context) error {
+	id := c.Param("id")
+	logger.Log.Infof("[StaffController][UpdateStaff] Intentando actualizar empleado con ID: %s", id)
+	staffID, err := strconv.ParseUint(id, 10, 32)
+	if err != nil {
+		logger.Log.Warn("[StaffController][UpdateStaff] ID inválido")
+		return helpers.RespondError(c, http.StatusBadRequest, "ID inválido")
+	}
+
+	var staffDto dtos.StaffDto
+	if err := c.Bind(&staffDto); err != nil {
+		logger.Log.Warn("[StaffController][UpdateStaff] Error al actualizar empleado: datos inválidos")
+		return helpers.RespondError(c, http.StatusBadRequest, "Datos inválidos")
+	}
+
+	if err := services.UpdateStaff(uint(staffID), staffDto); err != nil {
+		logger.Log.Error("[StaffController][UpdateStaff] Error al actualizar empleado: ", err)
+		return helpers.RespondError(c, http.StatusInternalServerError, err.Error())
+	}
+
+	logger.Log.Infof("[StaffController][UpdateStaff] Empleado actualizado: ID %d", staffID)
+	return helpers.RespondSuccess(c, "Empleado actualizado", nil)
+}
+
+// @Summary Eliminar empleado
+// @Description Elimina un empleado sin turnos asignados.
+// @Tags Empleados
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "ID del empleado"
+// @Success 200 {object} dtos.Response{data=nil} "Empleado eliminado"
+// @Failure 400 {object} dtos.ErrorResponse "ID inválido"
+// @Failure 404 {object} dtos.ErrorResponse "Empleado no encontrado"
+// @Failure 500 {object} dtos.ErrorResponse "Error interno del servidor"
+// @Router /empleado/{id} [delete]
+func DeleteStaff(c echo.Context) error {
+	id := c.Param("id")
+	logger.Log.Infof("[StaffController][DeleteStaff] Intentando eliminar empleado con ID: %s", id)
+	staffID, err := strconv.ParseUint(id, 10, 32)
+	if err != nil {
+		logger.Log.Warn("[StaffController][DeleteStaff] ID inválido")
+		return helpers.RespondError(c, http.StatusBadRequest, "ID inválido")
+	}
+
+	if err := services.DeleteStaff(uint(staffID)); err != nil {
+		logger.Log.Error("[StaffController][DeleteStaff] Error al eliminar empleado: ", err)
+		if errors.Is(err, services.ErrStaffNotFound) {
+			return helpers.RespondError(c, http.StatusNotFound, err.Error())
+		}
+		return helpers.RespondError(c, http.StatusInternalServerError, err.Error())
+	}
+
+	logger.Log.Infof("[StaffController][DeleteStaff] Empleado eliminado: ID %d", staffID)
+	return helpers.RespondSuccess(c, "Empleado eliminado", nil)
+}
+
+// @Summary Obtener servicios de un empleado
+// @Description Devuelve los servicios que realiza el empleado con su precio y tiempo efectivos. Sin servicios cargados, el empleado realiza todos con los valores de cada servicio.
+// @Tags Empleados
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "ID del empleado"
+// @Success 200 {object} dtos.Response{data=[]dtos.GetStaffSkillDto} "Servicios del empleado obtenidos"
+// @Failure 400 {object} dtos.ErrorResponse "ID inválido"
+// @Failure 500 {object} dtos.ErrorResponse "Error interno del servidor"
+// @Router /empleado/{id}/servicios [get]
+func GetStaffSkills(c echo.Context) error {
+	id := c.Param("id")
+	staffID, err := strconv.ParseUint(id, 10, 32)
+	if err != nil {
+		logger.Log.Warn("[StaffController][GetStaffSkills] ID inválido")
+		return helpers.RespondError(c, http.StatusBadRequest, "ID inválido")
+	}
+
+	skills, err := services.GetStaffSkills(uint(staffID))
+	if err != nil {
+		logger.Log.Error("[StaffController][GetStaffSkills] Error al obtener servicios del empleado: ", err)
+		return helpers.RespondError(c, http.StatusInternalServerError, err.Error())
+	}
+
+	return helpers.RespondSuccess(c, "Servicios del empleado obtenidos", skills)
+}
+
+// @Summary Asignar servicio a un empleado
+// @Description Habilita al empleado para realizar el servicio, opcionalmente con precio y tiempo activo propios. Si ya estaba asignado, actualiza esos valores.
+// @Tags Empleados
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "ID del empleado"
+// @Param serviceId path int true "ID del servicio"
+// @Param request body dtos.StaffSkillDto false "Precio y tiempo propios del empleado"
+// @Success 200 {object} dtos.Response{data=nil} "Servicio asignado"
+// @Failure 400 {object} dtos.ErrorResponse "ID o datos inválidos"
+// @Failure 500 {object} dtos.ErrorResponse "Error interno del servidor"
+// @Router /empleado/{id}/servicios/{serviceId} [put]
+func SetStaffSkill(c echo.Context) error {
+	id := c.Param("id")
+	logger.Log.Infof("[StaffController][SetStaffSkill] Intentando asignar servicio al empleado con ID: %s", id)
+	staffID, err := strconv.ParseUint(id, 10, 32)
+	if err != nil {
+		logger.Log.Warn("[StaffController][SetStaffSkill] ID inválido")
+		return helpers.RespondError(c, http.StatusBadRequest, "ID inválido")
+	}
+	serviceID, err := strconv.ParseUint(c.Param("serviceId"), 10, 32)
+	if err != nil {
+		logger.Log.Warn("[StaffController][SetStaffSkill] ID de servicio inválido")
+		return helpers.RespondError(c, http.StatusBadRequest, "ID de servicio inválido")
+	}
+
+	var skillDto dtos.StaffSkillDto
+	if err := c.Bind(&skillDto); err != nil {
+		logger.Log.Warn("[StaffController][SetStaffSkill] Error: datos inválidos")
+		return helpers.RespondError(c, http.StatusBadRequest, "Datos inválidos")
+	}
+
+	if err := services.SetStaffSkill(uint(staffID), uint(serviceID), skillDto); err != nil {
+		logger.Log.Error("[StaffController][SetStaffSkill] Error al asignar servicio: ", err)
+		return helpers.RespondError(c, http.StatusInternalServerError, err.Error())
+	}
+
+	return helpers.RespondSuccess(c, "Servicio asignado", nil)
+}
+
+// @Summary Quitar servicio a un empleado
+// @Description Deja de ofrecer el servicio con este empleado. Los turnos ya agendados conservan su precio.
+// @Tags Empleados
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "ID del empleado"
+// @Param serviceId path int true "ID del servicio"
+// @Success 200 {object} dtos.Response{data=nil} "Servicio quitado"
+// @Failure 400 {object} dtos.ErrorResponse "ID inválido"
+// @Failure 500 {object} dtos.ErrorResponse "Error interno del servidor"
+// @Router /empleado/{id}/servicios/{serviceId} [delete]
+func DeleteStaffSkill(c echo.Context) error {
+	id := c.Param("id")
+	logger.Log.Infof("[StaffController][DeleteStaffSkill] Intentando quitar servicio al empleado con ID: %s", id)
+	staffID, err := strconv.ParseUint(id, 10, 32)
+	if err != nil {
+		logger.Log.Warn("[StaffController][DeleteStaffSkill] ID inválido")
+		return helpers.RespondError(c, http.StatusBadRequest, "ID inválido")
+	}
+	serviceID, err := strconv.ParseUint(c.Param("serviceId"), 10, 32)
+	if err != nil {
+		logger.Log.Warn("[StaffController][DeleteStaffSkill] ID de servicio inválido")
+		return helpers.RespondError(c, http.StatusBadRequest, "ID de servicio inválido")
+	}
+
+	if err := services.DeleteStaffSkill(uint(staffID), uint(serviceID)); err != nil {
+		logger.Log.Error("[StaffController][DeleteStaffSkill] Error al quitar servicio: ", err)
+		return helpers.RespondError(c, http.StatusInternalServerError, err.Error())
+	}
+
+	return helpers.RespondSuccess(c, "Servicio quitado", nil)
+}
@@ -0,0 +1,510 @@
+package controllers
+
+import (
+	"errors"
+	"net/http"
+	"peluqueria/internal/dtos"
+	"peluqueria/internal/helpers"
+	"peluqueria/internal/services"
+	"peluqueria/logger"
+	"peluqueria/middlewares"
+	"strconv"
+
+	"github.com/labstack/echo/v4"
+)
+
+// @Summary Obtener franjas de trabajo de un empleado
+// @Description Devuelve el horario semanal del empleado. Sin franjas cargadas trabaja en todo el horario del salón.
+// @Tags Horarios de empleados
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "ID del empleado"
+// @Success 200 {object} dtos.Response{data=[]dtos.GetStaffShiftDto} "Franjas obtenidas"
+// @Failure 400 {object} dtos.ErrorResponse "ID inválido"
+// @Failure 500 {object} dtos.ErrorResponse "Error interno del servidor"
+// @Router /empleado/{id}/horarios [get]
+func GetStaffShifts(c echo.Context) error {
+	id := c.Param("id")
+	staffID, err := strconv.ParseUint(id, 10, 32)
+	if err != nil {
+		logger.Log.Warn("[StaffScheduleController][GetStaffShifts] ID inválido")
+		return helpers.RespondError(c, http.StatusBadRequest, "ID inválido")
+	}
+
+	shifts, err := services.GetStaffShifts(uint(staffID))
+	if err != nil {
+		logger.Log.Error("[StaffScheduleController][GetStaffShifts] Error al obtener franjas: ", err)
+		return helpers.RespondError(c, http.StatusInternalServerError, err.Error())
+	}
+
+	return helpers.RespondSuccess(c, "Franjas obtenidas", shifts)
+}
+
+// @Summary Crear franja de trabajo
+// @Description Agrega una franja semanal al horario del empleado. Un día puede tener varias franjas (turno cortado).
+// @Tags Horarios de empleados
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "ID del empleado"
+// @Param request body dtos.StaffShiftDto true "Datos de la franja"
+// @Success 200 {object} dtos.Response{data=nil} "Franja creada"
+// @Failure 400 {object} dtos.ErrorResponse "ID o datos inválidos"
+// @Failure 500 {object} dtos.ErrorResponse "Error interno del servidor"
+// @Router /empleado/{id}/horarios [post]
+func CreateStaffShift(c echo.Context) error {
+	id := c.Param("id")
+	logger.Log.Infof("[StaffScheduleController][CreateStaffShift] Intentando crear franja para el empleado ID: %s", id)
+	staffID, err := strconv.ParseUint(id, 10, 32)
+	if err != nil {
+		logger.Log.Warn("[StaffScheduleController][CreateStaffShift] ID inválido")
+		return helpers.RespondError(c, http.StatusBadRequest, "ID inválido")
+	}
+
+	var dto dtos.StaffShiftDto
+	if err := c.Bind(&dto); err != nil {
+		logger.Log.Warn("[StaffScheduleController][CreateStaffShift] Error: datos inválidos")
+		return helpers.RespondError(c, http.StatusBadRequest, "Datos inválidos")
+	}
+
+	if err := services.CreateStaffShift(uint(staffID), dto); err != nil {
+		logger.Log.Error("[StaffScheduleController][CreateStaffShift] Error al crear franja: ", err)
+		return helpers.RespondError(c, http.StatusInternalServerError, err.Error())
+	}
+
+	return helpers.RespondSuccess(c, "Franja creada", nil)
+}
+
+// @Summary Actualizar franja de trabajo
+// @Description Modifica una franja semanal de un empleado.
+// @Tags Horarios de empleados
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "ID de la franja"
+// @Param request body dtos.StaffShiftDto true "Datos de la franja"
+// @Success 200 {object} dtos.Response{data=nil} "Franja actualizada"
+// @Failure 400 {object} dtos.ErrorResponse "ID o datos inválidos"
+// @Failure 500 {object} dtos.ErrorResponse "Error interno del servidor"
+// @Router /empleado/horarios/{id} [put]
+func UpdateStaffShift(c echo.Context) error {
+	id := c.Param("id")
+	logger.Log.Infof("[StaffScheduleController][UpdateStaffShift] Intentando actualizar franja con ID: %s", id)
+	shiftID, err := strconv.ParseUint(id, 10, 32)
+	if err != nil {
+		logger.Log.Warn("[StaffScheduleController][UpdateStaffShift] ID inválido")
+		return helpers.RespondError(c, http.StatusBadRequest, "ID inválido")
+	}
+
+	var dto dtos.StaffShiftDto
+	if err := c.Bind(&dto); err != nil {
+		logger.Log.Warn("[StaffScheduleController][UpdateStaffShift] Error: datos inválidos")
+		return helpers.RespondError(c, http.StatusBadRequest, "Datos inválidos")
+	}
+
+	if err := services.UpdateStaffShift(uint(shiftID), dto); err != nil {
+		logger.Log.Error("[StaffScheduleController][UpdateStaffShift] Error al actualizar franja: ", err)
+		return helpers.RespondError(c, http.StatusInternalServerError, err.Error())
+	}
+
+	return helpers.RespondSuccess(c, "Franja actualizada", nil)
+}
+
+// @Summary Eliminar franja de trabajo
+// @Description Elimina una franja semanal de un empleado.
+// @Tags Horarios de empleados
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "ID de la franja"
+// @Success 200 {object} dtos.Response{data=nil} "Franja eliminada"
+// @Failure 400 {object} dtos.ErrorResponse "ID inválido"
+// @Failure 500 {object} dtos.ErrorResponse "Error interno del servidor"
+// @Router /empleado/horarios/{id} [delete]
+func DeleteStaffShift(c echo.Context) error {
+	id := c.Param("id")
+	logger.Log.Infof("[StaffScheduleController][DeleteStaffShift] Intentando eliminar franja con ID: %s", id)
+	shiftID, err := strconv.ParseUint(id, 10, 32)
+	if err != nil {
+		logger.Log.Warn("[StaffScheduleController][DeleteStaffShift] ID inválido")
+		return helpers.RespondError(c, http.StatusBadRequest, "ID inválido")
+	}
+
+	if err := services.DeleteStaffShift(uint(shiftID)); err != nil {
+		logger.Log.Error("[StaffScheduleController][DeleteStaffShift] Error al eliminar franja: ", err)
+		return helpers.RespondError(c, http.StatusInternalServerError, err.Error())
+	}
+
+	return helpers.RespondSuccess(c, "Franja eliminada", nil)
+}
+
+// @Summary Obtener excepciones de horario de un empleado
+// @Description Devuelve los cambios de horario y francos puntuales del empleado, opcionalmente entre dos fechas.
+// @Tags Horarios de empleados
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "ID del empleado"
+// @Param from query string false "Desde (DD/MM/YYYY)"
+// @Param to query string false "Hasta (DD/MM/YYYY)"
+// @Success 200 {object} dtos.Response{data=[]dtos.GetStaffScheduleExceptionDto} "Excepciones obtenidas"
+// @Failure 400 {object} dtos.ErrorResponse "ID o fechas inválidos"
+// @Failure 500 {object} dtos.ErrorResponse "Error interno del servidor"
+// @Router /empleado/{id}/excepciones [get]
+func GetStaffScheduleExceptions(c echo.Context) error {
+	id := c.Param("id")
+	staffID, err := strconv.ParseUint(id, 10, 32)
+	if err != nil {
+		logger.Log.Warn("[StaffScheduleController][GetStaffScheduleExceptions] ID inválido")
+		return helpers.RespondError(c, http.StatusBadRequest, "ID inválido")
+	}
+
+	exceptions, err := services.GetStaffScheduleExceptions(uint(staffID), c.QueryParam("from"), c.QueryParam("to"))
+	if err != nil {
+		logger.Log.Error("[StaffScheduleController][GetStaffScheduleExceptions] Error al obtener excepciones: ", err)
+		return helpers.RespondError(c, http.StatusInternalServerError, err.Error())
+	}
+
+	return helpers.RespondSuccess(c, "Excepciones obtenidas", exceptions)
+}
+
+// @Summary Crear excepción de horario
+// @Description Reemplaza el horario semanal del empleado en una fecha. Sin horario, el empleado no trabaja ese día. Devuelve los turnos ya agendados que quedan fuera del nuevo horario.
+// @Tags Horarios de empleados
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "ID del empleado"
+// @Param request body dtos.StaffScheduleExceptionDto true "Datos de la excepción"
+// @Success 200 {object} dtos.Response{data=dtos.GetStaffScheduleExceptionDto} "Excepción creada"
+// @Failure 400 {object} dtos.ErrorResponse "ID o datos inválidos"
+// @Failure 500 {object} dtos.ErrorResponse "Error interno del servidor"
+// @Router /empleado/{id}/excepciones [post]
+func CreateStaffScheduleException(c echo.Context) error {
+	id := c.Param("id")
+	logger.Log.Infof("[StaffScheduleController][CreateStaffScheduleException] Intentando crear excepción para el empleado ID: %s", id)
+	staffID, err := strconv.ParseUint(id, 10, 32)
+	if err != nil {
+		logger.Log.Warn("[StaffScheduleController][CreateStaffScheduleException] ID inválido")
+		return helpers.RespondError(c, http.StatusBadRequest, "ID inválido")
+	}
+
+	var dto dtos.StaffScheduleExceptionDto
+	if err := c.Bind(&dto); err != nil {
+		logger.Log.Warn("[StaffScheduleController][CreateStaffScheduleException] Error: datos inválidos")
+		return helpers.RespondError(c, http.StatusBadRequest, "Datos inválidos")
+	}
+
+	exception, err := services.CreateStaffScheduleException(uint(staffID), dto)
+	if err != nil {
+		logger.Log.Error("[StaffScheduleController][CreateStaffScheduleException] Error al crear excepción: ", err)
+		return helpers.RespondError(c, http.StatusInternalServerError, err.Error())
+	}
+
+	if len(exception.Conflicts) > 0 {
+		return helpers.RespondSuccess(c, "Excepción creada con turnos en conflicto", exception)
+	}
+	return helpers.RespondSuccess(c, "Excepción creada", exception)
+}
+
+// @Summary Actualizar excepción de horario
+// @Description Modifica una excepción de horario y devuelve los turnos ya agendados que quedan fuera del nuevo horario.
+// @Tags Horarios de empleados
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "ID de la excepción"
+// @Param request body dtos.StaffScheduleExceptionDto true "Datos de la excepción"
+// @Success 200 {object} dtos.Response{data=dtos.GetStaffScheduleExceptionDto} "Excepción actualizada"
+// @Failure 400 {object} dtos.ErrorResponse "ID o datos inválidos"
+// @Failure 500 {object} dtos.ErrorResponse "Error interno del servidor"
+// @Router /empleado/excepciones/{id} [put]
+func UpdateStaffScheduleException(c echo.Context) error {
+	id := c.Param("id")
+	logger.Log.Infof("[StaffScheduleController][UpdateStaffScheduleException] Intentando actualizar excepción con ID: %s", id)
+	exceptionID, err := strconv.ParseUint(id, 10, 32)
+	if err != nil {
+		logger.Log.Warn("[StaffScheduleController][UpdateStaffScheduleException] ID inválido")
+		return helpers.RespondError(c, http.StatusBadRequest, "ID inválido")
+	}
+
+	var dto dtos.StaffScheduleExceptionDto
+	if err := c.Bind(&dto); err != nil {
+		logger.Log.Warn("[StaffScheduleController][UpdateStaffScheduleException] Error: datos inválidos")
+		return helpers.RespondError(c, http.StatusBadRequest, "Datos inválidos")
+	}
+
+	exception, err := services.UpdateStaffScheduleException(uint(exceptionID), dto)
+	if err != nil {
+		logger.Log.Error("[StaffScheduleController][UpdateStaffScheduleException] Error al actualizar excepción: ", err)
+		return helpers.RespondError(c, http.StatusInternalServerError, err.Error())
+	}
+
+	if len(exception.Conflicts) > 0 {
+		return helpers.RespondSuccess(c, "Excepción actualizada con turnos en conflicto", exception)
+	}
+	return helpers.RespondSuccess(c, "Excepción actualizada", exception)
+}
+
+// @Summary Eliminar excepción de horario
+// @Description Elimina una excepción; el empleado vuelve a su horario semanal ese día.
+// @Tags Horarios de empleados
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "ID de la excepción"
+// @Success 200 {object} dtos.Response{data=nil} "Excepción eliminada"
+// @Failure 400 {object} dtos.ErrorResponse "ID inválido"
+// @Failure 500 {object} dtos.ErrorResponse "Error interno del servidor"
+// @Router /empleado/excepciones/{id} [delete]
+func DeleteStaffScheduleException(c echo.Context) error {
+	id := c.Param("id")
+	logger.Log.Infof("[StaffScheduleController][DeleteStaffScheduleException] Intentando eliminar excepción con ID: %s", id)
+	exceptionID, err := strconv.ParseUint(id, 10, 32)
+	if err != nil {
+		logger.Log.Warn("[StaffScheduleController][DeleteStaffScheduleException] ID inválido")
+		return helpers.RespondError(c, http.StatusBadRequest, "ID inválido")
+	}
+
+	if err := services.DeleteStaffScheduleException(uint(exceptionID)); err != nil {
+		logger.Log.Error("[StaffScheduleController][DeleteStaffScheduleException] Error al eliminar excepción: ", err)
+		return helpers.RespondError(c, http.StatusInternalServerError, err.Error())
+	}
+
+	return helpers.RespondSuccess(c, "Excepción eliminada", nil)
+}
+
+// @Summary Solicitar licencia
+// @Description Registra una solicitud de licencia (vacaciones, enfermedad u otro) pendiente de aprobación. Devuelve los turnos del empleado que caen dentro de ella. Sin el permiso approve_time_off solo se pueden pedir licencias para el empleado vinculado al usuario.
+// @Tags Licencias
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param request body dtos.TimeOffDto true "Datos de la licencia"
+// @Success 200 {object} dtos.Response{data=dtos.GetTimeOffDto} "Licencia solicitada"
+// @Failure 400 {object} dtos.ErrorResponse "Datos inválidos"
+// @Failure 403 {object} dtos.ErrorResponse "La licencia es de otro empleado"
+// @Failure 500 {object} dtos.ErrorResponse "Error interno del servidor"
+// @Router /licencia [post]
+func RequestTimeOff(c echo.Context) error {
+	logger.Log.Info("[StaffScheduleController][RequestTimeOff] Intentando solicitar licencia")
+	var dto dtos.TimeOffDto
+	if err := c.Bind(&dto); err != nil {
+		logger.Log.Warn("[StaffScheduleController][RequestTimeOff] Error: datos inválidos")
+		return helpers.RespondError(c, http.StatusBadRequest, "Datos inválidos")
+	}
+
+	canApprove, err := canApproveTimeOff(c)
+	if err != nil {
+		logger.Log.Error("[StaffScheduleController][RequestTimeOff] Error al verificar permisos: ", err)
+		return helpers.RespondError(c, http.StatusInternalServerError, "Error al verificar permisos")
+	}
+
+	timeOff, err := services.RequestTimeOff(dto, helpers.CurrentUserID(c), canApprove)
+	if err != nil {
+		logger.Log.Error("[StaffScheduleController][RequestTimeOff] Error al solicitar licencia: ", err)
+		if errors.Is(err, services.ErrTimeOffNotAllowed) {
+			return helpers.RespondError(c, http.StatusForbidden, err.Error())
+		}
+		return helpers.RespondError(c, http.StatusInternalServerError, err.Error())
+	}
+
+	return helpers.RespondSuccess(c, "Licencia solicitada", timeOff)
+}
+
+// @Summary Obtener licencias
+// @Description Devuelve las licencias, opcionalmente filtradas por empleado y estado.
+// @Tags Licencias
+// @Produce json
+// @Security BearerAuth
+// @Param staff_id query int false "ID del empleado"
+// @Param status query string false "Estado: pendiente, aprobada o rechazada"
+// @Success 200 {object} dtos.Response{data=[]dtos.GetTimeOffDto} "Licencias obtenidas"
+// @Failure 500 {object} dtos.ErrorResponse "Error interno del servidor"
+// @Router /licencia [get]
+func GetAllTimeOff(c echo.Context) error {
+	timeOffs, err := services.GetAllTimeOff(c.QueryParam("staff_id"), c.QueryParam("status"))
+	if err != nil {
+		logger.Log.Error("[StaffScheduleController][GetAllTimeOff] Error al obtener licencias: ", err)
+		return helpers.RespondError(c, http.StatusInternalServerError, err.Error())
+	}
+
+	return helpers.RespondSuccess(c, "Licencias obtenidas", timeOffs)
+}
+
+// @Summary Obtener licencia por ID
+// @Description Devuelve una licencia y los turnos del empleado que caen dentro de ella.
+// @Tags Licencias
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "ID de la licencia"
+// @Success 200 {object} dtos.Response{data=dtos.GetTimeOffDto} "Licencia encontrada"
+// @Failure 400 {object} dtos.ErrorResponse "ID inválido"
+// @Failure 404 {object} dtos.ErrorResponse "Licencia no encontrada"
+// @Router /licencia/{id} [get]
+func GetTimeOffByID(c echo.Context) error {
+	id := c.Param("id")
+	timeOffID, err := strconv.ParseUint(id, 10, 32)
+	if err != nil {
+		logger.Log.Warn("[StaffScheduleController][GetTimeOffByID] ID inválido")
+		return helpers.RespondError(c, http.StatusBadRequest, "ID inválido")
+	}
+
+	timeOff, err := services.GetTimeOffByID(uint(timeOffID))
+	if err != nil {
+		logger.Log.Error("[StaffScheduleController][GetTimeOffByID] Error al obtener licencia: ", err)
+		return helpers.RespondError(c, http.StatusNotFound, err.Error())
+	}
+
+	return helpers.RespondSuccess(c, "Licencia encontrada", timeOff)
+}
+
+// @Summary Actualizar licencia
+// @Description Modifica una licencia que todavía está pendiente de aprobación. Sin el permiso approve_time_off solo se pueden modificar las licencias del empleado vinculado al usuario.
+// @Tags Licencias
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "ID de la licencia"
+// @Param request body dtos.TimeOffDto true "Datos de la licencia"
+// @Success 200 {object} dtos.Response{data=nil} "Licencia actualizada"
+// @Failure 400 {object} dtos.ErrorResponse "ID o datos inválidos"
+// @Failure 403 {object} dtos.ErrorResponse "La licencia es de otro empleado"
+// @Failure 500 {object} dtos.ErrorResponse "Error interno del servidor"
+// @Router /licencia/{id} [put]
+func UpdateTimeOff(c echo.Context) error {
+	id := c.Param("id")
+	logger.Log.Infof("[StaffScheduleController][UpdateTimeOff] Intentando actualizar licencia con ID: %s", id)
+	timeOffID, err := strconv.ParseUint(id, 10, 32)
+	if err != nil {
+		logger.Log.Warn("[StaffScheduleController][UpdateTimeOff] ID inválido")
+		return helpers.RespondError(c, http.StatusBadRequest, "ID inválido")
+	}
+
+	var dto dtos.TimeOffDto
+	if err := c.Bind(&dto); err != nil {
+		logger.Log.Warn("[StaffScheduleController][UpdateTimeOff] Error: datos inválidos")
+		return helpers.RespondError(c, http.StatusBadRequest, "Datos inválidos")
+	}
+
+	canApprove, err := canApproveTimeOff(c)
+	if err != nil {
+		logger.Log.Error("[StaffScheduleController][UpdateTimeOff] Error al verificar permisos: ", err)
+		return helpers.RespondError(c, http.StatusInternalServerError, "Error al verificar permisos")
+	}
+
+	if err := services.UpdateTimeOff(uint(timeOffID), dto, helpers.CurrentUserID(c), canApprove); err != nil {
+		logger.Log.Error("[StaffScheduleController][UpdateTimeOff] Error al actualizar licencia: ", err)
+		if errors.Is(err, services.ErrTimeOffNotAllowed) {
+			return helpers.RespondError(c, http.StatusForbidden, err.Error())
+		}
+		return helpers.RespondError(c, http.StatusInternalServerError, err.Error())
+	}
+
+	return helpers.RespondSuccess(c, "Licencia actualizada", nil)
+}
+
+// @Summary Eliminar licencia
+// @Description Elimina una licencia; si estaba aprobada el empleado vuelve a estar disponible esos días.
+// @Tags Licencias
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "ID de la licencia"
+// @Success 200 {object} dtos.Response{data=nil} "Licencia eliminada"
+// @Failure 400 {object} dtos.ErrorResponse "ID inválido"
+// @Failure 500 {object} dtos.ErrorResponse "Error interno del servidor"
+// @Router /licencia/{id} [delete]
+func DeleteTimeOff(c echo.Context) error {
+	id := c.Param("id")
+	logger.Log.Infof("[StaffScheduleController][DeleteTimeOff] Intentando eliminar licencia con ID: %s", id)
+	timeOffID, err := strconv.ParseUint(id, 10, 32)
+	if err != nil {
+		logger.Log.Warn("[StaffScheduleController][DeleteTimeOff] ID inválido")
+		return helpers.RespondError(c, http.StatusBadRequest, "ID inválido")
+	}
+
+	if err := services.DeleteTimeOff(uint(timeOffID)); err != nil {
+		logger.Log.Error("[StaffScheduleController][DeleteTimeOff] Error al eliminar licencia: ", err)
+		return helpers.RespondError(c, http.StatusInternalServerError, err.Error())
+	}
+
+	return helpers.RespondSuccess(c, "Licencia eliminada", nil)
+}
+
+// @Summary Aprobar licencia
+// @Description Aprueba una licencia pendiente. El empleado deja de ofrecerse en la agenda esos días y se devuelven los turnos ya agendados que quedan en conflicto.
+// @Tags Licencias
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "ID de la licencia"
+// @Param request body dtos.ReviewTimeOffDto false "Nota de la revisión"
+// @Success 200 {object} dtos.Response{data=dtos.GetTimeOffDto} "Licencia aprobada"
+// @Failure 400 {object} dtos.ErrorResponse "ID inválido"
+// @Failure 500 {object} dtos.ErrorResponse "Error interno del servidor"
+// @Router /licencia/{id}/aprobar [put]
+func ApproveTimeOff(c echo.Context) error {
+	id := c.Param("id")
+	logger.Log.Infof("[StaffScheduleController][ApproveTimeOff] Intentando aprobar licencia con ID: %s", id)
+	timeOffID, err := strconv.ParseUint(id, 10, 32)
+	if err != nil {
+		logger.Log.Warn("[StaffScheduleController][ApproveTimeOff] ID inválido")
+		return helpers.RespondError(c, http.StatusBadRequest, "ID inválido")
+	}
+
+	var dto dtos.ReviewTimeOffDto
+	if err := c.Bind(&dto); err != nil {
+		logger.Log.Warn("[StaffScheduleController][ApproveTimeOff] Error: datos inválidos")
+		return helpers.RespondError(c, http.StatusBadRequest, "Datos inválidos")
+	}
+
+	timeOff, err := services.ApproveTimeOff(uint(timeOffID), dto, helpers.CurrentUserID(c))
+	if err != nil {
+		logger.Log.Error("[StaffScheduleController][ApproveTimeOff] Error al aprobar licencia: ", err)
+		return helpers.RespondError(c, http.StatusInternalServerError, err.Error())
+	}
+
+	if len(timeOff.Conflicts) > 0 {
+		return helpers.RespondSuccess(c, "Licencia aprobada con turnos en conflicto", timeOff)
+	}
+	return helpers.RespondSuccess(c, "Licencia aprobada", timeOff)
+}
+
+// @Summary Rechazar licencia
+// @Description Rechaza una licencia pendiente.
+// @Tags Licencias
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "ID de la licencia"
+// @Param request body dtos.ReviewTimeOffDto false "Nota de la revisión"
+// @Success 200 {object} dtos.Response{data=dtos.GetTimeOffDto} "Licencia rechazada"
+// @Failure 400 {object} dtos.ErrorResponse "ID inválido"
+// @Failure 500 {object} dtos.ErrorResponse "Error interno del servidor"
+// @Router /licencia/{id}/rechazar [put]
+func RejectTimeOff(c echo.Context) error {
+	id := c.Param("id")
+	logger.Log.Infof("[StaffScheduleController][RejectTimeOff] Intentando rechazar licencia con ID: %s", id)
+	timeOffID, err := strconv.ParseUint(id, 10, 32)
+	if err != nil {
+		logger.Log.Warn("[StaffScheduleController][RejectTimeOff] ID inválido")
+		return helpers.RespondError(c, http.StatusBadRequest, "ID inválido")
+	}
+
+	var dto dtos.ReviewTimeOffDto
+	if err := c.Bind(&dto); err != nil {
+		logger.Log.Warn("[StaffScheduleController][RejectTimeOff] Error: datos inválidos")
+		return helpers.RespondError(c, http.StatusBadRequest, "Datos inválidos")
+	}
+
+	timeOff, err := services.RejectTimeOff(uint(timeOffID), dto, helpers.CurrentUserID(c))
+	if err != nil {
+		logger.Log.Error("[StaffScheduleController][RejectTimeOff] Error al rechazar licencia: ", err)
+		return helpers.RespondError(c, http.StatusInternalServerError, err.Error())
+	}
+
+	return helpers.RespondSuccess(c, "Licencia rechazada", timeOff)
+}
+
+// canApproveTimeOff indica si el rol del usuario puede aprobar licencias, y por lo tanto
+// gestionar las de cualquier empleado.
+func canApproveTimeOff(c echo.Context) (bool, error) {
+	return middlewares.HasPermission(helpers.CurrentRoleID(c), "approve_time_off")
+}
@@ -21,3 +21,31 @@ func GetMonthlyStatistics(c echo.Context) error {
 
 	return helpers.RespondSuccess(c, "Estadísticas mensuales obtenidas", statistics)
 }
+
+func GetTimeStatistics(c echo.Context) error {
+	month := c.QueryParam("month")
+	if month == "" {
+		return helpers.RespondError(c, http.StatusBadRequest, "El parámetro 'month' es obligatorio")
+	}
+
+	statistics, err := services.GetTimeStatistics(month)
+	if err != nil {
+		return helpers.RespondError(c, http.StatusInternalServerError, err.Error())
+	}
+
+	return helpers.RespondSuccess(c, "Estadísticas de tiempos obtenidas", statistics)
+}
+
+func GetTipReport(c echo.Context) error {
+	from, to := c.QueryParam("from"), c.QueryParam("to")
+	if from == "" || to == "" {
+		return helpers.RespondError(c, http.StatusBadRequest, "Los parámetros 'from' y 'to' son obligatorios")
+	}
+
+	report, err := services.GetTipReport(from, to)
+	if err != nil {
+		return helpers.RespondError(c, http.StatusInternalServerError, err.Error())
+	}
+
+	return helpers.RespondSuccess(c, "Reporte de propinas obtenido", report)
+}
@@ -0,0 +1,202 @@
+package controllers
+
+import (
+	"net/http"
+	"peluqueria/internal/dtos"
+	"peluqueria/internal/helpers"
+	"peluqueria/internal/services"
+	"peluqueria/logger"
+	"strconv"
+
+	"github.com/labstack/echo/v4"
+)
+
+// @Summary Agregar a la lista de espera
+// @Description Registra un cliente que quiere un turno en un rango de fechas y horario preferido.
+// @Tags Lista de espera
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param request body dtos.WaitlistEntryDto true "Datos de la entrada"
+// @Success 200 {object} dtos.Response{data=nil} "Cliente agregado a la lista de espera"
+// @Failure 400 {object} dtos.ErrorResponse "Datos inválidos"
+// @Failure 500 {object} dtos.ErrorResponse "Error interno del servidor"
+// @Router /lista-espera [post]
+func CreateWaitlistEntry(c echo.Context) error {
+	logger.Log.Info("[WaitlistController][CreateWaitlistEntry] Intentando agregar a la lista de espera")
+	var entryDto dtos.WaitlistEntryDto
+	if err := c.Bind(&entryDto); err != nil {
+		logger.Log.Warn("[WaitlistController][CreateWaitlistEntry] Error al agregar a la lista de espera: datos inválidos")
+		return helpers.RespondError(c, http.StatusBadRequest, "Datos inválidos")
+	}
+
+	if err := services.CreateWaitlistEntry(entryDto); err != nil {
+		logger.Log.Error("[WaitlistController][CreateWaitlistEntry] Error al agregar a la lista de espera: ", err)
+		return helpers.RespondError(c, http.StatusInternalServerError, err.Error())
+	}
+
+	logger.Log.Infof("[WaitlistController][CreateWaitlistEntry] Cliente ID %d agregado a la lista de espera", entryDto.ClientID)
+	return helpers.RespondSuccess(c, "Cliente agregado a la lista de espera", nil)
+}
+
+// @Summary Obtener lista de espera
+// @Description Devuelve las entradas de la lista de espera, opcionalmente filtradas por estado.
+// @Tags Lista de espera
+// @Produce json
+// @Security BearerAuth
+// @Param status query string false "Estado (activa, atendida, cancelada)"
+// @Success 200 {object} dtos.Response{data=[]dtos.GetWaitlistEntryDto} "Lista de espera obtenida"
+// @Failure 500 {object} dtos.ErrorResponse "Error interno del servidor"
+// @Router /lista-espera [get]
+func GetAllWaitlistEntries(c echo.Context) error {
+	logger.Log.Info("[WaitlistController][GetAllWaitlistEntries] Obteniendo lista de espera")
+	entries, err := services.GetAllWaitlistEntries(c.QueryParam("status"))
+	if err != nil {
+		logger.Log.Error("[WaitlistController][GetAllWaitlistEntries] Error al obtener lista de espera: ", err)
+		return helpers.RespondError(c, http.StatusInternalServerError, err.Error())
+	}
+
+	logger.Log.Infof("[WaitlistController][GetAllWaitlistEntries] Entradas obtenidas: %d", len(entries))
+	return helpers.RespondSuccess(c, "Lista de espera obtenida", entries)
+}
+
+// @Summary Candidatos para horarios liberados
+// @Description Devuelve los horarios liberados por cancelaciones o cambios junto con los clientes de la lista de espera a los que les sirven.
+// @Tags Lista de espera
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {object} dtos.Response{data=[]dtos.WaitlistMatchDto} "Candidatos obtenidos"
+// @Failure 500 {object} dtos.ErrorResponse "Error interno del servidor"
+// @Router /lista-espera/coincidencias [get]
+func GetWaitlistMatches(c echo.Context) error {
+	logger.Log.Info("[WaitlistController][GetWaitlistMatches] Obteniendo candidatos")
+	matches, err := services.GetWaitlistMatches()
+	if err != nil {
+		logger.Log.Error("[WaitlistController][GetWaitlistMatches] Error al obtener candidatos: ", err)
+		return helpers.RespondError(c, http.StatusInternalServerError, err.Error())
+	}
+
+	logger.Log.Infof("[WaitlistController][GetWaitlistMatches] Candidatos obtenidos: %d", len(matches))
+	return helpers.RespondSuccess(c, "Candidatos obtenidos", matches)
+}
+
+// @Summary Descartar candidato
+// @Description Marca un candidato de la lista de espera como descartado para que no se vuelva a mostrar.
+// @Tags Lista de espera
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "ID del candidato"
+// @Success 200 {object} dtos.Response{data=nil} "Candidato descartado"
+// @Failure 400 {object} dtos.ErrorResponse "ID inválido"
+// @Failure 500 {object} dtos.ErrorResponse "Error interno del servidor"
+// @Router /lista-espera/coincidencias/{id}/descartar [put]
+func DismissWaitlistMatch(c echo.Context) error {
+	id := c.Param("id")
+	logger.Log.Infof("[WaitlistController][DismissWaitlistMatch] Intentando descartar candidato con ID: %s", id)
+	matchID, err := strconv.ParseUint(id, 10, 32)
+	if err != nil {
+		logger.Log.Warn("[WaitlistController][DismissWaitlistMatch] ID inválido")
+		return helpers.RespondError(c, http.StatusBadRequest, "ID inválido")
+	}
+
+	if err := services.DismissWaitlistMatch(uint(matchID)); err != nil {
+		logger.Log.Error("[WaitlistController][DismissWaitlistMatch] Error al descartar candidato: ", err)
+		return helpers.RespondError(c, http.StatusInternalServerError, err.Error())
+	}
+
+	logger.Log.Infof("[WaitlistController][DismissWaitlistMatch] Candidato descartado: ID %d", matchID)
+	return helpers.RespondSuccess(c, "Candidato descartado", nil)
+}
+
+// @Summary Obtener entrada de la lista de espera
+// @Description Devuelve los datos de una entrada específica de la lista de espera.
+// @Tags Lista de espera
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "ID de la entrada"
+// @Success 200 {object} dtos.Response{data=dtos.GetWaitlistEntryDto} "Entrada encontrada"
+// @Failure 400 {object} dtos.ErrorResponse "ID inválido"
+// @Failure 404 {object} dtos.ErrorResponse "Entrada no encontrada"
+// @Router /lista-espera/{id} [get]
+func GetWaitlistEntryByID(c echo.Context) error {
+	id := c.Param("id")
+	logger.Log.Infof("[WaitlistController][GetWaitlistEntryByID] Intentando obtener entrada con ID: %s", id)
+	entryID, err := strconv.ParseUint(id, 10, 32)
+	if err != nil {
+		logger.Log.Warn("[WaitlistController][GetWaitlistEntryByID] ID inválido")
+		return helpers.RespondError(c, http.StatusBadRequest, "ID inválido")
+	}
+
+	entry, err := services.GetWaitlistEntryByID(uint(entryID))
+	if err != nil {
+		logger.Log.Error("[WaitlistController][GetWaitlistEntryByID] Error al obtener entrada: ", err)
+		return helpers.RespondError(c, http.StatusNotFound, err.Error())
+	}
+
+	logger.Log.Infof("[WaitlistController][GetWaitlistEntryByID] Entrada obtenida: ID %d", entryID)
+	return helpers.RespondSuccess(c, "Entrada encontrada", entry)
+}
+
+// @Summary Actualizar entrada de la lista de espera
+// @Description Actualiza los datos o el estado de una entrada de la lista de espera.
+// @Tags Lista de espera
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "ID de la entrada"
+// @Param request body dtos.WaitlistEntryDto true "Datos de la entrada"
+// @Success 200 {object} dtos.Response{data=nil} "Entrada actualizada"
+// @Failure 400 {object} dtos.ErrorResponse "ID o datos inválidos"
+// @Failure 500 {object} dtos.ErrorResponse "Error interno del servidor"
+// @Router /lista-espera/{id} [put]
+func UpdateWaitlistEntry(c echo.Context) error {
+	id := c.Param("id")
+	logger.Log.Infof("[WaitlistController][UpdateWaitlistEntry] Intentando actualizar entrada con ID: %s", id)
+	entryID, err := strconv.ParseUint(id, 10, 32)
+	if err != nil {
+		logger.Log.Warn("[WaitlistController][UpdateWaitlistEntry] ID inválido")
+		return helpers.RespondError(c, http.StatusBadRequest, "ID inválido")
+	}
+
+	var entryDto dtos.WaitlistEntryDto
+	if err := c.Bind(&entryDto); err != nil {
+		logger.Log.Warn("[WaitlistController][UpdateWaitlistEntry] Error al actualizar entrada: datos inválidos")
+		return helpers.RespondError(c, http.StatusBadRequest, "Datos inválidos")
+	}
+
+	if err := services.UpdateWaitlistEntry(uint(entryID), entryDto); err != nil {
+		logger.Log.Error("[WaitlistController][UpdateWaitlistEntry] Error al actualizar entrada: ", err)
+		return helpers.RespondError(c, http.StatusInternalServerError, err.Error())
+	}
+
+	logger.Log.Infof("[WaitlistController][UpdateWaitlistEntry] Entrada actualizada: ID %d", entryID)
+	return helpers.RespondSuccess(c, "Entrada actualizada", nil)
+}
+
+// @Summary Eliminar entrada de la lista de espera
+// @Description Quita una entrada de la lista de espera.
+// @Tags Lista de espera
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "ID de la entrada"
+// @Success 200 {object} dtos.Response{data=nil} "Entrada eliminada"
+// @Failure 400 {object} dtos.ErrorResponse "ID inválido"
+// @Failure 500 {object} dtos.ErrorResponse "Error interno del servidor"
+// @Router /lista-espera/{id} [delete]
+func DeleteWaitlistEntry(c echo.Context) error {
+	id := c.Param("id")
+	logger.Log.Infof("[WaitlistController][DeleteWaitlistEntry] Intentando eliminar entrada con ID: %s", id)
+	entryID, err := strconv.ParseUint(id, 10, 32)
+	if err != nil {
+		logger.Log.Warn("[WaitlistController][DeleteWaitlistEntry] ID inválido")
+		return helpers.RespondError(c, http.StatusBadRequest, "ID inválido")
+	}
+
+	if err := services.DeleteWaitlistEntry(uint(entryID)); err != nil {
+		logger.Log.Error("[WaitlistController][DeleteWaitlistEntry] Error al eliminar entrada: ", err)
+		return helpers.RespondError(c, http.StatusInternalServerError, err.Error())
+	}
+
+	logger.Log.Infof("[WaitlistController][DeleteWaitlistEntry] Entrada eliminada: ID %d", entryID)
+	return helpers.RespondSuccess(c, "Entrada eliminada", nil)
+}
@@ -0,0 +1,177 @@
+package controllers
+
+import (
+	"net/http"
+	"peluqueria/internal/dtos"
+	"peluqueria/internal/helpers"
+	"peluqueria/internal/services"
+	"peluqueria/logger"
+	"strconv"
+
+	"github.com/labstack/echo/v4"
+)
+
+// @Summary Sacar ticket
+// @Description Agrega a la fila a un cliente que llegó sin turno. Si no se indica client_id se busca por teléfono o se crea con el nombre.
+// @Tags Fila
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param request body dtos.WalkInDto true "Datos del ticket"
+// @Success 200 {object} dtos.Response{data=dtos.GetWalkInDto} "Ticket creado"
+// @Failure 400 {object} dtos.ErrorResponse "Datos inválidos"
+// @Failure 500 {object} dtos.ErrorResponse "Error interno del servidor"
+// @Router /fila [post]
+func EnqueueWalkIn(c echo.Context) error {
+	logger.Log.Info("[WalkInController][EnqueueWalkIn] Intentando agregar a la fila")
+	var walkInDto dtos.WalkInDto
+	if err := c.Bind(&walkInDto); err != nil {
+		logger.Log.Warn("[WalkInController][EnqueueWalkIn] Error al agregar a la fila: datos inválidos")
+		return helpers.RespondError(c, http.StatusBadRequest, "Datos inválidos")
+	}
+
+	walkIn, err := services.EnqueueWalkIn(walkInDto)
+	if err != nil {
+		logger.Log.Error("[WalkInController][EnqueueWalkIn] Error al agregar a la fila: ", err)
+		return helpers.RespondError(c, http.StatusInternalServerError, err.Error())
+	}
+
+	logger.Log.Infof("[WalkInController][EnqueueWalkIn] Ticket %d creado", walkIn.TicketNumber)
+	return helpers.RespondSuccess(c, "Ticket creado", walkIn)
+}
+
+// @Summary Obtener fila
+// @Description Devuelve la fila del día en orden, con la espera estimada de cada ticket según los turnos en curso y la duración de los servicios.
+// @Tags Fila
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {object} dtos.Response{data=[]dtos.GetWalkInDto} "Fila obtenida"
+// @Failure 500 {object} dtos.ErrorResponse "Error interno del servidor"
+// @Router /fila [get]
+func GetWalkInQueue(c echo.Context) error {
+	logger.Log.Info("[WalkInController][GetWalkInQueue] Obteniendo fila")
+	queue, err := services.GetWalkInQueue()
+	if err != nil {
+		logger.Log.Error("[WalkInController][GetWalkInQueue] Error al obtener fila: ", err)
+		return helpers.RespondError(c, http.StatusInternalServerError, err.Error())
+	}
+	return helpers.RespondSuccess(c, "Fila obtenida", queue)
+}
+
+// @Summary Llamar al siguiente
+// @Description Llama al primer ticket en espera. Con staff_id solo considera los tickets sin preferencia o que prefieren a ese estilista.
+// @Tags Fila
+// @Produce json
+// @Security BearerAuth
+// @Param staff_id query int false "ID del estilista que llama"
+// @Success 200 {object} dtos.Response{data=dtos.GetWalkInDto} "Ticket llamado"
+// @Failure 400 {object} dtos.ErrorResponse "ID inválido o no hay clientes esperando"
+// @Router /fila/siguiente [put]
+func CallNextWalkIn(c echo.Context) error {
+	var staffID uint64
+	if staffParam := c.QueryParam("staff_id"); staffParam != "" {
+		var err error
+		staffID, err = strconv.ParseUint(staffParam, 10, 32)
+		if err != nil {
+			logger.Log.Warn("[WalkInController][CallNextWalkIn] ID de estilista inválido")
+			return helpers.RespondError(c, http.StatusBadRequest, "El ID del estilista es inválido")
+		}
+	}
+
+	walkIn, err := services.CallNextWalkIn(uint(staffID))
+	if err != nil {
+		logger.Log.Warn("[WalkInController][CallNextWalkIn] Error al llamar al siguiente: ", err)
+		return helpers.RespondError(c, http.StatusBadRequest, err.Error())
+	}
+	return helpers.RespondSuccess(c, "Ticket llamado", walkIn)
+}
+
+// @Summary Reordenar ticket
+// @Description Mueve un ticket a otra posición de la fila (1 es el primero).
+// @Tags Fila
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "ID del ticket"
+// @Param request body dtos.WalkInPositionDto true "Nueva posición"
+// @Success 200 {object} dtos.Response{data=nil} "Fila reordenada"
+// @Failure 400 {object} dtos.ErrorResponse "ID o posición inválidos"
+// @Router /fila/{id}/posicion [put]
+func ReorderWalkIn(c echo.Context) error {
+	walkInID, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		logger.Log.Warn("[WalkInController][ReorderWalkIn] ID inválido")
+		return helpers.RespondError(c, http.StatusBadRequest, "ID inválido")
+	}
+
+	var positionDto dtos.WalkInPositionDto
+	if err := c.Bind(&positionDto); err != nil {
+		logger.Log.Warn("[WalkInController][ReorderWalkIn] Error: datos inválidos")
+		return helpers.RespondError(c, http.StatusBadRequest, "Datos inválidos")
+	}
+
+	if err := services.ReorderWalkIn(uint(walkInID), positionDto.Position); err != nil {
+		logger.Log.Error("[WalkInController][ReorderWalkIn] Error al reordenar fila: ", err)
+		return helpers.RespondError(c, http.StatusBadRequest, err.Error())
+	}
+	return helpers.RespondSuccess(c, "Fila reordenada", nil)
+}
+
+// @Summary Saltear ticket
+// @Description Saca de la fila a un ticket que no respondió al llamado.
+// @Tags Fila
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "ID del ticket"
+// @Success 200 {object} dtos.Response{data=nil} "Ticket salteado"
+// @Failure 400 {object} dtos.ErrorResponse "ID inválido o el ticket no está en la fila"
+// @Router /fila/{id}/saltear [put]
+func SkipWalkIn(c echo.Context) error {
+	walkInID, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		logger.Log.Warn("[WalkInController][SkipWalkIn] ID inválido")
+		return helpers.RespondError(c, http.StatusBadRequest, "ID inválido")
+	}
+
+	if err := services.SkipWalkIn(uint(walkInID)); err != nil {
+		logger.Log.Error("[WalkInController][SkipWalkIn] Error al saltear ticket: ", err)
+		return helpers.RespondError(c, http.StatusBadRequest, err.Error())
+	}
+	return helpers.RespondSuccess(c, "Ticket salteado", nil)
+}
+
+// @Summary Atender ticket
+// @Description Convierte el ticket en un turno para ahora con sus servicios y lo marca en curso.
+// @Tags Fila
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "ID del ticket"
+// @Param request body dtos.ServeWalkInDto false "Estilista que atiende"
+// @Success 200 {object} dtos.Response{data=dtos.GetWalkInDto} "Ticket atendido"
+// @Failure 400 {object} dtos.ErrorResponse "ID inválido, ticket ya atendido o fuera del horario de atención"
+// @Failure 409 {object} dtos.Response{message=string,data=dtos.AppointmentConflictDto} "El estilista está ocupado o no quedan recursos libres"
+// @Failure 500 {object} dtos.ErrorResponse "Error interno del servidor"
+// @Router /fila/{id}/atender [put]
+func ServeWalkIn(c echo.Context) error {
+	walkInID, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		logger.Log.Warn("[WalkInController][ServeWalkIn] ID inválido")
+		return helpers.RespondError(c, http.StatusBadRequest, "ID inválido")
+	}
+
+	var serveDto dtos.ServeWalkInDto
+	if err := c.Bind(&serveDto); err != nil {
+		logger.Log.Warn("[WalkInController][ServeWalkIn] Error: datos inválidos")
+		return helpers.RespondError(c, http.StatusBadRequest, "Datos inválidos")
+	}
+
+	walkIn, err := services.ServeWalkIn(uint(walkInID), serveDto, helpers.CurrentUserID(c))
+	if err != nil {
+		logger.Log.Error("[WalkInController][ServeWalkIn] Error al atender ticket: ", err)
+		return respondScheduleError(c, "No se pudo atender el ticket: ", err)
+	}
+
+	logger.Log.Infof("[WalkInController][ServeWalkIn] Ticket %d atendido", walkIn.TicketNumber)
+	return helpers.RespondSuccess(c, "Ticket atendido", walkIn)
+}
@@ -3,42 +3,120 @@ package dtos
 import "time"
 
 type CreateAppointmentDto struct {
-	ClientID        uint   `json:"client_id" example:"1"`                       // ID del cliente
-	AppointmentDate string `json:"appointment_date" example:"15:30 12/01/2025"` // Formato: HH:MM DD/MM/YYYY
-	ServiceIds      []uint `json:"service_id" example:"1,2"`                    // IDs de los servicios asociados
+	ClientID         uint           `json:"client_id" example:"1"`                       // ID del cliente
+	StaffID          uint           `json:"staff_id" example:"1"`                        // ID del estilista (opcional)
+	AppointmentDate  string         `json:"appointment_date" example:"15:30 12/01/2025"` // Formato: HH:MM DD/MM/YYYY
+	ServiceIds       []uint         `json:"service_id" example:"1,2"`                    // IDs de los servicios asociados
+	BundleIDs        []uint         `json:"bundle_id" example:"1"`                       // IDs de los combos; se agregan sus servicios con el descuento repartido
+	VariantIDs       []uint         `json:"variant_id" example:"3"`                      // Variantes elegidas; se aplican a la primera línea de su servicio o agregan una
+	OptionIDs        []uint         `json:"option_id" example:"5"`                       // Adicionales elegidos; misma regla que las variantes
+	DurationOverride *uint          `json:"duration_override" example:"90"`              // Duración manual en minutos (opcional); 0 al actualizar vuelve a la de los servicios
+	Recurrence       *RecurrenceDto `json:"recurrence,omitempty"`                        // Regla de repetición (opcional, solo al crear)
+}
+
+// ServiceSelectionDto son los servicios pedidos para un turno o una consulta de disponibilidad.
+type ServiceSelectionDto struct {
+	ServiceIDs []uint
+	BundleIDs  []uint
+	VariantIDs []uint
+	OptionIDs  []uint
+}
+
+type RecurrenceDto struct {
+	Frequency string `json:"frequency" example:"semanal"` // "semanal" (cada N semanas) o "mensual" (mismo día de la semana del mes)
+	Interval  uint   `json:"interval" example:"3"`        // Cada cuántas semanas o meses
+	Count     uint   `json:"count" example:"6"`           // Cantidad de turnos (opcional si se indica until)
+	Until     string `json:"until" example:"30/06/2025"`  // Fecha límite DD/MM/YYYY (opcional si se indica count)
+}
+
+type SeriesConflictDto struct {
+	AppointmentDate          string `json:"appointment_date" example:"02/02/2025 15:30"`
+	Reason                   string `json:"reason" example:"el turno se superpone con el turno ID 12"`
+	ConflictingAppointmentID *uint  `json:"conflicting_appointment_id,omitempty" example:"12"`
+}
+
+type CreateAppointmentResultDto struct {
+	AppointmentIDs []uint              `json:"appointment_ids" example:"1,2,3"`
+	SeriesID       *uint               `json:"series_id,omitempty" example:"1"`
+	Conflicts      []SeriesConflictDto `json:"conflicts,omitempty"` // Ocurrencias que no pudieron agendarse
 }
 
 type AppointmentServiceDto struct {
-	ServiceID            uint    `json:"service_id" example:"1"`
-	ServiceName          string  `json:"service_name" example:"Corte de cabello"`
-	Price                float64 `json:"price" example:"1500.00"`
-	EstimatedTimeMinutes uint    `json:"estimated_time_minutes" example:"30"`
+	ServiceID            uint                          `json:"service_id" example:"1"`
+	ServiceName          string                        `json:"service_name" example:"Corte de cabello"`
+	Price                float64                       `json:"price" example:"1500.00"`      // Precio cobrado
+	ListPrice            float64                       `json:"list_price" example:"1800.00"` // Precio sin el descuento del combo
+	EstimatedTimeMinutes uint                          `json:"estimated_time_minutes" example:"30"`
+	StaffID              *uint                         `json:"staff_id" example:"1"`
+	StaffName            string                        `json:"staff_name" example:"Laura Gómez"`
+	BundleID             *uint                         `json:"bundle_id" example:"1"`
+	BundleName           string                        `json:"bundle_name" example:"Corte + color"`
+	VariantID            *uint                         `json:"variant_id" example:"3"`
+	VariantName          string                        `json:"variant_name" example:"Pelo largo"`
+	Options              []AppointmentServiceOptionDto `json:"options"`
+	Discount             float64                       `json:"discount" example:"300.00"` // Descuento de la promoción, ya restado del precio
+	PromotionID          *uint                         `json:"promotion_id" example:"2"`
+	PromotionName        string                        `json:"promotion_name" example:"Martes de color"`
+}
+
+type AppointmentServiceOptionDto struct {
+	OptionID     uint    `json:"option_id" example:"5"`
+	Name         string  `json:"name" example:"Matizador"`
+	Price        float64 `json:"price" example:"3000"`
+	ExtraMinutes uint    `json:"extra_minutes" example:"10"`
 }
 
 type AppointmentByIDDto struct {
-	ID              uint                    `json:"id" example:"1"`
-	ClientID        uint                    `json:"client_id" example:"1"`
-	ClientName      string                  `json:"client_name" example:"Juan Pérez"`
-	Status          string                  `json:"status" example:"pendiente"`
-	AppointmentDate string                  `json:"appointment_date" example:"12/01/2025 15:30"`
-	Services        []AppointmentServiceDto `json:"services"`
-	Products        []AppointmentProductDto `json:"products"`
-	CreatedAt       time.Time               `json:"created_at" example:"2025-01-08T10:00:00Z"`
-	UpdatedAt       time.Time               `json:"updated_at" example:"2025-01-08T12:00:00Z"`
+	ID                   uint                     `json:"id" example:"1"`
+	ClientID             uint                     `json:"client_id" example:"1"`
+	ClientName           string                   `json:"client_name" example:"Juan Pérez"`
+	StaffID              *uint                    `json:"staff_id" example:"1"`
+	StaffName            string                   `json:"staff_name" example:"Laura Gómez"`
+	Status               string                   `json:"status" example:"pendiente"`
+	CancellationReason   string                   `json:"cancellation_reason" example:""`
+	SeriesID             *uint                    `json:"series_id" example:"1"`
+	AppointmentDate      string                   `json:"appointment_date" example:"12/01/2025 15:30"`
+	EstimatedTimeMinutes uint                     `json:"estimated_time_minutes" example:"60"`
+	DurationOverride     *uint                    `json:"duration_override" example:"90"`
+	Services             []AppointmentServiceDto  `json:"services"`
+	Products             []AppointmentProductDto  `json:"products"`
+	Resources            []AppointmentResourceDto `json:"resources"`
+	CheckedInAt          string                   `json:"checked_in_at" example:"12/01/2025 15:25"` // Llegada del cliente; vacío si no llegó
+	StartedAt            string                   `json:"started_at" example:"12/01/2025 15:35"`    // Inicio real; vacío si no empezó
+	FinishedAt           string                   `json:"finished_at" example:"12/01/2025 16:40"`   // Fin real; vacío si no terminó
+	ActualMinutes        *uint                    `json:"actual_minutes" example:"65"`              // Duración real, si empezó y terminó
+	Notes                []GetAppointmentNoteDto  `json:"notes"`
+	ColorFormulas        []GetColorFormulaDto     `json:"color_formulas"`
+	Photos               []GetAppointmentPhotoDto `json:"photos"`
+	Total                float64                  `json:"total" example:"25000"`   // Suma de los servicios
+	Discount             float64                  `json:"discount" example:"3000"` // Descuentos de promociones, ya restados del total
+	PaymentMethod        string                   `json:"payment_method" example:"mixto"`
+	Payments             []GetPaymentDto          `json:"payments"`
+	Tips                 []GetTipDto              `json:"tips"`
+	CreatedAt            time.Time                `json:"created_at" example:"2025-01-08T10:00:00Z"`
+	UpdatedAt            time.Time                `json:"updated_at" example:"2025-01-08T12:00:00Z"`
 }
 
 type AllAppointmentDto struct {
 	ID                   uint   `json:"id" example:"1"`
 	ClientID             uint   `json:"client_id" example:"1"`
 	ClientName           string `json:"client_name" example:"Juan Pérez"`
+	StaffID              *uint  `json:"staff_id" example:"1"`
+	StaffName            string `json:"staff_name" example:"Laura Gómez"`
+	SeriesID             *uint  `json:"series_id" example:"1"`
 	Status               string `json:"status" example:"pendiente"`
 	AppointmentDate      string `json:"appointment_date" example:"12/01/2025 15:30"`
 	EstimatedTimeMinutes uint   `json:"estimated_time_minutes" example:"60"`
+	DurationOverride     *uint  `json:"duration_override" example:"90"`
 }
 
 type FinalizeAppointmentDto struct {
-	PaymentMethod string                          `json:"payment_method" example:"tarjeta"`
+	Payments      []PaymentDto                    `json:"payments"`                          // Pagos del turno; deben sumar el total
+	PaymentMethod string                          `json:"payment_method" example:"efectivo"` // Un único pago por el total; se usa si no se envían pagos
 	Products      []FinalizeAppointmentProductDto `json:"products"`
+	PromotionIDs  []uint                          `json:"promotion_id" example:"2"`      // Promociones sin código a aplicar
+	CouponCodes   []string                        `json:"coupon_code" example:"COLOR20"` // Cupones presentados por el cliente
+	Tips          []TipDto                        `json:"tips"`                          // Propinas; no forman parte del total
 }
 
 type FinalizeAppointmentProductDto struct {
@@ -56,3 +134,74 @@ type AppointmentProductDto struct {
 type UpdateAppointmentProductsDto struct {
 	Products []FinalizeAppointmentProductDto `json:"products"`
 }
+
+type AppointmentConflictDto struct {
+	ConflictingAppointmentID uint `json:"conflicting_appointment_id" example:"12"`
+}
+
+type AvailableSlotDto struct {
+	Time     string `json:"time" example:"15:30"`
+	StaffIDs []uint `json:"staff_ids" example:"1,2"` // Estilistas libres en ese horario
+}
+
+type AvailabilityDto struct {
+	Date                 string             `json:"date" example:"12/01/2025"`
+	EstimatedTimeMinutes uint               `json:"estimated_time_minutes" example:"60"`
+	SlotMinutes          uint               `json:"slot_minutes" example:"15"`
+	Closed               bool               `json:"closed" example:"false"`   // El salón no abre ese día
+	ClosedReason         string             `json:"closed_reason" example:""` // Motivo del cierre, si corresponde
+	Slots                []AvailableSlotDto `json:"slots"`
+}
+
+type ChangeAppointmentStatusDto struct {
+	Reason string `json:"reason" example:"El cliente avisó que no puede asistir"` // Obligatorio al cancelar
+}
+
+type AppointmentStatusHistoryDto struct {
+	FromStatus string `json:"from_status" example:"pendiente"`
+	ToStatus   string `json:"to_status" example:"confirmado"`
+	Reason     string `json:"reason" example:""`
+	UserID     *uint  `json:"user_id" example:"1"`
+	Username   string `json:"username" example:"admin"`
+	CreatedAt  string `json:"created_at" example:"12/01/2025 15:30"`
+}
+
+type FloorAppointmentDto struct {
+	AppointmentID   uint     `json:"appointment_id" example:"1"`
+	ClientName      string   `json:"client_name" example:"Juan Pérez"`
+	Status          string   `json:"status" example:"en_curso"`
+	Services        []string `json:"services" example:"Corte de cabello,Color"`
+	Resources       []string `json:"resources" example:"Sillón 1"` // Sillones y equipos asignados
+	AppointmentDate string   `json:"appointment_date" example:"15:30"`
+	CheckedInAt     string   `json:"checked_in_at" example:"15:25"`
+	StartedAt       string   `json:"started_at" example:"15:35"`
+	ExpectedEnd     string   `json:"expected_end" example:"16:35"` // Fin previsto según el inicio real, o el agendado si no empezó
+	DelayMinutes    int      `json:"delay_minutes" example:"5"`    // Atraso respecto del horario agendado
+}
+
+type FloorStylistDto struct {
+	StaffID      uint                  `json:"staff_id" example:"1"`
+	StaffName    string                `json:"staff_name" example:"Laura Gómez"`
+	Busy         bool                  `json:"busy" example:"true"` // Tiene turnos en curso
+	DelayMinutes int                   `json:"delay_minutes" example:"5"`
+	InService    []FloorAppointmentDto `json:"in_service"` // Turnos en curso (puede haber más de uno durante una espera)
+	Next         *FloorAppointmentDto  `json:"next"`       // Próximo turno del día sin empezar
+}
+
+type FloorStatusDto struct {
+	Date       string                `json:"date" example:"12/01/2025"`
+	Time       string                `json:"time" example:"15:40"`
+	Stylists   []FloorStylistDto     `json:"stylists"`
+	Unassigned []FloorAppointmentDto `json:"unassigned"` // Turnos en curso sin estilista asignado
+	Waiting    []FloorWaitingDto     `json:"waiting"`    // Clientes que llegaron y aún no fueron atendidos
+}
+
+type FloorWaitingDto struct {
+	AppointmentID   uint   `json:"appointment_id" example:"2"`
+	ClientName      string `json:"client_name" example:"Ana López"`
+	StaffID         *uint  `json:"staff_id" example:"1"`
+	StaffName       string `json:"staff_name" example:"Laura Gómez"`
+	AppointmentDate string `json:"appointment_date" example:"15:45"`
+	CheckedInAt     string `json:"checked_in_at" example:"15:38"`
+	WaitingMinutes  uint   `json:"waiting_minutes" example:"2"`
+}
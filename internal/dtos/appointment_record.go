@@ -0,0 +1,54 @@
+package dtos
+
+type AppointmentNoteDto struct {
+	Text string `json:"text" example:"Se emparejó el largo y se marcó la nuca más corta"`
+}
+
+type GetAppointmentNoteDto struct {
+	ID            uint   `json:"id" example:"1"`
+	AppointmentID uint   `json:"appointment_id" example:"1"`
+	Text          string `json:"text" example:"Se emparejó el largo y se marcó la nuca más corta"`
+	AuthorID      *uint  `json:"author_id" example:"1"`
+	Author        string `json:"author" example:"laura"`
+	CreatedAt     string `json:"created_at" example:"12/01/2025 16:40"`
+	UpdatedAt     string `json:"updated_at" example:"12/01/2025 16:45"`
+}
+
+type ColorFormulaDto struct {
+	Zone              string  `json:"zone" example:"raíz"` // Opcional
+	Brand             string  `json:"brand" example:"Igora Royal"`
+	Shade             string  `json:"shade" example:"6-0"`
+	DeveloperVolume   uint    `json:"developer_volume" example:"20"` // 0, 10, 20, 30 o 40
+	Grams             float64 `json:"grams" example:"30"`
+	ProcessingMinutes uint    `json:"processing_minutes" example:"35"`
+	Notes             string  `json:"notes" example:"Mezcla 1:1"`
+}
+
+type GetColorFormulaDto struct {
+	ID                uint    `json:"id" example:"1"`
+	AppointmentID     uint    `json:"appointment_id" example:"1"`
+	Zone              string  `json:"zone" example:"raíz"`
+	Brand             string  `json:"brand" example:"Igora Royal"`
+	Shade             string  `json:"shade" example:"6-0"`
+	DeveloperVolume   uint    `json:"developer_volume" example:"20"`
+	Grams             float64 `json:"grams" example:"30"`
+	ProcessingMinutes uint    `json:"processing_minutes" example:"35"`
+	Notes             string  `json:"notes" example:"Mezcla 1:1"`
+	Author            string  `json:"author" example:"laura"`
+	CreatedAt         string  `json:"created_at" example:"12/01/2025 16:40"`
+}
+
+type GetAppointmentPhotoDto struct {
+	ID            uint   `json:"id" example:"1"`
+	AppointmentID uint   `json:"appointment_id" example:"1"`
+	Kind          string `json:"kind" example:"despues"` // antes o despues
+	Caption       string `json:"caption" example:"Balayage terminado"`
+	ContentType   string `json:"content_type" example:"image/jpeg"`
+	Size          int64  `json:"size" example:"845213"`
+	Width         int    `json:"width" example:"1920"`
+	Height        int    `json:"height" example:"1080"`
+	URL           string `json:"url" example:"/api/v1/turno/fotos/1"`
+	ThumbnailURL  string `json:"thumbnail_url" example:"/api/v1/turno/fotos/1/miniatura"`
+	UploadedBy    string `json:"uploaded_by" example:"laura"`
+	CreatedAt     string `json:"created_at" example:"12/01/2025 16:40"`
+}
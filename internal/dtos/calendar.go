@@ -0,0 +1,32 @@
+package dtos
+
+type BusinessHourDto struct {
+	Weekday   int    `json:"weekday" example:"1"`        // 0 = domingo ... 6 = sábado
+	OpenTime  string `json:"open_time" example:"09:00"`  // Formato: HH:MM
+	CloseTime string `json:"close_time" example:"13:00"` // Formato: HH:MM
+}
+
+type GetBusinessHourDto struct {
+	ID          uint   `json:"id" example:"1"`
+	Weekday     int    `json:"weekday" example:"1"`
+	WeekdayName string `json:"weekday_name" example:"lunes"`
+	OpenTime    string `json:"open_time" example:"09:00"`
+	CloseTime   string `json:"close_time" example:"13:00"`
+}
+
+type ClosureDto struct {
+	StartDate string `json:"start_date" example:"25/12/2025"` // Formato: DD/MM/YYYY
+	EndDate   string `json:"end_date" example:"25/12/2025"`   // Formato: DD/MM/YYYY (opcional, por defecto igual a start_date)
+	Kind      string `json:"kind" example:"feriado"`          // "feriado" o "cierre"
+	Reason    string `json:"reason" example:"Navidad"`
+	Recurring bool   `json:"recurring" example:"true"` // Se repite todos los años
+}
+
+type GetClosureDto struct {
+	ID        uint   `json:"id" example:"1"`
+	StartDate string `json:"start_date" example:"25/12/2025"`
+	EndDate   string `json:"end_date" example:"25/12/2025"`
+	Kind      string `json:"kind" example:"feriado"`
+	Reason    string `json:"reason" example:"Navidad"`
+	Recurring bool   `json:"recurring" example:"true"`
+}
@@ -0,0 +1,13 @@
+package dtos
+
+type CalendarFeedDto struct {
+	StaffID uint `json:"staff_id" example:"1"` // Vacío para el calendario de todo el salón
+}
+
+type GetCalendarFeedDto struct {
+	ID        uint   `json:"id" example:"1"`
+	StaffID   *uint  `json:"staff_id" example:"1"`
+	StaffName string `json:"staff_name" example:"Laura Gómez"`
+	URL       string `json:"url" example:"/api/v1/ical/3f9a...c1.ics"`
+	CreatedAt string `json:"created_at" example:"10/01/2025 12:30"`
+}
@@ -0,0 +1,50 @@
+package dtos
+
+type OpenCashSessionDto struct {
+	OpeningFloat float64 `json:"opening_float" example:"20000"` // Fondo inicial de la caja
+	Notes        string  `json:"notes" example:"Turno mañana"`
+}
+
+type CashMovementDto struct {
+	Type        string  `json:"type" example:"gasto"` // "ingreso", "gasto" o "retiro"
+	Amount      float64 `json:"amount" example:"3500"`
+	Description string  `json:"description" example:"Artículos de limpieza"` // Obligatoria para gastos y retiros
+}
+
+type CloseCashSessionDto struct {
+	CountedAmount *float64 `json:"counted_amount" example:"84500"` // Efectivo contado al cerrar
+	Notes         string   `json:"notes" example:"Sin novedades"`
+}
+
+type GetCashSessionDto struct {
+	ID             uint                 `json:"id" example:"1"`
+	Status         string               `json:"status" example:"cerrada"` // "abierta" o "cerrada"
+	OpenedAt       string               `json:"opened_at" example:"12/01/2025 09:00"`
+	OpenedBy       string               `json:"opened_by" example:"admin"`
+	OpeningFloat   float64              `json:"opening_float" example:"20000"`
+	ClosedAt       string               `json:"closed_at" example:"12/01/2025 20:15"`
+	ClosedBy       string               `json:"closed_by" example:"admin"`
+	Totals         []CashTotalDto       `json:"totals"`                          // Suma por tipo de movimiento
+	ExpectedAmount float64              `json:"expected_amount" example:"85000"` // Fondo más movimientos
+	CountedAmount  *float64             `json:"counted_amount" example:"84500"`  // Vacío mientras está abierta
+	Difference     *float64             `json:"difference" example:"-500"`       // Contado menos esperado
+	Result         string               `json:"result" example:"faltante"`       // "sobrante", "faltante" o "sin diferencia" al cerrar
+	Notes          string               `json:"notes" example:"Sin novedades"`
+	Movements      []GetCashMovementDto `json:"movements,omitempty"` // Solo en el detalle de la sesión
+}
+
+type CashTotalDto struct {
+	Type   string  `json:"type" example:"pago"`
+	Count  int64   `json:"count" example:"18"`
+	Amount float64 `json:"amount" example:"62000"` // Negativo para salidas
+}
+
+type GetCashMovementDto struct {
+	ID            uint    `json:"id" example:"1"`
+	Type          string  `json:"type" example:"pago"`
+	Amount        float64 `json:"amount" example:"7500"` // Negativo para salidas
+	Description   string  `json:"description" example:"Cobro turno ID 12"`
+	AppointmentID *uint   `json:"appointment_id" example:"12"`
+	Username      string  `json:"username" example:"admin"`
+	CreatedAt     string  `json:"created_at" example:"12/01/2025 16:40"`
+}
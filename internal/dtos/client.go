@@ -17,7 +17,12 @@ type GetClientDto struct {
 }
 
 type ClientAppointmentDto struct {
-	ID              uint   `json:"id"`
-	AppointmentDate string `json:"appointment_date" example:"30/09/2002 16:30"`
-	Status          string `json:"status" example:"finalizado"`
+	ID              uint                     `json:"id"`
+	AppointmentDate string                   `json:"appointment_date" example:"30/09/2002 16:30"`
+	Status          string                   `json:"status" example:"finalizado"`
+	StaffName       string                   `json:"staff_name" example:"Laura Gómez"`
+	Services        []string                 `json:"services" example:"Corte de cabello,Color"`
+	Notes           []GetAppointmentNoteDto  `json:"notes"`
+	ColorFormulas   []GetColorFormulaDto     `json:"color_formulas"`
+	Photos          []GetAppointmentPhotoDto `json:"photos"`
 }
@@ -0,0 +1,21 @@
+package dtos
+
+type AppointmentEventDto struct {
+	ID              uint   `json:"id" example:"1"`
+	ClientID        uint   `json:"client_id" example:"1"`
+	ClientName      string `json:"client_name" example:"Juan Pérez"`
+	StaffID         *uint  `json:"staff_id" example:"1"`
+	StaffName       string `json:"staff_name" example:"Laura Gómez"`
+	SeriesID        *uint  `json:"series_id" example:"1"`
+	Status          string `json:"status" example:"confirmado"`
+	AppointmentDate string `json:"appointment_date" example:"12/01/2025 15:30"`
+}
+
+type StockEventDto struct {
+	ProductID uint    `json:"product_id" example:"1"`
+	Name      string  `json:"name" example:"Gel fijador"`
+	Quantity  float64 `json:"quantity" example:"8"`
+	Unit      string  `json:"unit" example:"unidad"`
+	LowStock  bool    `json:"low_stock" example:"false"` // La cantidad está en o por debajo del aviso
+	Deleted   bool    `json:"deleted" example:"false"`
+}
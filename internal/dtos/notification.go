@@ -0,0 +1,15 @@
+package dtos
+
+type NotificationDto struct {
+	ID            uint   `json:"id" example:"1"`
+	AppointmentID *uint  `json:"appointment_id" example:"12"`
+	Kind          string `json:"kind" example:"recordatorio_24h"`
+	Channel       string `json:"channel" example:"email"`
+	Recipient     string `json:"recipient" example:"juan@mail.com"`
+	Subject       string `json:"subject" example:"Recordatorio de turno"`
+	Status        string `json:"status" example:"enviada"`
+	Attempts      int    `json:"attempts" example:"1"`
+	NextAttemptAt string `json:"next_attempt_at" example:"10/01/2025 15:30"`
+	LastError     string `json:"last_error" example:""`
+	SentAt        string `json:"sent_at" example:"10/01/2025 15:30"`
+}
@@ -0,0 +1,35 @@
+package dtos
+
+type PaymentMethodDto struct {
+	Code              string `json:"code" example:"mercadopago"` // Identificador único; no se puede cambiar
+	Name              string `json:"name" example:"Mercado Pago"`
+	IsCash            *bool  `json:"is_cash" example:"false"`           // Entra a la caja del salón
+	RequiresReference *bool  `json:"requires_reference" example:"true"` // Exige número de operación al cobrar
+	Active            *bool  `json:"active" example:"true"`             // Opcional, por defecto activo
+}
+
+type GetPaymentMethodDto struct {
+	ID                uint   `json:"id" example:"1"`
+	Code              string `json:"code" example:"efectivo"`
+	Name              string `json:"name" example:"Efectivo"`
+	IsCash            bool   `json:"is_cash" example:"true"`
+	RequiresReference bool   `json:"requires_reference" example:"false"`
+	Active            bool   `json:"active" example:"true"`
+}
+
+type PaymentDto struct {
+	Method    string  `json:"method" example:"efectivo"` // Código del medio de pago
+	Amount    float64 `json:"amount" example:"7500"`
+	Reference string  `json:"reference" example:"TRX-88231"` // Opcional salvo que el medio lo exija
+}
+
+type GetPaymentDto struct {
+	ID         uint    `json:"id" example:"1"`
+	Method     string  `json:"method" example:"efectivo"`
+	MethodName string  `json:"method_name" example:"Efectivo"`
+	Amount     float64 `json:"amount" example:"7500"`
+	Reference  string  `json:"reference" example:"TRX-88231"`
+	PaidAt     string  `json:"paid_at" example:"12/01/2025 16:40"`
+	UserID     *uint   `json:"user_id" example:"1"`
+	Username   string  `json:"username" example:"admin"`
+}
@@ -0,0 +1,36 @@
+package dtos
+
+type PromotionDto struct {
+	Name             string  `json:"name" example:"Martes de color"`
+	Description      string  `json:"description" example:"20% en servicios de color los martes"`
+	Code             string  `json:"code" example:"COLOR20"`             // Código del cupón (opcional); sin código la promoción se aplica por ID
+	DiscountType     string  `json:"discount_type" example:"porcentaje"` // "porcentaje" o "monto"
+	Value            float64 `json:"value" example:"20"`                 // Porcentaje (hasta 100) o monto fijo
+	Scope            string  `json:"scope" example:"categoria"`          // "turno", "servicio" o "categoria"
+	ServiceID        *uint   `json:"service_id" example:"1"`             // Obligatorio si el alcance es "servicio"
+	Category         string  `json:"category" example:"color"`           // Obligatoria si el alcance es "categoria"
+	StartsOn         string  `json:"starts_on" example:"01/03/2025"`     // Formato: DD/MM/YYYY (opcional)
+	EndsOn           string  `json:"ends_on" example:"31/03/2025"`       // Formato: DD/MM/YYYY (opcional)
+	MaxUses          *uint   `json:"max_uses" example:"100"`             // Usos totales (opcional); 0 al actualizar quita el límite
+	MaxUsesPerClient *uint   `json:"max_uses_per_client" example:"1"`    // Usos por cliente (opcional); 0 al actualizar quita el límite
+	Active           *bool   `json:"active" example:"true"`              // Opcional, por defecto activa
+}
+
+type GetPromotionDto struct {
+	ID               uint    `json:"id" example:"1"`
+	Name             string  `json:"name" example:"Martes de color"`
+	Description      string  `json:"description" example:"20% en servicios de color los martes"`
+	Code             string  `json:"code" example:"COLOR20"`
+	DiscountType     string  `json:"discount_type" example:"porcentaje"`
+	Value            float64 `json:"value" example:"20"`
+	Scope            string  `json:"scope" example:"categoria"`
+	ServiceID        *uint   `json:"service_id" example:"1"`
+	ServiceName      string  `json:"service_name" example:"Tintura"`
+	Category         string  `json:"category" example:"color"`
+	StartsOn         string  `json:"starts_on" example:"01/03/2025"`
+	EndsOn           string  `json:"ends_on" example:"31/03/2025"`
+	MaxUses          *uint   `json:"max_uses" example:"100"`
+	MaxUsesPerClient *uint   `json:"max_uses_per_client" example:"1"`
+	Uses             int64   `json:"uses" example:"12"` // Veces que se aplicó
+	Active           bool    `json:"active" example:"true"`
+}
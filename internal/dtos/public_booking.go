@@ -0,0 +1,30 @@
+package dtos
+
+type PublicStaffDto struct {
+	ID       uint   `json:"id" example:"1"`
+	Name     string `json:"name" example:"Laura"`
+	LastName string `json:"last_name" example:"Gómez"`
+}
+
+type PublicBookingDto struct {
+	Name            string `json:"name" example:"Juan"`
+	LastName        string `json:"last_name" example:"Pérez"`
+	Phone           string `json:"phone" example:"3435343450"`
+	Email           string `json:"email" example:"juan@mail.com"`
+	StaffID         uint   `json:"staff_id" example:"1"`                        // Opcional
+	AppointmentDate string `json:"appointment_date" example:"15:30 10/01/2025"` // Formato: HH:MM DD/MM/YYYY
+	ServiceIds      []uint `json:"service_id" example:"1,2"`
+	BundleIDs       []uint `json:"bundle_id" example:"1"`  // Combos (opcional)
+	VariantIDs      []uint `json:"variant_id" example:"3"` // Variantes de los servicios (opcional)
+	OptionIDs       []uint `json:"option_id" example:"5"`  // Adicionales (opcional)
+}
+
+type PublicBookingResultDto struct {
+	AppointmentID uint   `json:"appointment_id" example:"12"`
+	Status        string `json:"status" example:"solicitado"`
+	ExpiresAt     string `json:"expires_at" example:"10/01/2025 12:30"` // Vencimiento del código enviado al cliente
+}
+
+type ConfirmPublicBookingDto struct {
+	Code string `json:"code" example:"483920"`
+}
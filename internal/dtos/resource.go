@@ -0,0 +1,28 @@
+package dtos
+
+type ResourceDto struct {
+	Name   string `json:"name" example:"Lavacabezas 1"`
+	Type   string `json:"type" example:"lavacabezas"` // Los recursos del mismo tipo son intercambiables
+	Active *bool  `json:"active" example:"true"`
+}
+
+type GetResourceDto struct {
+	ID     uint   `json:"id" example:"1"`
+	Name   string `json:"name" example:"Lavacabezas 1"`
+	Type   string `json:"type" example:"lavacabezas"`
+	Active bool   `json:"active" example:"true"`
+}
+
+type ServiceResourceDto struct {
+	ResourceType    string `json:"resource_type" example:"lavacabezas"`
+	OffsetMinutes   uint   `json:"offset_minutes" example:"0"` // Minutos desde el inicio del servicio
+	DurationMinutes uint   `json:"duration_minutes" example:"10"`
+}
+
+type AppointmentResourceDto struct {
+	ResourceID   uint   `json:"resource_id" example:"1"`
+	ResourceName string `json:"resource_name" example:"Lavacabezas 1"`
+	ResourceType string `json:"resource_type" example:"lavacabezas"`
+	Start        string `json:"start" example:"15:30"`
+	End          string `json:"end" example:"15:40"`
+}
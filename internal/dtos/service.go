@@ -1,17 +1,58 @@
 package dtos
 
 type ServiceDto struct {
-	Name                 string  `json:"name" example:"Corte de pelo"`
-	Description          string  `json:"description" example:"Corte de pelo clasico"`
-	Price                float64 `json:"price" example:"10000"`
-	EstimatedTimeMinutes uint    `json:"estimated_time_minutes" example:"30"`
-	EstimatedTimeHours   uint    `json:"estimated_time_hours" example:"1"`
+	Name                  string               `json:"name" example:"Corte de pelo"`
+	Description           string               `json:"description" example:"Corte de pelo clasico"`
+	Category              string               `json:"category" example:"corte"` // Agrupa servicios para las promociones
+	Price                 float64              `json:"price" example:"10000"`
+	EstimatedTimeMinutes  uint                 `json:"estimated_time_minutes" example:"30"`
+	EstimatedTimeHours    uint                 `json:"estimated_time_hours" example:"1"`
+	ProcessingTimeMinutes *uint                `json:"processing_time_minutes" example:"30"` // Tiempo de espera en que el estilista queda libre
+	BufferTimeMinutes     *uint                `json:"buffer_time_minutes" example:"10"`     // Limpieza posterior
+	Resources             []ServiceResourceDto `json:"resources"`                            // Recursos que ocupa; al actualizar, una lista vacía los quita y omitirla los conserva
+	Variants              []ServiceVariantDto  `json:"variants"`                             // Variantes de precio; al actualizar, las que no se envían se dan de baja y omitirla las conserva
+	Options               []ServiceOptionDto   `json:"options"`                              // Adicionales; misma regla que las variantes
+}
+
+type ServiceVariantDto struct {
+	ID                   uint    `json:"id" example:"0"` // Al actualizar, ID de la variante a modificar; 0 crea una nueva
+	Name                 string  `json:"name" example:"Pelo largo"`
+	Price                float64 `json:"price" example:"14000"`
+	EstimatedTimeMinutes *uint   `json:"estimated_time_minutes" example:"45"` // Opcional; vacío usa el del servicio
+}
+
+type ServiceOptionDto struct {
+	ID           uint    `json:"id" example:"0"` // Al actualizar, ID del adicional a modificar; 0 crea uno nuevo
+	Name         string  `json:"name" example:"Matizador"`
+	Price        float64 `json:"price" example:"3000"`
+	ExtraMinutes uint    `json:"extra_minutes" example:"10"`
 }
 
 type GetServiceDto struct {
-	ID            uint    `json:"id" example:"1"`
-	Name          string  `json:"name" example:"Corte de pelo"`
-	Description   string  `json:"description" example:"Corte de pelo clasico"`
-	Price         float64 `json:"price" example:"10000"`
-	EstimatedTime uint    `json:"estimated_time_minutes" example:"90"`
+	ID             uint                   `json:"id" example:"1"`
+	Name           string                 `json:"name" example:"Corte de pelo"`
+	Description    string                 `json:"description" example:"Corte de pelo clasico"`
+	Category       string                 `json:"category" example:"corte"`
+	Price          float64                `json:"price" example:"10000"`
+	EstimatedTime  uint                   `json:"estimated_time_minutes" example:"90"`
+	ProcessingTime uint                   `json:"processing_time_minutes" example:"30"`
+	BufferTime     uint                   `json:"buffer_time_minutes" example:"10"`
+	TotalTime      uint                   `json:"total_time_minutes" example:"130"` // Activo + espera + limpieza
+	Resources      []ServiceResourceDto   `json:"resources"`
+	Variants       []GetServiceVariantDto `json:"variants"`
+	Options        []GetServiceOptionDto  `json:"options"`
+}
+
+type GetServiceVariantDto struct {
+	ID                   uint    `json:"id" example:"1"`
+	Name                 string  `json:"name" example:"Pelo largo"`
+	Price                float64 `json:"price" example:"14000"`
+	EstimatedTimeMinutes uint    `json:"estimated_time_minutes" example:"45"`
+}
+
+type GetServiceOptionDto struct {
+	ID           uint    `json:"id" example:"1"`
+	Name         string  `json:"name" example:"Matizador"`
+	Price        float64 `json:"price" example:"3000"`
+	ExtraMinutes uint    `json:"extra_minutes" example:"10"`
 }
@@ -0,0 +1,29 @@
+package dtos
+
+type ServiceBundleDto struct {
+	Name            string  `json:"name" example:"Corte + color"`
+	Description     string  `json:"description" example:"Corte, tintura y peinado"`
+	Price           float64 `json:"price" example:"25000"`          // No puede superar la suma de los servicios
+	DurationMinutes uint    `json:"duration_minutes" example:"150"` // Duración total; 0 usa la suma de los servicios
+	ServiceIDs      []uint  `json:"service_ids" example:"1,2,3"`    // En el orden en que se realizan; al actualizar, omitirla los conserva
+	Active          *bool   `json:"active" example:"true"`          // Opcional, por defecto activo
+}
+
+type GetServiceBundleDto struct {
+	ID              uint                   `json:"id" example:"1"`
+	Name            string                 `json:"name" example:"Corte + color"`
+	Description     string                 `json:"description" example:"Corte, tintura y peinado"`
+	Price           float64                `json:"price" example:"25000"`
+	ListPrice       float64                `json:"list_price" example:"30000"` // Suma de los precios de los servicios
+	Savings         float64                `json:"savings" example:"5000"`
+	DurationMinutes uint                   `json:"duration_minutes" example:"150"`
+	Active          bool                   `json:"active" example:"true"`
+	Services        []ServiceBundleItemDto `json:"services"`
+}
+
+type ServiceBundleItemDto struct {
+	ServiceID   uint    `json:"service_id" example:"1"`
+	ServiceName string  `json:"service_name" example:"Corte de pelo"`
+	Price       float64 `json:"price" example:"10000"`
+	TotalTime   uint    `json:"total_time_minutes" example:"40"`
+}
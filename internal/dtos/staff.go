@@ -0,0 +1,33 @@
+package dtos
+
+type StaffDto struct {
+	Name     string `json:"name" example:"Laura"`
+	LastName string `json:"last_name" example:"Gómez"`
+	Phone    string `json:"phone" example:"343534345"`
+	UserID   *uint  `json:"user_id" example:"2"` // Usuario de login asociado (opcional)
+	Active   *bool  `json:"active" example:"true"`
+}
+
+type GetStaffDto struct {
+	ID       uint   `json:"id" example:"1"`
+	Name     string `json:"name" example:"Laura"`
+	LastName string `json:"last_name" example:"Gómez"`
+	Phone    string `json:"phone" example:"343534345"`
+	UserID   *uint  `json:"user_id" example:"2"`
+	Username string `json:"username" example:"laura"`
+	Active   bool   `json:"active" example:"true"`
+}
+
+type StaffSkillDto struct {
+	Price                *float64 `json:"price" example:"15000"`               // Precio propio del estilista (opcional)
+	EstimatedTimeMinutes *uint    `json:"estimated_time_minutes" example:"25"` // Tiempo activo propio del estilista (opcional)
+}
+
+type GetStaffSkillDto struct {
+	ServiceID            uint     `json:"service_id" example:"1"`
+	ServiceName          string   `json:"service_name" example:"Corte de pelo"`
+	Price                float64  `json:"price" example:"15000"`               // Precio efectivo para el estilista
+	EstimatedTimeMinutes uint     `json:"estimated_time_minutes" example:"25"` // Tiempo activo efectivo para el estilista
+	PriceOverride        *float64 `json:"price_override" example:"15000"`      // Vacío si usa el precio del servicio
+	DurationOverride     *uint    `json:"duration_override" example:"25"`      // Vacío si usa el tiempo del servicio
+}
@@ -0,0 +1,66 @@
+package dtos
+
+type StaffShiftDto struct {
+	Weekday   int    `json:"weekday" example:"1"`        // 0 = domingo ... 6 = sábado
+	StartTime string `json:"start_time" example:"09:00"` // Formato: HH:MM
+	EndTime   string `json:"end_time" example:"17:00"`   // Formato: HH:MM
+}
+
+type GetStaffShiftDto struct {
+	ID          uint   `json:"id" example:"1"`
+	StaffID     uint   `json:"staff_id" example:"1"`
+	Weekday     int    `json:"weekday" example:"1"`
+	WeekdayName string `json:"weekday_name" example:"lunes"`
+	StartTime   string `json:"start_time" example:"09:00"`
+	EndTime     string `json:"end_time" example:"17:00"`
+}
+
+type StaffScheduleExceptionDto struct {
+	Date      string `json:"date" example:"20/01/2025"`  // Formato: DD/MM/YYYY
+	StartTime string `json:"start_time" example:"12:00"` // Formato: HH:MM, vacío si no trabaja ese día
+	EndTime   string `json:"end_time" example:"16:00"`   // Formato: HH:MM, vacío si no trabaja ese día
+	Reason    string `json:"reason" example:"Trámite personal"`
+}
+
+type GetStaffScheduleExceptionDto struct {
+	ID        uint                       `json:"id" example:"1"`
+	StaffID   uint                       `json:"staff_id" example:"1"`
+	Date      string                     `json:"date" example:"20/01/2025"`
+	StartTime string                     `json:"start_time" example:"12:00"`
+	EndTime   string                     `json:"end_time" example:"16:00"`
+	Reason    string                     `json:"reason" example:"Trámite personal"`
+	Conflicts []StaffScheduleConflictDto `json:"conflicts"` // Turnos ya agendados que quedan fuera del horario
+}
+
+type TimeOffDto struct {
+	StaffID   uint   `json:"staff_id" example:"1"`
+	Kind      string `json:"kind" example:"vacaciones"`       // vacaciones, enfermedad u otro
+	StartDate string `json:"start_date" example:"03/02/2025"` // Formato: DD/MM/YYYY
+	EndDate   string `json:"end_date" example:"14/02/2025"`   // Formato: DD/MM/YYYY (opcional, por defecto igual a start_date)
+	Reason    string `json:"reason" example:"Vacaciones de verano"`
+}
+
+type ReviewTimeOffDto struct {
+	Note string `json:"note" example:"Aprobado, cubrir con Laura"`
+}
+
+type GetTimeOffDto struct {
+	ID         uint                       `json:"id" example:"1"`
+	StaffID    uint                       `json:"staff_id" example:"1"`
+	StaffName  string                     `json:"staff_name" example:"Laura Gómez"`
+	Kind       string                     `json:"kind" example:"vacaciones"`
+	StartDate  string                     `json:"start_date" example:"03/02/2025"`
+	EndDate    string                     `json:"end_date" example:"14/02/2025"`
+	Reason     string                     `json:"reason" example:"Vacaciones de verano"`
+	Status     string                     `json:"status" example:"pendiente"`
+	ReviewedAt string                     `json:"reviewed_at" example:"20/01/2025 10:00"`
+	ReviewNote string                     `json:"review_note" example:"Aprobado, cubrir con Laura"`
+	Conflicts  []StaffScheduleConflictDto `json:"conflicts"` // Turnos ya agendados dentro de la licencia
+}
+
+type StaffScheduleConflictDto struct {
+	AppointmentID   uint   `json:"appointment_id" example:"12"`
+	AppointmentDate string `json:"appointment_date" example:"05/02/2025 15:30"`
+	ClientName      string `json:"client_name" example:"Juan Pérez"`
+	Status          string `json:"status" example:"confirmado"`
+}
@@ -1,15 +1,46 @@
 package dtos
 
-type PaymentMethodBreakdownDto struct {
-	Debit    int64 `json:"debit"`    // Cantidad de pagos con débito
-	Cash     int64 `json:"cash"`     // Cantidad de pagos en efectivo
-	Transfer int64 `json:"transfer"` // Cantidad de pagos por transferencia
+type PaymentMethodStatDto struct {
+	Method string  `json:"method" example:"efectivo"`
+	Name   string  `json:"name" example:"Efectivo"`
+	Count  int64   `json:"count" example:"42"`      // Cantidad de pagos
+	Amount float64 `json:"amount" example:"315000"` // Total cobrado con el medio
 }
 
 type MonthlyStatisticsDto struct {
-	Incomes                float64                   `json:"income"`
-	Expenses               float64                   `json:"expenses"`
-	AppointmentsCount      int64                     `json:"appointments_count"`
-	ClientsCount           int64                     `json:"clients_count"`
-	PaymentMethodBreakdown PaymentMethodBreakdownDto `json:"payment_method_breakdown"`
+	Incomes           float64                `json:"income"`    // Lo efectivamente cobrado por servicios
+	Discounts         float64                `json:"discounts"` // Descuentos de promociones del mes
+	Expenses          float64                `json:"expenses"`
+	AppointmentsCount int64                  `json:"appointments_count"`
+	ClientsCount      int64                  `json:"clients_count"`
+	PaymentMethods    []PaymentMethodStatDto `json:"payment_methods"` // Cobrado por medio de pago
+}
+
+type TimeStatisticsDto struct {
+	Month        string               `json:"month" example:"01/2025"`
+	Appointments int64                `json:"appointments"` // Turnos con inicio y fin registrados
+	ByService    []ServiceTimeStatDto `json:"by_service"`
+	ByStaff      []StaffTimeStatDto   `json:"by_staff"`
+}
+
+type ServiceTimeStatDto struct {
+	ServiceID           uint    `json:"service_id" example:"1"`
+	ServiceName         string  `json:"service_name" example:"Corte de cabello"`
+	Count               int64   `json:"count" example:"12"`
+	AvgEstimatedMinutes float64 `json:"avg_estimated_minutes" example:"30"`
+	AvgActualMinutes    float64 `json:"avg_actual_minutes" example:"34.5"`
+	AvgDeviationMinutes float64 `json:"avg_deviation_minutes" example:"4.5"` // Real menos estimado; positivo es más lento
+	AvgDeviationPercent float64 `json:"avg_deviation_percent" example:"15"`
+}
+
+type StaffTimeStatDto struct {
+	StaffID              uint    `json:"staff_id" example:"1"`
+	StaffName            string  `json:"staff_name" example:"Laura Gómez"`
+	Count                int64   `json:"count" example:"20"` // Servicios realizados
+	AvgEstimatedMinutes  float64 `json:"avg_estimated_minutes" example:"45"`
+	AvgActualMinutes     float64 `json:"avg_actual_minutes" example:"48"`
+	AvgDeviationMinutes  float64 `json:"avg_deviation_minutes" example:"3"`
+	AvgDeviationPercent  float64 `json:"avg_deviation_percent" example:"6.7"`
+	AvgStartDelayMinutes float64 `json:"avg_start_delay_minutes" example:"4"` // Inicio real respecto del agendado, por turno
+	AvgClientWaitMinutes float64 `json:"avg_client_wait_minutes" example:"6"` // De la llegada al inicio, por turno
 }
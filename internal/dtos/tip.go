@@ -0,0 +1,38 @@
+package dtos
+
+type TipDto struct {
+	Amount  float64 `json:"amount" example:"2000"`
+	Method  string  `json:"method" example:"efectivo"` // Código del medio de pago
+	StaffID *uint   `json:"staff_id" example:"1"`      // Opcional; por defecto el estilista del turno
+}
+
+type GetTipDto struct {
+	ID         uint    `json:"id" example:"1"`
+	StaffID    uint    `json:"staff_id" example:"1"`
+	StaffName  string  `json:"staff_name" example:"Laura Gómez"`
+	Method     string  `json:"method" example:"efectivo"`
+	MethodName string  `json:"method_name" example:"Efectivo"`
+	Amount     float64 `json:"amount" example:"2000"`
+	PaidAt     string  `json:"paid_at" example:"12/01/2025 16:40"`
+}
+
+type TipReportDto struct {
+	From    string        `json:"from" example:"06/01/2025"`
+	To      string        `json:"to" example:"12/01/2025"`
+	Total   float64       `json:"total" example:"48000"`
+	ByStaff []StaffTipDto `json:"by_staff"`
+}
+
+type StaffTipDto struct {
+	StaffID   uint           `json:"staff_id" example:"1"`
+	StaffName string         `json:"staff_name" example:"Laura Gómez"`
+	Count     int64          `json:"count" example:"9"` // Cantidad de propinas
+	Amount    float64        `json:"amount" example:"18000"`
+	ByMethod  []TipMethodDto `json:"by_method"` // Para saber cuánto sale de la caja en efectivo
+}
+
+type TipMethodDto struct {
+	Method string  `json:"method" example:"efectivo"`
+	Name   string  `json:"name" example:"Efectivo"`
+	Amount float64 `json:"amount" example:"12000"`
+}
@@ -0,0 +1,38 @@
+package dtos
+
+type WaitlistEntryDto struct {
+	ClientID           uint   `json:"client_id" example:"1"`
+	StaffID            uint   `json:"staff_id" example:"1"`              // Estilista preferido (opcional)
+	ServiceIds         []uint `json:"service_id" example:"1,2"`          // Servicios deseados
+	DesiredFrom        string `json:"desired_from" example:"10/01/2025"` // Formato: DD/MM/YYYY
+	DesiredTo          string `json:"desired_to" example:"15/01/2025"`   // Formato: DD/MM/YYYY
+	PreferredStartTime string `json:"preferred_start_time" example:"14:00"`
+	PreferredEndTime   string `json:"preferred_end_time" example:"18:00"`
+	Notes              string `json:"notes" example:"Prefiere la tarde"`
+	Status             string `json:"status" example:"activa"` // Solo al actualizar: activa, atendida, cancelada
+}
+
+type GetWaitlistEntryDto struct {
+	ID                 uint                    `json:"id" example:"1"`
+	ClientID           uint                    `json:"client_id" example:"1"`
+	ClientName         string                  `json:"client_name" example:"Juan Pérez"`
+	ClientPhone        string                  `json:"client_phone" example:"343534345"`
+	StaffID            *uint                   `json:"staff_id" example:"1"`
+	StaffName          string                  `json:"staff_name" example:"Laura Gómez"`
+	Services           []AppointmentServiceDto `json:"services"`
+	DesiredFrom        string                  `json:"desired_from" example:"10/01/2025"`
+	DesiredTo          string                  `json:"desired_to" example:"15/01/2025"`
+	PreferredStartTime string                  `json:"preferred_start_time" example:"14:00"`
+	PreferredEndTime   string                  `json:"preferred_end_time" example:"18:00"`
+	Notes              string                  `json:"notes" example:"Prefiere la tarde"`
+	Status             string                  `json:"status" example:"activa"`
+}
+
+type WaitlistMatchDto struct {
+	ID                 uint                `json:"id" example:"1"`
+	FreedAppointmentID uint                `json:"freed_appointment_id" example:"12"`
+	StaffID            *uint               `json:"staff_id" example:"1"`
+	SlotStart          string              `json:"slot_start" example:"12/01/2025 15:30"`
+	SlotEnd            string              `json:"slot_end" example:"12/01/2025 16:30"`
+	Entry              GetWaitlistEntryDto `json:"entry"`
+}
@@ -0,0 +1,33 @@
+package dtos
+
+type WalkInDto struct {
+	ClientID   uint   `json:"client_id" example:"1"` // Opcional si se indica el nombre
+	Name       string `json:"name" example:"Juan"`
+	LastName   string `json:"last_name" example:"Pérez"`
+	Phone      string `json:"phone" example:"3435343450"`
+	StaffID    uint   `json:"staff_id" example:"1"` // Estilista preferido (opcional)
+	ServiceIds []uint `json:"service_id" example:"1,2"`
+}
+
+type WalkInPositionDto struct {
+	Position int `json:"position" example:"1"`
+}
+
+type ServeWalkInDto struct {
+	StaffID uint `json:"staff_id" example:"1"` // Si se omite se usa el estilista preferido
+}
+
+type GetWalkInDto struct {
+	ID                   uint                    `json:"id" example:"1"`
+	TicketNumber         uint                    `json:"ticket_number" example:"7"`
+	Position             int                     `json:"position" example:"2"`
+	ClientID             uint                    `json:"client_id" example:"1"`
+	ClientName           string                  `json:"client_name" example:"Juan Pérez"`
+	StaffID              *uint                   `json:"staff_id" example:"1"`
+	StaffName            string                  `json:"staff_name" example:"Laura Gómez"`
+	Services             []AppointmentServiceDto `json:"services"`
+	Status               string                  `json:"status" example:"esperando"`
+	EstimatedWaitMinutes uint                    `json:"estimated_wait_minutes" example:"25"`
+	AppointmentID        *uint                   `json:"appointment_id" example:"12"`
+	CreatedAt            string                  `json:"created_at" example:"10/01/2025 15:30"`
+}
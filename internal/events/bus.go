@@ -0,0 +1,65 @@
+package events
+
+import (
+	"sync"
+	"time"
+)
+
+// Tipos de eventos publicados por los servicios.
+const (
+	WaitlistMatchFound   = "waitlist.match_found"
+	AppointmentCreated   = "appointment.created"
+	AppointmentUpdated   = "appointment.updated" // Cambios de datos o de estado
+	AppointmentFinalized = "appointment.finalized"
+	AppointmentDeleted   = "appointment.deleted"
+	StockChanged         = "stock.changed"
+)
+
+// Event es un aviso emitido por un servicio después de confirmar sus cambios.
+type Event struct {
+	Type       string      `json:"type"`
+	Data       interface{} `json:"data"`
+	OccurredAt time.Time   `json:"occurred_at"`
+}
+
+var (
+	mu          sync.RWMutex
+	subscribers = make(map[int]chan Event)
+	nextID      int
+)
+
+// Publish envía el evento a todos los suscriptores. Si el buffer de un suscriptor está
+// lleno el evento se descarta para ese suscriptor, sin bloquear al servicio que publica.
+func Publish(eventType string, data interface{}) {
+	event := Event{Type: eventType, Data: data, OccurredAt: time.Now()}
+
+	mu.RLock()
+	defer mu.RUnlock()
+	for _, ch := range subscribers {
+		select {
+		case ch <- event:
+		default:
+		}
+	}
+}
+
+// Subscribe registra un nuevo suscriptor y devuelve su canal junto con la función para
+// darlo de baja.
+func Subscribe(buffer int) (<-chan Event, func()) {
+	mu.Lock()
+	defer mu.Unlock()
+
+	id := nextID
+	nextID++
+	ch := make(chan Event, buffer)
+	subscribers[id] = ch
+
+	return ch, func() {
+		mu.Lock()
+		defer mu.Unlock()
+		if _, ok := subscribers[id]; ok {
+			delete(subscribers, id)
+			close(ch)
+		}
+	}
+}
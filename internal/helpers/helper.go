@@ -3,6 +3,7 @@ package helpers
 import (
 	"errors"
 	"net/http"
+	"os"
 	"peluqueria/internal/dtos"
 	"time"
 
@@ -26,14 +27,51 @@ func RespondError(c echo.Context, status int, message string) error {
 	})
 }
 
+// Genera una respuesta de error con datos adicionales
+func RespondErrorWithData(c echo.Context, status int, message string, data interface{}) error {
+	return c.JSON(status, dtos.Response{
+		Status:  "error",
+		Message: message,
+		Data:    data,
+	})
+}
+
+// CurrentUserID devuelve el ID del usuario autenticado, o 0 si no hay uno
+func CurrentUserID(c echo.Context) uint {
+	userID, _ := c.Get("user_id").(uint)
+	return userID
+}
+
+// CurrentRoleID devuelve el ID del rol del usuario autenticado, o 0 si no hay uno
+func CurrentRoleID(c echo.Context) uint {
+	roleID, _ := c.Get("role_id").(uint)
+	return roleID
+}
+
+// DefaultSalonTimezone se usa cuando SALON_TIMEZONE no está definida.
+const DefaultSalonTimezone = "America/Argentina/Buenos_Aires"
+
+// SalonLocation devuelve la zona horaria del salón, configurable con SALON_TIMEZONE
+func SalonLocation() (*time.Location, error) {
+	name := os.Getenv("SALON_TIMEZONE")
+	if name == "" {
+		name = DefaultSalonTimezone
+	}
+	loc, err := time.LoadLocation(name)
+	if err != nil {
+		return nil, errors.New("no se pudo cargar la zona horaria")
+	}
+	return loc, nil
+}
+
 func ParseCustomDate(dateString string) (time.Time, error) {
 	// Formato esperado
 	layout := "15:04 02/01/2006"
 
 	// Cargar la zona horaria
-	loc, err := time.LoadLocation("America/Argentina/Buenos_Aires")
+	loc, err := SalonLocation()
 	if err != nil {
-		return time.Time{}, errors.New("no se pudo cargar la zona horaria")
+		return time.Time{}, err
 	}
 
 	// Parsear la fecha con la zona horaria especificada
@@ -56,3 +94,17 @@ func ParseMonthFilter(month string) (time.Time, time.Time, error) {
 
 	return startDate, endDate, nil
 }
+
+func ParseCustomDay(dayString string) (time.Time, error) {
+	loc, err := SalonLocation()
+	if err != nil {
+		return time.Time{}, err
+	}
+
+	parsedDay, err := time.ParseInLocation("02/01/2006", dayString, loc)
+	if err != nil {
+		return time.Time{}, errors.New("formato de fecha inválido, debe ser DD/MM/YYYY")
+	}
+
+	return parsedDay, nil
+}
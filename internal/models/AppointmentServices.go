@@ -7,13 +7,25 @@ import (
 )
 
 type AppointmentService struct {
-	ID            uint           `gorm:"primaryKey" json:"id"`
-	AppointmentID uint           `gorm:"not null" json:"appointment_id"`
-	Appointment   Appointment    `gorm:"constraint:OnUpdate:CASCADE,OnDelete:CASCADE;" json:"appointment"`
-	ServiceID     uint           `gorm:"not null" json:"service_id"`
-	Service       Service        `gorm:"constraint:OnUpdate:CASCADE,OnDelete:CASCADE;" json:"service"`
-	Price         float64        `gorm:"not null" json:"price"`
-	CreatedAt     time.Time      `json:"created_at"`
-	UpdatedAt     time.Time      `json:"updated_at"`
-	DeletedAt     gorm.DeletedAt `gorm:"index" json:"-" swag:"-"`
+	ID            uint                       `gorm:"primaryKey" json:"id"`
+	AppointmentID uint                       `gorm:"not null" json:"appointment_id"`
+	Appointment   Appointment                `gorm:"constraint:OnUpdate:CASCADE,OnDelete:CASCADE;" json:"appointment"`
+	ServiceID     uint                       `gorm:"not null" json:"service_id"`
+	Service       Service                    `gorm:"constraint:OnUpdate:CASCADE,OnDelete:CASCADE;" json:"service"`
+	StaffID       *uint                      `gorm:"index" json:"staff_id"` // Estilista que realiza el servicio
+	Staff         *Staff                     `gorm:"constraint:OnUpdate:CASCADE,OnDelete:SET NULL;" json:"staff,omitempty"`
+	Price         float64                    `gorm:"not null" json:"price"`                // Precio cobrado, con los descuentos del combo y de las promociones
+	ListPrice     float64                    `gorm:"not null;default:0" json:"list_price"` // Precio de lista del estilista, sin descuentos
+	ActiveMinutes *uint                      `json:"active_minutes"`                       // Tiempo activo propio del estilista; vacío usa el del servicio
+	BundleID      *uint                      `gorm:"index" json:"bundle_id"`               // Combo del que sale la línea (opcional)
+	Bundle        *ServiceBundle             `gorm:"constraint:OnUpdate:CASCADE,OnDelete:SET NULL;" json:"bundle,omitempty"`
+	VariantID     *uint                      `json:"variant_id"` // Variante elegida (opcional)
+	Variant       *ServiceVariant            `gorm:"constraint:OnUpdate:CASCADE,OnDelete:SET NULL;" json:"variant,omitempty"`
+	Options       []AppointmentServiceOption `gorm:"constraint:OnUpdate:CASCADE,OnDelete:CASCADE;" json:"options"` // Adicionales elegidos
+	Discount      float64                    `gorm:"not null;default:0" json:"discount"`                           // Descuento de la promoción, ya restado de Price
+	PromotionID   *uint                      `gorm:"index" json:"promotion_id"`                                    // Promoción aplicada al finalizar (opcional)
+	Promotion     *Promotion                 `gorm:"constraint:OnUpdate:CASCADE,OnDelete:SET NULL;" json:"promotion,omitempty"`
+	CreatedAt     time.Time                  `json:"created_at"`
+	UpdatedAt     time.Time                  `json:"updated_at"`
+	DeletedAt     gorm.DeletedAt             `gorm:"index" json:"-" swag:"-"`
 }
@@ -7,15 +7,26 @@ import (
 )
 
 type Appointment struct {
-	ID                  uint                 `gorm:"primaryKey" json:"id"`
-	ClientID            uint                 `gorm:"not null" json:"client_id"`
-	Client              Client               `gorm:"constraint:OnUpdate:CASCADE,OnDelete:CASCADE;" json:"client"`
-	Status              string               `gorm:"size:50;not null" json:"status"` // Ej: "pendiente", "cancelado", "finalizado"
-	PaymentMethod       string               `gorm:"size:50" json:"payment_method"`
-	AppointmentDate     time.Time            `gorm:"not null" json:"appointment_date"`
-	AppointmentServices []AppointmentService `gorm:"constraint:OnUpdate:CASCADE,OnDelete:CASCADE;" json:"appointment_services"`
-	AppointmentProducts []AppointmentProduct `gorm:"constraint:OnUpdate:CASCADE,OnDelete:CASCADE;" json:"appointment_products"`
-	CreatedAt           time.Time            `json:"created_at"`
-	UpdatedAt           time.Time            `json:"updated_at"`
-	DeletedAt           gorm.DeletedAt       `gorm:"index" json:"-" swag:"-"`
+	ID                  uint                  `gorm:"primaryKey" json:"id"`
+	ClientID            uint                  `gorm:"not null" json:"client_id"`
+	Client              Client                `gorm:"constraint:OnUpdate:CASCADE,OnDelete:CASCADE;" json:"client"`
+	StaffID             *uint                 `gorm:"index" json:"staff_id"` // Estilista asignado (opcional)
+	Staff               *Staff                `gorm:"constraint:OnUpdate:CASCADE,OnDelete:SET NULL;" json:"staff,omitempty"`
+	Status              string                `gorm:"size:50;not null" json:"status"` // Ver AppointmentStatus* en appointment_status.go
+	CancellationReason  string                `gorm:"size:255" json:"cancellation_reason"`
+	SeriesID            *uint                 `gorm:"index" json:"series_id"`        // Serie recurrente a la que pertenece (opcional)
+	PaymentMethod       string                `gorm:"size:50" json:"payment_method"` // Código del medio de pago, o "mixto" si se pagó con varios; el detalle está en Payments
+	AppointmentDate     time.Time             `gorm:"not null" json:"appointment_date"`
+	DurationOverride    *uint                 `json:"duration_override"`                  // Duración manual en minutos; reemplaza la de los servicios
+	Sequence            uint                  `gorm:"not null;default:0" json:"sequence"` // Revisión del turno, para los calendarios suscritos
+	CheckedInAt         *time.Time            `json:"checked_in_at"`                      // Llegada del cliente al salón
+	StartedAt           *time.Time            `json:"started_at"`                         // Inicio real de la atención
+	FinishedAt          *time.Time            `json:"finished_at"`                        // Fin real de la atención
+	AppointmentServices []AppointmentService  `gorm:"constraint:OnUpdate:CASCADE,OnDelete:CASCADE;" json:"appointment_services"`
+	AppointmentProducts []AppointmentProduct  `gorm:"constraint:OnUpdate:CASCADE,OnDelete:CASCADE;" json:"appointment_products"`
+	Resources           []AppointmentResource `gorm:"constraint:OnUpdate:CASCADE,OnDelete:CASCADE;" json:"resources"` // Recursos asignados
+	Payments            []Payment             `gorm:"constraint:OnUpdate:CASCADE,OnDelete:CASCADE;" json:"payments"`
+	CreatedAt           time.Time             `json:"created_at"`
+	UpdatedAt           time.Time             `json:"updated_at"`
+	DeletedAt           gorm.DeletedAt        `gorm:"index" json:"-" swag:"-"`
 }
@@ -0,0 +1,66 @@
+package models
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// Momentos de una foto del turno.
+const (
+	PhotoKindBefore = "antes"
+	PhotoKindAfter  = "despues"
+)
+
+// AppointmentNote es una nota libre del estilista sobre lo que hizo en el turno.
+type AppointmentNote struct {
+	ID            uint           `gorm:"primaryKey" json:"id"`
+	AppointmentID uint           `gorm:"not null;index" json:"appointment_id"`
+	Appointment   Appointment    `gorm:"constraint:OnUpdate:CASCADE,OnDelete:CASCADE;" json:"-"`
+	Text          string         `gorm:"type:text;not null" json:"text"`
+	AuthorID      *uint          `json:"author_id"` // Usuario que escribió la nota
+	Author        *User          `gorm:"constraint:OnUpdate:CASCADE,OnDelete:SET NULL;" json:"author,omitempty"`
+	CreatedAt     time.Time      `json:"created_at"`
+	UpdatedAt     time.Time      `json:"updated_at"`
+	DeletedAt     gorm.DeletedAt `gorm:"index" json:"-" swag:"-"`
+}
+
+// ColorFormula es un componente de la mezcla de color aplicada en el turno. Una fórmula
+// con varios tonos se registra como varias filas.
+type ColorFormula struct {
+	ID                uint           `gorm:"primaryKey" json:"id"`
+	AppointmentID     uint           `gorm:"not null;index" json:"appointment_id"`
+	Appointment       Appointment    `gorm:"constraint:OnUpdate:CASCADE,OnDelete:CASCADE;" json:"-"`
+	Zone              string         `gorm:"size:50" json:"zone"` // Raíz, medios, puntas... (opcional)
+	Brand             string         `gorm:"size:100;not null" json:"brand"`
+	Shade             string         `gorm:"size:50;not null" json:"shade"`
+	DeveloperVolume   uint           `json:"developer_volume"` // Volúmenes del oxidante; 0 sin oxidante
+	Grams             float64        `gorm:"not null" json:"grams"`
+	ProcessingMinutes uint           `json:"processing_minutes"`
+	Notes             string         `gorm:"size:255" json:"notes"`
+	AuthorID          *uint          `json:"author_id"`
+	Author            *User          `gorm:"constraint:OnUpdate:CASCADE,OnDelete:SET NULL;" json:"author,omitempty"`
+	CreatedAt         time.Time      `json:"created_at"`
+	UpdatedAt         time.Time      `json:"updated_at"`
+	DeletedAt         gorm.DeletedAt `gorm:"index" json:"-" swag:"-"`
+}
+
+// AppointmentPhoto es una foto del antes o el después del turno. El archivo y su
+// miniatura viven en el almacenamiento configurado, bajo las claves guardadas.
+type AppointmentPhoto struct {
+	ID            uint           `gorm:"primaryKey" json:"id"`
+	AppointmentID uint           `gorm:"not null;index" json:"appointment_id"`
+	Appointment   Appointment    `gorm:"constraint:OnUpdate:CASCADE,OnDelete:CASCADE;" json:"-"`
+	Kind          string         `gorm:"size:20;not null" json:"kind"` // Ver PhotoKind*
+	Caption       string         `gorm:"size:255" json:"caption"`
+	StorageKey    string         `gorm:"size:255;not null" json:"-"`
+	ThumbnailKey  string         `gorm:"size:255;not null" json:"-"`
+	ContentType   string         `gorm:"size:50;not null" json:"content_type"`
+	Size          int64          `gorm:"not null" json:"size"`
+	Width         int            `json:"width"`
+	Height        int            `json:"height"`
+	UploadedByID  *uint          `json:"uploaded_by_id"`
+	UploadedBy    *User          `gorm:"constraint:OnUpdate:CASCADE,OnDelete:SET NULL;" json:"uploaded_by,omitempty"`
+	CreatedAt     time.Time      `json:"created_at"`
+	DeletedAt     gorm.DeletedAt `gorm:"index" json:"-" swag:"-"`
+}
@@ -0,0 +1,22 @@
+package models
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// AppointmentSeries agrupa los turnos generados por una regla de repetición.
+type AppointmentSeries struct {
+	ID           uint           `gorm:"primaryKey" json:"id"`
+	ClientID     uint           `gorm:"not null;index" json:"client_id"`
+	Client       Client         `gorm:"constraint:OnUpdate:CASCADE,OnDelete:CASCADE;" json:"-"`
+	Frequency    string         `gorm:"size:20;not null" json:"frequency"` // "semanal" o "mensual"
+	Interval     uint           `gorm:"not null" json:"interval"`          // Cada cuántas semanas o meses
+	Count        uint           `json:"count"`                             // Cantidad de turnos (0 si se usa UntilDate)
+	UntilDate    *time.Time     `json:"until_date"`                        // Fecha límite (opcional)
+	Appointments []Appointment  `gorm:"foreignKey:SeriesID" json:"appointments,omitempty"`
+	CreatedAt    time.Time      `json:"created_at"`
+	UpdatedAt    time.Time      `json:"updated_at"`
+	DeletedAt    gorm.DeletedAt `gorm:"index" json:"-" swag:"-"`
+}
@@ -0,0 +1,27 @@
+package models
+
+import "time"
+
+// Estados posibles de un turno.
+const (
+	AppointmentStatusRequested  = "solicitado" // Reserva online a la espera del salón
+	AppointmentStatusPending    = "pendiente"
+	AppointmentStatusConfirmed  = "confirmado"
+	AppointmentStatusInProgress = "en_curso"
+	AppointmentStatusFinished   = "finalizado"
+	AppointmentStatusCancelled  = "cancelado"
+	AppointmentStatusNoShow     = "ausente"
+)
+
+// AppointmentStatusHistory registra cada cambio de estado de un turno.
+type AppointmentStatusHistory struct {
+	ID            uint        `gorm:"primaryKey" json:"id"`
+	AppointmentID uint        `gorm:"not null;index" json:"appointment_id"`
+	Appointment   Appointment `gorm:"constraint:OnUpdate:CASCADE,OnDelete:CASCADE;" json:"-"`
+	FromStatus    string      `gorm:"size:50" json:"from_status"`
+	ToStatus      string      `gorm:"size:50;not null" json:"to_status"`
+	Reason        string      `gorm:"size:255" json:"reason"`
+	UserID        *uint       `json:"user_id"` // Usuario que realizó el cambio
+	User          *User       `gorm:"constraint:OnUpdate:CASCADE,OnDelete:SET NULL;" json:"user,omitempty"`
+	CreatedAt     time.Time   `json:"created_at"`
+}
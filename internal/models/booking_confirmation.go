@@ -0,0 +1,17 @@
+package models
+
+import "time"
+
+// BookingConfirmation guarda el código de un solo uso con el que el cliente valida una
+// reserva hecha desde la web. Solo se almacena el hash del código.
+type BookingConfirmation struct {
+	ID            uint        `gorm:"primaryKey" json:"id"`
+	AppointmentID uint        `gorm:"not null;uniqueIndex" json:"appointment_id"`
+	Appointment   Appointment `gorm:"constraint:OnUpdate:CASCADE,OnDelete:CASCADE;" json:"-"`
+	ClientID      *uint       `json:"client_id"` // Cliente existente con el mismo teléfono; el turno pasa a él al validar el código
+	CodeHash      string      `gorm:"size:64;not null" json:"-"`
+	ExpiresAt     time.Time   `gorm:"not null;index" json:"expires_at"`
+	ConfirmedAt   *time.Time  `json:"confirmed_at"`
+	Attempts      int         `gorm:"not null;default:0" json:"attempts"`
+	CreatedAt     time.Time   `json:"created_at"`
+}
@@ -0,0 +1,32 @@
+package models
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// BusinessHour es una franja de atención de un día de la semana. Un mismo día puede
+// tener varias franjas (horario cortado).
+type BusinessHour struct {
+	ID        uint           `gorm:"primaryKey" json:"id"`
+	Weekday   int            `gorm:"not null;index" json:"weekday"`     // 0 = domingo ... 6 = sábado
+	OpenTime  string         `gorm:"size:5;not null" json:"open_time"`  // HH:MM
+	CloseTime string         `gorm:"size:5;not null" json:"close_time"` // HH:MM
+	CreatedAt time.Time      `json:"created_at"`
+	UpdatedAt time.Time      `json:"updated_at"`
+	DeletedAt gorm.DeletedAt `gorm:"index" json:"-" swag:"-"`
+}
+
+// Closure es un feriado o cierre eventual del salón, de uno o más días completos.
+type Closure struct {
+	ID        uint           `gorm:"primaryKey" json:"id"`
+	StartDate time.Time      `gorm:"type:date;not null;index" json:"start_date"`
+	EndDate   time.Time      `gorm:"type:date;not null;index" json:"end_date"`
+	Kind      string         `gorm:"size:50;not null" json:"kind"` // Ej: "feriado", "cierre"
+	Reason    string         `gorm:"size:255" json:"reason"`
+	Recurring bool           `gorm:"not null;default:false" json:"recurring"` // Se repite todos los años
+	CreatedAt time.Time      `json:"created_at"`
+	UpdatedAt time.Time      `json:"updated_at"`
+	DeletedAt gorm.DeletedAt `gorm:"index" json:"-" swag:"-"`
+}
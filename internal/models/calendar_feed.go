@@ -0,0 +1,13 @@
+package models
+
+import "time"
+
+// CalendarFeed es una suscripción iCal protegida por un token secreto. Sin estilista es
+// el calendario de todo el salón.
+type CalendarFeed struct {
+	ID        uint      `gorm:"primaryKey" json:"id"`
+	StaffID   *uint     `gorm:"index" json:"staff_id"`
+	Staff     *Staff    `gorm:"constraint:OnUpdate:CASCADE,OnDelete:CASCADE;" json:"staff,omitempty"`
+	Token     string    `gorm:"size:64;not null;uniqueIndex" json:"-"`
+	CreatedAt time.Time `json:"created_at"`
+}
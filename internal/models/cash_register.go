@@ -0,0 +1,49 @@
+package models
+
+import "time"
+
+// Tipos de movimiento de caja.
+const (
+	CashMovementPayment    = "pago"    // Cobro en efectivo de un turno
+	CashMovementTip        = "propina" // Propina en efectivo
+	CashMovementIncome     = "ingreso" // Ingreso manual (ej: cambio traído del banco)
+	CashMovementExpense    = "gasto"   // Gasto menor pagado con la caja
+	CashMovementWithdrawal = "retiro"  // Retiro de efectivo (ej: depósito, reparto de propinas)
+)
+
+// CashSession es una sesión de caja: se abre con un fondo inicial y se cierra contando el
+// efectivo. Solo puede haber una abierta a la vez.
+type CashSession struct {
+	ID             uint           `gorm:"primaryKey" json:"id"`
+	OpenedAt       time.Time      `gorm:"not null" json:"opened_at"`
+	OpenedByID     *uint          `json:"opened_by_id"`
+	OpenedBy       *User          `gorm:"constraint:OnUpdate:CASCADE,OnDelete:SET NULL;" json:"opened_by,omitempty"`
+	OpeningFloat   float64        `gorm:"not null" json:"opening_float"` // Fondo inicial
+	ClosedAt       *time.Time     `gorm:"index" json:"closed_at"`        // Vacío mientras está abierta
+	ClosedByID     *uint          `json:"closed_by_id"`
+	ClosedBy       *User          `gorm:"constraint:OnUpdate:CASCADE,OnDelete:SET NULL;" json:"closed_by,omitempty"`
+	ExpectedAmount *float64       `json:"expected_amount"` // Fondo más movimientos, al cerrar
+	CountedAmount  *float64       `json:"counted_amount"`  // Efectivo contado al cerrar
+	Difference     *float64       `json:"difference"`      // Contado menos esperado: positivo sobra, negativo falta
+	Notes          string         `gorm:"size:255" json:"notes"`
+	Movements      []CashMovement `gorm:"foreignKey:SessionID;constraint:OnUpdate:CASCADE,OnDelete:CASCADE;" json:"movements"`
+	CreatedAt      time.Time      `json:"created_at"`
+	UpdatedAt      time.Time      `json:"updated_at"`
+}
+
+// CashMovement es una entrada o salida de efectivo de la caja. Las salidas tienen monto
+// negativo.
+type CashMovement struct {
+	ID            uint         `gorm:"primaryKey" json:"id"`
+	SessionID     uint         `gorm:"not null;index" json:"session_id"`
+	Type          string       `gorm:"size:20;not null" json:"type"` // Ver CashMovement*
+	Amount        float64      `gorm:"not null" json:"amount"`
+	Description   string       `gorm:"size:255" json:"description"`
+	AppointmentID *uint        `gorm:"index" json:"appointment_id"` // Turno del cobro o la propina
+	Appointment   *Appointment `gorm:"constraint:OnUpdate:CASCADE,OnDelete:SET NULL;" json:"-"`
+	PaymentID     *uint        `json:"payment_id"`
+	TipID         *uint        `json:"tip_id"`
+	UserID        *uint        `json:"user_id"` // Usuario que registró el movimiento
+	User          *User        `gorm:"constraint:OnUpdate:CASCADE,OnDelete:SET NULL;" json:"user,omitempty"`
+	CreatedAt     time.Time    `json:"created_at"`
+}
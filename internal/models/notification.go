@@ -0,0 +1,33 @@
+package models
+
+import "time"
+
+// Estados de una notificación en la bandeja de salida.
+const (
+	NotificationStatusPending   = "pendiente"
+	NotificationStatusSent      = "enviada"
+	NotificationStatusFailed    = "fallida"
+	NotificationStatusCancelled = "cancelada" // El turno dejó de estar vigente antes del envío
+)
+
+// Notification es un mensaje en la bandeja de salida. El worker lo envía cuando llega
+// NextAttemptAt y lo reintenta con espera creciente si falla. Los recordatorios guardan la
+// fecha del turno para la que se programaron: si el turno se reprograma, se encola uno nuevo.
+type Notification struct {
+	ID            uint         `gorm:"primaryKey" json:"id"`
+	AppointmentID *uint        `gorm:"uniqueIndex:idx_notification_dedupe" json:"appointment_id"`
+	Appointment   *Appointment `gorm:"constraint:OnUpdate:CASCADE,OnDelete:CASCADE;" json:"-"`
+	Kind          string       `gorm:"size:50;not null;uniqueIndex:idx_notification_dedupe" json:"kind"` // Ej: recordatorio_24h
+	Channel       string       `gorm:"size:20;not null;uniqueIndex:idx_notification_dedupe" json:"channel"`
+	ScheduledFor  *time.Time   `gorm:"uniqueIndex:idx_notification_dedupe" json:"scheduled_for"` // Fecha del turno al programar el recordatorio
+	Recipient     string       `gorm:"size:100;not null" json:"recipient"`
+	Subject       string       `gorm:"size:255" json:"subject"`
+	Body          string       `gorm:"type:text" json:"body"`
+	Status        string       `gorm:"size:20;not null;index" json:"status"`
+	Attempts      int          `gorm:"not null;default:0" json:"attempts"`
+	NextAttemptAt time.Time    `gorm:"not null;index" json:"next_attempt_at"`
+	LastError     string       `gorm:"size:500" json:"last_error"`
+	SentAt        *time.Time   `json:"sent_at"`
+	CreatedAt     time.Time    `json:"created_at"`
+	UpdatedAt     time.Time    `json:"updated_at"`
+}
@@ -0,0 +1,36 @@
+package models
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// PaymentMethod es un medio de pago del catálogo (efectivo, débito, transferencia...).
+type PaymentMethod struct {
+	ID                uint           `gorm:"primaryKey" json:"id"`
+	Code              string         `gorm:"size:50;not null;uniqueIndex" json:"code"` // Ej: efectivo, debito
+	Name              string         `gorm:"size:100;not null" json:"name"`
+	IsCash            bool           `gorm:"not null;default:false" json:"is_cash"`            // Entra a la caja del salón
+	RequiresReference bool           `gorm:"not null;default:false" json:"requires_reference"` // Ej: número de operación de una transferencia
+	Active            bool           `gorm:"not null;default:true" json:"active"`
+	CreatedAt         time.Time      `json:"created_at"`
+	UpdatedAt         time.Time      `json:"updated_at"`
+	DeletedAt         gorm.DeletedAt `gorm:"index" json:"-" swag:"-"`
+}
+
+// Payment es un pago registrado para un turno. Un turno puede pagarse con varios medios.
+type Payment struct {
+	ID            uint          `gorm:"primaryKey" json:"id"`
+	AppointmentID uint          `gorm:"not null;index" json:"appointment_id"`
+	Appointment   Appointment   `gorm:"constraint:OnUpdate:CASCADE,OnDelete:CASCADE;" json:"-"`
+	MethodID      uint          `gorm:"not null;index" json:"method_id"`
+	Method        PaymentMethod `gorm:"constraint:OnUpdate:CASCADE,OnDelete:RESTRICT;" json:"method"`
+	Amount        float64       `gorm:"not null" json:"amount"`
+	Reference     string        `gorm:"size:100" json:"reference"` // Comprobante o número de operación
+	PaidAt        time.Time     `gorm:"not null;index" json:"paid_at"`
+	CashSessionID *uint         `gorm:"index" json:"cash_session_id"` // Caja en la que entró, si fue en efectivo
+	UserID        *uint         `json:"user_id"`                      // Usuario que registró el pago
+	User          *User         `gorm:"constraint:OnUpdate:CASCADE,OnDelete:SET NULL;" json:"user,omitempty"`
+	CreatedAt     time.Time     `json:"created_at"`
+}
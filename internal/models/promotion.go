@@ -0,0 +1,55 @@
+package models
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// Tipos de descuento de una promoción.
+const (
+	DiscountTypePercent = "porcentaje"
+	DiscountTypeFixed   = "monto"
+)
+
+// A qué se aplica una promoción.
+const (
+	PromotionScopeAppointment = "turno"     // Todos los servicios del turno
+	PromotionScopeService     = "servicio"  // Un servicio puntual
+	PromotionScopeCategory    = "categoria" // Los servicios de una categoría
+)
+
+// Promotion es un descuento que se aplica al finalizar un turno. Si tiene código es un
+// cupón y solo se aplica presentándolo.
+type Promotion struct {
+	ID               uint           `gorm:"primaryKey" json:"id"`
+	Name             string         `gorm:"size:100;not null" json:"name"`
+	Description      string         `gorm:"size:255" json:"description"`
+	Code             *string        `gorm:"size:50;uniqueIndex" json:"code"`       // Código del cupón (opcional)
+	DiscountType     string         `gorm:"size:20;not null" json:"discount_type"` // Ver DiscountType*
+	Value            float64        `gorm:"not null" json:"value"`                 // Porcentaje o monto según el tipo
+	Scope            string         `gorm:"size:20;not null" json:"scope"`         // Ver PromotionScope*
+	ServiceID        *uint          `gorm:"index" json:"service_id"`               // Servicio alcanzado, si el alcance es "servicio"
+	Service          *Service       `gorm:"constraint:OnUpdate:CASCADE,OnDelete:SET NULL;" json:"service,omitempty"`
+	Category         string         `gorm:"size:50" json:"category"` // Categoría alcanzada, si el alcance es "categoria"
+	StartsOn         *time.Time     `json:"starts_on"`               // Primer día de validez (opcional)
+	EndsOn           *time.Time     `json:"ends_on"`                 // Último día de validez (opcional)
+	MaxUses          *uint          `json:"max_uses"`                // Usos totales permitidos (opcional)
+	MaxUsesPerClient *uint          `json:"max_uses_per_client"`     // Usos permitidos por cliente (opcional)
+	Active           bool           `gorm:"not null;default:true" json:"active"`
+	CreatedAt        time.Time      `json:"created_at"`
+	UpdatedAt        time.Time      `json:"updated_at"`
+	DeletedAt        gorm.DeletedAt `gorm:"index" json:"-" swag:"-"`
+}
+
+// PromotionUse registra cada aplicación de una promoción, para controlar los límites de uso.
+type PromotionUse struct {
+	ID            uint        `gorm:"primaryKey" json:"id"`
+	PromotionID   uint        `gorm:"not null;index" json:"promotion_id"`
+	Promotion     Promotion   `gorm:"constraint:OnUpdate:CASCADE,OnDelete:CASCADE;" json:"-"`
+	AppointmentID uint        `gorm:"not null;index" json:"appointment_id"`
+	Appointment   Appointment `gorm:"constraint:OnUpdate:CASCADE,OnDelete:CASCADE;" json:"-"`
+	ClientID      uint        `gorm:"not null;index" json:"client_id"`
+	Amount        float64     `gorm:"not null" json:"amount"` // Descuento total aplicado en el turno
+	CreatedAt     time.Time   `json:"created_at"`
+}
@@ -0,0 +1,20 @@
+package models
+
+import "time"
+
+// Receipt es el comprobante emitido para un turno finalizado. El número es correlativo y
+// no se reutiliza.
+type Receipt struct {
+	ID            uint        `gorm:"primaryKey" json:"id"`
+	Number        uint        `gorm:"not null;uniqueIndex" json:"number"`
+	AppointmentID uint        `gorm:"not null;uniqueIndex" json:"appointment_id"`
+	Appointment   Appointment `gorm:"constraint:OnUpdate:CASCADE,OnDelete:RESTRICT;" json:"-"`
+	IssuedAt      time.Time   `gorm:"not null" json:"issued_at"`
+	CreatedAt     time.Time   `json:"created_at"`
+}
+
+// ReceiptSequence guarda el último número de comprobante emitido. Tiene una sola fila.
+type ReceiptSequence struct {
+	ID         uint `gorm:"primaryKey" json:"id"`
+	LastNumber uint `gorm:"not null;default:0" json:"last_number"`
+}
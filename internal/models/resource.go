@@ -0,0 +1,39 @@
+package models
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// Resource es un recurso físico del salón que los servicios ocupan (un sillón, un lavacabezas).
+// Los recursos del mismo tipo son intercambiables: su cantidad es la capacidad del salón.
+type Resource struct {
+	ID        uint           `gorm:"primaryKey" json:"id"`
+	Name      string         `gorm:"size:100;not null" json:"name"`
+	Type      string         `gorm:"size:50;not null;index" json:"type"` // Ej: sillon, lavacabezas
+	Active    bool           `gorm:"not null;default:true" json:"active"`
+	CreatedAt time.Time      `json:"created_at"`
+	UpdatedAt time.Time      `json:"updated_at"`
+	DeletedAt gorm.DeletedAt `gorm:"index" json:"-" swag:"-"`
+}
+
+// ServiceResource indica que un servicio necesita un recurso del tipo dado durante un tramo,
+// medido desde el inicio del servicio.
+type ServiceResource struct {
+	ID              uint   `gorm:"primaryKey" json:"id"`
+	ServiceID       uint   `gorm:"not null;index" json:"service_id"`
+	ResourceType    string `gorm:"size:50;not null" json:"resource_type"`
+	OffsetMinutes   uint   `gorm:"not null;default:0" json:"offset_minutes"`
+	DurationMinutes uint   `gorm:"not null" json:"duration_minutes"`
+}
+
+// AppointmentResource es la asignación de un recurso concreto a un turno durante un tramo.
+type AppointmentResource struct {
+	ID            uint      `gorm:"primaryKey" json:"id"`
+	AppointmentID uint      `gorm:"not null;index" json:"appointment_id"`
+	ResourceID    uint      `gorm:"not null;index:idx_resource_start" json:"resource_id"`
+	Resource      Resource  `gorm:"constraint:OnUpdate:CASCADE,OnDelete:CASCADE;" json:"resource"`
+	StartTime     time.Time `gorm:"not null;index:idx_resource_start" json:"start_time"`
+	EndTime       time.Time `gorm:"not null" json:"end_time"`
+}
@@ -7,12 +7,18 @@ import (
 )
 
 type Service struct {
-	ID                   uint           `gorm:"primaryKey" json:"id"`
-	Name                 string         `gorm:"size:100;not null" json:"name"`
-	Description          string         `gorm:"size:255" json:"description"`
-	Price                float64        `gorm:"not null" json:"price"`
-	EstimatedTimeMinutes uint           `gorm:"not null" json:"estimated_time"`
-	CreatedAt            time.Time      `json:"created_at"`
-	UpdatedAt            time.Time      `json:"updated_at"`
-	DeletedAt            gorm.DeletedAt `gorm:"index" json:"-" swag:"-"`
+	ID                    uint              `gorm:"primaryKey" json:"id"`
+	Name                  string            `gorm:"size:100;not null" json:"name"`
+	Description           string            `gorm:"size:255" json:"description"`
+	Category              string            `gorm:"size:50;index" json:"category"`                                  // Ej: corte, color, tratamiento; la usan las promociones
+	Price                 float64           `gorm:"not null" json:"price"`                                          // Precio base; las variantes lo reemplazan
+	EstimatedTimeMinutes  uint              `gorm:"not null" json:"estimated_time"`                                 // Tiempo activo: el estilista está ocupado
+	ProcessingTimeMinutes uint              `gorm:"not null;default:0" json:"processing_time"`                      // Espera (ej: tintura actuando), el estilista queda libre
+	BufferTimeMinutes     uint              `gorm:"not null;default:0" json:"buffer_time"`                          // Limpieza posterior, el estilista está ocupado
+	Resources             []ServiceResource `gorm:"constraint:OnUpdate:CASCADE,OnDelete:CASCADE;" json:"resources"` // Recursos que ocupa el servicio
+	Variants              []ServiceVariant  `gorm:"constraint:OnUpdate:CASCADE,OnDelete:CASCADE;" json:"variants"`  // Variantes de precio (ej: largo del pelo)
+	Options               []ServiceOption   `gorm:"constraint:OnUpdate:CASCADE,OnDelete:CASCADE;" json:"options"`   // Adicionales
+	CreatedAt             time.Time         `json:"created_at"`
+	UpdatedAt             time.Time         `json:"updated_at"`
+	DeletedAt             gorm.DeletedAt    `gorm:"index" json:"-" swag:"-"`
 }
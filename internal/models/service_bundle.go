@@ -0,0 +1,32 @@
+package models
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// ServiceBundle es un combo de servicios que se vende con precio y duración propios.
+type ServiceBundle struct {
+	ID              uint                `gorm:"primaryKey" json:"id"`
+	Name            string              `gorm:"size:100;not null" json:"name"`
+	Description     string              `gorm:"size:255" json:"description"`
+	Price           float64             `gorm:"not null" json:"price"`            // Precio del combo; el descuento se reparte entre sus servicios
+	DurationMinutes uint                `gorm:"not null" json:"duration_minutes"` // Duración total, incluidas esperas y limpieza
+	Active          bool                `gorm:"not null;default:true" json:"active"`
+	Items           []ServiceBundleItem `gorm:"foreignKey:BundleID;constraint:OnUpdate:CASCADE,OnDelete:CASCADE;" json:"items"`
+	CreatedAt       time.Time           `json:"created_at"`
+	UpdatedAt       time.Time           `json:"updated_at"`
+	DeletedAt       gorm.DeletedAt      `gorm:"index" json:"-" swag:"-"`
+}
+
+// ServiceBundleItem es un servicio del combo, en el orden en que se realiza.
+type ServiceBundleItem struct {
+	ID        uint      `gorm:"primaryKey" json:"id"`
+	BundleID  uint      `gorm:"not null;index" json:"bundle_id"`
+	ServiceID uint      `gorm:"not null" json:"service_id"`
+	Service   Service   `gorm:"constraint:OnUpdate:CASCADE,OnDelete:CASCADE;" json:"service"`
+	Position  uint      `gorm:"not null;default:0" json:"position"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
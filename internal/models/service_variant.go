@@ -0,0 +1,45 @@
+package models
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// ServiceVariant es una variante de precio de un servicio (ej: pelo corto, medio o largo).
+// Se elige una por línea de servicio al reservar.
+type ServiceVariant struct {
+	ID                   uint           `gorm:"primaryKey" json:"id"`
+	ServiceID            uint           `gorm:"not null;index" json:"service_id"`
+	Name                 string         `gorm:"size:100;not null" json:"name"`
+	Price                float64        `gorm:"not null" json:"price"`
+	EstimatedTimeMinutes *uint          `json:"estimated_time_minutes"` // Tiempo activo de la variante; vacío usa el del servicio
+	CreatedAt            time.Time      `json:"created_at"`
+	UpdatedAt            time.Time      `json:"updated_at"`
+	DeletedAt            gorm.DeletedAt `gorm:"index" json:"-" swag:"-"`
+}
+
+// ServiceOption es un adicional que se suma a un servicio (ej: producto extra, matizador).
+type ServiceOption struct {
+	ID           uint           `gorm:"primaryKey" json:"id"`
+	ServiceID    uint           `gorm:"not null;index" json:"service_id"`
+	Name         string         `gorm:"size:100;not null" json:"name"`
+	Price        float64        `gorm:"not null;default:0" json:"price"`         // Se suma al precio del servicio
+	ExtraMinutes uint           `gorm:"not null;default:0" json:"extra_minutes"` // Se suman al tiempo activo
+	CreatedAt    time.Time      `json:"created_at"`
+	UpdatedAt    time.Time      `json:"updated_at"`
+	DeletedAt    gorm.DeletedAt `gorm:"index" json:"-" swag:"-"`
+}
+
+// AppointmentServiceOption es un adicional elegido en una línea de servicio del turno. Guarda
+// nombre, precio y minutos del momento de la reserva.
+type AppointmentServiceOption struct {
+	ID                   uint           `gorm:"primaryKey" json:"id"`
+	AppointmentServiceID uint           `gorm:"not null;index" json:"appointment_service_id"`
+	OptionID             uint           `gorm:"not null" json:"option_id"`
+	Option               *ServiceOption `gorm:"constraint:OnUpdate:CASCADE,OnDelete:CASCADE;" json:"option,omitempty"`
+	Name                 string         `gorm:"size:100;not null" json:"name"`
+	Price                float64        `gorm:"not null" json:"price"`
+	ExtraMinutes         uint           `gorm:"not null;default:0" json:"extra_minutes"`
+	CreatedAt            time.Time      `json:"created_at"`
+}
@@ -0,0 +1,21 @@
+package models
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+)
+
+type Staff struct {
+	ID        uint           `gorm:"primaryKey" json:"id"`
+	Name      string         `gorm:"size:100;not null" json:"name"`
+	LastName  string         `gorm:"size:100" json:"last_name"`
+	Phone     string         `gorm:"size:15" json:"phone"`
+	UserID    *uint          `gorm:"uniqueIndex" json:"user_id"` // Usuario de login asociado (opcional)
+	User      *User          `gorm:"constraint:OnUpdate:CASCADE,OnDelete:SET NULL;" json:"user,omitempty"`
+	Active    bool           `gorm:"not null;default:true" json:"active"`
+	Skills    []StaffService `gorm:"constraint:OnUpdate:CASCADE,OnDelete:CASCADE;" json:"skills"` // Servicios que realiza; sin cargar, realiza todos
+	CreatedAt time.Time      `json:"created_at"`
+	UpdatedAt time.Time      `json:"updated_at"`
+	DeletedAt gorm.DeletedAt `gorm:"index" json:"-" swag:"-"`
+}
@@ -0,0 +1,63 @@
+package models
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// StaffShift es una franja de trabajo semanal de un empleado. Un día puede tener varias
+// franjas (turno cortado). Un empleado sin franjas cargadas trabaja en todo el horario del salón.
+type StaffShift struct {
+	ID        uint           `gorm:"primaryKey" json:"id"`
+	StaffID   uint           `gorm:"not null;index" json:"staff_id"`
+	Staff     Staff          `gorm:"constraint:OnUpdate:CASCADE,OnDelete:CASCADE;" json:"-"`
+	Weekday   int            `gorm:"not null" json:"weekday"`           // 0 = domingo ... 6 = sábado
+	StartTime string         `gorm:"size:5;not null" json:"start_time"` // HH:MM
+	EndTime   string         `gorm:"size:5;not null" json:"end_time"`   // HH:MM
+	CreatedAt time.Time      `json:"created_at"`
+	UpdatedAt time.Time      `json:"updated_at"`
+	DeletedAt gorm.DeletedAt `gorm:"index" json:"-" swag:"-"`
+}
+
+// StaffScheduleException reemplaza las franjas semanales de un empleado en una fecha puntual.
+// Sin horario indica que ese día no trabaja.
+type StaffScheduleException struct {
+	ID        uint           `gorm:"primaryKey" json:"id"`
+	StaffID   uint           `gorm:"not null;index" json:"staff_id"`
+	Staff     Staff          `gorm:"constraint:OnUpdate:CASCADE,OnDelete:CASCADE;" json:"-"`
+	Date      time.Time      `gorm:"type:date;not null;index" json:"date"`
+	StartTime string         `gorm:"size:5" json:"start_time"` // HH:MM (vacío = franco)
+	EndTime   string         `gorm:"size:5" json:"end_time"`   // HH:MM (vacío = franco)
+	Reason    string         `gorm:"size:255" json:"reason"`
+	CreatedAt time.Time      `json:"created_at"`
+	UpdatedAt time.Time      `json:"updated_at"`
+	DeletedAt gorm.DeletedAt `gorm:"index" json:"-" swag:"-"`
+}
+
+// Estados de una solicitud de licencia.
+const (
+	TimeOffStatusPending  = "pendiente"
+	TimeOffStatusApproved = "aprobada"
+	TimeOffStatusRejected = "rechazada"
+)
+
+// TimeOff es una licencia de días completos (vacaciones, enfermedad). Solo las aprobadas
+// quitan al empleado de la agenda.
+type TimeOff struct {
+	ID            uint           `gorm:"primaryKey" json:"id"`
+	StaffID       uint           `gorm:"not null;index" json:"staff_id"`
+	Staff         Staff          `gorm:"constraint:OnUpdate:CASCADE,OnDelete:CASCADE;" json:"staff"`
+	Kind          string         `gorm:"size:20;not null" json:"kind"` // Ej: vacaciones, enfermedad
+	StartDate     time.Time      `gorm:"type:date;not null;index" json:"start_date"`
+	EndDate       time.Time      `gorm:"type:date;not null;index" json:"end_date"`
+	Reason        string         `gorm:"size:255" json:"reason"`
+	Status        string         `gorm:"size:20;not null;index" json:"status"`
+	RequestedByID *uint          `json:"requested_by_id"`
+	ReviewedByID  *uint          `json:"reviewed_by_id"`
+	ReviewedAt    *time.Time     `json:"reviewed_at"`
+	ReviewNote    string         `gorm:"size:255" json:"review_note"`
+	CreatedAt     time.Time      `json:"created_at"`
+	UpdatedAt     time.Time      `json:"updated_at"`
+	DeletedAt     gorm.DeletedAt `gorm:"index" json:"-" swag:"-"`
+}
@@ -0,0 +1,17 @@
+package models
+
+import "time"
+
+// StaffService indica que un empleado realiza un servicio. Precio y duración son opcionales
+// y reemplazan a los del servicio para ese estilista (ej: un estilista senior cobra más y
+// trabaja más rápido).
+type StaffService struct {
+	StaffID              uint      `gorm:"primaryKey" json:"staff_id"`
+	Staff                Staff     `gorm:"constraint:OnUpdate:CASCADE,OnDelete:CASCADE;" json:"-"`
+	ServiceID            uint      `gorm:"primaryKey" json:"service_id"`
+	Service              Service   `gorm:"constraint:OnUpdate:CASCADE,OnDelete:CASCADE;" json:"service"`
+	Price                *float64  `json:"price"`                  // Precio propio del estilista (opcional)
+	EstimatedTimeMinutes *uint     `json:"estimated_time_minutes"` // Tiempo activo propio del estilista (opcional)
+	CreatedAt            time.Time `json:"created_at"`
+	UpdatedAt            time.Time `json:"updated_at"`
+}
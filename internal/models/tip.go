@@ -0,0 +1,21 @@
+package models
+
+import "time"
+
+// Tip es una propina dejada en un turno para un estilista. Se registra aparte de los pagos
+// porque no es ingreso del salón.
+type Tip struct {
+	ID            uint          `gorm:"primaryKey" json:"id"`
+	AppointmentID uint          `gorm:"not null;index" json:"appointment_id"`
+	Appointment   Appointment   `gorm:"constraint:OnUpdate:CASCADE,OnDelete:CASCADE;" json:"-"`
+	StaffID       uint          `gorm:"not null;index" json:"staff_id"` // Estilista que recibe la propina
+	Staff         Staff         `gorm:"constraint:OnUpdate:CASCADE,OnDelete:RESTRICT;" json:"staff"`
+	MethodID      uint          `gorm:"not null;index" json:"method_id"`
+	Method        PaymentMethod `gorm:"constraint:OnUpdate:CASCADE,OnDelete:RESTRICT;" json:"method"`
+	Amount        float64       `gorm:"not null" json:"amount"`
+	PaidAt        time.Time     `gorm:"not null;index" json:"paid_at"`
+	CashSessionID *uint         `gorm:"index" json:"cash_session_id"` // Caja en la que entró, si fue en efectivo
+	UserID        *uint         `json:"user_id"`                      // Usuario que registró la propina
+	User          *User         `gorm:"constraint:OnUpdate:CASCADE,OnDelete:SET NULL;" json:"user,omitempty"`
+	CreatedAt     time.Time     `json:"created_at"`
+}
@@ -0,0 +1,46 @@
+package models
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// Estados de una entrada de la lista de espera.
+const (
+	WaitlistStatusActive    = "activa"
+	WaitlistStatusServed    = "atendida"
+	WaitlistStatusCancelled = "cancelada"
+)
+
+// WaitlistEntry es un cliente esperando que se libere un horario.
+type WaitlistEntry struct {
+	ID                 uint           `gorm:"primaryKey" json:"id"`
+	ClientID           uint           `gorm:"not null;index" json:"client_id"`
+	Client             Client         `gorm:"constraint:OnUpdate:CASCADE,OnDelete:CASCADE;" json:"client"`
+	StaffID            *uint          `gorm:"index" json:"staff_id"` // Estilista preferido (opcional)
+	Staff              *Staff         `gorm:"constraint:OnUpdate:CASCADE,OnDelete:SET NULL;" json:"staff,omitempty"`
+	Services           []Service      `gorm:"many2many:waitlist_entry_services;" json:"services"`
+	DesiredFrom        time.Time      `gorm:"type:date;not null" json:"desired_from"`
+	DesiredTo          time.Time      `gorm:"type:date;not null" json:"desired_to"`
+	PreferredStartTime string         `gorm:"size:5" json:"preferred_start_time"` // HH:MM (opcional)
+	PreferredEndTime   string         `gorm:"size:5" json:"preferred_end_time"`   // HH:MM (opcional)
+	Notes              string         `gorm:"size:255" json:"notes"`
+	Status             string         `gorm:"size:20;not null;index" json:"status"`
+	CreatedAt          time.Time      `json:"created_at"`
+	UpdatedAt          time.Time      `json:"updated_at"`
+	DeletedAt          gorm.DeletedAt `gorm:"index" json:"-" swag:"-"`
+}
+
+// WaitlistMatch registra un horario liberado que le sirve a una entrada de la lista de espera.
+type WaitlistMatch struct {
+	ID                 uint          `gorm:"primaryKey" json:"id"`
+	WaitlistEntryID    uint          `gorm:"not null;index" json:"waitlist_entry_id"`
+	WaitlistEntry      WaitlistEntry `gorm:"constraint:OnUpdate:CASCADE,OnDelete:CASCADE;" json:"waitlist_entry"`
+	FreedAppointmentID uint          `gorm:"not null" json:"freed_appointment_id"` // Turno cancelado o movido
+	StaffID            *uint         `json:"staff_id"`
+	SlotStart          time.Time     `gorm:"not null" json:"slot_start"`
+	SlotEnd            time.Time     `gorm:"not null" json:"slot_end"`
+	Dismissed          bool          `gorm:"not null;default:false" json:"dismissed"`
+	CreatedAt          time.Time     `json:"created_at"`
+}
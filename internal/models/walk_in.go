@@ -0,0 +1,39 @@
+package models
+
+import "time"
+
+// Estados de un ticket de la fila de clientes sin turno.
+const (
+	WalkInStatusWaiting   = "esperando"
+	WalkInStatusCalled    = "llamado"
+	WalkInStatusServed    = "atendido"
+	WalkInStatusSkipped   = "salteado"
+	WalkInStatusCancelled = "cancelado"
+)
+
+// WalkIn es un ticket de la fila de clientes que llegan sin turno. Al atenderlo se
+// convierte en un Appointment.
+type WalkIn struct {
+	ID            uint         `gorm:"primaryKey" json:"id"`
+	QueueDate     time.Time    `gorm:"type:date;not null;uniqueIndex:idx_walk_in_ticket" json:"queue_date"`
+	TicketNumber  uint         `gorm:"not null;uniqueIndex:idx_walk_in_ticket" json:"ticket_number"` // Se reinicia cada día
+	Position      int          `gorm:"not null" json:"position"`
+	ClientID      uint         `gorm:"not null;index" json:"client_id"`
+	Client        Client       `gorm:"constraint:OnUpdate:CASCADE,OnDelete:CASCADE;" json:"client"`
+	StaffID       *uint        `gorm:"index" json:"staff_id"` // Estilista preferido (opcional)
+	Staff         *Staff       `gorm:"constraint:OnUpdate:CASCADE,OnDelete:SET NULL;" json:"staff,omitempty"`
+	Services      []Service    `gorm:"many2many:walk_in_services;" json:"services"`
+	Status        string       `gorm:"size:20;not null;index" json:"status"`
+	AppointmentID *uint        `json:"appointment_id"` // Turno creado al atenderlo
+	Appointment   *Appointment `gorm:"constraint:OnUpdate:CASCADE,OnDelete:SET NULL;" json:"-"`
+	CalledAt      *time.Time   `json:"called_at"`
+	CreatedAt     time.Time    `json:"created_at"`
+	UpdatedAt     time.Time    `json:"updated_at"`
+}
+
+// WalkInSequence guarda el último número de ticket entregado en el día. Su fila se bloquea
+// al sacar un ticket, así dos llegadas simultáneas nunca reciben el mismo número.
+type WalkInSequence struct {
+	QueueDate  time.Time `gorm:"type:date;primaryKey" json:"queue_date"`
+	LastNumber uint      `gorm:"not null;default:0" json:"last_number"`
+}
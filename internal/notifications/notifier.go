@@ -0,0 +1,56 @@
+package notifications
+
+import (
+	"context"
+	"os"
+	"strconv"
+	"time"
+)
+
+// Canales de envío soportados.
+const (
+	ChannelEmail   = "email"
+	ChannelWebhook = "webhook"
+)
+
+// Message es una notificación lista para enviar por un canal.
+type Message struct {
+	To      string `json:"to"` // Email o teléfono según el canal
+	Subject string `json:"subject"`
+	Body    string `json:"body"`
+}
+
+// Notifier envía mensajes por un canal concreto.
+type Notifier interface {
+	Send(ctx context.Context, msg Message) error
+}
+
+// FromEnv arma los canales configurados: email si está SMTP_HOST y webhook si está
+// NOTIFY_WEBHOOK_URL. Un canal sin configurar no se usa.
+func FromEnv() map[string]Notifier {
+	notifiers := make(map[string]Notifier)
+
+	if host := os.Getenv("SMTP_HOST"); host != "" {
+		port, err := strconv.Atoi(os.Getenv("SMTP_PORT"))
+		if err != nil || port <= 0 {
+			port = 25
+		}
+		notifiers[ChannelEmail] = &SMTPNotifier{
+			Host:     host,
+			Port:     port,
+			Username: os.Getenv("SMTP_USERNAME"),
+			Password: os.Getenv("SMTP_PASSWORD"),
+			From:     os.Getenv("SMTP_FROM"),
+		}
+	}
+
+	if url := os.Getenv("NOTIFY_WEBHOOK_URL"); url != "" {
+		notifiers[ChannelWebhook] = &WebhookNotifier{
+			URL:     url,
+			Token:   os.Getenv("NOTIFY_WEBHOOK_TOKEN"),
+			Timeout: 10 * time.Second,
+		}
+	}
+
+	return notifiers
+}
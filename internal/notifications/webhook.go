@@ -0,0 +1,63 @@
+package notifications
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+)
+
+// WebhookNotifier publica el mensaje como JSON en una URL, por ejemplo la de un
+// gateway de SMS o WhatsApp.
+type WebhookNotifier struct {
+	URL     string
+	Token   string // Se envía como Bearer si está definido
+	Client  *http.Client
+	Timeout time.Duration
+}
+
+func (n *WebhookNotifier) Send(ctx context.Context, msg Message) error {
+	if msg.To == "" {
+		return errors.New("destinatario vacío")
+	}
+
+	payload, err := json.Marshal(msg)
+	if err != nil {
+		return err
+	}
+
+	if n.Timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, n.Timeout)
+		defer cancel()
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, n.URL, bytes.NewReader(payload))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	if n.Token != "" {
+		req.Header.Set("Authorization", "Bearer "+n.Token)
+	}
+
+	client := n.Client
+	if client == nil {
+		client = http.DefaultClient
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return fmt.Errorf("error al llamar al webhook: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		body, _ := io.ReadAll(io.LimitReader(resp.Body, 512))
+		return fmt.Errorf("el webhook respondió %d: %s", resp.StatusCode, bytes.TrimSpace(body))
+	}
+	return nil
+}
@@ -0,0 +1,102 @@
+package receipts
+
+import (
+	"bytes"
+	"fmt"
+)
+
+// Medidas del PDF en puntos: una tira de 80mm de ancho con letra monoespaciada, para que
+// el renglón de Width caracteres entre justo.
+const (
+	pdfPageWidth  = 226.77 // 80mm
+	pdfFontSize   = 7.0
+	pdfLineHeight = 9.0
+	pdfMargin     = 14.0
+)
+
+// PDF devuelve el comprobante como documento PDF de una página, generado sin dependencias
+// externas con las fuentes estándar Courier y Courier-Bold.
+func PDF(r Receipt) []byte {
+	lines := r.lines()
+	height := 2*pdfMargin + float64(len(lines))*pdfLineHeight
+	left := (pdfPageWidth - float64(Width)*pdfFontSize*0.6) / 2
+
+	var content bytes.Buffer
+	content.WriteString("BT\n")
+	fmt.Fprintf(&content, "%.2f TL\n", pdfLineHeight)
+	fmt.Fprintf(&content, "%.2f %.2f Td\n", left, height-pdfMargin-pdfFontSize)
+	font := ""
+	for _, line := range lines {
+		if next := fontFor(line); next != font {
+			fmt.Fprintf(&content, "/%s %.1f Tf\n", next, pdfFontSize)
+			font = next
+		}
+		fmt.Fprintf(&content, "(%s) Tj T*\n", pdfString(line.Text))
+	}
+	content.WriteString("ET")
+
+	objects := []string{
+		"<< /Type /Catalog /Pages 2 0 R >>",
+		"<< /Type /Pages /Kids [3 0 R] /Count 1 >>",
+		fmt.Sprintf("<< /Type /Page /Parent 2 0 R /MediaBox [0 0 %.2f %.2f] /Resources << /Font << /F1 5 0 R /F2 6 0 R >> >> /Contents 4 0 R >>", pdfPageWidth, height),
+		fmt.Sprintf("<< /Length %d >>\nstream\n%s\nendstream", content.Len(), content.String()),
+		"<< /Type /Font /Subtype /Type1 /BaseFont /Courier /Encoding /WinAnsiEncoding >>",
+		"<< /Type /Font /Subtype /Type1 /BaseFont /Courier-Bold /Encoding /WinAnsiEncoding >>",
+	}
+
+	var doc bytes.Buffer
+	doc.WriteString("%PDF-1.4\n")
+	offsets := make([]int, len(objects))
+	for i, object := range objects {
+		offsets[i] = doc.Len()
+		fmt.Fprintf(&doc, "%d 0 obj\n%s\nendobj\n", i+1, object)
+	}
+	xref := doc.Len()
+	fmt.Fprintf(&doc, "xref\n0 %d\n0000000000 65535 f \n", len(objects)+1)
+	for _, offset := range offsets {
+		fmt.Fprintf(&doc, "%010d 00000 n \n", offset)
+	}
+	fmt.Fprintf(&doc, "trailer\n<< /Size %d /Root 1 0 R >>\nstartxref\n%d\n%%%%EOF\n", len(objects)+1, xref)
+	return doc.Bytes()
+}
+
+func fontFor(l line) string {
+	if l.Bold {
+		return "F2"
+	}
+	return "F1"
+}
+
+// winAnsi son los caracteres fuera de Latin-1 que tienen lugar en WinAnsiEncoding.
+var winAnsi = map[rune]byte{
+	'€': 0x80, '…': 0x85, '‘': 0x91, '’': 0x92, '“': 0x93, '”': 0x94, '•': 0x95, '–': 0x96, '—': 0x97,
+}
+
+// pdfString codifica el texto en WinAnsiEncoding y escapa lo necesario para un string PDF.
+// Los caracteres que la fuente no tiene se reemplazan por '?'.
+func pdfString(text string) string {
+	var encoded bytes.Buffer
+	for _, r := range text {
+		var b byte
+		switch {
+		case r == '(' || r == ')' || r == '\\':
+			encoded.WriteByte('\\')
+			b = byte(r)
+		case r < 0x80:
+			b = byte(r)
+		case r >= 0xA0 && r <= 0xFF:
+			b = byte(r)
+		default:
+			var ok bool
+			if b, ok = winAnsi[r]; !ok {
+				b = '?'
+			}
+		}
+		if b >= 0x80 {
+			fmt.Fprintf(&encoded, "\\%03o", b)
+			continue
+		}
+		encoded.WriteByte(b)
+	}
+	return encoded.String()
+}
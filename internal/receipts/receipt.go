@@ -0,0 +1,268 @@
+package receipts
+
+import (
+	"fmt"
+	"math"
+	"os"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+// Width es la cantidad de caracteres por renglón de una impresora térmica de 80mm.
+const Width = 48
+
+// Salon son los datos del encabezado, configurables con SALON_NAME, SALON_ADDRESS,
+// SALON_PHONE y SALON_TAX_ID.
+type Salon struct {
+	Name    string
+	Address string
+	Phone   string
+	TaxID   string // CUIT
+}
+
+// SalonFromEnv lee el encabezado del salón de la configuración.
+func SalonFromEnv() Salon {
+	salon := Salon{
+		Name:    os.Getenv("SALON_NAME"),
+		Address: os.Getenv("SALON_ADDRESS"),
+		Phone:   os.Getenv("SALON_PHONE"),
+		TaxID:   os.Getenv("SALON_TAX_ID"),
+	}
+	if salon.Name == "" {
+		salon.Name = "Peluquería"
+	}
+	return salon
+}
+
+// Receipt es el comprobante de un turno finalizado.
+type Receipt struct {
+	Number     uint
+	IssuedAt   time.Time
+	Salon      Salon
+	ClientName string
+	StaffName  string
+	Items      []Item
+	Products   []Product
+	Subtotal   float64 // Servicios sin los descuentos de promociones
+	Discount   float64
+	Total      float64
+	Payments   []Payment
+	Tips       []Tip
+}
+
+// Item es un servicio cobrado.
+type Item struct {
+	Description   string
+	Details       []string // Variante, combo y adicionales
+	Amount        float64  // Precio antes de la promoción
+	Discount      float64
+	DiscountLabel string
+}
+
+// Product es un producto usado en el turno.
+type Product struct {
+	Description string
+	Quantity    float64
+	Unit        string
+}
+
+type Payment struct {
+	Method    string
+	Reference string
+	Amount    float64
+}
+
+type Tip struct {
+	StaffName string
+	Method    string
+	Amount    float64
+}
+
+// line es un renglón del comprobante, ya ajustado al ancho.
+type line struct {
+	Text string
+	Bold bool
+}
+
+// FormatNumber devuelve el número del comprobante con ceros a la izquierda.
+func FormatNumber(number uint) string {
+	return fmt.Sprintf("%08d", number)
+}
+
+// lines arma el comprobante renglón por renglón. El texto y el PDF usan el mismo armado.
+func (r Receipt) lines() []line {
+	var lines []line
+	add := func(text string, bold bool) {
+		lines = append(lines, line{Text: text, Bold: bold})
+	}
+	separator := func() {
+		add(strings.Repeat("-", Width), false)
+	}
+	row := func(left, right string, bold bool) {
+		for _, text := range columns(left, right) {
+			add(text, bold)
+		}
+	}
+
+	for _, text := range wrap(r.Salon.Name, Width) {
+		add(center(text), true)
+	}
+	for _, text := range []string{r.Salon.Address, r.Salon.Phone} {
+		for _, wrapped := range wrap(text, Width) {
+			add(center(wrapped), false)
+		}
+	}
+	if r.Salon.TaxID != "" {
+		add(center("CUIT "+r.Salon.TaxID), false)
+	}
+	separator()
+	add("Comprobante N° "+FormatNumber(r.Number), true)
+	add("Fecha: "+r.IssuedAt.Format("02/01/2006 15:04"), false)
+	for _, text := range wrap("Cliente: "+r.ClientName, Width) {
+		add(text, false)
+	}
+	if r.StaffName != "" {
+		for _, text := range wrap("Atendió: "+r.StaffName, Width) {
+			add(text, false)
+		}
+	}
+	separator()
+
+	for _, item := range r.Items {
+		row(item.Description, formatAmount(item.Amount), false)
+		for _, detail := range item.Details {
+			for _, text := range wrap("  "+detail, Width) {
+				add(text, false)
+			}
+		}
+		if item.Discount > 0 {
+			row("  Desc. "+item.DiscountLabel, "-"+formatAmount(item.Discount), false)
+		}
+	}
+	if len(r.Products) > 0 {
+		add("", false)
+		add("Productos utilizados", false)
+		for _, product := range r.Products {
+			row("  "+product.Description, formatQuantity(product.Quantity)+" "+product.Unit, false)
+		}
+	}
+	separator()
+
+	if r.Discount > 0 {
+		row("Subtotal", formatAmount(r.Subtotal), false)
+		row("Descuentos", "-"+formatAmount(r.Discount), false)
+	}
+	row("TOTAL", formatAmount(r.Total), true)
+
+	if len(r.Payments) > 0 {
+		add("", false)
+		add("Pagos", false)
+		for _, payment := range r.Payments {
+			description := "  " + payment.Method
+			if payment.Reference != "" {
+				description += " (" + payment.Reference + ")"
+			}
+			row(description, formatAmount(payment.Amount), false)
+		}
+	}
+	if len(r.Tips) > 0 {
+		add("", false)
+		add("Propinas", false)
+		for _, tip := range r.Tips {
+			row("  "+tip.StaffName+" - "+tip.Method, formatAmount(tip.Amount), false)
+		}
+	}
+	separator()
+	add(center("Documento no válido como factura"), false)
+	add(center("¡Gracias por su visita!"), false)
+	return lines
+}
+
+// columns alinea el texto a la izquierda y el importe a la derecha. Si no entran en un
+// renglón, el texto se corta y el importe va en el último; si el importe solo ya no deja
+// lugar, va en un renglón aparte.
+func columns(left, right string) []string {
+	available := Width - utf8.RuneCountInString(right) - 1
+	if available < 1 {
+		// La columna derecha no deja lugar: va en su propio renglón, alineada a la derecha
+		padding := max(Width-utf8.RuneCountInString(right), 0)
+		return append(wrap(left, Width), strings.Repeat(" ", padding)+right)
+	}
+	wrapped := wrap(left, available)
+	if len(wrapped) == 0 {
+		wrapped = []string{""}
+	}
+	last := len(wrapped) - 1
+	padding := Width - utf8.RuneCountInString(wrapped[last]) - utf8.RuneCountInString(right)
+	wrapped[last] += strings.Repeat(" ", padding) + right
+	return wrapped
+}
+
+// wrap corta el texto en renglones de hasta width caracteres, respetando las palabras
+// salvo que una sola no entre.
+func wrap(text string, width int) []string {
+	var lines []string
+	width = max(width, 1)
+	indent := text[:len(text)-len(strings.TrimLeft(text, " "))]
+	if utf8.RuneCountInString(indent) >= width {
+		indent = ""
+	}
+	current := indent
+	for _, word := range strings.Fields(text) {
+		for utf8.RuneCountInString(word) > width-utf8.RuneCountInString(indent) {
+			if strings.TrimSpace(current) != "" {
+				lines = append(lines, current)
+			}
+			runes := []rune(word)
+			cut := width - utf8.RuneCountInString(indent)
+			lines = append(lines, indent+string(runes[:cut]))
+			word = string(runes[cut:])
+			current = indent
+		}
+		switch {
+		case strings.TrimSpace(current) == "":
+			current = indent + word
+		case utf8.RuneCountInString(current)+1+utf8.RuneCountInString(word) <= width:
+			current += " " + word
+		default:
+			lines = append(lines, current)
+			current = indent + word
+		}
+	}
+	if strings.TrimSpace(current) != "" {
+		lines = append(lines, current)
+	}
+	return lines
+}
+
+func center(text string) string {
+	padding := (Width - utf8.RuneCountInString(text)) / 2
+	if padding <= 0 {
+		return text
+	}
+	return strings.Repeat(" ", padding) + text
+}
+
+// formatAmount da formato de moneda argentino: $ 1.234,56.
+func formatAmount(amount float64) string {
+	cents := int64(math.Round(math.Abs(amount) * 100))
+	integer := fmt.Sprintf("%d", cents/100)
+	var grouped strings.Builder
+	for i, digit := range integer {
+		if i > 0 && (len(integer)-i)%3 == 0 {
+			grouped.WriteByte('.')
+		}
+		grouped.WriteRune(digit)
+	}
+	sign := ""
+	if amount < 0 {
+		sign = "-"
+	}
+	return fmt.Sprintf("%s$ %s,%02d", sign, grouped.String(), cents%100)
+}
+
+func formatQuantity(quantity float64) string {
+	text := strings.TrimRight(strings.TrimRight(fmt.Sprintf("%.2f", quantity), "0"), ".")
+	return strings.Replace(text, ".", ",", 1)
+}
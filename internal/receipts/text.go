@@ -0,0 +1,13 @@
+package receipts
+
+import "strings"
+
+// Text devuelve el comprobante en texto plano para impresoras térmicas de 80mm.
+func Text(r Receipt) string {
+	var text strings.Builder
+	for _, line := range r.lines() {
+		text.WriteString(line.Text)
+		text.WriteByte('\n')
+	}
+	return text.String()
+}
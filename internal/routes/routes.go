@@ -1,8 +1,12 @@
 package routes
 
 import (
+	"os"
 	"peluqueria/internal/controllers"
+	"peluqueria/internal/services"
 	"peluqueria/middlewares"
+	"strconv"
+	"time"
 
 	"github.com/labstack/echo/v4"
 	echoSwagger "github.com/swaggo/echo-swagger"
@@ -16,6 +20,18 @@ func RegisterRoutes(e *echo.Echo) {
 	// Rutas públicas
 	e.POST(prefix+"/login", controllers.Login) // Iniciar sesión
 
+	// Suscripción iCal, autenticada por el token de la URL
+	e.GET(prefix+"/ical/:token", controllers.GetCalendarFeedICS)
+
+	// Reservas online, sin autenticación
+	publicGroup := e.Group(prefix+"/public", middlewares.RateLimiter(publicRateLimit(), time.Minute))
+	publicGroup.GET("/servicios", controllers.GetPublicServices)
+	publicGroup.GET("/combos", controllers.GetPublicServiceBundles)
+	publicGroup.GET("/empleados", controllers.GetPublicStaff)
+	publicGroup.GET("/disponibilidad", controllers.GetPublicAvailability)
+	publicGroup.POST("/turno", controllers.RequestPublicBooking)
+	publicGroup.POST("/turno/:id/confirmar", controllers.ConfirmPublicBooking)
+
 	userGroup := e.Group(prefix+"/usuarios", middlewares.JWTMiddleware)
 	userGroup.POST("", controllers.CreateUser, middlewares.PermissionMiddleware("create_user"))
 	userGroup.GET("", controllers.GetAllUsers)
@@ -49,6 +65,41 @@ func RegisterRoutes(e *echo.Echo) {
 	stockGroup.GET("", controllers.GetStockMovements)                      // Todos los movimientos
 	stockGroup.GET("/product/:id", controllers.GetStockMovementsByProduct) // Movimientos por producto
 
+	staffGroup := e.Group(prefix+"/empleado", middlewares.JWTMiddleware)
+	staffGroup.POST("", controllers.CreateStaff, middlewares.PermissionMiddleware("create_staff"))
+	staffGroup.GET("", controllers.GetAllStaff)
+	staffGroup.GET("/:id", controllers.GetStaffByID)
+	staffGroup.PUT("/:id", controllers.UpdateStaff, middlewares.PermissionMiddleware("update_staff"))
+	staffGroup.DELETE("/:id", controllers.DeleteStaff, middlewares.PermissionMiddleware("delete_staff"))
+	staffGroup.GET("/:id/servicios", controllers.GetStaffSkills)
+	staffGroup.PUT("/:id/servicios/:serviceId", controllers.SetStaffSkill, middlewares.PermissionMiddleware("update_staff"))
+	staffGroup.DELETE("/:id/servicios/:serviceId", controllers.DeleteStaffSkill, middlewares.PermissionMiddleware("update_staff"))
+	staffGroup.GET("/:id/horarios", controllers.GetStaffShifts)
+	staffGroup.POST("/:id/horarios", controllers.CreateStaffShift, middlewares.PermissionMiddleware("update_staff"))
+	staffGroup.PUT("/horarios/:id", controllers.UpdateStaffShift, middlewares.PermissionMiddleware("update_staff"))
+	staffGroup.DELETE("/horarios/:id", controllers.DeleteStaffShift, middlewares.PermissionMiddleware("update_staff"))
+	staffGroup.GET("/:id/excepciones", controllers.GetStaffScheduleExceptions)
+	staffGroup.POST("/:id/excepciones", controllers.CreateStaffScheduleException, middlewares.PermissionMiddleware("update_staff"))
+	staffGroup.PUT("/excepciones/:id", controllers.UpdateStaffScheduleException, middlewares.PermissionMiddleware("update_staff"))
+	staffGroup.DELETE("/excepciones/:id", controllers.DeleteStaffScheduleException, middlewares.PermissionMiddleware("update_staff"))
+
+	// Licencias: cada usuario gestiona las de su empleado; las de otros y la aprobación requieren permiso
+	timeOffGroup := e.Group(prefix+"/licencia", middlewares.JWTMiddleware)
+	timeOffGroup.POST("", controllers.RequestTimeOff)
+	timeOffGroup.GET("", controllers.GetAllTimeOff)
+	timeOffGroup.GET("/:id", controllers.GetTimeOffByID)
+	timeOffGroup.PUT("/:id", controllers.UpdateTimeOff)
+	timeOffGroup.DELETE("/:id", controllers.DeleteTimeOff, middlewares.PermissionMiddleware("approve_time_off"))
+	timeOffGroup.PUT("/:id/aprobar", controllers.ApproveTimeOff, middlewares.PermissionMiddleware("approve_time_off"))
+	timeOffGroup.PUT("/:id/rechazar", controllers.RejectTimeOff, middlewares.PermissionMiddleware("approve_time_off"))
+
+	resourceGroup := e.Group(prefix+"/recurso", middlewares.JWTMiddleware)
+	resourceGroup.POST("", controllers.CreateResource, middlewares.PermissionMiddleware("create_resource"))
+	resourceGroup.GET("", controllers.GetAllResources)
+	resourceGroup.GET("/:id", controllers.GetResourceByID)
+	resourceGroup.PUT("/:id", controllers.UpdateResource, middlewares.PermissionMiddleware("update_resource"))
+	resourceGroup.DELETE("/:id", controllers.DeleteResource, middlewares.PermissionMiddleware("delete_resource"))
+
 	serviceGroup := e.Group(prefix+"/servicio", middlewares.JWTMiddleware)
 	serviceGroup.POST("", controllers.CreateService, middlewares.PermissionMiddleware("create_service"))
 	serviceGroup.GET("", controllers.GetAllServices)
@@ -56,15 +107,111 @@ func RegisterRoutes(e *echo.Echo) {
 	serviceGroup.PUT("/:id", controllers.UpdateService, middlewares.PermissionMiddleware("update_service"))
 	serviceGroup.DELETE("/:id", controllers.DeleteService, middlewares.PermissionMiddleware("delete_service"))
 
+	bundleGroup := e.Group(prefix+"/combo", middlewares.JWTMiddleware)
+	bundleGroup.POST("", controllers.CreateServiceBundle, middlewares.PermissionMiddleware("create_service"))
+	bundleGroup.GET("", controllers.GetAllServiceBundles)
+	bundleGroup.GET("/:id", controllers.GetServiceBundleByID)
+	bundleGroup.PUT("/:id", controllers.UpdateServiceBundle, middlewares.PermissionMiddleware("update_service"))
+	bundleGroup.DELETE("/:id", controllers.DeleteServiceBundle, middlewares.PermissionMiddleware("delete_service"))
+
+	cashGroup := e.Group(prefix+"/caja", middlewares.JWTMiddleware)
+	cashGroup.GET("", controllers.GetAllCashSessions)
+	cashGroup.GET("/actual", controllers.GetCurrentCashSession)
+	cashGroup.GET("/:id", controllers.GetCashSessionByID)
+	cashGroup.POST("/abrir", controllers.OpenCashSession, middlewares.PermissionMiddleware("manage_cash_register"))
+	cashGroup.POST("/movimientos", controllers.RecordCashMovement, middlewares.PermissionMiddleware("manage_cash_register"))
+	cashGroup.PUT("/cerrar", controllers.CloseCashSession, middlewares.PermissionMiddleware("manage_cash_register"))
+
+	promotionGroup := e.Group(prefix+"/promocion", middlewares.JWTMiddleware)
+	promotionGroup.POST("", controllers.CreatePromotion, middlewares.PermissionMiddleware("manage_promotions"))
+	promotionGroup.GET("", controllers.GetAllPromotions)
+	promotionGroup.GET("/:id", controllers.GetPromotionByID)
+	promotionGroup.PUT("/:id", controllers.UpdatePromotion, middlewares.PermissionMiddleware("manage_promotions"))
+	promotionGroup.DELETE("/:id", controllers.DeletePromotion, middlewares.PermissionMiddleware("manage_promotions"))
+
+	paymentMethodGroup := e.Group(prefix+"/medio-pago", middlewares.JWTMiddleware)
+	paymentMethodGroup.POST("", controllers.CreatePaymentMethod, middlewares.PermissionMiddleware("manage_payment_methods"))
+	paymentMethodGroup.GET("", controllers.GetAllPaymentMethods)
+	paymentMethodGroup.PUT("/:id", controllers.UpdatePaymentMethod, middlewares.PermissionMiddleware("manage_payment_methods"))
+	paymentMethodGroup.DELETE("/:id", controllers.DeletePaymentMethod, middlewares.PermissionMiddleware("manage_payment_methods"))
+
 	appointmentGroup := e.Group(prefix+"/turno", middlewares.JWTMiddleware)
 	appointmentGroup.POST("", controllers.CreateAppointment, middlewares.PermissionMiddleware("create_appointment"))
 	appointmentGroup.GET("", controllers.GetAllAppointments)
+	appointmentGroup.GET("/disponibilidad", controllers.GetAvailability)
+	appointmentGroup.GET("/sala", controllers.GetFloorStatus)
 	appointmentGroup.GET("/:id", controllers.GetAppointmentByID)
 	appointmentGroup.PUT("/:id", controllers.UpdateAppointment, middlewares.PermissionMiddleware("update_appointment"))
 	appointmentGroup.PUT("/:id/products", controllers.UpdateAppointmentProducts, middlewares.PermissionMiddleware("update_appointment"))
 	appointmentGroup.DELETE("/:id", controllers.DeleteAppointment, middlewares.PermissionMiddleware("delete_appointment"))
 	appointmentGroup.PUT("/:id/finalizar", controllers.FinalizeAppointment)
+	appointmentGroup.PUT("/:id/confirmar", controllers.ConfirmAppointment, middlewares.PermissionMiddleware("update_appointment"))
+	appointmentGroup.PUT("/:id/llegada", controllers.CheckInAppointment, middlewares.PermissionMiddleware("update_appointment"))
+	appointmentGroup.PUT("/:id/iniciar", controllers.StartAppointment, middlewares.PermissionMiddleware("update_appointment"))
+	appointmentGroup.PUT("/:id/cancelar", controllers.CancelAppointment, middlewares.PermissionMiddleware("update_appointment"))
+	appointmentGroup.PUT("/:id/ausente", controllers.MarkAppointmentNoShow, middlewares.PermissionMiddleware("update_appointment"))
+	appointmentGroup.GET("/:id/historial", controllers.GetAppointmentStatusHistory)
+	appointmentGroup.GET("/:id/comprobante", controllers.GetAppointmentReceipt)
+	appointmentGroup.POST("/:id/notas", controllers.CreateAppointmentNote, middlewares.PermissionMiddleware("update_appointment"))
+	appointmentGroup.PUT("/notas/:id", controllers.UpdateAppointmentNote, middlewares.PermissionMiddleware("update_appointment"))
+	appointmentGroup.DELETE("/notas/:id", controllers.DeleteAppointmentNote, middlewares.PermissionMiddleware("update_appointment"))
+	appointmentGroup.POST("/:id/formulas", controllers.CreateColorFormula, middlewares.PermissionMiddleware("update_appointment"))
+	appointmentGroup.PUT("/formulas/:id", controllers.UpdateColorFormula, middlewares.PermissionMiddleware("update_appointment"))
+	appointmentGroup.DELETE("/formulas/:id", controllers.DeleteColorFormula, middlewares.PermissionMiddleware("update_appointment"))
+	// El cuerpo de la subida admite la foto más el resto del formulario
+	appointmentGroup.POST("/:id/fotos", controllers.UploadAppointmentPhoto, middlewares.PermissionMiddleware("update_appointment"), middlewares.BodyLimit(services.PhotoMaxBytes()+1<<20))
+	appointmentGroup.GET("/fotos/:id", controllers.GetAppointmentPhoto)
+	appointmentGroup.GET("/fotos/:id/miniatura", controllers.GetAppointmentPhotoThumbnail)
+	appointmentGroup.DELETE("/fotos/:id", controllers.DeleteAppointmentPhoto, middlewares.PermissionMiddleware("update_appointment"))
+
+	calendarGroup := e.Group(prefix+"/calendario", middlewares.JWTMiddleware)
+	calendarGroup.GET("/horarios", controllers.GetAllBusinessHours)
+	calendarGroup.POST("/horarios", controllers.CreateBusinessHour, middlewares.PermissionMiddleware("update_calendar"))
+	calendarGroup.PUT("/horarios/:id", controllers.UpdateBusinessHour, middlewares.PermissionMiddleware("update_calendar"))
+	calendarGroup.DELETE("/horarios/:id", controllers.DeleteBusinessHour, middlewares.PermissionMiddleware("update_calendar"))
+	calendarGroup.GET("/cierres", controllers.GetAllClosures)
+	calendarGroup.POST("/cierres", controllers.CreateClosure, middlewares.PermissionMiddleware("update_calendar"))
+	calendarGroup.PUT("/cierres/:id", controllers.UpdateClosure, middlewares.PermissionMiddleware("update_calendar"))
+	calendarGroup.DELETE("/cierres/:id", controllers.DeleteClosure, middlewares.PermissionMiddleware("update_calendar"))
+	calendarGroup.GET("/ical", controllers.GetAllCalendarFeeds, middlewares.PermissionMiddleware("update_calendar"))
+	calendarGroup.POST("/ical", controllers.CreateCalendarFeed, middlewares.PermissionMiddleware("update_calendar"))
+	calendarGroup.DELETE("/ical/:id", controllers.DeleteCalendarFeed, middlewares.PermissionMiddleware("update_calendar"))
+
+	waitlistGroup := e.Group(prefix+"/lista-espera", middlewares.JWTMiddleware)
+	waitlistGroup.POST("", controllers.CreateWaitlistEntry, middlewares.PermissionMiddleware("create_appointment"))
+	waitlistGroup.GET("", controllers.GetAllWaitlistEntries)
+	waitlistGroup.GET("/coincidencias", controllers.GetWaitlistMatches)
+	waitlistGroup.PUT("/coincidencias/:id/descartar", controllers.DismissWaitlistMatch, middlewares.PermissionMiddleware("update_appointment"))
+	waitlistGroup.GET("/:id", controllers.GetWaitlistEntryByID)
+	waitlistGroup.PUT("/:id", controllers.UpdateWaitlistEntry, middlewares.PermissionMiddleware("update_appointment"))
+	waitlistGroup.DELETE("/:id", controllers.DeleteWaitlistEntry, middlewares.PermissionMiddleware("delete_appointment"))
+
+	walkInGroup := e.Group(prefix+"/fila", middlewares.JWTMiddleware)
+	walkInGroup.POST("", controllers.EnqueueWalkIn, middlewares.PermissionMiddleware("create_appointment"))
+	walkInGroup.GET("", controllers.GetWalkInQueue)
+	walkInGroup.PUT("/siguiente", controllers.CallNextWalkIn, middlewares.PermissionMiddleware("update_appointment"))
+	walkInGroup.PUT("/:id/posicion", controllers.ReorderWalkIn, middlewares.PermissionMiddleware("update_appointment"))
+	walkInGroup.PUT("/:id/saltear", controllers.SkipWalkIn, middlewares.PermissionMiddleware("update_appointment"))
+	walkInGroup.PUT("/:id/atender", controllers.ServeWalkIn, middlewares.PermissionMiddleware("update_appointment"))
+
+	notificationGroup := e.Group(prefix+"/notificaciones", middlewares.JWTMiddleware)
+	notificationGroup.GET("", controllers.GetNotifications, middlewares.PermissionMiddleware("see_notifications"))
+	notificationGroup.PUT("/:id/reintentar", controllers.RetryNotification, middlewares.PermissionMiddleware("update_appointment"))
+
+	// Flujo de eventos en vivo para las pantallas de recepción
+	e.GET(prefix+"/eventos", controllers.StreamEvents, middlewares.EventStreamTokenMiddleware, middlewares.JWTMiddleware, middlewares.PermissionMiddleware("see_events"))
 
 	appointmentStats := e.Group(prefix+"/estadisticas", middlewares.JWTMiddleware)
 	appointmentStats.GET("/", controllers.GetMonthlyStatistics)
+	appointmentStats.GET("/tiempos", controllers.GetTimeStatistics)
+	appointmentStats.GET("/propinas", controllers.GetTipReport)
+}
+
+// publicRateLimit devuelve las solicitudes por minuto permitidas por IP en las rutas públicas.
+func publicRateLimit() int {
+	limit, err := strconv.Atoi(os.Getenv("PUBLIC_RATE_LIMIT"))
+	if err != nil || limit <= 0 {
+		return 30
+	}
+	return limit
 }
@@ -0,0 +1,535 @@
+package services
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"image"
+	"image/color"
+	"image/jpeg"
+	_ "image/png" // Registra el decodificador PNG
+	"io"
+	"net/http"
+	"os"
+	"peluqueria/database"
+	"peluqueria/internal/dtos"
+	"peluqueria/internal/models"
+	"peluqueria/internal/storage"
+	"peluqueria/logger"
+	"slices"
+	"strconv"
+	"strings"
+
+	"gorm.io/gorm"
+)
+
+// ErrInvalidPhoto indica que el archivo subido no es una foto aceptada.
+var ErrInvalidPhoto = errors.New("foto inválida")
+
+// Tamaño máximo por defecto de una foto, en MB.
+const defaultPhotoMaxMB = 10
+
+// Lado mayor de las miniaturas, en píxeles.
+const thumbnailSize = 320
+
+// Resolución máxima aceptada, en píxeles.
+const maxPhotoPixels = 50_000_000
+
+// Tipos de imagen aceptados y la extensión con que se guardan.
+var photoContentTypes = map[string]string{
+	"image/jpeg": ".jpg",
+	"image/png":  ".png",
+}
+
+var photoKinds = []string{models.PhotoKindBefore, models.PhotoKindAfter}
+
+var developerVolumes = []uint{0, 10, 20, 30, 40}
+
+var photoStorage storage.Storage
+
+// UsePhotoStorage define dónde se guardan las fotos de los turnos.
+func UsePhotoStorage(s storage.Storage) {
+	photoStorage = s
+}
+
+func photoStore() storage.Storage {
+	if photoStorage == nil {
+		photoStorage = storage.FromEnv()
+	}
+	return photoStorage
+}
+
+func CreateAppointmentNote(appointmentID uint, dto dtos.AppointmentNoteDto, userID uint) (dtos.GetAppointmentNoteDto, error) {
+	logger.Log.Infof("[AppointmentRecordService][CreateAppointmentNote] Agregando nota al turno ID %d", appointmentID)
+
+	if err := findRecordAppointment(appointmentID); err != nil {
+		return dtos.GetAppointmentNoteDto{}, err
+	}
+	text := strings.TrimSpace(dto.Text)
+	if text == "" {
+		return dtos.GetAppointmentNoteDto{}, errors.New("el texto de la nota es obligatorio")
+	}
+
+	note := models.AppointmentNote{AppointmentID: appointmentID, Text: text, AuthorID: optionalUserID(userID)}
+	if err := database.DB.Create(&note).Error; err != nil {
+		logger.Log.Error("[AppointmentRecordService][CreateAppointmentNote] Error al crear nota: ", err)
+		return dtos.GetAppointmentNoteDto{}, errors.New("error al crear la nota")
+	}
+	if err := database.DB.Preload("Author").First(&note, note.ID).Error; err != nil {
+		return dtos.GetAppointmentNoteDto{}, errors.New("error al obtener la nota")
+	}
+
+	logger.Log.Infof("[AppointmentRecordService][CreateAppointmentNote] Nota ID %d creada", note.ID)
+	return toAppointmentNoteDto(note), nil
+}
+
+func UpdateAppointmentNote(id uint, dto dtos.AppointmentNoteDto) error {
+	logger.Log.Infof("[AppointmentRecordService][UpdateAppointmentNote] Actualizando nota ID %d", id)
+
+	text := strings.TrimSpace(dto.Text)
+	if text == "" {
+		return errors.New("el texto de la nota es obligatorio")
+	}
+
+	result := database.DB.Model(&models.AppointmentNote{}).Where("id = ?", id).Update("text", text)
+	if result.Error != nil {
+		logger.Log.Error("[AppointmentRecordService][UpdateAppointmentNote] Error al actualizar nota: ", result.Error)
+		return errors.New("error al actualizar la nota")
+	}
+	if result.RowsAffected == 0 {
+		return errors.New("nota no encontrada")
+	}
+	return nil
+}
+
+func DeleteAppointmentNote(id uint) error {
+	logger.Log.Infof("[AppointmentRecordService][DeleteAppointmentNote] Eliminando nota ID %d", id)
+
+	result := database.DB.Delete(&models.AppointmentNote{}, id)
+	if result.Error != nil {
+		logger.Log.Error("[AppointmentRecordService][DeleteAppointmentNote] Error al eliminar nota: ", result.Error)
+		return errors.New("error al eliminar la nota")
+	}
+	if result.RowsAffected == 0 {
+		return errors.New("nota no encontrada")
+	}
+	return nil
+}
+
+func CreateColorFormula(appointmentID uint, dto dtos.ColorFormulaDto, userID uint) (dtos.GetColorFormulaDto, error) {
+	logger.Log.Infof("[AppointmentRecordService][CreateColorFormula] Agregando fórmula de color al turno ID %d", appointmentID)
+
+	if err := findRecordAppointment(appointmentID); err != nil {
+		return dtos.GetColorFormulaDto{}, err
+	}
+	formula := models.ColorFormula{AppointmentID: appointmentID, AuthorID: optionalUserID(userID)}
+	if err := applyColorFormulaDto(&formula, dto); err != nil {
+		return dtos.GetColorFormulaDto{}, err
+	}
+
+	if err := database.DB.Create(&formula).Error; err != nil {
+		logger.Log.Error("[AppointmentRecordService][CreateColorFormula] Error al crear fórmula: ", err)
+		return dtos.GetColorFormulaDto{}, errors.New("error al crear la fórmula de color")
+	}
+	if err := database.DB.Preload("Author").First(&formula, formula.ID).Error; err != nil {
+		return dtos.GetColorFormulaDto{}, errors.New("error al obtener la fórmula de color")
+	}
+
+	logger.Log.Infof("[AppointmentRecordService][CreateColorFormula] Fórmula ID %d creada", formula.ID)
+	return toColorFormulaDto(formula), nil
+}
+
+func UpdateColorFormula(id uint, dto dtos.ColorFormulaDto) error {
+	logger.Log.Infof("[AppointmentRecordService][UpdateColorFormula] Actualizando fórmula de color ID %d", id)
+
+	var formula models.ColorFormula
+	if err := database.DB.First(&formula, id).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			logger.Log.Warnf("[AppointmentRecordService][UpdateColorFormula] Fórmula no encontrada: ID %d", id)
+			return errors.New("fórmula de color no encontrada")
+		}
+		logger.Log.Error("[AppointmentRecordService][UpdateColorFormula] Error al buscar fórmula: ", err)
+		return errors.New("error al buscar la fórmula de color")
+	}
+	if err := applyColorFormulaDto(&formula, dto); err != nil {
+		return err
+	}
+
+	if err := database.DB.Save(&formula).Error; err != nil {
+		logger.Log.Error("[AppointmentRecordService][UpdateColorFormula] Error al actualizar fórmula: ", err)
+		return errors.New("error al actualizar la fórmula de color")
+	}
+	return nil
+}
+
+func DeleteColorFormula(id uint) error {
+	logger.Log.Infof("[AppointmentRecordService][DeleteColorFormula] Eliminando fórmula de color ID %d", id)
+
+	result := database.DB.Delete(&models.ColorFormula{}, id)
+	if result.Error != nil {
+		logger.Log.Error("[AppointmentRecordService][DeleteColorFormula] Error al eliminar fórmula: ", result.Error)
+		return errors.New("error al eliminar la fórmula de color")
+	}
+	if result.RowsAffected == 0 {
+		return errors.New("fórmula de color no encontrada")
+	}
+	return nil
+}
+
+// UploadAppointmentPhoto valida la foto (tipo real y tamaño), la guarda junto con su
+// miniatura y la registra en el turno.
+func UploadAppointmentPhoto(appointmentID uint, kind, caption string, file io.Reader, userID uint) (dtos.GetAppointmentPhotoDto, error) {
+	logger.Log.Infof("[AppointmentRecordService][UploadAppointmentPhoto] Subiendo foto '%s' al turno ID %d", kind, appointmentID)
+
+	if err := findRecordAppointment(appointmentID); err != nil {
+		return dtos.GetAppointmentPhotoDto{}, err
+	}
+	kind = strings.ToLower(strings.TrimSpace(kind))
+	if !slices.Contains(photoKinds, kind) {
+		return dtos.GetAppointmentPhotoDto{}, fmt.Errorf("%w: el tipo debe ser uno de %v", ErrInvalidPhoto, photoKinds)
+	}
+
+	maxBytes := PhotoMaxBytes()
+	data, err := io.ReadAll(io.LimitReader(file, maxBytes+1))
+	if err != nil {
+		logger.Log.Error("[AppointmentRecordService][UploadAppointmentPhoto] Error al leer archivo: ", err)
+		return dtos.GetAppointmentPhotoDto{}, errors.New("error al leer el archivo")
+	}
+	if int64(len(data)) > maxBytes {
+		return dtos.GetAppointmentPhotoDto{}, fmt.Errorf("%w: supera el máximo de %d MB", ErrInvalidPhoto, maxBytes>>20)
+	}
+	if len(data) == 0 {
+		return dtos.GetAppointmentPhotoDto{}, fmt.Errorf("%w: el archivo está vacío", ErrInvalidPhoto)
+	}
+
+	// El tipo se toma del contenido, no del nombre ni del header del cliente
+	contentType := http.DetectContentType(data)
+	extension, ok := photoContentTypes[contentType]
+	if !ok {
+		logger.Log.Warnf("[AppointmentRecordService][UploadAppointmentPhoto] Tipo de archivo no permitido: %s", contentType)
+		return dtos.GetAppointmentPhotoDto{}, fmt.Errorf("%w: solo se aceptan imágenes JPEG o PNG", ErrInvalidPhoto)
+	}
+	// Se revisan las dimensiones antes de decodificar, para no reservar memoria de más
+	config, _, err := image.DecodeConfig(bytes.NewReader(data))
+	if err != nil {
+		logger.Log.Warn("[AppointmentRecordService][UploadAppointmentPhoto] Imagen ilegible: ", err)
+		return dtos.GetAppointmentPhotoDto{}, fmt.Errorf("%w: la imagen está dañada o no se puede leer", ErrInvalidPhoto)
+	}
+	if config.Width*config.Height > maxPhotoPixels {
+		return dtos.GetAppointmentPhotoDto{}, fmt.Errorf("%w: la imagen supera los %d megapíxeles", ErrInvalidPhoto, maxPhotoPixels/1_000_000)
+	}
+	img, _, err := image.Decode(bytes.NewReader(data))
+	if err != nil {
+		logger.Log.Warn("[AppointmentRecordService][UploadAppointmentPhoto] Imagen ilegible: ", err)
+		return dtos.GetAppointmentPhotoDto{}, fmt.Errorf("%w: la imagen está dañada o no se puede leer", ErrInvalidPhoto)
+	}
+
+	var thumbnail bytes.Buffer
+	if err := jpeg.Encode(&thumbnail, makeThumbnail(img, thumbnailSize), &jpeg.Options{Quality: 80}); err != nil {
+		logger.Log.Error("[AppointmentRecordService][UploadAppointmentPhoto] Error al generar miniatura: ", err)
+		return dtos.GetAppointmentPhotoDto{}, errors.New("error al generar la miniatura")
+	}
+
+	name, err := randomFileName()
+	if err != nil {
+		return dtos.GetAppointmentPhotoDto{}, err
+	}
+	photo := models.AppointmentPhoto{
+		AppointmentID: appointmentID,
+		Kind:          kind,
+		Caption:       strings.TrimSpace(caption),
+		StorageKey:    fmt.Sprintf("turnos/%d/%s%s", appointmentID, name, extension),
+		ThumbnailKey:  fmt.Sprintf("turnos/%d/%s_miniatura.jpg", appointmentID, name),
+		ContentType:   contentType,
+		Size:          int64(len(data)),
+		Width:         img.Bounds().Dx(),
+		Height:        img.Bounds().Dy(),
+		UploadedByID:  optionalUserID(userID),
+	}
+
+	ctx := context.Background()
+	store := photoStore()
+	if err := store.Save(ctx, photo.StorageKey, bytes.NewReader(data)); err != nil {
+		logger.Log.Error("[AppointmentRecordService][UploadAppointmentPhoto] Error al guardar foto: ", err)
+		return dtos.GetAppointmentPhotoDto{}, errors.New("error al guardar la foto")
+	}
+	if err := store.Save(ctx, photo.ThumbnailKey, &thumbnail); err != nil {
+		logger.Log.Error("[AppointmentRecordService][UploadAppointmentPhoto] Error al guardar miniatura: ", err)
+		removePhotoFiles(photo)
+		return dtos.GetAppointmentPhotoDto{}, errors.New("error al guardar la foto")
+	}
+
+	if err := database.DB.Create(&photo).Error; err != nil {
+		logger.Log.Error("[AppointmentRecordService][UploadAppointmentPhoto] Error al registrar foto: ", err)
+		removePhotoFiles(photo)
+		return dtos.GetAppointmentPhotoDto{}, errors.New("error al registrar la foto")
+	}
+	if err := database.DB.Preload("UploadedBy").First(&photo, photo.ID).Error; err != nil {
+		return dtos.GetAppointmentPhotoDto{}, errors.New("error al obtener la foto")
+	}
+
+	logger.Log.Infof("[AppointmentRecordService][UploadAppointmentPhoto] Foto ID %d guardada (%d bytes)", photo.ID, photo.Size)
+	return toAppointmentPhotoDto(photo), nil
+}
+
+// OpenAppointmentPhoto abre el archivo de la foto, o su miniatura, para enviarlo.
+func OpenAppointmentPhoto(id uint, thumbnail bool) (io.ReadCloser, string, error) {
+	var photo models.AppointmentPhoto
+	if err := database.DB.First(&photo, id).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			logger.Log.Warnf("[AppointmentRecordService][OpenAppointmentPhoto] Foto no encontrada: ID %d", id)
+			return nil, "", errors.New("foto no encontrada")
+		}
+		logger.Log.Error("[AppointmentRecordService][OpenAppointmentPhoto] Error al buscar foto: ", err)
+		return nil, "", errors.New("error al buscar la foto")
+	}
+
+	key, contentType := photo.StorageKey, photo.ContentType
+	if thumbnail {
+		key, contentType = photo.ThumbnailKey, "image/jpeg"
+	}
+	file, err := photoStore().Open(context.Background(), key)
+	if err != nil {
+		if errors.Is(err, storage.ErrNotFound) {
+			logger.Log.Warnf("[AppointmentRecordService][OpenAppointmentPhoto] Archivo faltante para foto ID %d: %s", id, key)
+			return nil, "", errors.New("foto no encontrada")
+		}
+		logger.Log.Error("[AppointmentRecordService][OpenAppointmentPhoto] Error al abrir foto: ", err)
+		return nil, "", errors.New("error al abrir la foto")
+	}
+	return file, contentType, nil
+}
+
+func DeleteAppointmentPhoto(id uint) error {
+	logger.Log.Infof("[AppointmentRecordService][DeleteAppointmentPhoto] Eliminando foto ID %d", id)
+
+	var photo models.AppointmentPhoto
+	if err := database.DB.First(&photo, id).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return errors.New("foto no encontrada")
+		}
+		logger.Log.Error("[AppointmentRecordService][DeleteAppointmentPhoto] Error al buscar foto: ", err)
+		return errors.New("error al buscar la foto")
+	}
+	if err := database.DB.Delete(&photo).Error; err != nil {
+		logger.Log.Error("[AppointmentRecordService][DeleteAppointmentPhoto] Error al eliminar foto: ", err)
+		return errors.New("error al eliminar la foto")
+	}
+
+	removePhotoFiles(photo)
+	return nil
+}
+
+// appointmentRecord agrupa lo registrado por el estilista en un turno.
+type appointmentRecord struct {
+	Notes    []dtos.GetAppointmentNoteDto
+	Formulas []dtos.GetColorFormulaDto
+	Photos   []dtos.GetAppointmentPhotoDto
+}
+
+// appointmentRecords carga notas, fórmulas y fotos de varios turnos, indexadas por turno.
+// Los turnos sin registros quedan con listas vacías.
+func appointmentRecords(db *gorm.DB, appointmentIDs []uint) (map[uint]*appointmentRecord, error) {
+	records := make(map[uint]*appointmentRecord, len(appointmentIDs))
+	for _, id := range appointmentIDs {
+		records[id] = &appointmentRecord{
+			Notes:    []dtos.GetAppointmentNoteDto{},
+			Formulas: []dtos.GetColorFormulaDto{},
+			Photos:   []dtos.GetAppointmentPhotoDto{},
+		}
+	}
+	if len(appointmentIDs) == 0 {
+		return records, nil
+	}
+
+	var notes []models.AppointmentNote
+	if err := db.Preload("Author").Where("appointment_id IN ?", appointmentIDs).Order("created_at, id").Find(&notes).Error; err != nil {
+		logger.Log.Error("[AppointmentRecordService][appointmentRecords] Error al obtener notas: ", err)
+		return nil, errors.New("error al obtener notas de los turnos")
+	}
+	for _, note := range notes {
+		records[note.AppointmentID].Notes = append(records[note.AppointmentID].Notes, toAppointmentNoteDto(note))
+	}
+
+	var formulas []models.ColorFormula
+	if err := db.Preload("Author").Where("appointment_id IN ?", appointmentIDs).Order("id").Find(&formulas).Error; err != nil {
+		logger.Log.Error("[AppointmentRecordService][appointmentRecords] Error al obtener fórmulas: ", err)
+		return nil, errors.New("error al obtener fórmulas de color de los turnos")
+	}
+	for _, formula := range formulas {
+		records[formula.AppointmentID].Formulas = append(records[formula.AppointmentID].Formulas, toColorFormulaDto(formula))
+	}
+
+	var photos []models.AppointmentPhoto
+	if err := db.Preload("UploadedBy").Where("appointment_id IN ?", appointmentIDs).Order("created_at, id").Find(&photos).Error; err != nil {
+		logger.Log.Error("[AppointmentRecordService][appointmentRecords] Error al obtener fotos: ", err)
+		return nil, errors.New("error al obtener fotos de los turnos")
+	}
+	for _, photo := range photos {
+		records[photo.AppointmentID].Photos = append(records[photo.AppointmentID].Photos, toAppointmentPhotoDto(photo))
+	}
+
+	return records, nil
+}
+
+func findRecordAppointment(appointmentID uint) error {
+	if err := database.DB.Select("id").First(&models.Appointment{}, appointmentID).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			logger.Log.Warnf("[AppointmentRecordService][findRecordAppointment] Turno no encontrado: ID %d", appointmentID)
+			return errors.New("turno no encontrado")
+		}
+		logger.Log.Error("[AppointmentRecordService][findRecordAppointment] Error al buscar turno: ", err)
+		return errors.New("error al buscar turno")
+	}
+	return nil
+}
+
+func applyColorFormulaDto(formula *models.ColorFormula, dto dtos.ColorFormulaDto) error {
+	brand := strings.TrimSpace(dto.Brand)
+	shade := strings.TrimSpace(dto.Shade)
+	if brand == "" || shade == "" {
+		return errors.New("la marca y el tono son obligatorios")
+	}
+	if dto.Grams <= 0 {
+		return errors.New("los gramos deben ser mayores a 0")
+	}
+	if !slices.Contains(developerVolumes, dto.DeveloperVolume) {
+		return fmt.Errorf("el volumen del oxidante debe ser uno de %v", developerVolumes)
+	}
+
+	formula.Zone = strings.TrimSpace(dto.Zone)
+	formula.Brand = brand
+	formula.Shade = shade
+	formula.DeveloperVolume = dto.DeveloperVolume
+	formula.Grams = dto.Grams
+	formula.ProcessingMinutes = dto.ProcessingMinutes
+	formula.Notes = strings.TrimSpace(dto.Notes)
+	return nil
+}
+
+// makeThumbnail reduce la imagen para que su lado mayor mida como mucho size píxeles,
+// promediando los píxeles de origen que cubre cada píxel de destino.
+func makeThumbnail(src image.Image, size int) image.Image {
+	bounds := src.Bounds()
+	width, height := bounds.Dx(), bounds.Dy()
+	if width <= size && height <= size {
+		size = max(width, height)
+	}
+	dstWidth, dstHeight := size, size
+	if width >= height {
+		dstHeight = max(1, height*size/width)
+	} else {
+		dstWidth = max(1, width*size/height)
+	}
+
+	dst := image.NewRGBA(image.Rect(0, 0, dstWidth, dstHeight))
+	for y := 0; y < dstHeight; y++ {
+		y0 := bounds.Min.Y + y*height/dstHeight
+		y1 := max(y0+1, bounds.Min.Y+(y+1)*height/dstHeight)
+		for x := 0; x < dstWidth; x++ {
+			x0 := bounds.Min.X + x*width/dstWidth
+			x1 := max(x0+1, bounds.Min.X+(x+1)*width/dstWidth)
+
+			var r, g, b, a, n uint64
+			for sy := y0; sy < y1; sy++ {
+				for sx := x0; sx < x1; sx++ {
+					cr, cg, cb, ca := src.At(sx, sy).RGBA()
+					r, g, b, a = r+uint64(cr), g+uint64(cg), b+uint64(cb), a+uint64(ca)
+					n++
+				}
+			}
+			dst.Set(x, y, color.RGBA64{R: uint16(r / n), G: uint16(g / n), B: uint16(b / n), A: uint16(a / n)})
+		}
+	}
+	return dst
+}
+
+func removePhotoFiles(photo models.AppointmentPhoto) {
+	ctx := context.Background()
+	for _, key := range []string{photo.StorageKey, photo.ThumbnailKey} {
+		if err := photoStore().Delete(ctx, key); err != nil {
+			logger.Log.Error("[AppointmentRecordService][removePhotoFiles] Error al borrar archivo ", key, ": ", err)
+		}
+	}
+}
+
+// PhotoMaxBytes devuelve el tamaño máximo de una foto, configurable con PHOTO_MAX_MB.
+func PhotoMaxBytes() int64 {
+	megabytes, err := strconv.Atoi(os.Getenv("PHOTO_MAX_MB"))
+	if err != nil || megabytes <= 0 {
+		megabytes = defaultPhotoMaxMB
+	}
+	return int64(megabytes) << 20
+}
+
+func randomFileName() (string, error) {
+	buf := make([]byte, 16)
+	if _, err := rand.Read(buf); err != nil {
+		logger.Log.Error("[AppointmentRecordService][randomFileName] Error al generar nombre: ", err)
+		return "", errors.New("error al generar nombre de archivo")
+	}
+	return hex.EncodeToString(buf), nil
+}
+
+func optionalUserID(userID uint) *uint {
+	if userID == 0 {
+		return nil
+	}
+	return &userID
+}
+
+func toAppointmentNoteDto(note models.AppointmentNote) dtos.GetAppointmentNoteDto {
+	return dtos.GetAppointmentNoteDto{
+		ID:            note.ID,
+		AppointmentID: note.AppointmentID,
+		Text:          note.Text,
+		AuthorID:      note.AuthorID,
+		Author:        username(note.Author),
+		CreatedAt:     note.CreatedAt.Format("02/01/2006 15:04"),
+		UpdatedAt:     note.UpdatedAt.Format("02/01/2006 15:04"),
+	}
+}
+
+func toColorFormulaDto(formula models.ColorFormula) dtos.GetColorFormulaDto {
+	return dtos.GetColorFormulaDto{
+		ID:                formula.ID,
+		AppointmentID:     formula.AppointmentID,
+		Zone:              formula.Zone,
+		Brand:             formula.Brand,
+		Shade:             formula.Shade,
+		DeveloperVolume:   formula.DeveloperVolume,
+		Grams:             formula.Grams,
+		ProcessingMinutes: formula.ProcessingMinutes,
+		Notes:             formula.Notes,
+		Author:            username(formula.Author),
+		CreatedAt:         formula.CreatedAt.Format("02/01/2006 15:04"),
+	}
+}
+
+func toAppointmentPhotoDto(photo models.AppointmentPhoto) dtos.GetAppointmentPhotoDto {
+	url := fmt.Sprintf("/api/v1/turno/fotos/%d", photo.ID)
+	return dtos.GetAppointmentPhotoDto{
+		ID:            photo.ID,
+		AppointmentID: photo.AppointmentID,
+		Kind:          photo.Kind,
+		Caption:       photo.Caption,
+		ContentType:   photo.ContentType,
+		Size:          photo.Size,
+		Width:         photo.Width,
+		Height:        photo.Height,
+		URL:           url,
+		ThumbnailURL:  url + "/miniatura",
+		UploadedBy:    username(photo.UploadedBy),
+		CreatedAt:     photo.CreatedAt.Format("02/01/2006 15:04"),
+	}
+}
+
+func username(user *models.User) string {
+	if user == nil {
+		return ""
+	}
+	return user.Username
+}
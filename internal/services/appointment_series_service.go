@@ -0,0 +1,221 @@
+package services
+
+import (
+	"errors"
+	"fmt"
+	"peluqueria/database"
+	"peluqueria/internal/dtos"
+	"peluqueria/internal/helpers"
+	"peluqueria/internal/models"
+	"peluqueria/logger"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// Límite de turnos que puede generar una serie.
+const maxSeriesOccurrences = 52
+
+// Frecuencias admitidas para las series recurrentes.
+const (
+	SeriesFrequencyWeekly  = "semanal"
+	SeriesFrequencyMonthly = "mensual"
+)
+
+// Alcance de una modificación sobre un turno que pertenece a una serie.
+const (
+	SeriesScopeThis      = "este"
+	SeriesScopeFollowing = "siguientes"
+	SeriesScopeAll       = "todos"
+)
+
+// createAppointmentSeries genera los turnos de una serie a partir del turno modelo. Las
+// ocurrencias que chocan con la agenda se informan como conflictos y no se guardan.
+func createAppointmentSeries(template models.Appointment, selection serviceSelection, recurrence dtos.RecurrenceDto, userID uint) (dtos.CreateAppointmentResultDto, error) {
+	logger.Log.Infof("[AppointmentSeriesService][createAppointmentSeries] Creando serie %s cada %d para cliente ID: %d", recurrence.Frequency, recurrence.Interval, template.ClientID)
+
+	series, dates, err := buildSeries(template, recurrence)
+	if err != nil {
+		return dtos.CreateAppointmentResultDto{}, err
+	}
+
+	result := dtos.CreateAppointmentResultDto{AppointmentIDs: []uint{}}
+	var firstConflict error
+
+	err = database.DB.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(&series).Error; err != nil {
+			logger.Log.Error("[AppointmentSeriesService][createAppointmentSeries] Error al crear serie: ", err)
+			return errors.New("error al crear la serie de turnos")
+		}
+
+		for _, date := range dates {
+			appointment := template
+			appointment.AppointmentDate = date
+			appointment.SeriesID = &series.ID
+
+			// Cada ocurrencia se guarda en un savepoint para poder descartarla si choca
+			err := tx.Transaction(func(occurrenceTx *gorm.DB) error {
+				return createAppointmentTx(occurrenceTx, &appointment, selection, userID)
+			})
+			if err == nil {
+				result.AppointmentIDs = append(result.AppointmentIDs, appointment.ID)
+				continue
+			}
+			if !isScheduleError(err) {
+				return err
+			}
+
+			if firstConflict == nil {
+				firstConflict = err
+			}
+			conflict := dtos.SeriesConflictDto{
+				AppointmentDate: date.Format("02/01/2006 15:04"),
+				Reason:          err.Error(),
+			}
+			var conflictErr *AppointmentConflictError
+			if errors.As(err, &conflictErr) {
+				conflict.ConflictingAppointmentID = &conflictErr.AppointmentID
+			}
+			result.Conflicts = append(result.Conflicts, conflict)
+		}
+
+		if len(result.AppointmentIDs) == 0 {
+			return firstConflict
+		}
+		return nil
+	})
+	if err != nil {
+		if isScheduleError(err) {
+			logger.Log.Warn("[AppointmentSeriesService][createAppointmentSeries] Ninguna ocurrencia pudo agendarse: ", err)
+			return dtos.CreateAppointmentResultDto{}, err
+		}
+		logger.Log.Error("[AppointmentSeriesService][createAppointmentSeries] Error al crear serie: ", err)
+		return dtos.CreateAppointmentResultDto{}, errors.New("error al crear la serie de turnos")
+	}
+
+	result.SeriesID = &series.ID
+	logger.Log.Infof("[AppointmentSeriesService][createAppointmentSeries] Serie ID %d creada: %d turnos, %d conflictos", series.ID, len(result.AppointmentIDs), len(result.Conflicts))
+	return result, nil
+}
+
+// buildSeries valida la regla de repetición y calcula las fechas de cada ocurrencia.
+func buildSeries(template models.Appointment, recurrence dtos.RecurrenceDto) (models.AppointmentSeries, []time.Time, error) {
+	if recurrence.Frequency != SeriesFrequencyWeekly && recurrence.Frequency != SeriesFrequencyMonthly {
+		logger.Log.Warnf("[AppointmentSeriesService][buildSeries] Frecuencia inválida: %s", recurrence.Frequency)
+		return models.AppointmentSeries{}, nil, errors.New("la frecuencia debe ser 'semanal' o 'mensual'")
+	}
+	if recurrence.Interval == 0 {
+		recurrence.Interval = 1
+	}
+	if recurrence.Count == 0 && recurrence.Until == "" {
+		logger.Log.Warn("[AppointmentSeriesService][buildSeries] Falta count o until")
+		return models.AppointmentSeries{}, nil, errors.New("debe indicar la cantidad de turnos o la fecha límite de la serie")
+	}
+	if recurrence.Count > maxSeriesOccurrences {
+		return models.AppointmentSeries{}, nil, fmt.Errorf("una serie no puede tener más de %d turnos", maxSeriesOccurrences)
+	}
+
+	series := models.AppointmentSeries{
+		ClientID:  template.ClientID,
+		Frequency: recurrence.Frequency,
+		Interval:  recurrence.Interval,
+		Count:     recurrence.Count,
+	}
+
+	var until time.Time
+	if recurrence.Until != "" {
+		untilDay, err := helpers.ParseCustomDay(recurrence.Until)
+		if err != nil {
+			return models.AppointmentSeries{}, nil, err
+		}
+		if untilDay.Before(template.AppointmentDate) {
+			return models.AppointmentSeries{}, nil, errors.New("la fecha límite debe ser posterior al primer turno")
+		}
+		until = untilDay.AddDate(0, 0, 1) // Inclusivo: hasta el final de ese día
+		series.UntilDate = &untilDay
+	}
+
+	dates := seriesOccurrences(template.AppointmentDate, recurrence.Frequency, recurrence.Interval, recurrence.Count, until)
+	return series, dates, nil
+}
+
+// seriesOccurrences calcula las fechas de la serie. La frecuencia mensual repite el mismo
+// día de la semana y número de semana del mes (por ejemplo, el segundo martes); los meses
+// que no tienen esa semana se saltean.
+func seriesOccurrences(first time.Time, frequency string, interval uint, count uint, until time.Time) []time.Time {
+	var dates []time.Time
+	weekOfMonth := (first.Day()-1)/7 + 1
+
+	for step := 0; len(dates) < maxSeriesOccurrences; step++ {
+		var date time.Time
+		if frequency == SeriesFrequencyWeekly {
+			date = first.AddDate(0, 0, 7*int(interval)*step)
+		} else {
+			var ok bool
+			date, ok = nthWeekdayOfMonth(first, step*int(interval), weekOfMonth)
+			if !ok {
+				continue
+			}
+		}
+
+		if !until.IsZero() && !date.Before(until) {
+			break
+		}
+		dates = append(dates, date)
+		if count > 0 && uint(len(dates)) >= count {
+			break
+		}
+	}
+	return dates
+}
+
+// nthWeekdayOfMonth devuelve el n-ésimo día de la semana de first, monthsAhead meses
+// después, manteniendo la hora. Devuelve false si ese mes no tiene esa semana.
+func nthWeekdayOfMonth(first time.Time, monthsAhead int, n int) (time.Time, bool) {
+	monthStart := time.Date(first.Year(), first.Month(), 1, first.Hour(), first.Minute(), 0, 0, first.Location()).AddDate(0, monthsAhead, 0)
+	offset := (int(first.Weekday()) - int(monthStart.Weekday()) + 7) % 7
+	date := monthStart.AddDate(0, 0, offset+7*(n-1))
+	if date.Month() != monthStart.Month() {
+		return time.Time{}, false
+	}
+	return date, true
+}
+
+// seriesScopeAppointments devuelve los turnos alcanzados por una modificación: solo el turno,
+// el turno y los siguientes de su serie, o toda la serie. Se excluyen los turnos en estado
+// terminal salvo el propio turno indicado.
+func seriesScopeAppointments(tx *gorm.DB, appointment models.Appointment, scope string) ([]models.Appointment, error) {
+	if scope == "" {
+		scope = SeriesScopeThis
+	}
+	if scope != SeriesScopeThis && scope != SeriesScopeFollowing && scope != SeriesScopeAll {
+		logger.Log.Warnf("[AppointmentSeriesService][seriesScopeAppointments] Alcance inválido: %s", scope)
+		return nil, errors.New("el alcance debe ser 'este', 'siguientes' o 'todos'")
+	}
+	if scope == SeriesScopeThis || appointment.SeriesID == nil {
+		return []models.Appointment{appointment}, nil
+	}
+
+	query := tx.Where("series_id = ? AND id <> ?", *appointment.SeriesID, appointment.ID).
+		Where("status NOT IN ?", terminalStatuses())
+	if scope == SeriesScopeFollowing {
+		query = query.Where("appointment_date > ?", appointment.AppointmentDate)
+	}
+
+	var others []models.Appointment
+	if err := query.Order("appointment_date").Find(&others).Error; err != nil {
+		logger.Log.Error("[AppointmentSeriesService][seriesScopeAppointments] Error al obtener turnos de la serie: ", err)
+		return nil, errors.New("error al obtener turnos de la serie")
+	}
+
+	return append([]models.Appointment{appointment}, others...), nil
+}
+
+// terminalStatuses lista los estados que ya no admiten cambios.
+func terminalStatuses() []string {
+	return []string{
+		models.AppointmentStatusFinished,
+		models.AppointmentStatusCancelled,
+		models.AppointmentStatusNoShow,
+	}
+}
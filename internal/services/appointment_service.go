@@ -5,90 +5,151 @@ import (
 	"fmt"
 	"peluqueria/database"
 	"peluqueria/internal/dtos"
+	"peluqueria/internal/events"
 	"peluqueria/internal/helpers"
 	"peluqueria/internal/models"
 	"peluqueria/logger"
+	"strings"
+	"time"
 
 	"gorm.io/gorm"
 )
 
-func CreateAppointment(appointmentDto dtos.CreateAppointmentDto) error {
+func CreateAppointment(appointmentDto dtos.CreateAppointmentDto, userID uint) (dtos.CreateAppointmentResultDto, error) {
+	return createAppointment(appointmentDto, models.AppointmentStatusPending, userID)
+}
+
+// createAppointment crea el turno (o la serie) con el estado inicial indicado.
+func createAppointment(appointmentDto dtos.CreateAppointmentDto, status string, userID uint) (dtos.CreateAppointmentResultDto, error) {
 	logger.Log.Infof("[AppointmentService][CreateAppointment] Creando cita para cliente ID: %d", appointmentDto.ClientID)
 
+	appointment, selection, err := newAppointment(database.DB, appointmentDto, status)
+	if err != nil {
+		return dtos.CreateAppointmentResultDto{}, err
+	}
+
+	if appointmentDto.Recurrence != nil {
+		result, err := createAppointmentSeries(appointment, selection, *appointmentDto.Recurrence, userID)
+		if err != nil {
+			return result, err
+		}
+		publishAppointmentEvent(events.AppointmentCreated, result.AppointmentIDs...)
+		return result, nil
+	}
+
+	err = database.DB.Transaction(func(tx *gorm.DB) error {
+		return createAppointmentTx(tx, &appointment, selection, userID)
+	})
+
+	if err != nil {
+		if isScheduleError(err) {
+			return dtos.CreateAppointmentResultDto{}, err
+		}
+		logger.Log.Error("[AppointmentService][CreateAppointment] Error al crear cita: ", err)
+		return dtos.CreateAppointmentResultDto{}, errors.New("error al crear cita")
+	}
+
+	logger.Log.Infof("[AppointmentService][CreateAppointment] Cita creada con éxito: ID %d", appointment.ID)
+	publishAppointmentEvent(events.AppointmentCreated, appointment.ID)
+	return dtos.CreateAppointmentResultDto{AppointmentIDs: []uint{appointment.ID}}, nil
+}
+
+// newAppointment valida los datos del turno a crear y arma el turno con sus servicios, sin
+// guardarlo. Recibe la transacción cuando el cliente se crea junto con el turno.
+func newAppointment(db *gorm.DB, appointmentDto dtos.CreateAppointmentDto, status string) (models.Appointment, serviceSelection, error) {
 	appointmentDate, err := helpers.ParseCustomDate(appointmentDto.AppointmentDate)
 	if err != nil {
-		logger.Log.Warn("[AppointmentService][CreateAppointment] Error al parsear fecha: ", err)
-		return err
+		logger.Log.Warn("[AppointmentService][newAppointment] Error al parsear fecha: ", err)
+		return models.Appointment{}, serviceSelection{}, err
 	}
 
 	// Validar que el cliente existe
 	var client models.Client
-	if err := database.DB.First(&client, appointmentDto.ClientID).Error; err != nil {
+	if err := db.First(&client, appointmentDto.ClientID).Error; err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
-			logger.Log.Warnf("[AppointmentService][CreateAppointment] Cliente no encontrado: ID %d", appointmentDto.ClientID)
-			return errors.New("cliente no encontrado")
+			logger.Log.Warnf("[AppointmentService][newAppointment] Cliente no encontrado: ID %d", appointmentDto.ClientID)
+			return models.Appointment{}, serviceSelection{}, errors.New("cliente no encontrado")
 		}
-		logger.Log.Error("[AppointmentService][CreateAppointment] Error al buscar cliente: ", err)
-		return errors.New("error al buscar cliente")
+		logger.Log.Error("[AppointmentService][newAppointment] Error al buscar cliente: ", err)
+		return models.Appointment{}, serviceSelection{}, errors.New("error al buscar cliente")
 	}
 
-	// Validar que los servicios existen
-	var services []models.Service
-	if len(appointmentDto.ServiceIds) > 0 {
-		if err := database.DB.Where("id IN ?", appointmentDto.ServiceIds).Find(&services).Error; err != nil {
-			logger.Log.Error("[AppointmentService][CreateAppointment] Error al buscar servicios: ", err)
-			return errors.New("error al buscar servicios")
-		}
-		if len(services) != len(appointmentDto.ServiceIds) {
-			logger.Log.Warn("[AppointmentService][CreateAppointment] Uno o más servicios no existen")
-			return errors.New("uno o más servicios no existen")
+	// Validar el estilista asignado (opcional)
+	var staffID *uint
+	if appointmentDto.StaffID != 0 {
+		staff, err := findActiveStaff(db, appointmentDto.StaffID)
+		if err != nil {
+			return models.Appointment{}, serviceSelection{}, err
 		}
+		staffID = &staff.ID
+	}
+
+	// Validar que los servicios y los combos existen
+	selection, err := loadServiceSelection(db, appointmentSelection(appointmentDto))
+	if err != nil {
+		return models.Appointment{}, serviceSelection{}, err
+	}
+
+	// Validar que el estilista realice los servicios
+	if _, err := staffServiceTerms(db, staffID, selection.Services); err != nil {
+		return models.Appointment{}, serviceSelection{}, err
 	}
 
-	// Crear la cita
 	appointment := models.Appointment{
 		ClientID:        appointmentDto.ClientID,
+		StaffID:         staffID,
 		AppointmentDate: appointmentDate,
-		Status:          "pendiente", // Estado inicial
+		Status:          status,
+	}
+	if appointmentDto.DurationOverride != nil && *appointmentDto.DurationOverride > 0 {
+		appointment.DurationOverride = appointmentDto.DurationOverride
 	}
+	return appointment, selection, nil
+}
 
-	err = database.DB.Transaction(func(tx *gorm.DB) error {
-		if err := tx.Create(&appointment).Error; err != nil {
+// createAppointmentTx guarda el turno con sus servicios y su estado inicial en el historial,
+// y valida la agenda dentro de la transacción recibida.
+func createAppointmentTx(tx *gorm.DB, appointment *models.Appointment, selection serviceSelection, userID uint) error {
+	if appointment.StaffID != nil {
+		if err := lockStaffAgenda(tx, *appointment.StaffID); err != nil {
 			return err
 		}
+	}
 
-		// Asociar servicios al appointment
-		for _, service := range services {
-			appointmentService := models.AppointmentService{
-				AppointmentID: appointment.ID,
-				ServiceID:     service.ID,
-				Price:         service.Price,
-			}
-			if err := tx.Create(&appointmentService).Error; err != nil {
-				return err
-			}
-		}
-		return nil
-	})
-
+	terms, err := selection.terms(tx, appointment.StaffID)
 	if err != nil {
-		logger.Log.Error("[AppointmentService][CreateAppointment] Error al crear cita: ", err)
-		return errors.New("error al crear cita")
+		return err
 	}
 
-	logger.Log.Infof("[AppointmentService][CreateAppointment] Cita creada con éxito: ID %d", appointment.ID)
-	return nil
+	if err := tx.Create(appointment).Error; err != nil {
+		return err
+	}
+	if err := recordStatusHistory(tx, appointment.ID, "", appointment.Status, "", userID); err != nil {
+		return err
+	}
+
+	// Asociar servicios al appointment, con el precio y el tiempo del estilista
+	for _, appointmentService := range serviceLines(appointment.ID, appointment.StaffID, selection, terms) {
+		if err := tx.Create(&appointmentService).Error; err != nil {
+			return err
+		}
+	}
+
+	return validateAppointmentSchedule(tx, appointment.ID)
 }
 
-func GetAllAppointments(clientID, status, startDate, endDate string) ([]dtos.AllAppointmentDto, error) {
+func GetAllAppointments(clientID, staffID, status, startDate, endDate string) ([]dtos.AllAppointmentDto, error) {
 	logger.Log.Info("[AppointmentService][GetAllAppointments] Obteniendo todas las citas con filtros")
 
 	var appointments []models.Appointment
-	query := database.DB.Preload("Client").Preload("AppointmentServices.Service")
+	query := database.DB.Preload("Client").Preload("Staff").Preload("AppointmentServices.Service")
 
 	if clientID != "" {
 		query = query.Where("client_id = ?", clientID)
 	}
+	if staffID != "" {
+		query = query.Where("staff_id = ?", staffID)
+	}
 	if status != "" {
 		query = query.Where("status = ?", status)
 	}
@@ -103,17 +164,18 @@ func GetAllAppointments(clientID, status, startDate, endDate string) ([]dtos.All
 
 	var appointmentsDto []dtos.AllAppointmentDto
 	for _, appointment := range appointments {
-		var timeInMinutes uint
-		for _, appService := range appointment.AppointmentServices {
-			timeInMinutes += appService.Service.EstimatedTimeMinutes
-		}
+		timeInMinutes := uint(appointmentDuration(appointment).Minutes())
 		appointmentsDto = append(appointmentsDto, dtos.AllAppointmentDto{
 			ID:                   appointment.ID,
 			ClientID:             appointment.ClientID,
 			ClientName:           fmt.Sprintf("%s %s", appointment.Client.Name, appointment.Client.LastName),
+			StaffID:              appointment.StaffID,
+			StaffName:            staffFullName(appointment.Staff),
+			SeriesID:             appointment.SeriesID,
 			Status:               appointment.Status,
 			AppointmentDate:      appointment.AppointmentDate.Format("02/01/2006 15:04"),
 			EstimatedTimeMinutes: timeInMinutes,
+			DurationOverride:     appointment.DurationOverride,
 		})
 	}
 
@@ -125,8 +187,15 @@ func GetAppointmentByID(id uint) (dtos.AppointmentByIDDto, error) {
 	var appointment models.Appointment
 	err := database.DB.
 		Preload("Client").
+		Preload("Staff").
 		Preload("AppointmentServices.Service").
+		Preload("AppointmentServices.Staff").
+		Preload("AppointmentServices.Bundle", func(db *gorm.DB) *gorm.DB { return db.Unscoped() }).
+		Preload("AppointmentServices.Variant", func(db *gorm.DB) *gorm.DB { return db.Unscoped() }).
+		Preload("AppointmentServices.Options").
+		Preload("AppointmentServices.Promotion", func(db *gorm.DB) *gorm.DB { return db.Unscoped() }).
 		Preload("AppointmentProducts.Product").
+		Preload("Resources.Resource", func(db *gorm.DB) *gorm.DB { return db.Unscoped() }).
 		First(&appointment, id).
 		Error
 
@@ -141,12 +210,30 @@ func GetAppointmentByID(id uint) (dtos.AppointmentByIDDto, error) {
 
 	var services []dtos.AppointmentServiceDto
 	for _, appService := range appointment.AppointmentServices {
-		services = append(services, dtos.AppointmentServiceDto{
+		serviceDto := dtos.AppointmentServiceDto{
 			ServiceID:            appService.Service.ID,
 			ServiceName:          appService.Service.Name,
 			Price:                appService.Price,
-			EstimatedTimeMinutes: appService.Service.EstimatedTimeMinutes,
-		})
+			ListPrice:            lineListPrice(appService),
+			EstimatedTimeMinutes: lineActiveMinutes(appService),
+			StaffID:              appService.StaffID,
+			StaffName:            staffFullName(appService.Staff),
+			BundleID:             appService.BundleID,
+			VariantID:            appService.VariantID,
+			Options:              toAppointmentServiceOptionDtos(appService.Options),
+			Discount:             appService.Discount,
+			PromotionID:          appService.PromotionID,
+		}
+		if appService.Bundle != nil {
+			serviceDto.BundleName = appService.Bundle.Name
+		}
+		if appService.Variant != nil {
+			serviceDto.VariantName = appService.Variant.Name
+		}
+		if appService.Promotion != nil {
+			serviceDto.PromotionName = appService.Promotion.Name
+		}
+		services = append(services, serviceDto)
 	}
 
 	var products []dtos.AppointmentProductDto
@@ -159,29 +246,72 @@ func GetAppointmentByID(id uint) (dtos.AppointmentByIDDto, error) {
 		})
 	}
 
+	records, err := appointmentRecords(database.DB, []uint{appointment.ID})
+	if err != nil {
+		return dtos.AppointmentByIDDto{}, err
+	}
+	record := records[appointment.ID]
+
+	payments, err := appointmentPayments(database.DB, []uint{appointment.ID})
+	if err != nil {
+		return dtos.AppointmentByIDDto{}, err
+	}
+	tips, err := appointmentTips(database.DB, []uint{appointment.ID})
+	if err != nil {
+		return dtos.AppointmentByIDDto{}, err
+	}
+	var total, discount float64
+	for _, appService := range appointment.AppointmentServices {
+		total += appService.Price
+		discount += appService.Discount
+	}
+
 	appointmentDto := dtos.AppointmentByIDDto{
-		ID:              appointment.ID,
-		ClientID:        appointment.ClientID,
-		ClientName:      fmt.Sprintf("%s %s", appointment.Client.Name, appointment.Client.LastName),
-		Status:          appointment.Status,
-		AppointmentDate: appointment.AppointmentDate.Format("02/01/2006 15:04"),
-		Services:        services,
-		Products:        products,
-		CreatedAt:       appointment.CreatedAt,
-		UpdatedAt:       appointment.UpdatedAt,
+		ID:                   appointment.ID,
+		ClientID:             appointment.ClientID,
+		ClientName:           fmt.Sprintf("%s %s", appointment.Client.Name, appointment.Client.LastName),
+		StaffID:              appointment.StaffID,
+		StaffName:            staffFullName(appointment.Staff),
+		Status:               appointment.Status,
+		CancellationReason:   appointment.CancellationReason,
+		SeriesID:             appointment.SeriesID,
+		AppointmentDate:      appointment.AppointmentDate.Format("02/01/2006 15:04"),
+		EstimatedTimeMinutes: uint(appointmentDuration(appointment).Minutes()),
+		DurationOverride:     appointment.DurationOverride,
+		Services:             services,
+		Products:             products,
+		Resources:            toAppointmentResourceDtos(appointment.Resources),
+		CheckedInAt:          formatTimestamp(appointment.CheckedInAt, "02/01/2006 15:04"),
+		StartedAt:            formatTimestamp(appointment.StartedAt, "02/01/2006 15:04"),
+		FinishedAt:           formatTimestamp(appointment.FinishedAt, "02/01/2006 15:04"),
+		ActualMinutes:        actualMinutes(appointment),
+		Notes:                record.Notes,
+		ColorFormulas:        record.Formulas,
+		Photos:               record.Photos,
+		Total:                roundCents(total),
+		Discount:             roundCents(discount),
+		PaymentMethod:        appointment.PaymentMethod,
+		Payments:             append([]dtos.GetPaymentDto{}, payments[appointment.ID]...),
+		Tips:                 append([]dtos.GetTipDto{}, tips[appointment.ID]...),
+		CreatedAt:            appointment.CreatedAt,
+		UpdatedAt:            appointment.UpdatedAt,
 	}
 
 	logger.Log.Infof("[AppointmentService][GetAppointmentByID] Turno obtenido con éxito: ID %d", id)
 	return appointmentDto, nil
 }
 
-func UpdateAppointment(id uint, appointmentDto dtos.CreateAppointmentDto) error {
+func UpdateAppointment(id uint, appointmentDto dtos.CreateAppointmentDto, scope string) error {
 	logger.Log.Infof("[AppointmentService][UpdateAppointment] Actualizando turno con ID: %d", id)
 	if id == 0 {
 		logger.Log.Warn("[AppointmentService][UpdateAppointment] ID del turno faltante en actualización")
 		return errors.New("el ID del turno es obligatorio")
 	}
 
+	// Horarios previos, para ofrecer a la lista de espera lo que quede libre
+	var freed []freedSlot
+	var updatedIDs []uint
+
 	// Iniciar transacción
 	err := database.DB.Transaction(func(tx *gorm.DB) error {
 		var existingAppointment models.Appointment
@@ -193,13 +323,20 @@ func UpdateAppointment(id uint, appointmentDto dtos.CreateAppointmentDto) error
 			logger.Log.Error("[AppointmentService][UpdateAppointment] Error al buscar Turno para actualizar: ", err)
 			return err
 		}
+		if isTerminalStatus(existingAppointment.Status) {
+			logger.Log.Warnf("[AppointmentService][UpdateAppointment] Turno en estado terminal: %s", existingAppointment.Status)
+			return fmt.Errorf("no se puede modificar un turno en estado '%s'", existingAppointment.Status)
+		}
+
+		// El cambio de fecha se aplica como desplazamiento para el resto de la serie
+		var shift time.Duration
 		if appointmentDto.AppointmentDate != "" {
 			appointmentDate, err := helpers.ParseCustomDate(appointmentDto.AppointmentDate)
 			if err != nil {
 				logger.Log.Warn("[AppointmentService][UpdateAppointment] Error al parsear fecha: ", err)
 				return err
 			}
-			existingAppointment.AppointmentDate = appointmentDate
+			shift = appointmentDate.Sub(existingAppointment.AppointmentDate)
 		}
 
 		if appointmentDto.ClientID != 0 {
@@ -207,56 +344,161 @@ func UpdateAppointment(id uint, appointmentDto dtos.CreateAppointmentDto) error
 				logger.Log.Warn("[AppointmentService][UpdateAppointment] cliente no encontrado: ")
 				return errors.New("cliente no encontrado")
 			}
-			existingAppointment.ClientID = appointmentDto.ClientID
 		}
 
-		if err := tx.Save(&existingAppointment).Error; err != nil {
-			logger.Log.Error("[AppointmentService][UpdateAppointment] Error al actualizar fecha: ", err)
-			return errors.New("error al actualizar la fecha del turno")
+		appointments, err := seriesScopeAppointments(tx, existingAppointment, scope)
+		if err != nil {
+			return err
 		}
-
-		if len(appointmentDto.ServiceIds) > 0 {
-			logger.Log.Infof("[AppointmentService][UpdateAppointment] Actualizando servicios del turno")
-			if err := tx.Unscoped().Where("appointment_id = ?", existingAppointment.ID).Delete(&models.AppointmentService{}).Error; err != nil {
-				logger.Log.Error("[AppointmentService][UpdateAppointment] Error al eliminar servicios antiguos: ", err)
-				return errors.New("error al eliminar servicios antiguos")
+		for i := range appointments {
+			slot, err := captureFreedSlot(tx, appointments[i].ID)
+			if err != nil {
+				return err
 			}
-
-			for _, serviceId := range appointmentDto.ServiceIds {
-				var service models.Service
-				if err := tx.First(&service, serviceId).Error; err != nil {
-					logger.Log.Warn("[AppointmentService][UpdateAppointment] Servicio no encontrado: ID ", serviceId)
-					return errors.New("servicio no encontrado")
-				}
-
-				appointmentService := models.AppointmentService{
-					AppointmentID: existingAppointment.ID,
-					ServiceID:     serviceId,
-					Price:         service.Price,
-				}
-
-				if err := tx.Create(&appointmentService).Error; err != nil {
-					logger.Log.Error("[AppointmentService][UpdateAppointment] Error al asignar servicios al turno: ", err)
-					return errors.New("error al asignar servicios al turno")
-				}
+			if err := applyAppointmentUpdate(tx, &appointments[i], appointmentDto, shift); err != nil {
+				return err
 			}
+			// Solo se libera un horario si el turno cambió de horario o de estilista
+			updated, err := captureFreedSlot(tx, appointments[i].ID)
+			if err != nil {
+				return err
+			}
+			if slot.movedFrom(updated) {
+				freed = append(freed, slot)
+			}
+			updatedIDs = append(updatedIDs, appointments[i].ID)
 		}
 		return nil
 	})
 	if err != nil {
 		logger.Log.Error("[AppointmentService][UpdateAppointment] Error en transacción: ", err)
-		return errors.New("error al actualizar el turno: " + err.Error())
+		return fmt.Errorf("error al actualizar el turno: %w", err)
 	}
+	publishAppointmentEvent(events.AppointmentUpdated, updatedIDs...)
+	matchWaitlist(freed)
 	logger.Log.Infof("[AppointmentService][UpdateAppointment] Turno actualizado con éxito")
 	return nil
 }
 
+// applyAppointmentUpdate aplica los cambios del DTO a un turno ya cargado, desplazando su
+// fecha según shift, y valida la agenda resultante.
+func applyAppointmentUpdate(tx *gorm.DB, existingAppointment *models.Appointment, appointmentDto dtos.CreateAppointmentDto, shift time.Duration) error {
+	existingAppointment.AppointmentDate = existingAppointment.AppointmentDate.Add(shift)
+
+	if appointmentDto.ClientID != 0 {
+		existingAppointment.ClientID = appointmentDto.ClientID
+	}
+	if appointmentDto.DurationOverride != nil {
+		existingAppointment.DurationOverride = nil
+		if *appointmentDto.DurationOverride > 0 {
+			override := *appointmentDto.DurationOverride
+			existingAppointment.DurationOverride = &override
+		}
+	}
+
+	staffChanged := false
+	if appointmentDto.StaffID != 0 {
+		staff, err := findActiveStaff(tx, appointmentDto.StaffID)
+		if err != nil {
+			return err
+		}
+		staffChanged = existingAppointment.StaffID == nil || *existingAppointment.StaffID != staff.ID
+		existingAppointment.StaffID = &staff.ID
+	}
+
+	if existingAppointment.StaffID != nil {
+		if err := lockStaffAgenda(tx, *existingAppointment.StaffID); err != nil {
+			return err
+		}
+	}
+
+	existingAppointment.Sequence++
+	if err := tx.Save(existingAppointment).Error; err != nil {
+		logger.Log.Error("[AppointmentService][UpdateAppointment] Error al actualizar fecha: ", err)
+		return errors.New("error al actualizar la fecha del turno")
+	}
+
+	if request := appointmentSelection(appointmentDto); !isEmptySelection(request) {
+		logger.Log.Infof("[AppointmentService][UpdateAppointment] Actualizando servicios del turno")
+		selection, err := loadServiceSelection(tx, request)
+		if err != nil {
+			return err
+		}
+		terms, err := selection.terms(tx, existingAppointment.StaffID)
+		if err != nil {
+			return err
+		}
+
+		if err := tx.Unscoped().Where("appointment_id = ?", existingAppointment.ID).Delete(&models.AppointmentService{}).Error; err != nil {
+			logger.Log.Error("[AppointmentService][UpdateAppointment] Error al eliminar servicios antiguos: ", err)
+			return errors.New("error al eliminar servicios antiguos")
+		}
+
+		for _, appointmentService := range serviceLines(existingAppointment.ID, existingAppointment.StaffID, selection, terms) {
+			if err := tx.Create(&appointmentService).Error; err != nil {
+				logger.Log.Error("[AppointmentService][UpdateAppointment] Error al asignar servicios al turno: ", err)
+				return errors.New("error al asignar servicios al turno")
+			}
+		}
+	} else if staffChanged {
+		// Reasignar las líneas de servicio al nuevo estilista, con su precio y su tiempo; las
+		// líneas de un combo conservan el precio y el tiempo con los que se reservaron
+		var lines []models.AppointmentService
+		if err := tx.
+			Preload("Service").
+			Preload("Bundle", func(db *gorm.DB) *gorm.DB { return db.Unscoped() }).
+			Preload("Variant", func(db *gorm.DB) *gorm.DB { return db.Unscoped() }).
+			Preload("Options").
+			Where("appointment_id = ?", existingAppointment.ID).
+			Order("id").
+			Find(&lines).Error; err != nil {
+			logger.Log.Error("[AppointmentService][UpdateAppointment] Error al obtener servicios del turno: ", err)
+			return errors.New("error al reasignar estilista de los servicios")
+		}
+		terms, err := lineSelection(lines).terms(tx, existingAppointment.StaffID)
+		if err != nil {
+			return err
+		}
+		for i, line := range lines {
+			values := map[string]interface{}{"staff_id": existingAppointment.StaffID}
+			if line.BundleID == nil {
+				values["price"] = terms[i].Price
+				values["list_price"] = terms[i].ListPrice
+				values["active_minutes"] = terms[i].ActiveMinutes
+			}
+			if err := tx.Model(&models.AppointmentService{}).Where("id = ?", line.ID).Updates(values).Error; err != nil {
+				logger.Log.Error("[AppointmentService][UpdateAppointment] Error al reasignar estilista de los servicios: ", err)
+				return errors.New("error al reasignar estilista de los servicios")
+			}
+		}
+	}
+
+	return validateAppointmentSchedule(tx, existingAppointment.ID)
+}
+
 func DeleteAppointment(id uint) error {
 	logger.Log.Infof("eliminando turno con id: %v", id)
 	if id == 0 {
 		logger.Log.Warn("ID del turno faltante en eliminación")
 		return errors.New("el ID del turno es obligatorio")
 	}
+	var appointment models.Appointment
+	if err := database.DB.Select("id", "status").First(&appointment, id).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			logger.Log.Warnf("turno no encontrado: ID %d", id)
+			return errors.New("turno no encontrado")
+		}
+		logger.Log.Error("Error al buscar turno: ", err)
+		return errors.New("error al buscar turno")
+	}
+	var freed []freedSlot
+	if !isInactiveStatus(appointment.Status) && appointment.Status != models.AppointmentStatusFinished {
+		slot, err := captureFreedSlot(database.DB, id)
+		if err != nil {
+			return err
+		}
+		freed = append(freed, slot)
+	}
 	if err := database.DB.Delete(&models.Appointment{}, id).Error; err != nil {
 		logger.Log.Error("Error al eliminar turno: ", err)
 		return errors.New("error al eliminar turno")
@@ -270,18 +512,16 @@ func DeleteAppointment(id uint) error {
 		return errors.New("error al eliminar servicios productos al turno")
 	}
 	logger.Log.Infof("turno eliminado con éxito: ID %d", id)
+	publishAppointmentEvent(events.AppointmentDeleted, id)
+	matchWaitlist(freed)
 	return nil
 }
 
-func FinalizeAppointment(id uint, finalizeDto dtos.FinalizeAppointmentDto) error {
+func FinalizeAppointment(id uint, finalizeDto dtos.FinalizeAppointmentDto, userID uint) error {
 	logger.Log.Infof("[AppointmentService][FinalizeAppointment] Finalizando turno con ID: %d", id)
 
-	if finalizeDto.PaymentMethod == "" {
-		logger.Log.Warn("[AppointmentService][FinalizeAppointment] Método de pago faltante")
-		return errors.New("el método de pago es obligatorio")
-	}
-
-	return database.DB.Transaction(func(tx *gorm.DB) error {
+	var usedProductIDs []uint
+	err := database.DB.Transaction(func(tx *gorm.DB) error {
 		var appointment models.Appointment
 		if err := tx.First(&appointment, id).Error; err != nil {
 			if errors.Is(err, gorm.ErrRecordNotFound) {
@@ -292,17 +532,62 @@ func FinalizeAppointment(id uint, finalizeDto dtos.FinalizeAppointmentDto) error
 			return err
 		}
 
-		if appointment.Status == "finalizado" {
+		if appointment.Status == models.AppointmentStatusFinished {
 			logger.Log.Warn("[AppointmentService][FinalizeAppointment] El turno ya está finalizado")
 			return errors.New("el turno ya está finalizado")
 		}
 
+		// Aplicar promociones y cupones antes de calcular el total a cobrar
+		if err := applyPromotions(tx, appointment, finalizeDto); err != nil {
+			return err
+		}
+
+		// Los pagos deben cubrir exactamente el total del turno
+		total, err := appointmentTotal(tx, appointment.ID)
+		if err != nil {
+			return err
+		}
+		payments, err := buildPayments(tx, appointment.ID, finalizeDto, total, userID)
+		if err != nil {
+			return err
+		}
+
+		// Las propinas se registran aparte de los pagos del turno
+		tips, err := buildTips(tx, appointment, finalizeDto.Tips, userID)
+		if err != nil {
+			return err
+		}
+
+		// Lo cobrado en efectivo entra en la caja abierta
+		if err := attachCashSession(tx, payments, tips); err != nil {
+			return err
+		}
+
 		// Actualizar el estado del turno
-		appointment.Status = "finalizado"
-		appointment.PaymentMethod = finalizeDto.PaymentMethod
-		if err := tx.Save(&appointment).Error; err != nil {
-			logger.Log.Error("[AppointmentService][FinalizeAppointment] Error al actualizar estado del turno: ", err)
-			return errors.New("error al actualizar estado del turno")
+		appointment.PaymentMethod = paymentSummary(payments)
+		if err := changeAppointmentStatus(tx, &appointment, models.AppointmentStatusFinished, "", userID); err != nil {
+			return err
+		}
+
+		for i := range payments {
+			if err := tx.Omit("Method").Create(&payments[i]).Error; err != nil {
+				logger.Log.Error("[AppointmentService][FinalizeAppointment] Error al registrar pago: ", err)
+				return errors.New("error al registrar pagos del turno")
+			}
+		}
+		for i := range tips {
+			if err := tx.Omit("Staff", "Method").Create(&tips[i]).Error; err != nil {
+				logger.Log.Error("[AppointmentService][FinalizeAppointment] Error al registrar propina: ", err)
+				return errors.New("error al registrar propinas del turno")
+			}
+		}
+		if err := recordCashMovements(tx, appointment.ID, payments, tips, userID); err != nil {
+			return err
+		}
+
+		// Emitir el número de comprobante en el orden en que se finalizan los turnos
+		if _, err := issueReceipt(tx, appointment.ID); err != nil {
+			return err
 		}
 
 		// Registrar productos utilizados (si se incluyen)
@@ -326,6 +611,8 @@ func FinalizeAppointment(id uint, finalizeDto dtos.FinalizeAppointmentDto) error
 					return errors.New("error al actualizar stock del producto")
 				}
 
+				usedProductIDs = append(usedProductIDs, product.ID)
+
 				// Registrar el producto en AppointmentProducts
 				appointmentProduct := models.AppointmentProduct{
 					AppointmentID: appointment.ID,
@@ -354,6 +641,13 @@ func FinalizeAppointment(id uint, finalizeDto dtos.FinalizeAppointmentDto) error
 		logger.Log.Infof("[AppointmentService][FinalizeAppointment] Turno finalizado con éxito: ID %d", id)
 		return nil
 	})
+	if err != nil {
+		return err
+	}
+
+	publishAppointmentEvent(events.AppointmentFinalized, id)
+	publishStockChanged(usedProductIDs...)
+	return nil
 }
 
 func UpdateAppointmentProducts(appointmentID uint, dto dtos.UpdateAppointmentProductsDto) error {
@@ -369,7 +663,7 @@ func UpdateAppointmentProducts(appointmentID uint, dto dtos.UpdateAppointmentPro
 		return errors.New("error al buscar turno")
 	}
 
-	if appointment.Status != "finalizado" {
+	if appointment.Status != models.AppointmentStatusFinished {
 		logger.Log.Warnf("[AppointmentService][UpdateAppointmentProducts] El turno no está finalizado: ID %d", appointmentID)
 		return errors.New("solo se pueden actualizar productos de un turno finalizado")
 	}
@@ -410,5 +704,14 @@ func UpdateAppointmentProducts(appointmentID uint, dto dtos.UpdateAppointmentPro
 	}
 
 	logger.Log.Infof("[AppointmentService][UpdateAppointmentProducts] Productos actualizados con éxito para turno ID: %d", appointmentID)
+	publishAppointmentEvent(events.AppointmentUpdated, appointmentID)
 	return nil
 }
+
+// staffFullName devuelve el nombre completo del estilista o una cadena vacía si no hay asignado.
+func staffFullName(staff *models.Staff) string {
+	if staff == nil {
+		return ""
+	}
+	return strings.TrimSpace(fmt.Sprintf("%s %s", staff.Name, staff.LastName))
+}
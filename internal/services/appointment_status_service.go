@@ -0,0 +1,267 @@
+package services
+
+import (
+	"errors"
+	"fmt"
+	"peluqueria/database"
+	"peluqueria/internal/dtos"
+	"peluqueria/internal/events"
+	"peluqueria/internal/models"
+	"peluqueria/logger"
+	"slices"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+var ErrInvalidStatusTransition = errors.New("transición de estado no permitida")
+
+// appointmentTransitions define a qué estados puede pasar un turno desde cada estado.
+// Finalizado, cancelado y ausente son estados terminales. Un turno pendiente no se
+// finaliza directamente: primero se confirma o se inicia.
+var appointmentTransitions = map[string][]string{
+	models.AppointmentStatusRequested: {
+		models.AppointmentStatusConfirmed,
+		models.AppointmentStatusCancelled,
+	},
+	models.AppointmentStatusPending: {
+		models.AppointmentStatusConfirmed,
+		models.AppointmentStatusInProgress,
+		models.AppointmentStatusCancelled,
+		models.AppointmentStatusNoShow,
+	},
+	models.AppointmentStatusConfirmed: {
+		models.AppointmentStatusInProgress,
+		models.AppointmentStatusFinished,
+		models.AppointmentStatusCancelled,
+		models.AppointmentStatusNoShow,
+	},
+	models.AppointmentStatusInProgress: {
+		models.AppointmentStatusFinished,
+	},
+}
+
+func ConfirmAppointment(id uint, userID uint) error {
+	return transitionAppointment(id, models.AppointmentStatusConfirmed, "", userID)
+}
+
+func StartAppointment(id uint, userID uint) error {
+	return transitionAppointment(id, models.AppointmentStatusInProgress, "", userID)
+}
+
+// CheckInAppointment registra la llegada del cliente. No cambia el estado del turno.
+func CheckInAppointment(id uint, userID uint) error {
+	logger.Log.Infof("[AppointmentStatusService][CheckInAppointment] Registrando llegada del turno ID %d", id)
+
+	err := database.DB.Transaction(func(tx *gorm.DB) error {
+		var appointment models.Appointment
+		if err := tx.First(&appointment, id).Error; err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				logger.Log.Warnf("[AppointmentStatusService][CheckInAppointment] Turno no encontrado: ID %d", id)
+				return errors.New("turno no encontrado")
+			}
+			logger.Log.Error("[AppointmentStatusService][CheckInAppointment] Error al buscar turno: ", err)
+			return errors.New("error al buscar turno")
+		}
+
+		if appointment.Status != models.AppointmentStatusPending && appointment.Status != models.AppointmentStatusConfirmed {
+			logger.Log.Warnf("[AppointmentStatusService][CheckInAppointment] Turno ID %d en estado %s", id, appointment.Status)
+			return fmt.Errorf("%w: no se puede registrar la llegada de un turno '%s'", ErrInvalidStatusTransition, appointment.Status)
+		}
+		if appointment.CheckedInAt != nil {
+			logger.Log.Warnf("[AppointmentStatusService][CheckInAppointment] Llegada ya registrada para turno ID %d", id)
+			return fmt.Errorf("%w: la llegada ya fue registrada", ErrInvalidStatusTransition)
+		}
+
+		if err := tx.Model(&appointment).Update("checked_in_at", time.Now()).Error; err != nil {
+			logger.Log.Error("[AppointmentStatusService][CheckInAppointment] Error al registrar llegada: ", err)
+			return errors.New("error al registrar llegada del cliente")
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	logger.Log.Infof("[AppointmentStatusService][CheckInAppointment] Llegada registrada para turno ID %d (usuario ID %d)", id, userID)
+	publishAppointmentEvent(events.AppointmentUpdated, id)
+	return nil
+}
+
+// CancelAppointment cancela el turno y, según el alcance, los siguientes o todos los turnos
+// pendientes de su serie.
+func CancelAppointment(id uint, dto dtos.ChangeAppointmentStatusDto, userID uint, scope string) error {
+	logger.Log.Infof("[AppointmentStatusService][CancelAppointment] Cancelando turno ID %d con alcance '%s'", id, scope)
+	if dto.Reason == "" {
+		logger.Log.Warn("[AppointmentStatusService][CancelAppointment] Motivo de cancelación faltante")
+		return errors.New("el motivo de cancelación es obligatorio")
+	}
+
+	var freed []freedSlot
+	var cancelledIDs []uint
+	err := database.DB.Transaction(func(tx *gorm.DB) error {
+		var appointment models.Appointment
+		if err := tx.First(&appointment, id).Error; err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				logger.Log.Warnf("[AppointmentStatusService][CancelAppointment] Turno no encontrado: ID %d", id)
+				return errors.New("turno no encontrado")
+			}
+			logger.Log.Error("[AppointmentStatusService][CancelAppointment] Error al buscar turno: ", err)
+			return errors.New("error al buscar turno")
+		}
+
+		appointments, err := seriesScopeAppointments(tx, appointment, scope)
+		if err != nil {
+			return err
+		}
+		for i := range appointments {
+			slot, err := captureFreedSlot(tx, appointments[i].ID)
+			if err != nil {
+				return err
+			}
+			if err := changeAppointmentStatus(tx, &appointments[i], models.AppointmentStatusCancelled, dto.Reason, userID); err != nil {
+				return err
+			}
+			freed = append(freed, slot)
+			cancelledIDs = append(cancelledIDs, appointments[i].ID)
+		}
+
+		logger.Log.Infof("[AppointmentStatusService][CancelAppointment] %d turnos cancelados", len(appointments))
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	publishAppointmentEvent(events.AppointmentUpdated, cancelledIDs...)
+	matchWaitlist(freed)
+	return nil
+}
+
+func MarkAppointmentNoShow(id uint, dto dtos.ChangeAppointmentStatusDto, userID uint) error {
+	return transitionAppointment(id, models.AppointmentStatusNoShow, dto.Reason, userID)
+}
+
+func GetAppointmentStatusHistory(id uint) ([]dtos.AppointmentStatusHistoryDto, error) {
+	logger.Log.Infof("[AppointmentStatusService][GetAppointmentStatusHistory] Obteniendo historial del turno ID: %d", id)
+
+	if err := database.DB.Select("id").First(&models.Appointment{}, id).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			logger.Log.Warnf("[AppointmentStatusService][GetAppointmentStatusHistory] Turno no encontrado: ID %d", id)
+			return nil, errors.New("turno no encontrado")
+		}
+		logger.Log.Error("[AppointmentStatusService][GetAppointmentStatusHistory] Error al buscar turno: ", err)
+		return nil, errors.New("error al buscar turno")
+	}
+
+	var history []models.AppointmentStatusHistory
+	if err := database.DB.Preload("User").Where("appointment_id = ?", id).Order("created_at, id").Find(&history).Error; err != nil {
+		logger.Log.Error("[AppointmentStatusService][GetAppointmentStatusHistory] Error al obtener historial: ", err)
+		return nil, errors.New("error al obtener historial del turno")
+	}
+
+	historyDtos := []dtos.AppointmentStatusHistoryDto{}
+	for _, entry := range history {
+		username := ""
+		if entry.User != nil {
+			username = entry.User.Username
+		}
+		historyDtos = append(historyDtos, dtos.AppointmentStatusHistoryDto{
+			FromStatus: entry.FromStatus,
+			ToStatus:   entry.ToStatus,
+			Reason:     entry.Reason,
+			UserID:     entry.UserID,
+			Username:   username,
+			CreatedAt:  entry.CreatedAt.Format("02/01/2006 15:04"),
+		})
+	}
+
+	return historyDtos, nil
+}
+
+func transitionAppointment(id uint, to string, reason string, userID uint) error {
+	logger.Log.Infof("[AppointmentStatusService][transitionAppointment] Cambiando turno ID %d a estado %s", id, to)
+
+	err := database.DB.Transaction(func(tx *gorm.DB) error {
+		var appointment models.Appointment
+		if err := tx.First(&appointment, id).Error; err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				logger.Log.Warnf("[AppointmentStatusService][transitionAppointment] Turno no encontrado: ID %d", id)
+				return errors.New("turno no encontrado")
+			}
+			logger.Log.Error("[AppointmentStatusService][transitionAppointment] Error al buscar turno: ", err)
+			return errors.New("error al buscar turno")
+		}
+		return changeAppointmentStatus(tx, &appointment, to, reason, userID)
+	})
+	if err != nil {
+		return err
+	}
+
+	logger.Log.Infof("[AppointmentStatusService][transitionAppointment] Turno ID %d ahora está %s", id, to)
+	publishAppointmentEvent(events.AppointmentUpdated, id)
+	return nil
+}
+
+// changeAppointmentStatus valida la transición, guarda el turno con el nuevo estado y
+// registra el cambio en el historial. Debe llamarse dentro de una transacción.
+func changeAppointmentStatus(tx *gorm.DB, appointment *models.Appointment, to string, reason string, userID uint) error {
+	from := appointment.Status
+	if !canTransition(from, to) {
+		logger.Log.Warnf("[AppointmentStatusService][changeAppointmentStatus] Transición inválida para turno ID %d: %s -> %s", appointment.ID, from, to)
+		return fmt.Errorf("%w: de '%s' a '%s'", ErrInvalidStatusTransition, from, to)
+	}
+	if from == models.AppointmentStatusRequested && to == models.AppointmentStatusConfirmed {
+		if err := checkBookingValidated(tx, appointment.ID); err != nil {
+			return err
+		}
+	}
+
+	appointment.Status = to
+	appointment.Sequence++
+	now := time.Now()
+	switch to {
+	case models.AppointmentStatusCancelled:
+		appointment.CancellationReason = reason
+	case models.AppointmentStatusInProgress:
+		// Si no se registró la llegada, el cliente llegó al empezar
+		if appointment.CheckedInAt == nil {
+			appointment.CheckedInAt = &now
+		}
+		appointment.StartedAt = &now
+	case models.AppointmentStatusFinished:
+		appointment.FinishedAt = &now
+	}
+	if err := tx.Save(appointment).Error; err != nil {
+		logger.Log.Error("[AppointmentStatusService][changeAppointmentStatus] Error al actualizar estado del turno: ", err)
+		return errors.New("error al actualizar estado del turno")
+	}
+
+	return recordStatusHistory(tx, appointment.ID, from, to, reason, userID)
+}
+
+// recordStatusHistory registra un cambio de estado en el historial del turno. Al crear el
+// turno se registra su estado inicial, sin estado anterior.
+func recordStatusHistory(tx *gorm.DB, appointmentID uint, from, to string, reason string, userID uint) error {
+	history := models.AppointmentStatusHistory{
+		AppointmentID: appointmentID,
+		FromStatus:    from,
+		ToStatus:      to,
+		Reason:        reason,
+		UserID:        optionalUserID(userID),
+	}
+	if err := tx.Create(&history).Error; err != nil {
+		logger.Log.Error("[AppointmentStatusService][recordStatusHistory] Error al registrar historial: ", err)
+		return errors.New("error al registrar historial de estado")
+	}
+	return nil
+}
+
+func canTransition(from, to string) bool {
+	return slices.Contains(appointmentTransitions[from], to)
+}
+
+// isTerminalStatus indica si el turno ya no admite cambios de estado.
+func isTerminalStatus(status string) bool {
+	return len(appointmentTransitions[status]) == 0
+}
@@ -0,0 +1,62 @@
+package services
+
+import (
+	"peluqueria/internal/models"
+	"testing"
+)
+
+func TestCanTransition(t *testing.T) {
+	tests := []struct {
+		from, to string
+		want     bool
+	}{
+		{models.AppointmentStatusRequested, models.AppointmentStatusConfirmed, true},
+		{models.AppointmentStatusRequested, models.AppointmentStatusCancelled, true},
+		{models.AppointmentStatusRequested, models.AppointmentStatusInProgress, false},
+		{models.AppointmentStatusRequested, models.AppointmentStatusFinished, false},
+		{models.AppointmentStatusPending, models.AppointmentStatusConfirmed, true},
+		{models.AppointmentStatusPending, models.AppointmentStatusInProgress, true},
+		{models.AppointmentStatusPending, models.AppointmentStatusCancelled, true},
+		{models.AppointmentStatusPending, models.AppointmentStatusNoShow, true},
+		{models.AppointmentStatusPending, models.AppointmentStatusFinished, false},
+		{models.AppointmentStatusConfirmed, models.AppointmentStatusInProgress, true},
+		{models.AppointmentStatusConfirmed, models.AppointmentStatusFinished, true},
+		{models.AppointmentStatusConfirmed, models.AppointmentStatusPending, false},
+		{models.AppointmentStatusInProgress, models.AppointmentStatusFinished, true},
+		{models.AppointmentStatusInProgress, models.AppointmentStatusCancelled, false},
+		{models.AppointmentStatusFinished, models.AppointmentStatusCancelled, false},
+		{models.AppointmentStatusCancelled, models.AppointmentStatusPending, false},
+		{models.AppointmentStatusNoShow, models.AppointmentStatusConfirmed, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.from+"->"+tt.to, func(t *testing.T) {
+			if got := canTransition(tt.from, tt.to); got != tt.want {
+				t.Errorf("canTransition(%q, %q) = %v, se esperaba %v", tt.from, tt.to, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestIsTerminalStatus(t *testing.T) {
+	tests := []struct {
+		status string
+		want   bool
+	}{
+		{models.AppointmentStatusRequested, false},
+		{models.AppointmentStatusPending, false},
+		{models.AppointmentStatusConfirmed, false},
+		{models.AppointmentStatusInProgress, false},
+		{models.AppointmentStatusFinished, true},
+		{models.AppointmentStatusCancelled, true},
+		{models.AppointmentStatusNoShow, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.status, func(t *testing.T) {
+			if got := isTerminalStatus(tt.status); got != tt.want {
+				t.Errorf("isTerminalStatus(%q) = %v, se esperaba %v", tt.status, got, tt.want)
+			}
+		})
+	}
+}
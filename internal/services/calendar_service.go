@@ -0,0 +1,362 @@
+package services
+
+import (
+	"errors"
+	"fmt"
+	"peluqueria/database"
+	"peluqueria/internal/dtos"
+	"peluqueria/internal/helpers"
+	"peluqueria/internal/models"
+	"peluqueria/logger"
+	"slices"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+var (
+	ErrSalonClosed          = errors.New("el salón está cerrado en la fecha solicitada")
+	ErrOutsideBusinessHours = errors.New("el turno está fuera del horario de atención")
+)
+
+var weekdayNames = []string{"domingo", "lunes", "martes", "miércoles", "jueves", "viernes", "sábado"}
+
+var closureKinds = []string{"feriado", "cierre"}
+
+func CreateBusinessHour(dto dtos.BusinessHourDto) error {
+	logger.Log.Infof("[CalendarService][CreateBusinessHour] Creando franja horaria para el día %d", dto.Weekday)
+
+	if err := validateBusinessHour(dto, 0); err != nil {
+		return err
+	}
+
+	businessHour := models.BusinessHour{
+		Weekday:   dto.Weekday,
+		OpenTime:  dto.OpenTime,
+		CloseTime: dto.CloseTime,
+	}
+	if err := database.DB.Create(&businessHour).Error; err != nil {
+		logger.Log.Error("[CalendarService][CreateBusinessHour] Error al crear franja horaria: ", err)
+		return errors.New("error al crear franja horaria")
+	}
+
+	logger.Log.Infof("[CalendarService][CreateBusinessHour] Franja horaria creada: ID %d", businessHour.ID)
+	return nil
+}
+
+func GetAllBusinessHours() ([]dtos.GetBusinessHourDto, error) {
+	logger.Log.Info("[CalendarService][GetAllBusinessHours] Obteniendo horarios de atención")
+
+	var businessHours []models.BusinessHour
+	if err := database.DB.Order("weekday, open_time").Find(&businessHours).Error; err != nil {
+		logger.Log.Error("[CalendarService][GetAllBusinessHours] Error al obtener horarios: ", err)
+		return nil, errors.New("error al obtener horarios de atención")
+	}
+
+	var businessHourDtos []dtos.GetBusinessHourDto
+	for _, businessHour := range businessHours {
+		businessHourDtos = append(businessHourDtos, dtos.GetBusinessHourDto{
+			ID:          businessHour.ID,
+			Weekday:     businessHour.Weekday,
+			WeekdayName: weekdayNames[businessHour.Weekday],
+			OpenTime:    businessHour.OpenTime,
+			CloseTime:   businessHour.CloseTime,
+		})
+	}
+
+	logger.Log.Infof("[CalendarService][GetAllBusinessHours] Franjas obtenidas: %d", len(businessHourDtos))
+	return businessHourDtos, nil
+}
+
+func UpdateBusinessHour(id uint, dto dtos.BusinessHourDto) error {
+	logger.Log.Infof("[CalendarService][UpdateBusinessHour] Actualizando franja horaria con ID: %d", id)
+
+	var businessHour models.BusinessHour
+	if err := database.DB.First(&businessHour, id).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			logger.Log.Warnf("[CalendarService][UpdateBusinessHour] Franja no encontrada: ID %d", id)
+			return errors.New("la franja horaria no existe")
+		}
+		logger.Log.Error("[CalendarService][UpdateBusinessHour] Error al buscar franja: ", err)
+		return errors.New("error al buscar franja horaria")
+	}
+
+	if err := validateBusinessHour(dto, id); err != nil {
+		return err
+	}
+
+	businessHour.Weekday = dto.Weekday
+	businessHour.OpenTime = dto.OpenTime
+	businessHour.CloseTime = dto.CloseTime
+	if err := database.DB.Save(&businessHour).Error; err != nil {
+		logger.Log.Error("[CalendarService][UpdateBusinessHour] Error al actualizar franja: ", err)
+		return errors.New("error al actualizar franja horaria")
+	}
+
+	logger.Log.Infof("[CalendarService][UpdateBusinessHour] Franja horaria actualizada: ID %d", id)
+	return nil
+}
+
+func DeleteBusinessHour(id uint) error {
+	logger.Log.Infof("[CalendarService][DeleteBusinessHour] Eliminando franja horaria con ID: %d", id)
+
+	if err := database.DB.Delete(&models.BusinessHour{}, id).Error; err != nil {
+		logger.Log.Error("[CalendarService][DeleteBusinessHour] Error al eliminar franja: ", err)
+		return errors.New("error al eliminar franja horaria")
+	}
+
+	logger.Log.Infof("[CalendarService][DeleteBusinessHour] Franja horaria eliminada: ID %d", id)
+	return nil
+}
+
+func CreateClosure(dto dtos.ClosureDto) error {
+	logger.Log.Infof("[CalendarService][CreateClosure] Creando cierre: %s", dto.Reason)
+
+	closure, err := closureFromDto(dto)
+	if err != nil {
+		return err
+	}
+
+	if err := database.DB.Create(&closure).Error; err != nil {
+		logger.Log.Error("[CalendarService][CreateClosure] Error al crear cierre: ", err)
+		return errors.New("error al crear cierre")
+	}
+
+	logger.Log.Infof("[CalendarService][CreateClosure] Cierre creado: ID %d", closure.ID)
+	return nil
+}
+
+func GetAllClosures() ([]dtos.GetClosureDto, error) {
+	logger.Log.Info("[CalendarService][GetAllClosures] Obteniendo feriados y cierres")
+
+	var closures []models.Closure
+	if err := database.DB.Order("start_date").Find(&closures).Error; err != nil {
+		logger.Log.Error("[CalendarService][GetAllClosures] Error al obtener cierres: ", err)
+		return nil, errors.New("error al obtener feriados y cierres")
+	}
+
+	var closureDtos []dtos.GetClosureDto
+	for _, closure := range closures {
+		closureDtos = append(closureDtos, dtos.GetClosureDto{
+			ID:        closure.ID,
+			StartDate: closure.StartDate.Format("02/01/2006"),
+			EndDate:   closure.EndDate.Format("02/01/2006"),
+			Kind:      closure.Kind,
+			Reason:    closure.Reason,
+			Recurring: closure.Recurring,
+		})
+	}
+
+	logger.Log.Infof("[CalendarService][GetAllClosures] Cierres obtenidos: %d", len(closureDtos))
+	return closureDtos, nil
+}
+
+func UpdateClosure(id uint, dto dtos.ClosureDto) error {
+	logger.Log.Infof("[CalendarService][UpdateClosure] Actualizando cierre con ID: %d", id)
+
+	var existing models.Closure
+	if err := database.DB.First(&existing, id).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			logger.Log.Warnf("[CalendarService][UpdateClosure] Cierre no encontrado: ID %d", id)
+			return errors.New("el cierre no existe")
+		}
+		logger.Log.Error("[CalendarService][UpdateClosure] Error al buscar cierre: ", err)
+		return errors.New("error al buscar cierre")
+	}
+
+	closure, err := closureFromDto(dto)
+	if err != nil {
+		return err
+	}
+	closure.ID = existing.ID
+	closure.CreatedAt = existing.CreatedAt
+
+	if err := database.DB.Save(&closure).Error; err != nil {
+		logger.Log.Error("[CalendarService][UpdateClosure] Error al actualizar cierre: ", err)
+		return errors.New("error al actualizar cierre")
+	}
+
+	logger.Log.Infof("[CalendarService][UpdateClosure] Cierre actualizado: ID %d", id)
+	return nil
+}
+
+func DeleteClosure(id uint) error {
+	logger.Log.Infof("[CalendarService][DeleteClosure] Eliminando cierre con ID: %d", id)
+
+	if err := database.DB.Delete(&models.Closure{}, id).Error; err != nil {
+		logger.Log.Error("[CalendarService][DeleteClosure] Error al eliminar cierre: ", err)
+		return errors.New("error al eliminar cierre")
+	}
+
+	logger.Log.Infof("[CalendarService][DeleteClosure] Cierre eliminado: ID %d", id)
+	return nil
+}
+
+// openingRanges devuelve las franjas de atención del día indicado (medianoche en la zona
+// horaria del salón). Si el salón está cerrado devuelve el cierre correspondiente.
+func openingRanges(db *gorm.DB, day time.Time) ([]timeRange, *models.Closure, error) {
+	closure, err := closureOn(db, day)
+	if err != nil {
+		return nil, nil, err
+	}
+	if closure != nil {
+		return nil, closure, nil
+	}
+
+	var businessHours []models.BusinessHour
+	if err := db.Where("weekday = ?", int(day.Weekday())).Order("open_time").Find(&businessHours).Error; err != nil {
+		logger.Log.Error("[CalendarService][openingRanges] Error al obtener horarios: ", err)
+		return nil, nil, errors.New("error al obtener horarios de atención")
+	}
+
+	var ranges []timeRange
+	for _, businessHour := range businessHours {
+		opening, err := parseClock(businessHour.OpenTime)
+		if err != nil {
+			return nil, nil, err
+		}
+		closing, err := parseClock(businessHour.CloseTime)
+		if err != nil {
+			return nil, nil, err
+		}
+		ranges = append(ranges, timeRange{Start: day.Add(opening), End: day.Add(closing)})
+	}
+	return ranges, nil, nil
+}
+
+// checkBusinessHours valida que el tramo [start, end) caiga completo dentro de una franja
+// de atención de un día abierto.
+func checkBusinessHours(db *gorm.DB, start, end time.Time) error {
+	loc, err := helpers.SalonLocation()
+	if err != nil {
+		return err
+	}
+	local := start.In(loc)
+	day := time.Date(local.Year(), local.Month(), local.Day(), 0, 0, 0, 0, loc)
+
+	ranges, closure, err := openingRanges(db, day)
+	if err != nil {
+		return err
+	}
+	if closure != nil {
+		logger.Log.Warnf("[CalendarService][checkBusinessHours] Salón cerrado el %s: %s", day.Format("02/01/2006"), closure.Reason)
+		return fmt.Errorf("%w (%s %s)", ErrSalonClosed, closure.Kind, closure.Reason)
+	}
+
+	for _, openRange := range ranges {
+		if !start.Before(openRange.Start) && !end.After(openRange.End) {
+			return nil
+		}
+	}
+
+	logger.Log.Warnf("[CalendarService][checkBusinessHours] Turno fuera del horario de atención: %s", local.Format("02/01/2006 15:04"))
+	return ErrOutsideBusinessHours
+}
+
+// closureOn devuelve el cierre vigente en el día indicado, o nil si el salón abre.
+func closureOn(db *gorm.DB, day time.Time) (*models.Closure, error) {
+	dayKey := day.Format("2006-01-02")
+
+	var closures []models.Closure
+	if err := db.Where("recurring = ? OR (start_date <= ? AND end_date >= ?)", true, dayKey, dayKey).
+		Find(&closures).Error; err != nil {
+		logger.Log.Error("[CalendarService][closureOn] Error al obtener cierres: ", err)
+		return nil, errors.New("error al obtener feriados y cierres")
+	}
+
+	for i, closure := range closures {
+		if closureCovers(closure, day) {
+			return &closures[i], nil
+		}
+	}
+	return nil, nil
+}
+
+func closureCovers(closure models.Closure, day time.Time) bool {
+	if !closure.Recurring {
+		key := day.Format("2006-01-02")
+		return closure.StartDate.Format("2006-01-02") <= key && key <= closure.EndDate.Format("2006-01-02")
+	}
+
+	// Los cierres recurrentes se comparan por mes y día, admitiendo rangos que cruzan el año
+	key := day.Format("01-02")
+	startKey := closure.StartDate.Format("01-02")
+	endKey := closure.EndDate.Format("01-02")
+	if startKey <= endKey {
+		return startKey <= key && key <= endKey
+	}
+	return key >= startKey || key <= endKey
+}
+
+func validateBusinessHour(dto dtos.BusinessHourDto, id uint) error {
+	if dto.Weekday < 0 || dto.Weekday > 6 {
+		logger.Log.Warnf("[CalendarService][validateBusinessHour] Día inválido: %d", dto.Weekday)
+		return errors.New("el día de la semana debe estar entre 0 (domingo) y 6 (sábado)")
+	}
+
+	opening, err := parseClock(dto.OpenTime)
+	if err != nil {
+		return err
+	}
+	closing, err := parseClock(dto.CloseTime)
+	if err != nil {
+		return err
+	}
+	if closing <= opening {
+		logger.Log.Warn("[CalendarService][validateBusinessHour] Horario de cierre anterior a la apertura")
+		return errors.New("el horario de cierre debe ser posterior al de apertura")
+	}
+
+	// Las franjas de un mismo día no pueden superponerse
+	var sameDay []models.BusinessHour
+	if err := database.DB.Where("weekday = ? AND id <> ?", dto.Weekday, id).Find(&sameDay).Error; err != nil {
+		logger.Log.Error("[CalendarService][validateBusinessHour] Error al obtener franjas del día: ", err)
+		return errors.New("error al validar franja horaria")
+	}
+	for _, other := range sameDay {
+		otherOpening, _ := parseClock(other.OpenTime)
+		otherClosing, _ := parseClock(other.CloseTime)
+		if opening < otherClosing && otherOpening < closing {
+			logger.Log.Warnf("[CalendarService][validateBusinessHour] Franja superpuesta con ID %d", other.ID)
+			return fmt.Errorf("la franja se superpone con %s-%s", other.OpenTime, other.CloseTime)
+		}
+	}
+	return nil
+}
+
+func closureFromDto(dto dtos.ClosureDto) (models.Closure, error) {
+	if !slices.Contains(closureKinds, dto.Kind) {
+		logger.Log.Warnf("[CalendarService][closureFromDto] Tipo de cierre inválido: %s", dto.Kind)
+		return models.Closure{}, errors.New("el tipo de cierre debe ser 'feriado' o 'cierre'")
+	}
+
+	startDate, err := helpers.ParseCustomDay(dto.StartDate)
+	if err != nil {
+		return models.Closure{}, err
+	}
+	endDate := startDate
+	if dto.EndDate != "" {
+		endDate, err = helpers.ParseCustomDay(dto.EndDate)
+		if err != nil {
+			return models.Closure{}, err
+		}
+	}
+	if endDate.Before(startDate) {
+		logger.Log.Warn("[CalendarService][closureFromDto] Fecha de fin anterior a la de inicio")
+		return models.Closure{}, errors.New("la fecha de fin debe ser posterior o igual a la de inicio")
+	}
+
+	return models.Closure{
+		StartDate: dateOnly(startDate),
+		EndDate:   dateOnly(endDate),
+		Kind:      dto.Kind,
+		Reason:    dto.Reason,
+		Recurring: dto.Recurring,
+	}, nil
+}
+
+// dateOnly normaliza una fecha a medianoche local para guardarla en columnas DATE sin
+// corrimientos de zona horaria.
+func dateOnly(t time.Time) time.Time {
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.Local)
+}
@@ -0,0 +1,419 @@
+package services
+
+import (
+	"errors"
+	"fmt"
+	"peluqueria/database"
+	"peluqueria/internal/dtos"
+	"peluqueria/internal/helpers"
+	"peluqueria/internal/models"
+	"peluqueria/logger"
+	"strings"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+var (
+	ErrNoOpenCashSession    = errors.New("no hay una caja abierta")
+	ErrInvalidCashOperation = errors.New("operación de caja inválida")
+	ErrCashSessionNotFound  = errors.New("sesión de caja no encontrada")
+)
+
+// OpenCashSession abre la caja con el fondo inicial indicado.
+func OpenCashSession(dto dtos.OpenCashSessionDto, userID uint) (dtos.GetCashSessionDto, error) {
+	logger.Log.Infof("[CashRegisterService][OpenCashSession] Abriendo caja con fondo de %.2f", dto.OpeningFloat)
+
+	if dto.OpeningFloat < 0 {
+		return dtos.GetCashSessionDto{}, fmt.Errorf("%w: el fondo inicial no puede ser negativo", ErrInvalidCashOperation)
+	}
+
+	var session models.CashSession
+	err := database.DB.Transaction(func(tx *gorm.DB) error {
+		if _, err := openCashSession(tx); err == nil {
+			logger.Log.Warn("[CashRegisterService][OpenCashSession] Ya hay una caja abierta")
+			return fmt.Errorf("%w: ya hay una caja abierta", ErrInvalidCashOperation)
+		} else if !errors.Is(err, ErrNoOpenCashSession) {
+			return err
+		}
+
+		session = models.CashSession{
+			OpenedAt:     time.Now(),
+			OpenedByID:   optionalUserID(userID),
+			OpeningFloat: roundCents(dto.OpeningFloat),
+			Notes:        dto.Notes,
+		}
+		if err := tx.Create(&session).Error; err != nil {
+			logger.Log.Error("[CashRegisterService][OpenCashSession] Error al abrir caja: ", err)
+			return errors.New("error al abrir caja")
+		}
+		return nil
+	})
+	if err != nil {
+		return dtos.GetCashSessionDto{}, err
+	}
+
+	logger.Log.Infof("[CashRegisterService][OpenCashSession] Caja abierta: ID %d", session.ID)
+	return GetCashSessionByID(session.ID)
+}
+
+// GetCurrentCashSession devuelve la caja abierta con sus movimientos.
+func GetCurrentCashSession() (dtos.GetCashSessionDto, error) {
+	logger.Log.Info("[CashRegisterService][GetCurrentCashSession] Obteniendo caja abierta")
+
+	session, err := openCashSession(database.DB)
+	if err != nil {
+		return dtos.GetCashSessionDto{}, err
+	}
+	return GetCashSessionByID(session.ID)
+}
+
+// GetAllCashSessions devuelve las sesiones abiertas entre dos días, ambos opcionales, sin el
+// detalle de movimientos.
+func GetAllCashSessions(from, to string) ([]dtos.GetCashSessionDto, error) {
+	logger.Log.Info("[CashRegisterService][GetAllCashSessions] Obteniendo sesiones de caja")
+
+	query := database.DB.Preload("OpenedBy").Preload("ClosedBy").Order("opened_at DESC")
+	if from != "" {
+		fromDate, err := helpers.ParseCustomDay(from)
+		if err != nil {
+			return nil, err
+		}
+		query = query.Where("opened_at >= ?", fromDate)
+	}
+	if to != "" {
+		toDate, err := helpers.ParseCustomDay(to)
+		if err != nil {
+			return nil, err
+		}
+		query = query.Where("opened_at < ?", toDate.AddDate(0, 0, 1))
+	}
+
+	var sessions []models.CashSession
+	if err := query.Find(&sessions).Error; err != nil {
+		logger.Log.Error("[CashRegisterService][GetAllCashSessions] Error al obtener sesiones: ", err)
+		return nil, errors.New("error al obtener sesiones de caja")
+	}
+
+	sessionDtos := []dtos.GetCashSessionDto{}
+	for _, session := range sessions {
+		totals, err := cashTotals(database.DB, session.ID)
+		if err != nil {
+			return nil, err
+		}
+		sessionDtos = append(sessionDtos, toCashSessionDto(session, totals))
+	}
+	return sessionDtos, nil
+}
+
+// GetCashSessionByID devuelve la sesión con sus totales y movimientos. Una sesión cerrada
+// es el reporte de cierre con la diferencia de caja.
+func GetCashSessionByID(id uint) (dtos.GetCashSessionDto, error) {
+	logger.Log.Infof("[CashRegisterService][GetCashSessionByID] Obteniendo sesión de caja ID %d", id)
+
+	var session models.CashSession
+	if err := database.DB.
+		Preload("OpenedBy").
+		Preload("ClosedBy").
+		Preload("Movements", func(db *gorm.DB) *gorm.DB { return db.Order("created_at, id") }).
+		Preload("Movements.User").
+		First(&session, id).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			logger.Log.Warnf("[CashRegisterService][GetCashSessionByID] Sesión no encontrada: ID %d", id)
+			return dtos.GetCashSessionDto{}, ErrCashSessionNotFound
+		}
+		logger.Log.Error("[CashRegisterService][GetCashSessionByID] Error al obtener sesión: ", err)
+		return dtos.GetCashSessionDto{}, errors.New("error al obtener sesión de caja")
+	}
+
+	totals, err := cashTotals(database.DB, session.ID)
+	if err != nil {
+		return dtos.GetCashSessionDto{}, err
+	}
+	sessionDto := toCashSessionDto(session, totals)
+	sessionDto.Movements = []dtos.GetCashMovementDto{}
+	for _, movement := range session.Movements {
+		sessionDto.Movements = append(sessionDto.Movements, dtos.GetCashMovementDto{
+			ID:            movement.ID,
+			Type:          movement.Type,
+			Amount:        movement.Amount,
+			Description:   movement.Description,
+			AppointmentID: movement.AppointmentID,
+			Username:      username(movement.User),
+			CreatedAt:     movement.CreatedAt.Format("02/01/2006 15:04"),
+		})
+	}
+	return sessionDto, nil
+}
+
+// RecordCashMovement registra un ingreso, gasto o retiro manual en la caja abierta. Los
+// cobros y propinas en efectivo se registran solos al finalizar el turno.
+func RecordCashMovement(dto dtos.CashMovementDto, userID uint) error {
+	logger.Log.Infof("[CashRegisterService][RecordCashMovement] Registrando movimiento de caja: %s por %.2f", dto.Type, dto.Amount)
+
+	amount := roundCents(dto.Amount)
+	if amount <= 0 {
+		return fmt.Errorf("%w: el monto debe ser mayor a 0", ErrInvalidCashOperation)
+	}
+	description := strings.TrimSpace(dto.Description)
+	switch dto.Type {
+	case models.CashMovementIncome:
+	case models.CashMovementExpense, models.CashMovementWithdrawal:
+		if description == "" {
+			return fmt.Errorf("%w: debe indicar el motivo del %s", ErrInvalidCashOperation, dto.Type)
+		}
+		amount = -amount
+	default:
+		return fmt.Errorf("%w: tipo de movimiento inválido, debe ser '%s', '%s' o '%s'", ErrInvalidCashOperation,
+			models.CashMovementIncome, models.CashMovementExpense, models.CashMovementWithdrawal)
+	}
+
+	err := database.DB.Transaction(func(tx *gorm.DB) error {
+		session, err := openCashSession(tx)
+		if err != nil {
+			return err
+		}
+		if amount < 0 {
+			expected, err := expectedCash(tx, session)
+			if err != nil {
+				return err
+			}
+			if roundCents(expected+amount) < 0 {
+				return fmt.Errorf("%w: en la caja hay %.2f en efectivo", ErrInvalidCashOperation, expected)
+			}
+		}
+
+		movement := models.CashMovement{
+			SessionID:   session.ID,
+			Type:        dto.Type,
+			Amount:      amount,
+			Description: description,
+			UserID:      optionalUserID(userID),
+		}
+		if err := tx.Create(&movement).Error; err != nil {
+			logger.Log.Error("[CashRegisterService][RecordCashMovement] Error al registrar movimiento: ", err)
+			return errors.New("error al registrar movimiento de caja")
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	logger.Log.Infof("[CashRegisterService][RecordCashMovement] Movimiento registrado: %s por %.2f", dto.Type, amount)
+	return nil
+}
+
+// CloseCashSession cierra la caja abierta con el efectivo contado y devuelve el reporte de
+// cierre con la diferencia respecto de lo esperado.
+func CloseCashSession(dto dtos.CloseCashSessionDto, userID uint) (dtos.GetCashSessionDto, error) {
+	logger.Log.Info("[CashRegisterService][CloseCashSession] Cerrando caja")
+
+	if dto.CountedAmount == nil || *dto.CountedAmount < 0 {
+		return dtos.GetCashSessionDto{}, fmt.Errorf("%w: debe indicar el efectivo contado", ErrInvalidCashOperation)
+	}
+
+	var session models.CashSession
+	err := database.DB.Transaction(func(tx *gorm.DB) error {
+		var err error
+		if session, err = openCashSession(tx); err != nil {
+			return err
+		}
+		expected, err := expectedCash(tx, session)
+		if err != nil {
+			return err
+		}
+
+		now := time.Now()
+		counted := roundCents(*dto.CountedAmount)
+		difference := cashDifference(counted, expected)
+		session.ClosedAt = &now
+		session.ClosedByID = optionalUserID(userID)
+		session.ExpectedAmount = &expected
+		session.CountedAmount = &counted
+		session.Difference = &difference
+		if dto.Notes != "" {
+			session.Notes = dto.Notes
+		}
+		if err := tx.Save(&session).Error; err != nil {
+			logger.Log.Error("[CashRegisterService][CloseCashSession] Error al cerrar caja: ", err)
+			return errors.New("error al cerrar caja")
+		}
+		return nil
+	})
+	if err != nil {
+		return dtos.GetCashSessionDto{}, err
+	}
+
+	logger.Log.Infof("[CashRegisterService][CloseCashSession] Caja ID %d cerrada con diferencia de %.2f", session.ID, *session.Difference)
+	return GetCashSessionByID(session.ID)
+}
+
+// openCashSession devuelve la caja abierta, bloqueada hasta el fin de la transacción para
+// que los movimientos no se crucen con el cierre.
+func openCashSession(tx *gorm.DB) (models.CashSession, error) {
+	var session models.CashSession
+	if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).Where("closed_at IS NULL").First(&session).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return models.CashSession{}, ErrNoOpenCashSession
+		}
+		logger.Log.Error("[CashRegisterService][openCashSession] Error al buscar caja abierta: ", err)
+		return models.CashSession{}, errors.New("error al buscar caja abierta")
+	}
+	return session, nil
+}
+
+// expectedCash es el efectivo que debería haber en la caja: el fondo más los movimientos.
+func expectedCash(tx *gorm.DB, session models.CashSession) (float64, error) {
+	var movements float64
+	if err := tx.Model(&models.CashMovement{}).
+		Select("COALESCE(SUM(amount), 0)").
+		Where("session_id = ?", session.ID).
+		Scan(&movements).Error; err != nil {
+		logger.Log.Error("[CashRegisterService][expectedCash] Error al sumar movimientos: ", err)
+		return 0, errors.New("error al calcular el efectivo de la caja")
+	}
+	return roundCents(session.OpeningFloat + movements), nil
+}
+
+// cashDifference es el sobrante (positivo) o faltante (negativo) del efectivo contado
+// respecto del esperado, calculado en centavos.
+func cashDifference(counted, expected float64) float64 {
+	return float64(cents(counted)-cents(expected)) / 100
+}
+
+// attachCashSession asigna la caja abierta a los pagos y propinas en efectivo. Sin caja
+// abierta no se puede cobrar en efectivo. Debe llamarse dentro de una transacción.
+func attachCashSession(tx *gorm.DB, payments []models.Payment, tips []models.Tip) error {
+	var cash bool
+	for _, payment := range payments {
+		cash = cash || payment.Method.IsCash
+	}
+	for _, tip := range tips {
+		cash = cash || tip.Method.IsCash
+	}
+	if !cash {
+		return nil
+	}
+
+	session, err := openCashSession(tx)
+	if err != nil {
+		if errors.Is(err, ErrNoOpenCashSession) {
+			logger.Log.Warn("[CashRegisterService][attachCashSession] Cobro en efectivo sin caja abierta")
+			return fmt.Errorf("%w: no hay una caja abierta para cobrar en efectivo", ErrInvalidPayment)
+		}
+		return err
+	}
+	for i := range payments {
+		if payments[i].Method.IsCash {
+			payments[i].CashSessionID = &session.ID
+		}
+	}
+	for i := range tips {
+		if tips[i].Method.IsCash {
+			tips[i].CashSessionID = &session.ID
+		}
+	}
+	return nil
+}
+
+// recordCashMovements registra en la caja los pagos y propinas en efectivo ya guardados.
+// Debe llamarse dentro de una transacción.
+func recordCashMovements(tx *gorm.DB, appointmentID uint, payments []models.Payment, tips []models.Tip, userID uint) error {
+	var movements []models.CashMovement
+	for _, payment := range payments {
+		if payment.CashSessionID == nil {
+			continue
+		}
+		movements = append(movements, models.CashMovement{
+			SessionID:     *payment.CashSessionID,
+			Type:          models.CashMovementPayment,
+			Amount:        payment.Amount,
+			Description:   fmt.Sprintf("Cobro turno ID %d", appointmentID),
+			AppointmentID: &appointmentID,
+			PaymentID:     &payment.ID,
+			UserID:        optionalUserID(userID),
+		})
+	}
+	for _, tip := range tips {
+		if tip.CashSessionID == nil {
+			continue
+		}
+		movements = append(movements, models.CashMovement{
+			SessionID:     *tip.CashSessionID,
+			Type:          models.CashMovementTip,
+			Amount:        tip.Amount,
+			Description:   fmt.Sprintf("Propina turno ID %d", appointmentID),
+			AppointmentID: &appointmentID,
+			TipID:         &tip.ID,
+			UserID:        optionalUserID(userID),
+		})
+	}
+	if len(movements) == 0 {
+		return nil
+	}
+	if err := tx.Create(&movements).Error; err != nil {
+		logger.Log.Error("[CashRegisterService][recordCashMovements] Error al registrar movimientos: ", err)
+		return errors.New("error al registrar movimientos de caja")
+	}
+	return nil
+}
+
+// cashTotals suma los movimientos de la sesión por tipo.
+func cashTotals(db *gorm.DB, sessionID uint) ([]dtos.CashTotalDto, error) {
+	totals := []dtos.CashTotalDto{}
+	if err := db.Model(&models.CashMovement{}).
+		Select("type, COUNT(*) AS count, COALESCE(SUM(amount), 0) AS amount").
+		Where("session_id = ?", sessionID).
+		Group("type").
+		Order("type").
+		Scan(&totals).Error; err != nil {
+		logger.Log.Error("[CashRegisterService][cashTotals] Error al sumar movimientos: ", err)
+		return nil, errors.New("error al sumar movimientos de caja")
+	}
+	for i := range totals {
+		totals[i].Amount = roundCents(totals[i].Amount)
+	}
+	return totals, nil
+}
+
+func toCashSessionDto(session models.CashSession, totals []dtos.CashTotalDto) dtos.GetCashSessionDto {
+	sessionDto := dtos.GetCashSessionDto{
+		ID:            session.ID,
+		Status:        "abierta",
+		OpenedAt:      session.OpenedAt.Format("02/01/2006 15:04"),
+		OpenedBy:      username(session.OpenedBy),
+		OpeningFloat:  session.OpeningFloat,
+		ClosedAt:      formatTimestamp(session.ClosedAt, "02/01/2006 15:04"),
+		ClosedBy:      username(session.ClosedBy),
+		Totals:        totals,
+		CountedAmount: session.CountedAmount,
+		Difference:    session.Difference,
+		Notes:         session.Notes,
+	}
+
+	// Mientras está abierta, lo esperado se calcula con los movimientos hasta el momento
+	if session.ExpectedAmount != nil {
+		sessionDto.ExpectedAmount = *session.ExpectedAmount
+	} else {
+		expected := session.OpeningFloat
+		for _, total := range totals {
+			expected += total.Amount
+		}
+		sessionDto.ExpectedAmount = roundCents(expected)
+	}
+
+	if session.ClosedAt != nil {
+		sessionDto.Status = "cerrada"
+		switch {
+		case session.Difference == nil || *session.Difference == 0:
+			sessionDto.Result = "sin diferencia"
+		case *session.Difference > 0:
+			sessionDto.Result = "sobrante"
+		default:
+			sessionDto.Result = "faltante"
+		}
+	}
+	return sessionDto
+}
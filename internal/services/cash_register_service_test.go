@@ -0,0 +1,91 @@
+package services
+
+import (
+	"peluqueria/internal/dtos"
+	"peluqueria/internal/models"
+	"testing"
+	"time"
+)
+
+func TestCashDifference(t *testing.T) {
+	tests := []struct {
+		name              string
+		counted, expected float64
+		want              float64
+	}{
+		{"sin diferencia", 15000, 15000, 0},
+		{"sobrante", 15250.5, 15000, 250.5},
+		{"faltante", 14900, 15000, -100},
+		{"centavos sin error de punto flotante", 0.3, 0.1 + 0.2, 0},
+		{"faltante en centavos", 100.1, 100.3, -0.2},
+		{"caja vacía", 0, 1200.75, -1200.75},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := cashDifference(tt.counted, tt.expected); got != tt.want {
+				t.Errorf("cashDifference(%v, %v) = %v, se esperaba %v", tt.counted, tt.expected, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestToCashSessionDtoResult(t *testing.T) {
+	amount := func(value float64) *float64 { return &value }
+	closedAt := time.Date(2025, 1, 10, 21, 0, 0, 0, time.UTC)
+	totals := []dtos.CashTotalDto{
+		{Type: "pago", Count: 3, Amount: 4500.1},
+		{Type: "gasto", Count: 1, Amount: -500.2},
+	}
+
+	tests := []struct {
+		name         string
+		session      models.CashSession
+		wantStatus   string
+		wantResult   string
+		wantExpected float64
+	}{
+		{
+			name:         "abierta calcula lo esperado con los movimientos",
+			session:      models.CashSession{OpeningFloat: 1000},
+			wantStatus:   "abierta",
+			wantExpected: 4999.9,
+		},
+		{
+			name:         "cerrada sin diferencia",
+			session:      models.CashSession{OpeningFloat: 1000, ClosedAt: &closedAt, ExpectedAmount: amount(4999.9), Difference: amount(0)},
+			wantStatus:   "cerrada",
+			wantResult:   "sin diferencia",
+			wantExpected: 4999.9,
+		},
+		{
+			name:         "cerrada con sobrante",
+			session:      models.CashSession{OpeningFloat: 1000, ClosedAt: &closedAt, ExpectedAmount: amount(4999.9), Difference: amount(0.1)},
+			wantStatus:   "cerrada",
+			wantResult:   "sobrante",
+			wantExpected: 4999.9,
+		},
+		{
+			name:         "cerrada con faltante",
+			session:      models.CashSession{OpeningFloat: 1000, ClosedAt: &closedAt, ExpectedAmount: amount(4999.9), Difference: amount(-99.9)},
+			wantStatus:   "cerrada",
+			wantResult:   "faltante",
+			wantExpected: 4999.9,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := toCashSessionDto(tt.session, totals)
+			if got.Status != tt.wantStatus {
+				t.Errorf("Status = %q, se esperaba %q", got.Status, tt.wantStatus)
+			}
+			if got.Result != tt.wantResult {
+				t.Errorf("Result = %q, se esperaba %q", got.Result, tt.wantResult)
+			}
+			if got.ExpectedAmount != tt.wantExpected {
+				t.Errorf("ExpectedAmount = %v, se esperaba %v", got.ExpectedAmount, tt.wantExpected)
+			}
+		})
+	}
+}
@@ -85,19 +85,42 @@ func GetClientByID(id uint) (dtos.GetClientDto, error) {
 	var appointments []models.Appointment
 
 	if err := database.DB.
+		Preload("Staff").
+		Preload("AppointmentServices.Service").
 		Where("client_id = ?", id).
+		Order("appointment_date DESC").
 		Find(&appointments).Error; err != nil {
 		logger.Log.Error("[ClientService][GetClientByID] Error al obtener turnos del cliente: ", err)
 		return dtos.GetClientDto{}, errors.New("error al obtener turnos del cliente")
 	}
 
+	// Historial técnico de cada turno: notas, fórmulas de color y fotos
+	appointmentIDs := make([]uint, 0, len(appointments))
+	for _, appointment := range appointments {
+		appointmentIDs = append(appointmentIDs, appointment.ID)
+	}
+	records, err := appointmentRecords(database.DB, appointmentIDs)
+	if err != nil {
+		return dtos.GetClientDto{}, err
+	}
+
 	var appointmentDtos []dtos.ClientAppointmentDto
 
 	for _, appointment := range appointments {
+		serviceNames := []string{}
+		for _, appService := range appointment.AppointmentServices {
+			serviceNames = append(serviceNames, appService.Service.Name)
+		}
+		record := records[appointment.ID]
 		appointmentDtos = append(appointmentDtos, dtos.ClientAppointmentDto{
 			ID:              appointment.ID,
 			AppointmentDate: appointment.AppointmentDate.Format("02/01/2006 15:04"),
 			Status:          appointment.Status,
+			StaffName:       staffFullName(appointment.Staff),
+			Services:        serviceNames,
+			Notes:           record.Notes,
+			ColorFormulas:   record.Formulas,
+			Photos:          record.Photos,
 		})
 	}
 	clientDto := dtos.GetClientDto{
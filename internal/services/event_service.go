@@ -0,0 +1,62 @@
+package services
+
+import (
+	"fmt"
+	"peluqueria/database"
+	"peluqueria/internal/dtos"
+	"peluqueria/internal/events"
+	"peluqueria/internal/models"
+	"peluqueria/logger"
+)
+
+// publishAppointmentEvent publica el estado actual de los turnos indicados. Debe llamarse
+// después de confirmar la transacción; los turnos eliminados también se cargan.
+func publishAppointmentEvent(eventType string, ids ...uint) {
+	if len(ids) == 0 {
+		return
+	}
+
+	var appointments []models.Appointment
+	if err := database.DB.Unscoped().Preload("Client").Preload("Staff").Where("id IN ?", ids).Find(&appointments).Error; err != nil {
+		logger.Log.Error("[EventService][publishAppointmentEvent] Error al cargar turnos para el evento: ", err)
+		return
+	}
+
+	for _, appointment := range appointments {
+		events.Publish(eventType, dtos.AppointmentEventDto{
+			ID:              appointment.ID,
+			ClientID:        appointment.ClientID,
+			ClientName:      fmt.Sprintf("%s %s", appointment.Client.Name, appointment.Client.LastName),
+			StaffID:         appointment.StaffID,
+			StaffName:       staffFullName(appointment.Staff),
+			SeriesID:        appointment.SeriesID,
+			Status:          appointment.Status,
+			AppointmentDate: appointment.AppointmentDate.Format("02/01/2006 15:04"),
+		})
+	}
+}
+
+// publishStockChanged publica la cantidad actual de los productos indicados. Debe llamarse
+// después de confirmar la transacción.
+func publishStockChanged(productIDs ...uint) {
+	if len(productIDs) == 0 {
+		return
+	}
+
+	var products []models.Product
+	if err := database.DB.Unscoped().Where("id IN ?", uniqueIDs(productIDs)).Find(&products).Error; err != nil {
+		logger.Log.Error("[EventService][publishStockChanged] Error al cargar productos para el evento: ", err)
+		return
+	}
+
+	for _, product := range products {
+		events.Publish(events.StockChanged, dtos.StockEventDto{
+			ProductID: product.ID,
+			Name:      product.Name,
+			Quantity:  product.Quantity,
+			Unit:      product.Unit,
+			LowStock:  product.Quantity <= product.LowStockAlert,
+			Deleted:   product.DeletedAt.Valid,
+		})
+	}
+}
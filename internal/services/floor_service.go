@@ -0,0 +1,223 @@
+package services
+
+import (
+	"errors"
+	"fmt"
+	"peluqueria/database"
+	"peluqueria/internal/dtos"
+	"peluqueria/internal/helpers"
+	"peluqueria/internal/models"
+	"peluqueria/logger"
+	"sort"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// Estados de los turnos que se muestran en la sala.
+var floorAppointmentStatuses = []string{
+	models.AppointmentStatusPending,
+	models.AppointmentStatusConfirmed,
+	models.AppointmentStatusInProgress,
+}
+
+// GetFloorStatus arma el estado de la sala del día: qué atiende cada estilista, en qué
+// sillón, cuánto viene atrasado y quién está esperando.
+func GetFloorStatus() (dtos.FloorStatusDto, error) {
+	logger.Log.Info("[FloorService][GetFloorStatus] Generando estado de la sala")
+
+	loc, err := helpers.SalonLocation()
+	if err != nil {
+		return dtos.FloorStatusDto{}, err
+	}
+	now := time.Now().In(loc)
+	dayStart := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, loc)
+	dayEnd := dayStart.AddDate(0, 0, 1)
+
+	// Los turnos en curso se incluyen aunque hayan empezado otro día
+	var appointments []models.Appointment
+	if err := database.DB.
+		Preload("Client").
+		Preload("Staff").
+		Preload("AppointmentServices.Service").
+		Preload("Resources.Resource", func(db *gorm.DB) *gorm.DB { return db.Unscoped() }).
+		Where("status IN ?", floorAppointmentStatuses).
+		Where("(appointment_date >= ? AND appointment_date < ?) OR status = ?", dayStart, dayEnd, models.AppointmentStatusInProgress).
+		Order("appointment_date, id").
+		Find(&appointments).Error; err != nil {
+		logger.Log.Error("[FloorService][GetFloorStatus] Error al obtener turnos del día: ", err)
+		return dtos.FloorStatusDto{}, errors.New("error al obtener turnos del día")
+	}
+
+	stylists, err := floorStylists(dayStart, appointments)
+	if err != nil {
+		return dtos.FloorStatusDto{}, err
+	}
+
+	status := dtos.FloorStatusDto{
+		Date:       now.Format("02/01/2006"),
+		Time:       now.Format("15:04"),
+		Stylists:   []dtos.FloorStylistDto{},
+		Unassigned: []dtos.FloorAppointmentDto{},
+		Waiting:    []dtos.FloorWaitingDto{},
+	}
+	byStaff := make(map[uint]*dtos.FloorStylistDto)
+	for _, staff := range stylists {
+		status.Stylists = append(status.Stylists, dtos.FloorStylistDto{
+			StaffID:   staff.ID,
+			StaffName: staffFullName(&staff),
+			InService: []dtos.FloorAppointmentDto{},
+		})
+	}
+	for i := range status.Stylists {
+		byStaff[status.Stylists[i].StaffID] = &status.Stylists[i]
+	}
+
+	for _, appointment := range appointments {
+		floorAppointment := toFloorAppointmentDto(appointment, now, loc)
+		inProgress := appointment.Status == models.AppointmentStatusInProgress
+
+		if !inProgress && appointment.CheckedInAt != nil {
+			status.Waiting = append(status.Waiting, dtos.FloorWaitingDto{
+				AppointmentID:   appointment.ID,
+				ClientName:      floorAppointment.ClientName,
+				StaffID:         appointment.StaffID,
+				StaffName:       staffFullName(appointment.Staff),
+				AppointmentDate: floorAppointment.AppointmentDate,
+				CheckedInAt:     floorAppointment.CheckedInAt,
+				WaitingMinutes:  uint(now.Sub(*appointment.CheckedInAt).Minutes()),
+			})
+		}
+
+		if appointment.StaffID == nil {
+			if inProgress {
+				status.Unassigned = append(status.Unassigned, floorAppointment)
+			}
+			continue
+		}
+		stylist, ok := byStaff[*appointment.StaffID]
+		if !ok {
+			continue
+		}
+		if inProgress {
+			stylist.InService = append(stylist.InService, floorAppointment)
+			stylist.Busy = true
+		} else if stylist.Next == nil {
+			next := floorAppointment
+			stylist.Next = &next
+		}
+	}
+
+	for i := range status.Stylists {
+		stylist := &status.Stylists[i]
+		for _, appointment := range stylist.InService {
+			stylist.DelayMinutes = max(stylist.DelayMinutes, appointment.DelayMinutes)
+		}
+		if stylist.Next != nil {
+			stylist.DelayMinutes = max(stylist.DelayMinutes, stylist.Next.DelayMinutes)
+		}
+	}
+
+	logger.Log.Infof("[FloorService][GetFloorStatus] Estado de la sala generado: %d estilistas, %d clientes esperando", len(status.Stylists), len(status.Waiting))
+	return status, nil
+}
+
+// floorStylists devuelve los estilistas activos que trabajan ese día y los que tienen
+// turnos en la sala, ordenados por nombre.
+func floorStylists(day time.Time, appointments []models.Appointment) ([]models.Staff, error) {
+	var active []models.Staff
+	if err := database.DB.Where("active = ?", true).Find(&active).Error; err != nil {
+		logger.Log.Error("[FloorService][floorStylists] Error al obtener estilistas: ", err)
+		return nil, errors.New("error al obtener estilistas")
+	}
+
+	seen := make(map[uint]bool)
+	var stylists []models.Staff
+	for _, staff := range active {
+		ranges, restricted, err := staffWorkingRanges(database.DB, staff.ID, day)
+		if err != nil {
+			return nil, err
+		}
+		if restricted && len(ranges) == 0 {
+			continue
+		}
+		seen[staff.ID] = true
+		stylists = append(stylists, staff)
+	}
+	for _, appointment := range appointments {
+		if appointment.Staff != nil && !seen[appointment.Staff.ID] {
+			seen[appointment.Staff.ID] = true
+			stylists = append(stylists, *appointment.Staff)
+		}
+	}
+
+	sort.SliceStable(stylists, func(i, j int) bool {
+		return staffFullName(&stylists[i]) < staffFullName(&stylists[j])
+	})
+	return stylists, nil
+}
+
+// toFloorAppointmentDto calcula el fin previsto y el atraso del turno. Un turno en curso
+// termina según su inicio real y, si se pasó del tiempo, no antes de ahora; uno sin
+// empezar no puede empezar antes de ahora.
+func toFloorAppointmentDto(appointment models.Appointment, now time.Time, loc *time.Location) dtos.FloorAppointmentDto {
+	duration := appointmentDuration(appointment)
+	scheduledEnd := appointment.AppointmentDate.Add(duration)
+
+	var expectedEnd time.Time
+	if appointment.StartedAt != nil {
+		expectedEnd = appointment.StartedAt.Add(duration)
+		if expectedEnd.Before(now) {
+			expectedEnd = now
+		}
+	} else {
+		start := appointment.AppointmentDate
+		if start.Before(now) {
+			start = now
+		}
+		expectedEnd = start.Add(duration)
+	}
+
+	serviceNames := []string{}
+	for _, appService := range appointment.AppointmentServices {
+		serviceNames = append(serviceNames, appService.Service.Name)
+	}
+	resourceNames := []string{}
+	for _, allocation := range appointment.Resources {
+		resourceNames = append(resourceNames, allocation.Resource.Name)
+	}
+
+	floorAppointment := dtos.FloorAppointmentDto{
+		AppointmentID:   appointment.ID,
+		ClientName:      fmt.Sprintf("%s %s", appointment.Client.Name, appointment.Client.LastName),
+		Status:          appointment.Status,
+		Services:        serviceNames,
+		Resources:       resourceNames,
+		AppointmentDate: appointment.AppointmentDate.In(loc).Format("15:04"),
+		ExpectedEnd:     expectedEnd.In(loc).Format("15:04"),
+		DelayMinutes:    max(0, int(expectedEnd.Sub(scheduledEnd).Minutes())),
+	}
+	if appointment.CheckedInAt != nil {
+		floorAppointment.CheckedInAt = appointment.CheckedInAt.In(loc).Format("15:04")
+	}
+	if appointment.StartedAt != nil {
+		floorAppointment.StartedAt = appointment.StartedAt.In(loc).Format("15:04")
+	}
+	return floorAppointment
+}
+
+// actualMinutes es la duración real del turno, si se registraron su inicio y su fin.
+func actualMinutes(appointment models.Appointment) *uint {
+	if appointment.StartedAt == nil || appointment.FinishedAt == nil {
+		return nil
+	}
+	minutes := uint(appointment.FinishedAt.Sub(*appointment.StartedAt).Round(time.Minute).Minutes())
+	return &minutes
+}
+
+func formatTimestamp(t *time.Time, layout string) string {
+	if t == nil {
+		return ""
+	}
+	return t.Format(layout)
+}
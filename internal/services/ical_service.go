@@ -0,0 +1,335 @@
+package services
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"peluqueria/database"
+	"peluqueria/internal/dtos"
+	"peluqueria/internal/helpers"
+	"peluqueria/internal/models"
+	"peluqueria/logger"
+	"strings"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+var ErrCalendarFeedNotFound = errors.New("calendario no encontrado")
+
+const (
+	icalFeedPath   = "/api/v1/ical/"
+	icalPastDays   = 30
+	icalFutureDays = 180
+)
+
+// CreateCalendarFeed genera la URL secreta de un calendario. Si ya existía una para el
+// mismo estilista (o para el salón) se reemplaza, lo que invalida la anterior.
+func CreateCalendarFeed(dto dtos.CalendarFeedDto) (dtos.GetCalendarFeedDto, error) {
+	logger.Log.Infof("[ICalService][CreateCalendarFeed] Generando calendario para estilista ID %d", dto.StaffID)
+
+	feed := models.CalendarFeed{}
+	if dto.StaffID != 0 {
+		staff, err := findActiveStaff(database.DB, dto.StaffID)
+		if err != nil {
+			return dtos.GetCalendarFeedDto{}, err
+		}
+		feed.StaffID = &staff.ID
+		feed.Staff = &staff
+	}
+
+	token, err := generateFeedToken()
+	if err != nil {
+		logger.Log.Error("[ICalService][CreateCalendarFeed] Error al generar token: ", err)
+		return dtos.GetCalendarFeedDto{}, errors.New("error al generar el calendario")
+	}
+	feed.Token = token
+
+	err = database.DB.Transaction(func(tx *gorm.DB) error {
+		previous := tx.Where("staff_id IS NULL")
+		if feed.StaffID != nil {
+			previous = tx.Where("staff_id = ?", *feed.StaffID)
+		}
+		if err := previous.Delete(&models.CalendarFeed{}).Error; err != nil {
+			return err
+		}
+		return tx.Omit("Staff").Create(&feed).Error
+	})
+	if err != nil {
+		logger.Log.Error("[ICalService][CreateCalendarFeed] Error al guardar calendario: ", err)
+		return dtos.GetCalendarFeedDto{}, errors.New("error al generar el calendario")
+	}
+
+	logger.Log.Infof("[ICalService][CreateCalendarFeed] Calendario generado: ID %d", feed.ID)
+	return toCalendarFeedDto(feed), nil
+}
+
+func GetAllCalendarFeeds() ([]dtos.GetCalendarFeedDto, error) {
+	logger.Log.Info("[ICalService][GetAllCalendarFeeds] Obteniendo calendarios")
+
+	var feeds []models.CalendarFeed
+	if err := database.DB.Preload("Staff").Order("id").Find(&feeds).Error; err != nil {
+		logger.Log.Error("[ICalService][GetAllCalendarFeeds] Error al obtener calendarios: ", err)
+		return nil, errors.New("error al obtener calendarios")
+	}
+
+	feedDtos := []dtos.GetCalendarFeedDto{}
+	for _, feed := range feeds {
+		feedDtos = append(feedDtos, toCalendarFeedDto(feed))
+	}
+	return feedDtos, nil
+}
+
+func DeleteCalendarFeed(id uint) error {
+	logger.Log.Infof("[ICalService][DeleteCalendarFeed] Revocando calendario ID: %d", id)
+
+	if err := database.DB.Delete(&models.CalendarFeed{}, id).Error; err != nil {
+		logger.Log.Error("[ICalService][DeleteCalendarFeed] Error al revocar calendario: ", err)
+		return errors.New("error al revocar el calendario")
+	}
+	return nil
+}
+
+// GetCalendarFeedICS arma el archivo .ics del calendario asociado al token. Incluye los
+// turnos eliminados como cancelados para que desaparezcan de los calendarios suscritos.
+func GetCalendarFeedICS(token string) (string, error) {
+	var feed models.CalendarFeed
+	if err := database.DB.Preload("Staff").Where("token = ?", token).First(&feed).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			logger.Log.Warn("[ICalService][GetCalendarFeedICS] Token de calendario inválido")
+			return "", ErrCalendarFeedNotFound
+		}
+		logger.Log.Error("[ICalService][GetCalendarFeedICS] Error al buscar calendario: ", err)
+		return "", errors.New("error al buscar calendario")
+	}
+
+	loc, err := helpers.SalonLocation()
+	if err != nil {
+		return "", err
+	}
+
+	now := time.Now()
+	from := now.AddDate(0, 0, -icalPastDays)
+	to := now.AddDate(0, 0, icalFutureDays)
+
+	var appointments []models.Appointment
+	query := database.DB.Unscoped().
+		Preload("Client", func(db *gorm.DB) *gorm.DB { return db.Unscoped() }).
+		Preload("Staff", func(db *gorm.DB) *gorm.DB { return db.Unscoped() }).
+		Preload("AppointmentServices", func(db *gorm.DB) *gorm.DB { return db.Unscoped() }).
+		Preload("AppointmentServices.Service", func(db *gorm.DB) *gorm.DB { return db.Unscoped() }).
+		Where("appointment_date BETWEEN ? AND ?", from, to)
+	if feed.StaffID != nil {
+		query = query.Where("staff_id = ?", *feed.StaffID)
+	}
+	if err := query.Order("appointment_date").Find(&appointments).Error; err != nil {
+		logger.Log.Error("[ICalService][GetCalendarFeedICS] Error al obtener turnos: ", err)
+		return "", errors.New("error al obtener turnos")
+	}
+
+	calendarName := "Peluquería"
+	if feed.Staff != nil {
+		calendarName = "Turnos de " + staffFullName(feed.Staff)
+	}
+
+	w := &icalWriter{}
+	w.line("BEGIN", "VCALENDAR")
+	w.line("VERSION", "2.0")
+	w.line("PRODID", "-//Peluqueria//Agenda//ES")
+	w.line("CALSCALE", "GREGORIAN")
+	w.line("METHOD", "PUBLISH")
+	w.line("X-WR-CALNAME", icalEscape(calendarName))
+	w.line("X-WR-TIMEZONE", loc.String())
+	writeVTimezone(w, loc, from, to)
+	for _, appointment := range appointments {
+		writeAppointmentEvent(w, appointment, loc, feed.StaffID == nil)
+	}
+	w.line("END", "VCALENDAR")
+
+	logger.Log.Infof("[ICalService][GetCalendarFeedICS] Calendario ID %d generado con %d turnos", feed.ID, len(appointments))
+	return w.String(), nil
+}
+
+func writeAppointmentEvent(w *icalWriter, appointment models.Appointment, loc *time.Location, includeStaff bool) {
+	duration := appointmentDuration(appointment)
+	if duration == 0 {
+		if slot, err := slotDuration(); err == nil {
+			duration = slot
+		} else {
+			duration = defaultSlotMinutes * time.Minute
+		}
+	}
+	start := appointment.AppointmentDate.In(loc)
+	end := start.Add(duration)
+
+	// Un turno eliminado se publica como cancelado con una revisión más
+	status := icalStatus(appointment.Status)
+	sequence := appointment.Sequence
+	if appointment.DeletedAt.Valid {
+		status = "CANCELLED"
+		sequence++
+	}
+
+	var serviceNames []string
+	for _, line := range appointment.AppointmentServices {
+		serviceNames = append(serviceNames, line.Service.Name)
+	}
+	clientName := strings.TrimSpace(fmt.Sprintf("%s %s", appointment.Client.Name, appointment.Client.LastName))
+	summary := clientName
+	if len(serviceNames) > 0 {
+		summary = fmt.Sprintf("%s - %s", clientName, strings.Join(serviceNames, ", "))
+	}
+
+	description := []string{"Estado: " + appointment.Status}
+	if appointment.Client.Phone != "" {
+		description = append(description, "Teléfono: "+appointment.Client.Phone)
+	}
+	if includeStaff && appointment.Staff != nil {
+		description = append(description, "Estilista: "+staffFullName(appointment.Staff))
+	}
+	if appointment.CancellationReason != "" {
+		description = append(description, "Motivo de cancelación: "+appointment.CancellationReason)
+	}
+
+	w.line("BEGIN", "VEVENT")
+	w.line("UID", fmt.Sprintf("turno-%d@%s", appointment.ID, envOrDefault("ICAL_DOMAIN", "peluqueria")))
+	w.line("DTSTAMP", appointment.UpdatedAt.UTC().Format("20060102T150405Z"))
+	w.line("LAST-MODIFIED", appointment.UpdatedAt.UTC().Format("20060102T150405Z"))
+	w.line("DTSTART;TZID="+loc.String(), start.Format("20060102T150405"))
+	w.line("DTEND;TZID="+loc.String(), end.Format("20060102T150405"))
+	w.line("SEQUENCE", fmt.Sprint(sequence))
+	w.line("STATUS", status)
+	w.line("SUMMARY", icalEscape(summary))
+	w.line("DESCRIPTION", icalEscape(strings.Join(description, "\n")))
+	w.line("END", "VEVENT")
+}
+
+// icalStatus traduce el estado del turno al STATUS de iCalendar.
+func icalStatus(status string) string {
+	switch status {
+	case models.AppointmentStatusConfirmed, models.AppointmentStatusInProgress, models.AppointmentStatusFinished:
+		return "CONFIRMED"
+	case models.AppointmentStatusCancelled, models.AppointmentStatusNoShow:
+		return "CANCELLED"
+	default:
+		return "TENTATIVE"
+	}
+}
+
+// writeVTimezone describe la zona horaria con un componente por cada tramo de offset
+// entre from y to, para que los clientes no dependan de su propia base de zonas.
+func writeVTimezone(w *icalWriter, loc *time.Location, from, to time.Time) {
+	w.line("BEGIN", "VTIMEZONE")
+	w.line("TZID", loc.String())
+
+	_, previousOffset := from.In(loc).Zone()
+	for start := from; start.Before(to); {
+		local := start.In(loc)
+		name, offset := local.Zone()
+		component := "STANDARD"
+		if local.IsDST() {
+			component = "DAYLIGHT"
+		}
+
+		w.line("BEGIN", component)
+		// DTSTART va en la hora local previa al cambio
+		w.line("DTSTART", start.UTC().Add(time.Duration(previousOffset)*time.Second).Format("20060102T150405"))
+		w.line("TZOFFSETFROM", icalOffset(previousOffset))
+		w.line("TZOFFSETTO", icalOffset(offset))
+		w.line("TZNAME", name)
+		w.line("END", component)
+
+		previousOffset = offset
+		start = nextZoneTransition(loc, start, to)
+	}
+
+	w.line("END", "VTIMEZONE")
+}
+
+// nextZoneTransition busca el próximo cambio de offset después de from, o devuelve to.
+func nextZoneTransition(loc *time.Location, from, to time.Time) time.Time {
+	_, offset := from.In(loc).Zone()
+	low := from
+	for high := from.Add(24 * time.Hour); ; high = high.Add(24 * time.Hour) {
+		if !high.Before(to) {
+			if _, o := to.In(loc).Zone(); o == offset {
+				return to
+			}
+			high = to
+		}
+		if _, o := high.In(loc).Zone(); o != offset {
+			// Búsqueda binaria hasta el minuto del cambio
+			for high.Sub(low) > time.Minute {
+				mid := low.Add(high.Sub(low) / 2)
+				if _, o := mid.In(loc).Zone(); o == offset {
+					low = mid
+				} else {
+					high = mid
+				}
+			}
+			return high.Truncate(time.Minute)
+		}
+		low = high
+	}
+}
+
+func icalOffset(seconds int) string {
+	sign := "+"
+	if seconds < 0 {
+		sign = "-"
+		seconds = -seconds
+	}
+	return fmt.Sprintf("%s%02d%02d", sign, seconds/3600, seconds%3600/60)
+}
+
+func icalEscape(value string) string {
+	return strings.NewReplacer(`\`, `\\`, ";", `\;`, ",", `\,`, "\r\n", `\n`, "\n", `\n`).Replace(value)
+}
+
+// icalWriter escribe líneas de contenido iCalendar plegadas a 75 octetos.
+type icalWriter struct {
+	b strings.Builder
+}
+
+func (w *icalWriter) line(name, value string) {
+	content := name + ":" + value
+	width := 75
+	for len(content) > width {
+		cut := width
+		// No cortar en medio de un carácter UTF-8
+		for cut > 0 && content[cut]&0xC0 == 0x80 {
+			cut--
+		}
+		w.b.WriteString(content[:cut] + "\r\n ")
+		content = content[cut:]
+		width = 74
+	}
+	w.b.WriteString(content + "\r\n")
+}
+
+func (w *icalWriter) String() string {
+	return w.b.String()
+}
+
+func generateFeedToken() (string, error) {
+	buf := make([]byte, 32)
+	if _, err := rand.Read(buf); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(buf), nil
+}
+
+func toCalendarFeedDto(feed models.CalendarFeed) dtos.GetCalendarFeedDto {
+	loc, err := helpers.SalonLocation()
+	if err != nil {
+		loc = time.Local
+	}
+	return dtos.GetCalendarFeedDto{
+		ID:        feed.ID,
+		StaffID:   feed.StaffID,
+		StaffName: staffFullName(feed.Staff),
+		URL:       icalFeedPath + feed.Token + ".ics",
+		CreatedAt: feed.CreatedAt.In(loc).Format("02/01/2006 15:04"),
+	}
+}
@@ -0,0 +1,375 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"peluqueria/database"
+	"peluqueria/internal/dtos"
+	"peluqueria/internal/helpers"
+	"peluqueria/internal/models"
+	"peluqueria/internal/notifications"
+	"peluqueria/logger"
+	"slices"
+	"sort"
+	"strings"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+const (
+	defaultReminderOffsets   = "24h,2h"
+	maxNotificationAttempts  = 5
+	notificationBatchSize    = 50
+	notificationBaseBackoff  = time.Minute
+	notificationMaxBackoff   = time.Hour
+	notificationSendTimeout  = 30 * time.Second
+	defaultNotificationCycle = time.Minute
+)
+
+// notificationChannels son los canales configurados al iniciar el worker.
+var notificationChannels []string
+
+// reminderStatuses son los estados de turno a los que se les envía recordatorio.
+var reminderStatuses = []string{models.AppointmentStatusPending, models.AppointmentStatusConfirmed}
+
+// StartNotificationWorker programa y envía recordatorios periódicamente hasta que se
+// cancele el contexto. Se ejecuta dentro del proceso web.
+func StartNotificationWorker(ctx context.Context, notifiers map[string]notifications.Notifier) {
+	if len(notifiers) == 0 {
+		logger.Log.Warn("[NotificationService][StartNotificationWorker] No hay canales de notificación configurados, el worker no se inicia")
+		return
+	}
+
+	for channel := range notifiers {
+		notificationChannels = append(notificationChannels, channel)
+	}
+	sort.Strings(notificationChannels)
+
+	interval := defaultNotificationCycle
+	if value := envOrDefault("NOTIFY_INTERVAL", ""); value != "" {
+		if parsed, err := time.ParseDuration(value); err == nil && parsed > 0 {
+			interval = parsed
+		}
+	}
+
+	logger.Log.Infof("[NotificationService][StartNotificationWorker] Worker de notificaciones iniciado cada %s", interval)
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for {
+			RunNotificationCycle(ctx, notifiers)
+			select {
+			case <-ctx.Done():
+				logger.Log.Info("[NotificationService][StartNotificationWorker] Worker de notificaciones detenido")
+				return
+			case <-ticker.C:
+			}
+		}
+	}()
+}
+
+// RunNotificationCycle libera las reservas online vencidas, programa los recordatorios que
+// correspondan y envía los pendientes.
+func RunNotificationCycle(ctx context.Context, notifiers map[string]notifications.Notifier) {
+	expireBookingRequests()
+
+	channels := make([]string, 0, len(notifiers))
+	for channel := range notifiers {
+		channels = append(channels, channel)
+	}
+	if err := scheduleReminders(channels, time.Now()); err != nil {
+		logger.Log.Error("[NotificationService][RunNotificationCycle] Error al programar recordatorios: ", err)
+	}
+	if err := deliverPendingNotifications(ctx, notifiers, time.Now()); err != nil {
+		logger.Log.Error("[NotificationService][RunNotificationCycle] Error al enviar notificaciones: ", err)
+	}
+}
+
+func GetNotifications(status, appointmentID string) ([]dtos.NotificationDto, error) {
+	logger.Log.Info("[NotificationService][GetNotifications] Obteniendo notificaciones")
+
+	query := database.DB.Order("next_attempt_at DESC").Limit(200)
+	if status != "" {
+		query = query.Where("status = ?", status)
+	}
+	if appointmentID != "" {
+		query = query.Where("appointment_id = ?", appointmentID)
+	}
+
+	var list []models.Notification
+	if err := query.Find(&list).Error; err != nil {
+		logger.Log.Error("[NotificationService][GetNotifications] Error al obtener notificaciones: ", err)
+		return nil, errors.New("error al obtener notificaciones")
+	}
+
+	notificationDtos := []dtos.NotificationDto{}
+	for _, notification := range list {
+		notificationDtos = append(notificationDtos, toNotificationDto(notification))
+	}
+	return notificationDtos, nil
+}
+
+// RetryNotification vuelve a poner en cola una notificación fallida.
+func RetryNotification(id uint) error {
+	logger.Log.Infof("[NotificationService][RetryNotification] Reintentando notificación ID: %d", id)
+
+	result := database.DB.Model(&models.Notification{}).
+		Where("id = ? AND status = ?", id, models.NotificationStatusFailed).
+		Updates(map[string]interface{}{
+			"status":          models.NotificationStatusPending,
+			"attempts":        0,
+			"next_attempt_at": time.Now(),
+		})
+	if result.Error != nil {
+		logger.Log.Error("[NotificationService][RetryNotification] Error al reintentar notificación: ", result.Error)
+		return errors.New("error al reintentar notificación")
+	}
+	if result.RowsAffected == 0 {
+		return errors.New("la notificación no existe o no está fallida")
+	}
+	return nil
+}
+
+// scheduleReminders encola, para cada turno que entró en la ventana de un recordatorio,
+// el recordatorio más cercano que le corresponde. El índice único evita duplicados para una
+// misma fecha del turno; al reprogramarlo se encolan los de la nueva fecha. El mensaje se
+// arma al enviarlo, así refleja los cambios que tenga el turno mientras tanto.
+func scheduleReminders(channels []string, now time.Time) error {
+	offsets := reminderOffsets()
+	if len(offsets) == 0 || len(channels) == 0 {
+		return nil
+	}
+
+	var appointments []models.Appointment
+	if err := database.DB.Preload("Client").
+		Where("status IN ? AND appointment_date > ? AND appointment_date <= ?", reminderStatuses, now, now.Add(offsets[0])).
+		Find(&appointments).Error; err != nil {
+		return err
+	}
+
+	for _, appointment := range appointments {
+		until := appointment.AppointmentDate.Sub(now)
+		// Offsets ordenados de mayor a menor: el último que aún cubre el turno es el más cercano
+		var offset time.Duration
+		for _, candidate := range offsets {
+			if until <= candidate {
+				offset = candidate
+			}
+		}
+		kind := reminderKind(offset)
+
+		for _, channel := range channels {
+			recipient := appointment.Client.Email
+			if channel != notifications.ChannelEmail {
+				recipient = appointment.Client.Phone
+			}
+			if recipient == "" {
+				continue
+			}
+
+			notification := models.Notification{
+				AppointmentID: &appointment.ID,
+				Kind:          kind,
+				Channel:       channel,
+				ScheduledFor:  &appointment.AppointmentDate,
+				Recipient:     recipient,
+				Status:        models.NotificationStatusPending,
+				NextAttemptAt: now,
+			}
+			result := database.DB.Clauses(clause.OnConflict{DoNothing: true}).Create(&notification)
+			if result.Error != nil {
+				return result.Error
+			}
+			if result.RowsAffected > 0 {
+				logger.Log.Infof("[NotificationService][scheduleReminders] Recordatorio %s por %s encolado para turno ID %d", kind, channel, appointment.ID)
+			}
+		}
+	}
+	return nil
+}
+
+// deliverPendingNotifications envía las notificaciones vencidas y reprograma las fallidas.
+func deliverPendingNotifications(ctx context.Context, notifiers map[string]notifications.Notifier, now time.Time) error {
+	var pending []models.Notification
+	if err := database.DB.
+		Where("status = ? AND next_attempt_at <= ?", models.NotificationStatusPending, now).
+		Order("next_attempt_at").Limit(notificationBatchSize).
+		Find(&pending).Error; err != nil {
+		return err
+	}
+
+	for _, notification := range pending {
+		if notification.AppointmentID != nil {
+			valid, err := prepareNotification(&notification, now)
+			if err != nil {
+				return err
+			}
+			if !valid {
+				notification.Status = models.NotificationStatusCancelled
+				if err := database.DB.Save(&notification).Error; err != nil {
+					return err
+				}
+				continue
+			}
+		}
+
+		notifier, ok := notifiers[notification.Channel]
+		if !ok {
+			// Canal desconfigurado: queda pendiente hasta que vuelva a estar disponible
+			continue
+		}
+
+		sendCtx, cancel := context.WithTimeout(ctx, notificationSendTimeout)
+		err := notifier.Send(sendCtx, notifications.Message{
+			To:      notification.Recipient,
+			Subject: notification.Subject,
+			Body:    notification.Body,
+		})
+		cancel()
+
+		notification.Attempts++
+		if err == nil {
+			sentAt := time.Now()
+			notification.Status = models.NotificationStatusSent
+			notification.SentAt = &sentAt
+			notification.LastError = ""
+			logger.Log.Infof("[NotificationService][deliverPendingNotifications] Notificación ID %d enviada por %s", notification.ID, notification.Channel)
+		} else {
+			notification.LastError = truncate(err.Error(), 500)
+			if notification.Attempts >= maxNotificationAttempts {
+				notification.Status = models.NotificationStatusFailed
+				logger.Log.Errorf("[NotificationService][deliverPendingNotifications] Notificación ID %d fallida tras %d intentos: %v", notification.ID, notification.Attempts, err)
+			} else {
+				notification.NextAttemptAt = time.Now().Add(notificationBackoff(notification.Attempts))
+				logger.Log.Warnf("[NotificationService][deliverPendingNotifications] Error al enviar notificación ID %d, reintento a las %s: %v", notification.ID, notification.NextAttemptAt.Format("15:04"), err)
+			}
+		}
+		if err := database.DB.Save(&notification).Error; err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// prepareNotification indica si todavía corresponde enviar la notificación del turno. Los
+// recordatorios se arman con los datos actuales del turno; el código de una reserva ya
+// viene armado.
+func prepareNotification(notification *models.Notification, now time.Time) (bool, error) {
+	if notification.Kind == bookingCodeKind {
+		return bookingCodeStillValid(*notification.AppointmentID, now), nil
+	}
+
+	var appointment models.Appointment
+	err := database.DB.Preload("Client").Preload("Staff").Preload("AppointmentServices.Service").
+		First(&appointment, *notification.AppointmentID).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+	if !slices.Contains(reminderStatuses, appointment.Status) || !appointment.AppointmentDate.After(now) {
+		return false, nil
+	}
+	// El turno se reprogramó: el recordatorio de la nueva fecha se encola aparte
+	if notification.ScheduledFor == nil || !notification.ScheduledFor.Equal(appointment.AppointmentDate) {
+		return false, nil
+	}
+
+	notification.Subject, notification.Body = reminderMessage(appointment)
+	return true, nil
+}
+
+// notificationBackoff duplica la espera con cada intento, con un máximo de una hora.
+func notificationBackoff(attempts int) time.Duration {
+	backoff := notificationBaseBackoff
+	for i := 1; i < attempts; i++ {
+		backoff *= 2
+		if backoff >= notificationMaxBackoff {
+			return notificationMaxBackoff
+		}
+	}
+	return backoff
+}
+
+// reminderOffsets lee REMINDER_OFFSETS (ej: "24h,2h") y los devuelve de mayor a menor.
+func reminderOffsets() []time.Duration {
+	var offsets []time.Duration
+	for _, value := range strings.Split(envOrDefault("REMINDER_OFFSETS", defaultReminderOffsets), ",") {
+		offset, err := time.ParseDuration(strings.TrimSpace(value))
+		if err != nil || offset <= 0 {
+			logger.Log.Warnf("[NotificationService][reminderOffsets] Anticipación inválida ignorada: %s", value)
+			continue
+		}
+		offsets = append(offsets, offset)
+	}
+	sort.Slice(offsets, func(i, j int) bool { return offsets[i] > offsets[j] })
+	return offsets
+}
+
+func reminderKind(offset time.Duration) string {
+	if offset%time.Hour == 0 {
+		return fmt.Sprintf("recordatorio_%dh", int(offset.Hours()))
+	}
+	return fmt.Sprintf("recordatorio_%dm", int(offset.Minutes()))
+}
+
+func reminderMessage(appointment models.Appointment) (string, string) {
+	loc, err := helpers.SalonLocation()
+	if err != nil {
+		loc = time.Local
+	}
+
+	var serviceNames []string
+	for _, line := range appointment.AppointmentServices {
+		serviceNames = append(serviceNames, line.Service.Name)
+	}
+
+	body := fmt.Sprintf("Hola %s, te recordamos tu turno del %s a las %s",
+		appointment.Client.Name,
+		appointment.AppointmentDate.In(loc).Format("02/01/2006"),
+		appointment.AppointmentDate.In(loc).Format("15:04"))
+	if appointment.Staff != nil {
+		body += " con " + staffFullName(appointment.Staff)
+	}
+	if len(serviceNames) > 0 {
+		body += " (" + strings.Join(serviceNames, ", ") + ")"
+	}
+	body += ".\nSi no podés asistir, avisanos para liberar el horario."
+
+	return "Recordatorio de turno", body
+}
+
+func truncate(value string, max int) string {
+	if len(value) <= max {
+		return value
+	}
+	return value[:max]
+}
+
+func toNotificationDto(notification models.Notification) dtos.NotificationDto {
+	loc, err := helpers.SalonLocation()
+	if err != nil {
+		loc = time.Local
+	}
+	sentAt := ""
+	if notification.SentAt != nil {
+		sentAt = notification.SentAt.In(loc).Format("02/01/2006 15:04")
+	}
+	return dtos.NotificationDto{
+		ID:            notification.ID,
+		AppointmentID: notification.AppointmentID,
+		Kind:          notification.Kind,
+		Channel:       notification.Channel,
+		Recipient:     notification.Recipient,
+		Subject:       notification.Subject,
+		Status:        notification.Status,
+		Attempts:      notification.Attempts,
+		NextAttemptAt: notification.NextAttemptAt.In(loc).Format("02/01/2006 15:04"),
+		LastError:     notification.LastError,
+		SentAt:        sentAt,
+	}
+}
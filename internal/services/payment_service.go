@@ -0,0 +1,287 @@
+package services
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"peluqueria/database"
+	"peluqueria/internal/dtos"
+	"peluqueria/internal/models"
+	"peluqueria/logger"
+	"strings"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+var (
+	ErrInvalidPayment        = errors.New("pago inválido")
+	ErrInvalidPaymentMethod  = errors.New("medio de pago inválido")
+	ErrPaymentMethodNotFound = errors.New("el medio de pago no existe")
+)
+
+// Medio de pago que se registra en el turno cuando se pagó con más de uno.
+const mixedPaymentMethod = "mixto"
+
+func CreatePaymentMethod(dto dtos.PaymentMethodDto) error {
+	logger.Log.Infof("[PaymentService][CreatePaymentMethod] Intentando crear medio de pago: %s", dto.Code)
+
+	code := normalizePaymentMethodCode(dto.Code)
+	if code == "" || strings.TrimSpace(dto.Name) == "" {
+		logger.Log.Warn("[PaymentService][CreatePaymentMethod] Código o nombre faltante")
+		return fmt.Errorf("%w: el código y el nombre del medio de pago son obligatorios", ErrInvalidPaymentMethod)
+	}
+	if code == mixedPaymentMethod {
+		return fmt.Errorf("%w: el código '%s' está reservado", ErrInvalidPaymentMethod, mixedPaymentMethod)
+	}
+	if err := database.DB.Unscoped().Where("code = ?", code).First(&models.PaymentMethod{}).Error; err == nil {
+		logger.Log.Warn("[PaymentService][CreatePaymentMethod] Medio de pago existente")
+		return fmt.Errorf("%w: ya existe un medio de pago con ese código", ErrInvalidPaymentMethod)
+	}
+
+	method := models.PaymentMethod{
+		Code:   code,
+		Name:   strings.TrimSpace(dto.Name),
+		Active: true,
+	}
+	if dto.IsCash != nil {
+		method.IsCash = *dto.IsCash
+	}
+	if dto.RequiresReference != nil {
+		method.RequiresReference = *dto.RequiresReference
+	}
+	if dto.Active != nil {
+		method.Active = *dto.Active
+	}
+
+	if err := database.DB.Create(&method).Error; err != nil {
+		logger.Log.Error("[PaymentService][CreatePaymentMethod] Error al crear medio de pago: ", err)
+		return errors.New("error al crear medio de pago")
+	}
+
+	logger.Log.Infof("[PaymentService][CreatePaymentMethod] Medio de pago creado: %s", method.Code)
+	return nil
+}
+
+func GetAllPaymentMethods(onlyActive bool) ([]dtos.GetPaymentMethodDto, error) {
+	logger.Log.Info("[PaymentService][GetAllPaymentMethods] Obteniendo medios de pago")
+
+	query := database.DB.Order("name")
+	if onlyActive {
+		query = query.Where("active = ?", true)
+	}
+	var methods []models.PaymentMethod
+	if err := query.Find(&methods).Error; err != nil {
+		logger.Log.Error("[PaymentService][GetAllPaymentMethods] Error al obtener medios de pago: ", err)
+		return nil, errors.New("error al obtener medios de pago")
+	}
+
+	methodDtos := []dtos.GetPaymentMethodDto{}
+	for _, method := range methods {
+		methodDtos = append(methodDtos, toPaymentMethodDto(method))
+	}
+	return methodDtos, nil
+}
+
+// UpdatePaymentMethod modifica nombre y configuración del medio de pago. El código no cambia
+// para no alterar los pagos ya registrados.
+func UpdatePaymentMethod(id uint, dto dtos.PaymentMethodDto) error {
+	logger.Log.Infof("[PaymentService][UpdatePaymentMethod] Actualizando medio de pago con ID: %d", id)
+
+	var method models.PaymentMethod
+	if err := database.DB.First(&method, id).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			logger.Log.Warnf("[PaymentService][UpdatePaymentMethod] Medio de pago no encontrado: ID %d", id)
+			return ErrPaymentMethodNotFound
+		}
+		logger.Log.Error("[PaymentService][UpdatePaymentMethod] Error al buscar medio de pago: ", err)
+		return errors.New("error al buscar medio de pago")
+	}
+	if dto.Code != "" && normalizePaymentMethodCode(dto.Code) != method.Code {
+		return fmt.Errorf("%w: el código del medio de pago no se puede modificar", ErrInvalidPaymentMethod)
+	}
+
+	if name := strings.TrimSpace(dto.Name); name != "" {
+		method.Name = name
+	}
+	if dto.IsCash != nil {
+		method.IsCash = *dto.IsCash
+	}
+	if dto.RequiresReference != nil {
+		method.RequiresReference = *dto.RequiresReference
+	}
+	if dto.Active != nil {
+		method.Active = *dto.Active
+	}
+
+	if err := database.DB.Save(&method).Error; err != nil {
+		logger.Log.Error("[PaymentService][UpdatePaymentMethod] Error al actualizar medio de pago: ", err)
+		return errors.New("error al actualizar medio de pago")
+	}
+
+	logger.Log.Infof("[PaymentService][UpdatePaymentMethod] Medio de pago actualizado: %s", method.Code)
+	return nil
+}
+
+// DeletePaymentMethod da de baja el medio de pago. Los pagos registrados lo conservan.
+func DeletePaymentMethod(id uint) error {
+	logger.Log.Infof("[PaymentService][DeletePaymentMethod] Eliminando medio de pago con ID: %d", id)
+
+	result := database.DB.Delete(&models.PaymentMethod{}, id)
+	if result.Error != nil {
+		logger.Log.Error("[PaymentService][DeletePaymentMethod] Error al eliminar medio de pago: ", result.Error)
+		return errors.New("error al eliminar medio de pago")
+	}
+	if result.RowsAffected == 0 {
+		logger.Log.Warnf("[PaymentService][DeletePaymentMethod] Medio de pago no encontrado: ID %d", id)
+		return ErrPaymentMethodNotFound
+	}
+
+	logger.Log.Infof("[PaymentService][DeletePaymentMethod] Medio de pago eliminado: ID %d", id)
+	return nil
+}
+
+// appointmentTotal es lo que se cobra por el turno: la suma de sus líneas de servicio.
+func appointmentTotal(db *gorm.DB, appointmentID uint) (float64, error) {
+	var total float64
+	if err := db.Model(&models.AppointmentService{}).
+		Select("COALESCE(SUM(price), 0)").
+		Where("appointment_id = ?", appointmentID).
+		Scan(&total).Error; err != nil {
+		logger.Log.Error("[PaymentService][appointmentTotal] Error al calcular total del turno: ", err)
+		return 0, errors.New("error al calcular el total del turno")
+	}
+	return roundCents(total), nil
+}
+
+// buildPayments arma los pagos del turno y verifica que sumen exactamente el total. Sin
+// pagos detallados se usa el medio de pago indicado por el total.
+func buildPayments(tx *gorm.DB, appointmentID uint, finalizeDto dtos.FinalizeAppointmentDto, total float64, userID uint) ([]models.Payment, error) {
+	paymentDtos := finalizeDto.Payments
+	if len(paymentDtos) == 0 && finalizeDto.PaymentMethod != "" {
+		paymentDtos = []dtos.PaymentDto{{Method: finalizeDto.PaymentMethod, Amount: total}}
+	}
+	if len(paymentDtos) == 0 {
+		if total == 0 {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("%w: debe indicar al menos un pago", ErrInvalidPayment)
+	}
+
+	now := time.Now()
+	var payments []models.Payment
+	for _, paymentDto := range paymentDtos {
+		method, err := findActivePaymentMethod(tx, paymentDto.Method)
+		if err != nil {
+			return nil, err
+		}
+		payments = append(payments, models.Payment{
+			AppointmentID: appointmentID,
+			MethodID:      method.ID,
+			Method:        method,
+			Amount:        roundCents(paymentDto.Amount),
+			Reference:     strings.TrimSpace(paymentDto.Reference),
+			PaidAt:        now,
+			UserID:        optionalUserID(userID),
+		})
+	}
+
+	if err := validatePaymentSplit(payments, total); err != nil {
+		logger.Log.Warnf("[PaymentService][buildPayments] Pagos inválidos en turno ID %d: %v", appointmentID, err)
+		return nil, err
+	}
+	return payments, nil
+}
+
+// validatePaymentSplit verifica cada pago y que entre todos sumen exactamente el total,
+// comparando en centavos.
+func validatePaymentSplit(payments []models.Payment, total float64) error {
+	var paid int64
+	for _, payment := range payments {
+		if payment.Amount <= 0 {
+			return fmt.Errorf("%w: el monto de cada pago debe ser mayor a 0", ErrInvalidPayment)
+		}
+		if payment.Method.RequiresReference && payment.Reference == "" {
+			return fmt.Errorf("%w: el pago con '%s' requiere número de operación", ErrInvalidPayment, payment.Method.Name)
+		}
+		paid += cents(payment.Amount)
+	}
+
+	if paid != cents(total) {
+		return fmt.Errorf("%w: los pagos suman %.2f y el total del turno es %.2f", ErrInvalidPayment, float64(paid)/100, total)
+	}
+	return nil
+}
+
+// findActivePaymentMethod busca un medio de pago activo por su código.
+func findActivePaymentMethod(tx *gorm.DB, code string) (models.PaymentMethod, error) {
+	var method models.PaymentMethod
+	if err := tx.Where("code = ? AND active = ?", normalizePaymentMethodCode(code), true).First(&method).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return models.PaymentMethod{}, fmt.Errorf("%w: el medio de pago '%s' no existe o no está activo", ErrInvalidPayment, code)
+		}
+		logger.Log.Error("[PaymentService][findActivePaymentMethod] Error al buscar medio de pago: ", err)
+		return models.PaymentMethod{}, errors.New("error al buscar medio de pago")
+	}
+	return method, nil
+}
+
+// paymentSummary es el medio de pago que se guarda en el turno: el único usado o "mixto".
+func paymentSummary(payments []models.Payment) string {
+	summary := ""
+	for _, payment := range payments {
+		if summary != "" && summary != payment.Method.Code {
+			return mixedPaymentMethod
+		}
+		summary = payment.Method.Code
+	}
+	return summary
+}
+
+// appointmentPayments devuelve los pagos de los turnos, indexados por turno.
+func appointmentPayments(db *gorm.DB, appointmentIDs []uint) (map[uint][]dtos.GetPaymentDto, error) {
+	var payments []models.Payment
+	if err := db.
+		Preload("Method", func(db *gorm.DB) *gorm.DB { return db.Unscoped() }).
+		Preload("User").
+		Where("appointment_id IN ?", appointmentIDs).
+		Order("paid_at, id").
+		Find(&payments).Error; err != nil {
+		logger.Log.Error("[PaymentService][appointmentPayments] Error al obtener pagos: ", err)
+		return nil, errors.New("error al obtener pagos del turno")
+	}
+
+	byAppointment := make(map[uint][]dtos.GetPaymentDto)
+	for _, payment := range payments {
+		byAppointment[payment.AppointmentID] = append(byAppointment[payment.AppointmentID], dtos.GetPaymentDto{
+			ID:         payment.ID,
+			Method:     payment.Method.Code,
+			MethodName: payment.Method.Name,
+			Amount:     payment.Amount,
+			Reference:  payment.Reference,
+			PaidAt:     payment.PaidAt.Format("02/01/2006 15:04"),
+			UserID:     payment.UserID,
+			Username:   username(payment.User),
+		})
+	}
+	return byAppointment, nil
+}
+
+func normalizePaymentMethodCode(code string) string {
+	return strings.ToLower(strings.TrimSpace(code))
+}
+
+func roundCents(amount float64) float64 {
+	return math.Round(amount*100) / 100
+}
+
+func toPaymentMethodDto(method models.PaymentMethod) dtos.GetPaymentMethodDto {
+	return dtos.GetPaymentMethodDto{
+		ID:                method.ID,
+		Code:              method.Code,
+		Name:              method.Name,
+		IsCash:            method.IsCash,
+		RequiresReference: method.RequiresReference,
+		Active:            method.Active,
+	}
+}
@@ -0,0 +1,94 @@
+package services
+
+import (
+	"errors"
+	"peluqueria/internal/models"
+	"testing"
+)
+
+func TestValidatePaymentSplit(t *testing.T) {
+	cash := models.PaymentMethod{Code: "efectivo", Name: "Efectivo", IsCash: true}
+	card := models.PaymentMethod{Code: "tarjeta", Name: "Tarjeta", RequiresReference: true}
+
+	tests := []struct {
+		name     string
+		payments []models.Payment
+		total    float64
+		wantErr  bool
+	}{
+		{
+			name:     "un pago por el total",
+			payments: []models.Payment{{Method: cash, Amount: 1500}},
+			total:    1500,
+		},
+		{
+			name: "pago dividido que suma el total",
+			payments: []models.Payment{
+				{Method: cash, Amount: 1000},
+				{Method: card, Amount: 500.5, Reference: "A123"},
+			},
+			total: 1500.5,
+		},
+		{
+			name: "centavos que en punto flotante no suman exacto",
+			payments: []models.Payment{
+				{Method: cash, Amount: 0.1},
+				{Method: cash, Amount: 0.2},
+			},
+			total: 0.3,
+		},
+		{
+			name:     "pagos que no cubren el total",
+			payments: []models.Payment{{Method: cash, Amount: 1000}},
+			total:    1500,
+			wantErr:  true,
+		},
+		{
+			name: "pagos que superan el total",
+			payments: []models.Payment{
+				{Method: cash, Amount: 1000},
+				{Method: cash, Amount: 600},
+			},
+			total:   1500,
+			wantErr: true,
+		},
+		{
+			name:     "pago en cero",
+			payments: []models.Payment{{Method: cash, Amount: 1500}, {Method: cash, Amount: 0}},
+			total:    1500,
+			wantErr:  true,
+		},
+		{
+			name:     "pago negativo",
+			payments: []models.Payment{{Method: cash, Amount: 1600}, {Method: cash, Amount: -100}},
+			total:    1500,
+			wantErr:  true,
+		},
+		{
+			name:     "medio que requiere número de operación sin número",
+			payments: []models.Payment{{Method: card, Amount: 1500}},
+			total:    1500,
+			wantErr:  true,
+		},
+		{
+			name:    "sin pagos para un total mayor a cero",
+			total:   1500,
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := validatePaymentSplit(tt.payments, tt.total)
+			if tt.wantErr {
+				if !errors.Is(err, ErrInvalidPayment) {
+					t.Fatalf("se esperaba ErrInvalidPayment, se obtuvo %v", err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("error inesperado: %v", err)
+			}
+		})
+	}
+}
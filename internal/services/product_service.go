@@ -65,6 +65,7 @@ func CreateProduct(productDto dtos.CreateProductDto) error {
 	}
 
 	logger.Log.Infof("[ProductService][CreateProduct] Producto creado con éxito: %s", product.Name)
+	publishStockChanged(product.ID)
 	return nil
 }
 
@@ -147,6 +148,7 @@ func UpdateProduct(id uint, productDto dtos.UpdateProductDto) error {
 	}
 
 	logger.Log.Infof("[ProductService][UpdateProduct] Producto actualizado con éxito: %s", product.Name)
+	publishStockChanged(product.ID)
 	return nil
 }
 
@@ -170,6 +172,7 @@ func DeleteProduct(id uint) error {
 	}
 
 	logger.Log.Infof("[ProductService][DeleteProduct] Producto eliminado con éxito: ID %d", id)
+	publishStockChanged(id)
 	return nil
 }
 
@@ -216,5 +219,6 @@ func RestockProduct(id uint, restockDto dtos.RestockProductDto) error {
 	}
 
 	logger.Log.Infof("[ProductService][RestockProduct] Producto reestockeado con éxito: %s", product.Name)
+	publishStockChanged(product.ID)
 	return nil
 }
@@ -0,0 +1,503 @@
+package services
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"peluqueria/database"
+	"peluqueria/internal/dtos"
+	"peluqueria/internal/helpers"
+	"peluqueria/internal/models"
+	"peluqueria/logger"
+	"strings"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+var (
+	ErrInvalidPromotion  = errors.New("promoción inválida")
+	ErrPromotionNotFound = errors.New("promoción no encontrada")
+)
+
+func CreatePromotion(dto dtos.PromotionDto) error {
+	logger.Log.Infof("[PromotionService][CreatePromotion] Intentando crear promoción: %s", dto.Name)
+
+	promotion := models.Promotion{Active: true}
+	if err := applyPromotionDto(database.DB, &promotion, dto); err != nil {
+		logger.Log.Warn("[PromotionService][CreatePromotion] Promoción inválida: ", err)
+		return err
+	}
+
+	if err := database.DB.Create(&promotion).Error; err != nil {
+		logger.Log.Error("[PromotionService][CreatePromotion] Error al crear promoción: ", err)
+		return errors.New("error al crear promoción")
+	}
+
+	logger.Log.Infof("[PromotionService][CreatePromotion] Promoción creada: %s", promotion.Name)
+	return nil
+}
+
+func GetAllPromotions(onlyActive bool) ([]dtos.GetPromotionDto, error) {
+	logger.Log.Info("[PromotionService][GetAllPromotions] Obteniendo promociones")
+
+	query := database.DB.Preload("Service", func(db *gorm.DB) *gorm.DB { return db.Unscoped() }).Order("name")
+	if onlyActive {
+		query = query.Where("active = ?", true)
+	}
+	var promotions []models.Promotion
+	if err := query.Find(&promotions).Error; err != nil {
+		logger.Log.Error("[PromotionService][GetAllPromotions] Error al obtener promociones: ", err)
+		return nil, errors.New("error al obtener promociones")
+	}
+
+	ids := make([]uint, len(promotions))
+	for i, promotion := range promotions {
+		ids[i] = promotion.ID
+	}
+	uses, err := promotionUses(database.DB, ids)
+	if err != nil {
+		return nil, err
+	}
+
+	promotionDtos := []dtos.GetPromotionDto{}
+	for _, promotion := range promotions {
+		promotionDtos = append(promotionDtos, toPromotionDto(promotion, uses[promotion.ID]))
+	}
+
+	logger.Log.Infof("[PromotionService][GetAllPromotions] %d promociones obtenidas", len(promotionDtos))
+	return promotionDtos, nil
+}
+
+func GetPromotionByID(id uint) (dtos.GetPromotionDto, error) {
+	logger.Log.Infof("[PromotionService][GetPromotionByID] Obteniendo promoción con ID: %d", id)
+
+	var promotion models.Promotion
+	if err := database.DB.Preload("Service", func(db *gorm.DB) *gorm.DB { return db.Unscoped() }).First(&promotion, id).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			logger.Log.Warnf("[PromotionService][GetPromotionByID] Promoción no encontrada: ID %d", id)
+			return dtos.GetPromotionDto{}, ErrPromotionNotFound
+		}
+		logger.Log.Error("[PromotionService][GetPromotionByID] Error al obtener promoción: ", err)
+		return dtos.GetPromotionDto{}, errors.New("error al obtener promoción")
+	}
+
+	uses, err := promotionUses(database.DB, []uint{promotion.ID})
+	if err != nil {
+		return dtos.GetPromotionDto{}, err
+	}
+	return toPromotionDto(promotion, uses[promotion.ID]), nil
+}
+
+// UpdatePromotion modifica la promoción. Los turnos ya finalizados conservan el descuento
+// con el que se cobraron.
+func UpdatePromotion(id uint, dto dtos.PromotionDto) error {
+	logger.Log.Infof("[PromotionService][UpdatePromotion] Actualizando promoción con ID: %d", id)
+
+	var promotion models.Promotion
+	if err := database.DB.First(&promotion, id).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			logger.Log.Warnf("[PromotionService][UpdatePromotion] Promoción no encontrada: ID %d", id)
+			return ErrPromotionNotFound
+		}
+		logger.Log.Error("[PromotionService][UpdatePromotion] Error al buscar promoción: ", err)
+		return errors.New("error al buscar promoción")
+	}
+
+	if err := applyPromotionDto(database.DB, &promotion, dto); err != nil {
+		logger.Log.Warn("[PromotionService][UpdatePromotion] Promoción inválida: ", err)
+		return err
+	}
+
+	if err := database.DB.Omit("Service").Save(&promotion).Error; err != nil {
+		logger.Log.Error("[PromotionService][UpdatePromotion] Error al actualizar promoción: ", err)
+		return errors.New("error al actualizar promoción")
+	}
+
+	logger.Log.Infof("[PromotionService][UpdatePromotion] Promoción actualizada: %s", promotion.Name)
+	return nil
+}
+
+func DeletePromotion(id uint) error {
+	logger.Log.Infof("[PromotionService][DeletePromotion] Eliminando promoción con ID: %d", id)
+
+	result := database.DB.Delete(&models.Promotion{}, id)
+	if result.Error != nil {
+		logger.Log.Error("[PromotionService][DeletePromotion] Error al eliminar promoción: ", result.Error)
+		return errors.New("error al eliminar promoción")
+	}
+	if result.RowsAffected == 0 {
+		logger.Log.Warnf("[PromotionService][DeletePromotion] Promoción no encontrada: ID %d", id)
+		return ErrPromotionNotFound
+	}
+
+	logger.Log.Infof("[PromotionService][DeletePromotion] Promoción eliminada: ID %d", id)
+	return nil
+}
+
+// applyPromotionDto vuelca en la promoción los campos enviados y valida el resultado. Los
+// campos vacíos conservan el valor actual.
+func applyPromotionDto(db *gorm.DB, promotion *models.Promotion, dto dtos.PromotionDto) error {
+	if name := strings.TrimSpace(dto.Name); name != "" {
+		promotion.Name = name
+	}
+	if dto.Description != "" {
+		promotion.Description = dto.Description
+	}
+	if code := normalizeCouponCode(dto.Code); code != "" {
+		if err := db.Unscoped().Where("code = ? AND id <> ?", code, promotion.ID).First(&models.Promotion{}).Error; err == nil {
+			return fmt.Errorf("%w: ya existe una promoción con ese código", ErrInvalidPromotion)
+		}
+		promotion.Code = &code
+	}
+	if dto.DiscountType != "" {
+		promotion.DiscountType = dto.DiscountType
+	}
+	if dto.Value > 0 {
+		promotion.Value = dto.Value
+	}
+	if dto.Scope != "" {
+		promotion.Scope = dto.Scope
+	}
+	if dto.ServiceID != nil {
+		promotion.ServiceID = dto.ServiceID
+	}
+	if dto.Category != "" {
+		promotion.Category = normalizeCategory(dto.Category)
+	}
+	if dto.StartsOn != "" {
+		startsOn, err := helpers.ParseCustomDay(dto.StartsOn)
+		if err != nil {
+			return fmt.Errorf("%w: %v", ErrInvalidPromotion, err)
+		}
+		promotion.StartsOn = &startsOn
+	}
+	if dto.EndsOn != "" {
+		endsOn, err := helpers.ParseCustomDay(dto.EndsOn)
+		if err != nil {
+			return fmt.Errorf("%w: %v", ErrInvalidPromotion, err)
+		}
+		promotion.EndsOn = &endsOn
+	}
+	if dto.MaxUses != nil {
+		promotion.MaxUses = optionalLimit(*dto.MaxUses)
+	}
+	if dto.MaxUsesPerClient != nil {
+		promotion.MaxUsesPerClient = optionalLimit(*dto.MaxUsesPerClient)
+	}
+	if dto.Active != nil {
+		promotion.Active = *dto.Active
+	}
+
+	return validatePromotion(db, promotion)
+}
+
+func validatePromotion(db *gorm.DB, promotion *models.Promotion) error {
+	if promotion.Name == "" {
+		return fmt.Errorf("%w: el nombre de la promoción es requerido", ErrInvalidPromotion)
+	}
+
+	switch promotion.DiscountType {
+	case models.DiscountTypePercent:
+		if promotion.Value <= 0 || promotion.Value > 100 {
+			return fmt.Errorf("%w: el porcentaje de descuento debe estar entre 0 y 100", ErrInvalidPromotion)
+		}
+	case models.DiscountTypeFixed:
+		if promotion.Value <= 0 {
+			return fmt.Errorf("%w: el monto de descuento debe ser mayor a 0", ErrInvalidPromotion)
+		}
+	default:
+		return fmt.Errorf("%w: tipo de descuento inválido, debe ser '%s' o '%s'", ErrInvalidPromotion, models.DiscountTypePercent, models.DiscountTypeFixed)
+	}
+
+	// Cada alcance usa solo su propio destino
+	switch promotion.Scope {
+	case models.PromotionScopeAppointment:
+		promotion.ServiceID = nil
+		promotion.Category = ""
+	case models.PromotionScopeService:
+		if promotion.ServiceID == nil {
+			return fmt.Errorf("%w: debe indicar el servicio de la promoción", ErrInvalidPromotion)
+		}
+		if err := db.Select("id").First(&models.Service{}, *promotion.ServiceID).Error; err != nil {
+			return fmt.Errorf("%w: el servicio de la promoción no existe", ErrInvalidPromotion)
+		}
+		promotion.Category = ""
+	case models.PromotionScopeCategory:
+		if promotion.Category == "" {
+			return fmt.Errorf("%w: debe indicar la categoría de la promoción", ErrInvalidPromotion)
+		}
+		promotion.ServiceID = nil
+	default:
+		return fmt.Errorf("%w: alcance inválido, debe ser '%s', '%s' o '%s'", ErrInvalidPromotion, models.PromotionScopeAppointment, models.PromotionScopeService, models.PromotionScopeCategory)
+	}
+
+	if promotion.StartsOn != nil && promotion.EndsOn != nil && promotion.EndsOn.Before(*promotion.StartsOn) {
+		return fmt.Errorf("%w: la fecha de fin no puede ser anterior a la de inicio", ErrInvalidPromotion)
+	}
+	return nil
+}
+
+// applyPromotions aplica al turno las promociones y cupones indicados al finalizar, en el
+// orden recibido. Cada línea admite una sola promoción y las de un combo no admiten
+// ninguna, porque ya tienen su descuento. El descuento se resta del precio de cada línea
+// y se registra aparte. Debe llamarse dentro de una transacción.
+func applyPromotions(tx *gorm.DB, appointment models.Appointment, finalizeDto dtos.FinalizeAppointmentDto) error {
+	if len(finalizeDto.PromotionIDs) == 0 && len(finalizeDto.CouponCodes) == 0 {
+		return nil
+	}
+
+	promotions, err := requestedPromotions(tx, finalizeDto)
+	if err != nil {
+		return err
+	}
+
+	var lines []models.AppointmentService
+	if err := tx.
+		Preload("Service", func(db *gorm.DB) *gorm.DB { return db.Unscoped() }).
+		Where("appointment_id = ?", appointment.ID).
+		Order("id").
+		Find(&lines).Error; err != nil {
+		logger.Log.Error("[PromotionService][applyPromotions] Error al obtener servicios del turno: ", err)
+		return errors.New("error al obtener servicios del turno")
+	}
+
+	now := time.Now()
+	for _, promotion := range promotions {
+		if err := checkPromotionAvailable(tx, promotion, appointment.ClientID, now); err != nil {
+			return err
+		}
+
+		var eligible []int
+		for i, line := range lines {
+			if line.BundleID == nil && line.PromotionID == nil && line.Price > 0 && promotionApplies(promotion, line) {
+				eligible = append(eligible, i)
+			}
+		}
+		if len(eligible) == 0 {
+			return fmt.Errorf("%w: '%s' no aplica a ningún servicio del turno", ErrInvalidPromotion, promotion.Name)
+		}
+
+		var applied int64
+		for i, discount := range promotionDiscounts(promotion, lines, eligible) {
+			line := &lines[eligible[i]]
+			if line.ListPrice == 0 {
+				line.ListPrice = line.Price
+			}
+			line.Price = float64(cents(line.Price)-discount) / 100
+			line.Discount = float64(discount) / 100
+			line.PromotionID = &promotion.ID
+			if err := tx.Model(line).Updates(map[string]interface{}{
+				"price":        line.Price,
+				"list_price":   line.ListPrice,
+				"discount":     line.Discount,
+				"promotion_id": promotion.ID,
+			}).Error; err != nil {
+				logger.Log.Error("[PromotionService][applyPromotions] Error al aplicar descuento: ", err)
+				return errors.New("error al aplicar descuento")
+			}
+			applied += discount
+		}
+
+		use := models.PromotionUse{
+			PromotionID:   promotion.ID,
+			AppointmentID: appointment.ID,
+			ClientID:      appointment.ClientID,
+			Amount:        float64(applied) / 100,
+		}
+		if err := tx.Create(&use).Error; err != nil {
+			logger.Log.Error("[PromotionService][applyPromotions] Error al registrar uso de promoción: ", err)
+			return errors.New("error al registrar uso de promoción")
+		}
+		logger.Log.Infof("[PromotionService][applyPromotions] Promoción '%s' aplicada al turno ID %d por %.2f", promotion.Name, appointment.ID, use.Amount)
+	}
+	return nil
+}
+
+// requestedPromotions busca las promociones pedidas, bloqueándolas hasta el fin de la
+// transacción para que dos cobros simultáneos no superen los límites de uso. Las que tienen
+// código solo se aceptan por cupón.
+func requestedPromotions(tx *gorm.DB, finalizeDto dtos.FinalizeAppointmentDto) ([]models.Promotion, error) {
+	var promotions []models.Promotion
+	seen := make(map[uint]bool)
+
+	for _, id := range finalizeDto.PromotionIDs {
+		var promotion models.Promotion
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).First(&promotion, id).Error; err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return nil, fmt.Errorf("%w: la promoción ID %d no existe", ErrInvalidPromotion, id)
+			}
+			logger.Log.Error("[PromotionService][requestedPromotions] Error al buscar promoción: ", err)
+			return nil, errors.New("error al buscar promoción")
+		}
+		if promotion.Code != nil {
+			return nil, fmt.Errorf("%w: '%s' requiere presentar el cupón", ErrInvalidPromotion, promotion.Name)
+		}
+		if seen[promotion.ID] {
+			return nil, fmt.Errorf("%w: '%s' se indicó más de una vez", ErrInvalidPromotion, promotion.Name)
+		}
+		seen[promotion.ID] = true
+		promotions = append(promotions, promotion)
+	}
+
+	for _, code := range finalizeDto.CouponCodes {
+		var promotion models.Promotion
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).Where("code = ?", normalizeCouponCode(code)).First(&promotion).Error; err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return nil, fmt.Errorf("%w: el cupón '%s' no existe", ErrInvalidPromotion, code)
+			}
+			logger.Log.Error("[PromotionService][requestedPromotions] Error al buscar cupón: ", err)
+			return nil, errors.New("error al buscar cupón")
+		}
+		if seen[promotion.ID] {
+			return nil, fmt.Errorf("%w: el cupón '%s' se indicó más de una vez", ErrInvalidPromotion, code)
+		}
+		seen[promotion.ID] = true
+		promotions = append(promotions, promotion)
+	}
+	return promotions, nil
+}
+
+// checkPromotionAvailable verifica que la promoción esté activa, vigente y sin agotar sus
+// usos totales ni los del cliente.
+func checkPromotionAvailable(tx *gorm.DB, promotion models.Promotion, clientID uint, now time.Time) error {
+	if !promotion.Active {
+		return fmt.Errorf("%w: '%s' no está activa", ErrInvalidPromotion, promotion.Name)
+	}
+	if promotion.StartsOn != nil && now.Before(*promotion.StartsOn) {
+		return fmt.Errorf("%w: '%s' rige desde el %s", ErrInvalidPromotion, promotion.Name, promotion.StartsOn.Format("02/01/2006"))
+	}
+	if promotion.EndsOn != nil && !now.Before(promotion.EndsOn.AddDate(0, 0, 1)) {
+		return fmt.Errorf("%w: '%s' venció el %s", ErrInvalidPromotion, promotion.Name, promotion.EndsOn.Format("02/01/2006"))
+	}
+
+	if promotion.MaxUses != nil {
+		var count int64
+		if err := tx.Model(&models.PromotionUse{}).Where("promotion_id = ?", promotion.ID).Count(&count).Error; err != nil {
+			logger.Log.Error("[PromotionService][checkPromotionAvailable] Error al contar usos: ", err)
+			return errors.New("error al verificar usos de la promoción")
+		}
+		if count >= int64(*promotion.MaxUses) {
+			return fmt.Errorf("%w: '%s' alcanzó su límite de usos", ErrInvalidPromotion, promotion.Name)
+		}
+	}
+	if promotion.MaxUsesPerClient != nil {
+		var count int64
+		if err := tx.Model(&models.PromotionUse{}).Where("promotion_id = ? AND client_id = ?", promotion.ID, clientID).Count(&count).Error; err != nil {
+			logger.Log.Error("[PromotionService][checkPromotionAvailable] Error al contar usos del cliente: ", err)
+			return errors.New("error al verificar usos de la promoción")
+		}
+		if count >= int64(*promotion.MaxUsesPerClient) {
+			return fmt.Errorf("%w: el cliente ya usó '%s' las veces permitidas", ErrInvalidPromotion, promotion.Name)
+		}
+	}
+	return nil
+}
+
+func promotionApplies(promotion models.Promotion, line models.AppointmentService) bool {
+	switch promotion.Scope {
+	case models.PromotionScopeService:
+		return promotion.ServiceID != nil && *promotion.ServiceID == line.ServiceID
+	case models.PromotionScopeCategory:
+		return promotion.Category == line.Service.Category
+	default:
+		return true
+	}
+}
+
+// promotionDiscounts calcula en centavos el descuento de cada línea elegible. El porcentaje
+// se aplica a cada línea; el monto fijo se descuenta de cada servicio alcanzado o, si la
+// promoción es para todo el turno, se reparte entre las líneas en proporción a su precio.
+// Ningún descuento supera el precio de su línea.
+func promotionDiscounts(promotion models.Promotion, lines []models.AppointmentService, eligible []int) []int64 {
+	discounts := make([]int64, len(eligible))
+	if promotion.DiscountType == models.DiscountTypePercent {
+		for i, line := range eligible {
+			discounts[i] = int64(math.Round(float64(cents(lines[line].Price)) * promotion.Value / 100))
+		}
+		return discounts
+	}
+
+	value := cents(promotion.Value)
+	if promotion.Scope != models.PromotionScopeAppointment {
+		for i, line := range eligible {
+			discounts[i] = min(value, cents(lines[line].Price))
+		}
+		return discounts
+	}
+
+	weights := make([]float64, len(eligible))
+	var total int64
+	for i, line := range eligible {
+		weights[i] = lines[line].Price
+		total += cents(lines[line].Price)
+	}
+	return distribute(min(value, total), weights)
+}
+
+// promotionUses cuenta las veces que se aplicó cada promoción.
+func promotionUses(db *gorm.DB, ids []uint) (map[uint]int64, error) {
+	uses := make(map[uint]int64)
+	if len(ids) == 0 {
+		return uses, nil
+	}
+	var rows []struct {
+		PromotionID uint
+		Count       int64
+	}
+	if err := db.Model(&models.PromotionUse{}).
+		Select("promotion_id, COUNT(*) AS count").
+		Where("promotion_id IN ?", ids).
+		Group("promotion_id").
+		Scan(&rows).Error; err != nil {
+		logger.Log.Error("[PromotionService][promotionUses] Error al contar usos: ", err)
+		return nil, errors.New("error al contar usos de promociones")
+	}
+	for _, row := range rows {
+		uses[row.PromotionID] = row.Count
+	}
+	return uses, nil
+}
+
+func normalizeCouponCode(code string) string {
+	return strings.ToUpper(strings.TrimSpace(code))
+}
+
+// optionalLimit convierte un límite de usos en opcional: 0 significa sin límite.
+func optionalLimit(limit uint) *uint {
+	if limit == 0 {
+		return nil
+	}
+	return &limit
+}
+
+func cents(amount float64) int64 {
+	return int64(math.Round(amount * 100))
+}
+
+func toPromotionDto(promotion models.Promotion, uses int64) dtos.GetPromotionDto {
+	promotionDto := dtos.GetPromotionDto{
+		ID:               promotion.ID,
+		Name:             promotion.Name,
+		Description:      promotion.Description,
+		DiscountType:     promotion.DiscountType,
+		Value:            promotion.Value,
+		Scope:            promotion.Scope,
+		ServiceID:        promotion.ServiceID,
+		Category:         promotion.Category,
+		StartsOn:         formatTimestamp(promotion.StartsOn, "02/01/2006"),
+		EndsOn:           formatTimestamp(promotion.EndsOn, "02/01/2006"),
+		MaxUses:          promotion.MaxUses,
+		MaxUsesPerClient: promotion.MaxUsesPerClient,
+		Uses:             uses,
+		Active:           promotion.Active,
+	}
+	if promotion.Code != nil {
+		promotionDto.Code = *promotion.Code
+	}
+	if promotion.Service != nil {
+		promotionDto.ServiceName = promotion.Service.Name
+	}
+	return promotionDto
+}
@@ -0,0 +1,104 @@
+package services
+
+import (
+	"peluqueria/internal/models"
+	"slices"
+	"testing"
+)
+
+func TestPromotionDiscounts(t *testing.T) {
+	lines := func(prices ...float64) []models.AppointmentService {
+		result := make([]models.AppointmentService, len(prices))
+		for i, price := range prices {
+			result[i] = models.AppointmentService{Price: price}
+		}
+		return result
+	}
+
+	tests := []struct {
+		name      string
+		promotion models.Promotion
+		lines     []models.AppointmentService
+		eligible  []int
+		want      []int64
+	}{
+		{
+			name:      "porcentaje por línea",
+			promotion: models.Promotion{DiscountType: models.DiscountTypePercent, Value: 10, Scope: models.PromotionScopeAppointment},
+			lines:     lines(1000, 333.33),
+			eligible:  []int{0, 1},
+			want:      []int64{10000, 3333},
+		},
+		{
+			name:      "porcentaje redondea al centavo",
+			promotion: models.Promotion{DiscountType: models.DiscountTypePercent, Value: 15, Scope: models.PromotionScopeService},
+			lines:     lines(99.99),
+			eligible:  []int{0},
+			want:      []int64{1500},
+		},
+		{
+			name:      "porcentaje solo en las líneas elegibles",
+			promotion: models.Promotion{DiscountType: models.DiscountTypePercent, Value: 50, Scope: models.PromotionScopeCategory},
+			lines:     lines(100, 200, 300),
+			eligible:  []int{2},
+			want:      []int64{15000},
+		},
+		{
+			name:      "monto fijo por servicio sin superar el precio",
+			promotion: models.Promotion{DiscountType: models.DiscountTypeFixed, Value: 500, Scope: models.PromotionScopeService},
+			lines:     lines(300, 800),
+			eligible:  []int{0, 1},
+			want:      []int64{30000, 50000},
+		},
+		{
+			name:      "monto fijo del turno repartido según el precio",
+			promotion: models.Promotion{DiscountType: models.DiscountTypeFixed, Value: 100, Scope: models.PromotionScopeAppointment},
+			lines:     lines(200, 100),
+			eligible:  []int{0, 1},
+			want:      []int64{6667, 3333},
+		},
+		{
+			name:      "monto fijo del turno en partes iguales sin perder centavos",
+			promotion: models.Promotion{DiscountType: models.DiscountTypeFixed, Value: 10, Scope: models.PromotionScopeAppointment},
+			lines:     lines(10, 10, 10),
+			eligible:  []int{0, 1, 2},
+			want:      []int64{333, 334, 333},
+		},
+		{
+			name:      "monto fijo del turno mayor al total",
+			promotion: models.Promotion{DiscountType: models.DiscountTypeFixed, Value: 500, Scope: models.PromotionScopeAppointment},
+			lines:     lines(100, 50),
+			eligible:  []int{0, 1},
+			want:      []int64{10000, 5000},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := promotionDiscounts(tt.promotion, tt.lines, tt.eligible)
+			if !slices.Equal(got, tt.want) {
+				t.Errorf("promotionDiscounts() = %v, se esperaba %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCents(t *testing.T) {
+	tests := []struct {
+		amount float64
+		want   int64
+	}{
+		{0, 0},
+		{0.1 + 0.2, 30},
+		{19.99, 1999},
+		{1.25, 125},
+		{1234.565, 123457},
+		{-10.25, -1025},
+	}
+
+	for _, tt := range tests {
+		if got := cents(tt.amount); got != tt.want {
+			t.Errorf("cents(%v) = %d, se esperaba %d", tt.amount, got, tt.want)
+		}
+	}
+}
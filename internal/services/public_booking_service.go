@@ -0,0 +1,425 @@
+package services
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"math/big"
+	"peluqueria/database"
+	"peluqueria/internal/dtos"
+	"peluqueria/internal/events"
+	"peluqueria/internal/helpers"
+	"peluqueria/internal/models"
+	"peluqueria/internal/notifications"
+	"peluqueria/logger"
+	"strconv"
+	"strings"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+var ErrInvalidBookingCode = errors.New("código de confirmación inválido o vencido")
+
+const (
+	bookingCodeKind        = "codigo_reserva"
+	bookingCodeDigits      = 6
+	maxBookingCodeAttempts = 5
+	defaultBookingCodeTTL  = 30 * time.Minute
+)
+
+// GetPublicStaff devuelve los estilistas activos que se pueden elegir desde la web.
+func GetPublicStaff() ([]dtos.PublicStaffDto, error) {
+	logger.Log.Info("[PublicBookingService][GetPublicStaff] Obteniendo estilistas")
+
+	var staff []models.Staff
+	if err := database.DB.Where("active = ?", true).Order("name, last_name").Find(&staff).Error; err != nil {
+		logger.Log.Error("[PublicBookingService][GetPublicStaff] Error al obtener estilistas: ", err)
+		return nil, errors.New("error al obtener estilistas")
+	}
+
+	staffDtos := []dtos.PublicStaffDto{}
+	for _, member := range staff {
+		staffDtos = append(staffDtos, dtos.PublicStaffDto{ID: member.ID, Name: member.Name, LastName: member.LastName})
+	}
+	return staffDtos, nil
+}
+
+// RequestPublicBooking registra una reserva hecha desde la web: crea el turno en estado
+// solicitado para un cliente nuevo con los datos recibidos y envía el código de
+// confirmación por los canales de notificación. Si el teléfono ya es de un cliente, el
+// turno pasa a ese cliente recién cuando se valida el código, y el código se envía a los
+// datos de contacto que el cliente ya tiene cargados.
+func RequestPublicBooking(dto dtos.PublicBookingDto) (dtos.PublicBookingResultDto, error) {
+	logger.Log.Infof("[PublicBookingService][RequestPublicBooking] Reserva online para el teléfono %s", dto.Phone)
+
+	if strings.TrimSpace(dto.Name) == "" || normalizePhone(dto.Phone) == "" {
+		logger.Log.Warn("[PublicBookingService][RequestPublicBooking] Nombre o teléfono faltante")
+		return dtos.PublicBookingResultDto{}, errors.New("nombre y teléfono son obligatorios")
+	}
+	if len(dto.ServiceIds) == 0 && len(dto.BundleIDs) == 0 && len(dto.VariantIDs) == 0 && len(dto.OptionIDs) == 0 {
+		logger.Log.Warn("[PublicBookingService][RequestPublicBooking] Servicios faltantes")
+		return dtos.PublicBookingResultDto{}, errors.New("debe indicar al menos un servicio o combo")
+	}
+
+	expireBookingRequests()
+
+	existing, err := findClientByPhone(dto.Phone)
+	if err != nil {
+		return dtos.PublicBookingResultDto{}, err
+	}
+	recipients := bookingCodeRecipients(dto.Phone, dto.Email, existing)
+	if len(recipients) == 0 {
+		logger.Log.Warn("[PublicBookingService][RequestPublicBooking] No hay a quién enviar el código de confirmación")
+		return dtos.PublicBookingResultDto{}, errors.New("no se puede enviar el código de confirmación, intente más tarde")
+	}
+
+	code, err := generateBookingCode()
+	if err != nil {
+		logger.Log.Error("[PublicBookingService][RequestPublicBooking] Error al generar código: ", err)
+		return dtos.PublicBookingResultDto{}, errors.New("error al generar código de confirmación")
+	}
+	confirmation := models.BookingConfirmation{
+		CodeHash:  hashBookingCode(code),
+		ExpiresAt: time.Now().Add(bookingCodeTTL()),
+	}
+	if existing != nil {
+		confirmation.ClientID = &existing.ID
+	}
+
+	loc, err := helpers.SalonLocation()
+	if err != nil {
+		loc = time.Local
+	}
+
+	// El cliente, el turno, el código y su envío se guardan juntos: si algo falla no queda
+	// un turno solicitado sin código ni un cliente suelto
+	var appointmentID, clientID uint
+	err = database.DB.Transaction(func(tx *gorm.DB) error {
+		client, err := createContactClient(tx, dto.Name, dto.LastName, dto.Phone, dto.Email)
+		if err != nil {
+			return err
+		}
+		clientID = client.ID
+
+		appointment, selection, err := newAppointment(tx, dtos.CreateAppointmentDto{
+			ClientID:        client.ID,
+			StaffID:         dto.StaffID,
+			AppointmentDate: dto.AppointmentDate,
+			ServiceIds:      dto.ServiceIds,
+			BundleIDs:       dto.BundleIDs,
+			VariantIDs:      dto.VariantIDs,
+			OptionIDs:       dto.OptionIDs,
+		}, models.AppointmentStatusRequested)
+		if err != nil {
+			return err
+		}
+		if err := createAppointmentTx(tx, &appointment, selection, 0); err != nil {
+			if isScheduleError(err) {
+				return err
+			}
+			logger.Log.Error("[PublicBookingService][RequestPublicBooking] Error al crear turno: ", err)
+			return errors.New("error al crear cita")
+		}
+		appointmentID = appointment.ID
+
+		confirmation.AppointmentID = appointmentID
+		if err := tx.Create(&confirmation).Error; err != nil {
+			logger.Log.Error("[PublicBookingService][RequestPublicBooking] Error al guardar código: ", err)
+			return errors.New("error al generar código de confirmación")
+		}
+		return queueBookingCode(tx, appointmentID, recipients, code, confirmation.ExpiresAt.In(loc))
+	})
+	if err != nil {
+		return dtos.PublicBookingResultDto{}, err
+	}
+
+	logger.Log.Infof("[PublicBookingService][RequestPublicBooking] Turno ID %d solicitado por cliente ID %d", appointmentID, clientID)
+	publishAppointmentEvent(events.AppointmentCreated, appointmentID)
+	return dtos.PublicBookingResultDto{
+		AppointmentID: appointmentID,
+		Status:        models.AppointmentStatusRequested,
+		ExpiresAt:     confirmation.ExpiresAt.In(loc).Format("02/01/2006 15:04"),
+	}, nil
+}
+
+// bookingCodeRecipients devuelve, por cada canal configurado, a quién enviar el código: el
+// email por correo y el teléfono por los demás canales. Si el teléfono es de un cliente
+// existente se usa el email que ese cliente tiene cargado y no el recibido.
+func bookingCodeRecipients(phone, email string, existing *models.Client) map[string]string {
+	email = strings.TrimSpace(email)
+	if existing != nil {
+		email = existing.Email
+	}
+
+	recipients := make(map[string]string)
+	for _, channel := range notificationChannels {
+		recipient := normalizePhone(phone)
+		if channel == notifications.ChannelEmail {
+			recipient = email
+		}
+		if recipient != "" {
+			recipients[channel] = recipient
+		}
+	}
+	return recipients
+}
+
+// queueBookingCode encola el código de la reserva en la bandeja de salida, una vez por
+// canal. El código nunca se devuelve en la respuesta de la reserva.
+func queueBookingCode(tx *gorm.DB, appointmentID uint, recipients map[string]string, code string, expiresAt time.Time) error {
+	var appointment models.Appointment
+	if err := tx.Select("id", "appointment_date").First(&appointment, appointmentID).Error; err != nil {
+		logger.Log.Error("[PublicBookingService][queueBookingCode] Error al buscar turno: ", err)
+		return errors.New("error al buscar turno")
+	}
+	date := appointment.AppointmentDate.In(expiresAt.Location())
+	body := fmt.Sprintf("Tu código para confirmar el turno del %s a las %s es %s. Vence a las %s.",
+		date.Format("02/01/2006"), date.Format("15:04"), code, expiresAt.Format("15:04"))
+
+	now := time.Now()
+	for channel, recipient := range recipients {
+		notification := models.Notification{
+			AppointmentID: &appointmentID,
+			Kind:          bookingCodeKind,
+			Channel:       channel,
+			Recipient:     recipient,
+			Subject:       "Código de confirmación de turno",
+			Body:          body,
+			Status:        models.NotificationStatusPending,
+			NextAttemptAt: now,
+		}
+		if err := tx.Create(&notification).Error; err != nil {
+			logger.Log.Error("[PublicBookingService][queueBookingCode] Error al encolar código: ", err)
+			return errors.New("error al enviar código de confirmación")
+		}
+	}
+	return nil
+}
+
+// ConfirmPublicBooking valida el código de una reserva online. El código se puede usar una
+// sola vez y tiene una cantidad limitada de intentos.
+func ConfirmPublicBooking(appointmentID uint, code string) error {
+	logger.Log.Infof("[PublicBookingService][ConfirmPublicBooking] Validando código del turno ID %d", appointmentID)
+
+	matched := false
+	err := database.DB.Transaction(func(tx *gorm.DB) error {
+		var confirmation models.BookingConfirmation
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).Where("appointment_id = ?", appointmentID).First(&confirmation).Error; err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return ErrInvalidBookingCode
+			}
+			return err
+		}
+
+		if !bookingCodeUsable(confirmation, time.Now()) {
+			return ErrInvalidBookingCode
+		}
+
+		if subtle.ConstantTimeCompare([]byte(hashBookingCode(strings.TrimSpace(code))), []byte(confirmation.CodeHash)) != 1 {
+			// El intento fallido se confirma igual para que cuente
+			return tx.Model(&confirmation).Update("attempts", confirmation.Attempts+1).Error
+		}
+
+		var appointment models.Appointment
+		if err := tx.Select("id", "status").First(&appointment, appointmentID).Error; err != nil {
+			return err
+		}
+		if appointment.Status != models.AppointmentStatusRequested {
+			return ErrInvalidBookingCode
+		}
+
+		now := time.Now()
+		confirmation.ConfirmedAt = &now
+		matched = true
+		if err := tx.Save(&confirmation).Error; err != nil {
+			return err
+		}
+		return assignBookingClient(tx, appointmentID, confirmation.ClientID)
+	})
+	if err != nil {
+		if errors.Is(err, ErrInvalidBookingCode) {
+			logger.Log.Warnf("[PublicBookingService][ConfirmPublicBooking] Código inválido o vencido para turno ID %d", appointmentID)
+			return err
+		}
+		logger.Log.Error("[PublicBookingService][ConfirmPublicBooking] Error al validar código: ", err)
+		return errors.New("error al validar código de confirmación")
+	}
+
+	if !matched {
+		logger.Log.Warnf("[PublicBookingService][ConfirmPublicBooking] Código incorrecto para turno ID %d", appointmentID)
+		return ErrInvalidBookingCode
+	}
+
+	logger.Log.Infof("[PublicBookingService][ConfirmPublicBooking] Código validado para turno ID %d", appointmentID)
+	publishAppointmentEvent(events.AppointmentUpdated, appointmentID)
+	return nil
+}
+
+// checkBookingValidated impide que el salón confirme una reserva online cuyo código el
+// cliente todavía no validó.
+func checkBookingValidated(tx *gorm.DB, appointmentID uint) error {
+	var confirmation models.BookingConfirmation
+	err := tx.Where("appointment_id = ?", appointmentID).First(&confirmation).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil
+	}
+	if err != nil {
+		logger.Log.Error("[PublicBookingService][checkBookingValidated] Error al buscar código: ", err)
+		return errors.New("error al buscar código de confirmación")
+	}
+	if confirmation.ConfirmedAt == nil {
+		return fmt.Errorf("%w: el cliente todavía no validó el código de la reserva", ErrInvalidStatusTransition)
+	}
+	return nil
+}
+
+// expireBookingRequests cancela las reservas online cuyo código venció sin validarse, para
+// liberar el horario. Corre en cada ciclo del worker de notificaciones y antes de calcular
+// disponibilidad o recibir una reserva online.
+func expireBookingRequests() {
+	var appointmentIDs []uint
+	if err := database.DB.Model(&models.BookingConfirmation{}).
+		Joins("JOIN appointments ON appointments.id = booking_confirmations.appointment_id").
+		Where("booking_confirmations.confirmed_at IS NULL AND booking_confirmations.expires_at < ?", time.Now()).
+		Where("appointments.status = ? AND appointments.deleted_at IS NULL", models.AppointmentStatusRequested).
+		Pluck("booking_confirmations.appointment_id", &appointmentIDs).Error; err != nil {
+		logger.Log.Error("[PublicBookingService][expireBookingRequests] Error al buscar reservas vencidas: ", err)
+		return
+	}
+
+	for _, id := range appointmentIDs {
+		err := database.DB.Transaction(func(tx *gorm.DB) error {
+			var appointment models.Appointment
+			if err := tx.First(&appointment, id).Error; err != nil {
+				return err
+			}
+			return changeAppointmentStatus(tx, &appointment, models.AppointmentStatusCancelled, "código de reserva no validado a tiempo", 0)
+		})
+		if err != nil {
+			logger.Log.Error("[PublicBookingService][expireBookingRequests] Error al cancelar reserva vencida ID ", id, ": ", err)
+			continue
+		}
+		logger.Log.Infof("[PublicBookingService][expireBookingRequests] Reserva online vencida cancelada: turno ID %d", id)
+		publishAppointmentEvent(events.AppointmentUpdated, id)
+	}
+}
+
+// assignBookingClient pasa el turno validado al cliente existente con el mismo teléfono y
+// descarta el cliente creado para la reserva. Si ese cliente ya no existe, el turno queda
+// con el cliente de la reserva.
+func assignBookingClient(tx *gorm.DB, appointmentID uint, clientID *uint) error {
+	if clientID == nil {
+		return nil
+	}
+	if err := tx.Select("id").First(&models.Client{}, *clientID).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil
+		}
+		return err
+	}
+
+	var appointment models.Appointment
+	if err := tx.Select("id", "client_id").First(&appointment, appointmentID).Error; err != nil {
+		return err
+	}
+	if err := tx.Model(&models.Appointment{}).Where("id = ?", appointmentID).Update("client_id", *clientID).Error; err != nil {
+		return err
+	}
+	if err := tx.Delete(&models.Client{}, appointment.ClientID).Error; err != nil {
+		return err
+	}
+	logger.Log.Infof("[PublicBookingService][assignBookingClient] Turno ID %d asignado al cliente ID %d", appointmentID, *clientID)
+	return nil
+}
+
+// findClientByPhone busca al cliente con el teléfono indicado. Devuelve nil si no hay.
+func findClientByPhone(phone string) (*models.Client, error) {
+	normalized := normalizePhone(phone)
+	if normalized == "" {
+		return nil, nil
+	}
+
+	var client models.Client
+	err := database.DB.Where("phone = ? OR phone = ?", normalized, strings.TrimSpace(phone)).First(&client).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		logger.Log.Error("[PublicBookingService][findClientByPhone] Error al buscar cliente: ", err)
+		return nil, errors.New("error al buscar cliente")
+	}
+	return &client, nil
+}
+
+// createContactClient crea un cliente con los datos de contacto recibidos. Las reservas
+// online siempre crean uno propio: desde la web nunca se modifica un cliente existente.
+func createContactClient(db *gorm.DB, name, lastName, phone, email string) (models.Client, error) {
+	client := models.Client{
+		Name:     strings.TrimSpace(name),
+		LastName: strings.TrimSpace(lastName),
+		Phone:    normalizePhone(phone),
+		Email:    strings.TrimSpace(email),
+	}
+	if err := db.Create(&client).Error; err != nil {
+		logger.Log.Error("[PublicBookingService][createContactClient] Error al crear cliente: ", err)
+		return models.Client{}, errors.New("error al crear cliente")
+	}
+	logger.Log.Infof("[PublicBookingService][createContactClient] Cliente creado: ID %d", client.ID)
+	return client, nil
+}
+
+// normalizePhone deja solo los dígitos del teléfono.
+func normalizePhone(phone string) string {
+	var digits strings.Builder
+	for _, r := range phone {
+		if r >= '0' && r <= '9' {
+			digits.WriteRune(r)
+		}
+	}
+	return digits.String()
+}
+
+func generateBookingCode() (string, error) {
+	limit := big.NewInt(1)
+	for i := 0; i < bookingCodeDigits; i++ {
+		limit.Mul(limit, big.NewInt(10))
+	}
+	n, err := rand.Int(rand.Reader, limit)
+	if err != nil {
+		return "", err
+	}
+	return fmt.Sprintf("%0*d", bookingCodeDigits, n.Int64()), nil
+}
+
+func hashBookingCode(code string) string {
+	sum := sha256.Sum256([]byte(code))
+	return hex.EncodeToString(sum[:])
+}
+
+func bookingCodeTTL() time.Duration {
+	minutes, err := strconv.Atoi(envOrDefault("BOOKING_CODE_MINUTES", ""))
+	if err != nil || minutes <= 0 {
+		return defaultBookingCodeTTL
+	}
+	return time.Duration(minutes) * time.Minute
+}
+
+// bookingCodeStillValid indica si el código de la reserva todavía se puede validar.
+func bookingCodeStillValid(appointmentID uint, now time.Time) bool {
+	var confirmation models.BookingConfirmation
+	if err := database.DB.Where("appointment_id = ?", appointmentID).First(&confirmation).Error; err != nil {
+		return false
+	}
+	return bookingCodeUsable(confirmation, now)
+}
+
+// bookingCodeUsable indica si el código no se validó todavía, no venció y le quedan
+// intentos.
+func bookingCodeUsable(confirmation models.BookingConfirmation, now time.Time) bool {
+	return confirmation.ConfirmedAt == nil && confirmation.Attempts < maxBookingCodeAttempts && now.Before(confirmation.ExpiresAt)
+}
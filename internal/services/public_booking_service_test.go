@@ -0,0 +1,75 @@
+package services
+
+import (
+	"peluqueria/internal/models"
+	"testing"
+	"time"
+)
+
+func TestBookingCodeUsable(t *testing.T) {
+	now := time.Date(2025, 1, 10, 15, 0, 0, 0, time.UTC)
+	confirmedAt := now.Add(-5 * time.Minute)
+
+	tests := []struct {
+		name         string
+		confirmation models.BookingConfirmation
+		want         bool
+	}{
+		{
+			name:         "vigente",
+			confirmation: models.BookingConfirmation{ExpiresAt: now.Add(10 * time.Minute)},
+			want:         true,
+		},
+		{
+			name:         "vigente con intentos fallidos",
+			confirmation: models.BookingConfirmation{ExpiresAt: now.Add(time.Minute), Attempts: maxBookingCodeAttempts - 1},
+			want:         true,
+		},
+		{
+			name:         "vencido",
+			confirmation: models.BookingConfirmation{ExpiresAt: now.Add(-time.Second)},
+		},
+		{
+			name:         "vence justo ahora",
+			confirmation: models.BookingConfirmation{ExpiresAt: now},
+		},
+		{
+			name:         "sin intentos",
+			confirmation: models.BookingConfirmation{ExpiresAt: now.Add(10 * time.Minute), Attempts: maxBookingCodeAttempts},
+		},
+		{
+			name:         "ya validado",
+			confirmation: models.BookingConfirmation{ExpiresAt: now.Add(10 * time.Minute), ConfirmedAt: &confirmedAt},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := bookingCodeUsable(tt.confirmation, now); got != tt.want {
+				t.Errorf("bookingCodeUsable() = %v, se esperaba %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestBookingCodeTTL(t *testing.T) {
+	tests := []struct {
+		value string
+		want  time.Duration
+	}{
+		{"", defaultBookingCodeTTL},
+		{"15", 15 * time.Minute},
+		{"0", defaultBookingCodeTTL},
+		{"-5", defaultBookingCodeTTL},
+		{"media hora", defaultBookingCodeTTL},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.value, func(t *testing.T) {
+			t.Setenv("BOOKING_CODE_MINUTES", tt.value)
+			if got := bookingCodeTTL(); got != tt.want {
+				t.Errorf("bookingCodeTTL() = %v, se esperaba %v", got, tt.want)
+			}
+		})
+	}
+}
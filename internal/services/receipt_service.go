@@ -0,0 +1,195 @@
+package services
+
+import (
+	"errors"
+	"fmt"
+	"peluqueria/database"
+	"peluqueria/internal/helpers"
+	"peluqueria/internal/models"
+	"peluqueria/internal/receipts"
+	"peluqueria/logger"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+var ErrAppointmentNotFound = errors.New("turno no encontrado")
+
+// Formatos del comprobante.
+const (
+	ReceiptFormatPDF  = "pdf"
+	ReceiptFormatText = "texto" // Para impresoras térmicas de 80mm
+)
+
+// GetAppointmentReceipt devuelve el comprobante del turno finalizado en el formato pedido,
+// junto con su número. Los turnos finalizados antes de que existieran los comprobantes
+// reciben su número la primera vez que se piden.
+func GetAppointmentReceipt(id uint, format string) ([]byte, string, error) {
+	logger.Log.Infof("[ReceiptService][GetAppointmentReceipt] Generando comprobante del turno ID %d en formato %s", id, format)
+
+	var appointment models.Appointment
+	if err := database.DB.
+		Preload("Client").
+		Preload("Staff").
+		Preload("AppointmentServices", func(db *gorm.DB) *gorm.DB { return db.Order("id") }).
+		Preload("AppointmentServices.Service", func(db *gorm.DB) *gorm.DB { return db.Unscoped() }).
+		Preload("AppointmentServices.Bundle", func(db *gorm.DB) *gorm.DB { return db.Unscoped() }).
+		Preload("AppointmentServices.Variant", func(db *gorm.DB) *gorm.DB { return db.Unscoped() }).
+		Preload("AppointmentServices.Options").
+		Preload("AppointmentServices.Promotion", func(db *gorm.DB) *gorm.DB { return db.Unscoped() }).
+		Preload("AppointmentProducts.Product", func(db *gorm.DB) *gorm.DB { return db.Unscoped() }).
+		First(&appointment, id).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			logger.Log.Warnf("[ReceiptService][GetAppointmentReceipt] Turno no encontrado: ID %d", id)
+			return nil, "", ErrAppointmentNotFound
+		}
+		logger.Log.Error("[ReceiptService][GetAppointmentReceipt] Error al buscar turno: ", err)
+		return nil, "", errors.New("error al buscar turno")
+	}
+	if appointment.Status != models.AppointmentStatusFinished {
+		logger.Log.Warnf("[ReceiptService][GetAppointmentReceipt] Turno ID %d en estado %s", id, appointment.Status)
+		return nil, "", fmt.Errorf("%w: solo los turnos finalizados tienen comprobante", ErrInvalidStatusTransition)
+	}
+
+	var receipt models.Receipt
+	err := database.DB.Transaction(func(tx *gorm.DB) error {
+		var err error
+		receipt, err = issueReceipt(tx, appointment.ID)
+		return err
+	})
+	if err != nil {
+		return nil, "", err
+	}
+
+	data, err := receiptData(appointment, receipt)
+	if err != nil {
+		return nil, "", err
+	}
+
+	number := receipts.FormatNumber(receipt.Number)
+	if format == ReceiptFormatText {
+		return []byte(receipts.Text(data)), number, nil
+	}
+	return receipts.PDF(data), number, nil
+}
+
+// issueReceipt devuelve el comprobante del turno, emitiéndolo con el próximo número si
+// todavía no tiene. La fila de la numeración queda bloqueada hasta el fin de la
+// transacción, así dos comprobantes nunca comparten número. Debe llamarse dentro de una
+// transacción.
+func issueReceipt(tx *gorm.DB, appointmentID uint) (models.Receipt, error) {
+	var receipt models.Receipt
+	err := tx.Where("appointment_id = ?", appointmentID).First(&receipt).Error
+	if err == nil {
+		return receipt, nil
+	}
+	if !errors.Is(err, gorm.ErrRecordNotFound) {
+		logger.Log.Error("[ReceiptService][issueReceipt] Error al buscar comprobante: ", err)
+		return models.Receipt{}, errors.New("error al buscar comprobante")
+	}
+
+	sequence := models.ReceiptSequence{ID: 1}
+	if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).FirstOrCreate(&sequence).Error; err != nil {
+		logger.Log.Error("[ReceiptService][issueReceipt] Error al obtener numeración: ", err)
+		return models.Receipt{}, errors.New("error al obtener numeración de comprobantes")
+	}
+	sequence.LastNumber++
+	if err := tx.Save(&sequence).Error; err != nil {
+		logger.Log.Error("[ReceiptService][issueReceipt] Error al actualizar numeración: ", err)
+		return models.Receipt{}, errors.New("error al actualizar numeración de comprobantes")
+	}
+
+	receipt = models.Receipt{
+		Number:        sequence.LastNumber,
+		AppointmentID: appointmentID,
+		IssuedAt:      time.Now(),
+	}
+	if err := tx.Create(&receipt).Error; err != nil {
+		logger.Log.Error("[ReceiptService][issueReceipt] Error al emitir comprobante: ", err)
+		return models.Receipt{}, errors.New("error al emitir comprobante")
+	}
+
+	logger.Log.Infof("[ReceiptService][issueReceipt] Comprobante %s emitido para turno ID %d", receipts.FormatNumber(receipt.Number), appointmentID)
+	return receipt, nil
+}
+
+// receiptData arma el contenido del comprobante a partir del turno con sus líneas,
+// productos, pagos y propinas.
+func receiptData(appointment models.Appointment, receipt models.Receipt) (receipts.Receipt, error) {
+	loc, err := helpers.SalonLocation()
+	if err != nil {
+		return receipts.Receipt{}, err
+	}
+
+	data := receipts.Receipt{
+		Number:     receipt.Number,
+		IssuedAt:   receipt.IssuedAt.In(loc),
+		Salon:      receipts.SalonFromEnv(),
+		ClientName: fmt.Sprintf("%s %s", appointment.Client.Name, appointment.Client.LastName),
+		StaffName:  staffFullName(appointment.Staff),
+	}
+
+	var subtotal, discount, total float64
+	for _, line := range appointment.AppointmentServices {
+		item := receipts.Item{
+			Description: line.Service.Name,
+			Amount:      roundCents(line.Price + line.Discount),
+			Discount:    line.Discount,
+		}
+		if line.Variant != nil {
+			item.Details = append(item.Details, line.Variant.Name)
+		}
+		if line.Bundle != nil {
+			item.Details = append(item.Details, "Combo "+line.Bundle.Name)
+		}
+		for _, option := range line.Options {
+			item.Details = append(item.Details, "+ "+option.Name)
+		}
+		if line.Promotion != nil {
+			item.DiscountLabel = line.Promotion.Name
+		}
+		data.Items = append(data.Items, item)
+
+		subtotal += item.Amount
+		discount += line.Discount
+		total += line.Price
+	}
+	data.Subtotal = roundCents(subtotal)
+	data.Discount = roundCents(discount)
+	data.Total = roundCents(total)
+
+	for _, appProduct := range appointment.AppointmentProducts {
+		data.Products = append(data.Products, receipts.Product{
+			Description: appProduct.Product.Name,
+			Quantity:    appProduct.Quantity,
+			Unit:        appProduct.Product.Unit,
+		})
+	}
+
+	payments, err := appointmentPayments(database.DB, []uint{appointment.ID})
+	if err != nil {
+		return receipts.Receipt{}, err
+	}
+	for _, payment := range payments[appointment.ID] {
+		data.Payments = append(data.Payments, receipts.Payment{
+			Method:    payment.MethodName,
+			Reference: payment.Reference,
+			Amount:    payment.Amount,
+		})
+	}
+
+	tips, err := appointmentTips(database.DB, []uint{appointment.ID})
+	if err != nil {
+		return receipts.Receipt{}, err
+	}
+	for _, tip := range tips[appointment.ID] {
+		data.Tips = append(data.Tips, receipts.Tip{
+			StaffName: tip.StaffName,
+			Method:    tip.MethodName,
+			Amount:    tip.Amount,
+		})
+	}
+
+	return data, nil
+}
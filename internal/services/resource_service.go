@@ -0,0 +1,399 @@
+package services
+
+import (
+	"errors"
+	"fmt"
+	"peluqueria/database"
+	"peluqueria/internal/dtos"
+	"peluqueria/internal/models"
+	"peluqueria/logger"
+	"slices"
+	"strings"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+var (
+	// ErrResourceUnavailable indica que no queda ningún recurso libre del tipo que necesita el turno.
+	ErrResourceUnavailable = errors.New("no hay recursos disponibles para el turno")
+	ErrResourceNotFound    = errors.New("recurso no encontrado")
+	ErrInvalidResource     = errors.New("recurso inválido")
+)
+
+// resourceNeed es un tramo absoluto en el que el turno necesita un recurso del tipo indicado.
+type resourceNeed struct {
+	Type  string
+	Start time.Time
+	End   time.Time
+}
+
+func CreateResource(resourceDto dtos.ResourceDto) error {
+	logger.Log.Infof("[ResourceService][CreateResource] Intentando crear recurso: %s", resourceDto.Name)
+
+	resourceType := normalizeResourceType(resourceDto.Type)
+	if resourceDto.Name == "" || resourceType == "" {
+		logger.Log.Warn("[ResourceService][CreateResource] Nombre o tipo faltante")
+		return fmt.Errorf("%w: el nombre y el tipo del recurso son obligatorios", ErrInvalidResource)
+	}
+
+	resource := models.Resource{
+		Name:   resourceDto.Name,
+		Type:   resourceType,
+		Active: true,
+	}
+	if resourceDto.Active != nil {
+		resource.Active = *resourceDto.Active
+	}
+
+	if err := database.DB.Create(&resource).Error; err != nil {
+		logger.Log.Error("[ResourceService][CreateResource] Error al crear recurso: ", err)
+		return errors.New("error al crear recurso")
+	}
+
+	logger.Log.Infof("[ResourceService][CreateResource] Recurso creado con éxito: ID %d", resource.ID)
+	return nil
+}
+
+func GetAllResources(resourceType string) ([]dtos.GetResourceDto, error) {
+	logger.Log.Info("[ResourceService][GetAllResources] Obteniendo recursos")
+
+	query := database.DB.Order("type, name")
+	if resourceType != "" {
+		query = query.Where("type = ?", normalizeResourceType(resourceType))
+	}
+
+	var resources []models.Resource
+	if err := query.Find(&resources).Error; err != nil {
+		logger.Log.Error("[ResourceService][GetAllResources] Error al obtener recursos: ", err)
+		return nil, errors.New("error al obtener recursos")
+	}
+
+	resourceDtos := []dtos.GetResourceDto{}
+	for _, resource := range resources {
+		resourceDtos = append(resourceDtos, toResourceDto(resource))
+	}
+
+	logger.Log.Infof("[ResourceService][GetAllResources] Recursos obtenidos: %d", len(resourceDtos))
+	return resourceDtos, nil
+}
+
+func GetResourceByID(id uint) (dtos.GetResourceDto, error) {
+	logger.Log.Infof("[ResourceService][GetResourceByID] Obteniendo recurso con ID: %d", id)
+
+	var resource models.Resource
+	if err := database.DB.First(&resource, id).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			logger.Log.Warnf("[ResourceService][GetResourceByID] Recurso no encontrado: ID %d", id)
+			return dtos.GetResourceDto{}, ErrResourceNotFound
+		}
+		logger.Log.Error("[ResourceService][GetResourceByID] Error al obtener recurso: ", err)
+		return dtos.GetResourceDto{}, errors.New("error al obtener recurso")
+	}
+
+	return toResourceDto(resource), nil
+}
+
+func UpdateResource(id uint, resourceDto dtos.ResourceDto) error {
+	logger.Log.Infof("[ResourceService][UpdateResource] Actualizando recurso con ID: %d", id)
+
+	var resource models.Resource
+	if err := database.DB.First(&resource, id).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			logger.Log.Warnf("[ResourceService][UpdateResource] Recurso no encontrado: ID %d", id)
+			return ErrResourceNotFound
+		}
+		logger.Log.Error("[ResourceService][UpdateResource] Error al buscar recurso: ", err)
+		return errors.New("error al buscar recurso")
+	}
+
+	if resourceDto.Name != "" {
+		resource.Name = resourceDto.Name
+	}
+	newType := normalizeResourceType(resourceDto.Type)
+	typeChanged := newType != "" && newType != resource.Type
+	deactivated := resourceDto.Active != nil && !*resourceDto.Active && resource.Active
+
+	// Un recurso con turnos por delante no puede dejar de cubrir su tipo
+	if typeChanged || deactivated {
+		if err := checkResourceUnused(id); err != nil {
+			return err
+		}
+	}
+	if newType != "" {
+		resource.Type = newType
+	}
+	if resourceDto.Active != nil {
+		resource.Active = *resourceDto.Active
+	}
+
+	if err := database.DB.Save(&resource).Error; err != nil {
+		logger.Log.Error("[ResourceService][UpdateResource] Error al actualizar recurso: ", err)
+		return errors.New("error al actualizar recurso")
+	}
+
+	logger.Log.Infof("[ResourceService][UpdateResource] Recurso actualizado con éxito: ID %d", id)
+	return nil
+}
+
+func DeleteResource(id uint) error {
+	logger.Log.Infof("[ResourceService][DeleteResource] Eliminando recurso con ID: %d", id)
+
+	if err := checkResourceUnused(id); err != nil {
+		return err
+	}
+
+	result := database.DB.Delete(&models.Resource{}, id)
+	if result.Error != nil {
+		logger.Log.Error("[ResourceService][DeleteResource] Error al eliminar recurso: ", result.Error)
+		return errors.New("error al eliminar recurso")
+	}
+	if result.RowsAffected == 0 {
+		logger.Log.Warnf("[ResourceService][DeleteResource] Recurso no encontrado: ID %d", id)
+		return ErrResourceNotFound
+	}
+
+	logger.Log.Infof("[ResourceService][DeleteResource] Recurso eliminado con éxito: ID %d", id)
+	return nil
+}
+
+// checkResourceUnused verifica que el recurso no esté asignado a turnos activos futuros.
+func checkResourceUnused(id uint) error {
+	var count int64
+	if err := activeResourceAllocations(database.DB).
+		Where("appointment_resources.resource_id = ? AND appointment_resources.end_time > ?", id, time.Now()).
+		Count(&count).Error; err != nil {
+		logger.Log.Error("[ResourceService][checkResourceUnused] Error al verificar turnos del recurso: ", err)
+		return errors.New("error al verificar turnos del recurso")
+	}
+	if count > 0 {
+		logger.Log.Warnf("[ResourceService][checkResourceUnused] El recurso ID %d tiene %d turnos asignados", id, count)
+		return fmt.Errorf("%w: el recurso tiene turnos asignados, reprográmelos antes de modificarlo", ErrInvalidResource)
+	}
+	return nil
+}
+
+// buildServiceResources valida los recursos declarados por un servicio. Cada tramo debe caer
+// dentro de la duración total del servicio y su tipo debe existir entre los recursos activos.
+func buildServiceResources(resourceDtos []dtos.ServiceResourceDto, service models.Service) ([]models.ServiceResource, error) {
+	_, total := servicePlan([]models.Service{service})
+	totalMinutes := uint(total / time.Minute)
+
+	var requirements []models.ServiceResource
+	for _, resourceDto := range resourceDtos {
+		resourceType := normalizeResourceType(resourceDto.ResourceType)
+		if resourceType == "" || resourceDto.DurationMinutes == 0 {
+			return nil, errors.New("cada recurso del servicio debe indicar tipo y duración")
+		}
+		if resourceDto.OffsetMinutes+resourceDto.DurationMinutes > totalMinutes {
+			return nil, fmt.Errorf("el recurso '%s' excede la duración del servicio (%d minutos)", resourceType, totalMinutes)
+		}
+
+		var count int64
+		if err := database.DB.Model(&models.Resource{}).Where("type = ? AND active = ?", resourceType, true).Count(&count).Error; err != nil {
+			logger.Log.Error("[ResourceService][buildServiceResources] Error al buscar recursos: ", err)
+			return nil, errors.New("error al buscar recursos")
+		}
+		if count == 0 {
+			return nil, fmt.Errorf("no hay recursos activos del tipo '%s'", resourceType)
+		}
+
+		requirements = append(requirements, models.ServiceResource{
+			ServiceID:       service.ID,
+			ResourceType:    resourceType,
+			OffsetMinutes:   resourceDto.OffsetMinutes,
+			DurationMinutes: resourceDto.DurationMinutes,
+		})
+	}
+	return requirements, nil
+}
+
+// resourceNeeds encadena los servicios igual que servicePlan y ubica en el tiempo los
+// recursos que declara cada uno.
+func resourceNeeds(start time.Time, services []models.Service) []resourceNeed {
+	var needs []resourceNeed
+	var offset time.Duration
+	for _, service := range services {
+		for _, requirement := range service.Resources {
+			needStart := start.Add(offset + time.Duration(requirement.OffsetMinutes)*time.Minute)
+			needs = append(needs, resourceNeed{
+				Type:  requirement.ResourceType,
+				Start: needStart,
+				End:   needStart.Add(time.Duration(requirement.DurationMinutes) * time.Minute),
+			})
+		}
+		_, duration := servicePlan([]models.Service{service})
+		offset += duration
+	}
+	return needs
+}
+
+// shiftNeeds ubica en start las necesidades calculadas desde un inicio cero.
+func shiftNeeds(needs []resourceNeed, start time.Time) []resourceNeed {
+	shifted := make([]resourceNeed, len(needs))
+	for i, need := range needs {
+		shifted[i] = resourceNeed{
+			Type:  need.Type,
+			Start: start.Add(need.Start.Sub(time.Time{})),
+			End:   start.Add(need.End.Sub(time.Time{})),
+		}
+	}
+	return shifted
+}
+
+// activeResourceAllocations filtra las asignaciones de turnos vigentes; las de turnos
+// cancelados, ausentes o eliminados no ocupan el recurso.
+func activeResourceAllocations(db *gorm.DB) *gorm.DB {
+	return db.Model(&models.AppointmentResource{}).
+		Joins("JOIN appointments ON appointments.id = appointment_resources.appointment_id").
+		Where("appointments.deleted_at IS NULL").
+		Where("appointments.status NOT IN ?", inactiveAppointmentStatuses)
+}
+
+// loadResourcePool devuelve los recursos activos de los tipos pedidos agrupados por tipo y
+// los tramos en que ya están ocupados entre from y to. Con lock, bloquea las filas de los
+// recursos para serializar las reservas que compiten por ellos.
+func loadResourcePool(tx *gorm.DB, types []string, from, to time.Time, excludeAppointmentID uint, lock bool) (map[string][]uint, map[uint][]timeRange, error) {
+	query := tx.Where("type IN ? AND active = ?", types, true).Order("id")
+	if lock {
+		query = query.Clauses(clause.Locking{Strength: "UPDATE"})
+	}
+	var resources []models.Resource
+	if err := query.Find(&resources).Error; err != nil {
+		logger.Log.Error("[ResourceService][loadResourcePool] Error al buscar recursos: ", err)
+		return nil, nil, errors.New("error al buscar recursos")
+	}
+
+	byType := make(map[string][]uint)
+	var ids []uint
+	for _, resource := range resources {
+		byType[resource.Type] = append(byType[resource.Type], resource.ID)
+		ids = append(ids, resource.ID)
+	}
+
+	busy := make(map[uint][]timeRange)
+	if len(ids) == 0 {
+		return byType, busy, nil
+	}
+
+	var allocations []models.AppointmentResource
+	if err := activeResourceAllocations(tx).
+		Where("appointment_resources.resource_id IN ?", ids).
+		Where("appointment_resources.appointment_id <> ?", excludeAppointmentID).
+		Where("appointment_resources.start_time < ? AND appointment_resources.end_time > ?", to, from).
+		Select("appointment_resources.*").
+		Find(&allocations).Error; err != nil {
+		logger.Log.Error("[ResourceService][loadResourcePool] Error al buscar asignaciones: ", err)
+		return nil, nil, errors.New("error al buscar recursos ocupados")
+	}
+	for _, allocation := range allocations {
+		busy[allocation.ResourceID] = append(busy[allocation.ResourceID], timeRange{Start: allocation.StartTime, End: allocation.EndTime})
+	}
+	return byType, busy, nil
+}
+
+// assignResources elige para cada necesidad el primer recurso libre de su tipo. Devuelve los
+// recursos elegidos, o el índice de la primera necesidad que no pudo cubrirse.
+func assignResources(needs []resourceNeed, byType map[string][]uint, busy map[uint][]timeRange) ([]uint, int) {
+	taken := make(map[uint][]timeRange)
+	chosen := make([]uint, 0, len(needs))
+	for i, need := range needs {
+		found := false
+		for _, id := range byType[need.Type] {
+			if isFree(busy[id], need.Start, need.End) && isFree(taken[id], need.Start, need.End) {
+				taken[id] = append(taken[id], timeRange{Start: need.Start, End: need.End})
+				chosen = append(chosen, id)
+				found = true
+				break
+			}
+		}
+		if !found {
+			return nil, i
+		}
+	}
+	return chosen, -1
+}
+
+// allocateResources reemplaza los recursos asignados al turno según sus servicios. Debe
+// llamarse dentro de la transacción que guarda el turno, con los servicios y sus recursos
+// precargados.
+func allocateResources(tx *gorm.DB, appointment models.Appointment) error {
+	if err := tx.Where("appointment_id = ?", appointment.ID).Delete(&models.AppointmentResource{}).Error; err != nil {
+		logger.Log.Error("[ResourceService][allocateResources] Error al liberar recursos del turno: ", err)
+		return errors.New("error al liberar recursos del turno")
+	}
+
+	needs := resourceNeeds(appointment.AppointmentDate, appointmentServices(appointment))
+	if len(needs) == 0 {
+		return nil
+	}
+
+	var types []string
+	from, to := needs[0].Start, needs[0].End
+	for _, need := range needs {
+		if !slices.Contains(types, need.Type) {
+			types = append(types, need.Type)
+		}
+		if need.Start.Before(from) {
+			from = need.Start
+		}
+		if need.End.After(to) {
+			to = need.End
+		}
+	}
+
+	byType, busy, err := loadResourcePool(tx, types, from, to, appointment.ID, true)
+	if err != nil {
+		return err
+	}
+
+	chosen, failed := assignResources(needs, byType, busy)
+	if failed >= 0 {
+		need := needs[failed]
+		logger.Log.Warnf("[ResourceService][allocateResources] Sin recursos '%s' libres para el turno ID %d a las %s", need.Type, appointment.ID, need.Start.Format("15:04"))
+		return fmt.Errorf("%w: no queda %s libre a las %s", ErrResourceUnavailable, need.Type, need.Start.Format("15:04"))
+	}
+
+	allocations := make([]models.AppointmentResource, 0, len(needs))
+	for i, need := range needs {
+		allocations = append(allocations, models.AppointmentResource{
+			AppointmentID: appointment.ID,
+			ResourceID:    chosen[i],
+			StartTime:     need.Start,
+			EndTime:       need.End,
+		})
+	}
+	if err := tx.Create(&allocations).Error; err != nil {
+		logger.Log.Error("[ResourceService][allocateResources] Error al asignar recursos: ", err)
+		return errors.New("error al asignar recursos al turno")
+	}
+	return nil
+}
+
+func normalizeResourceType(resourceType string) string {
+	return strings.ToLower(strings.TrimSpace(resourceType))
+}
+
+func toResourceDto(resource models.Resource) dtos.GetResourceDto {
+	return dtos.GetResourceDto{
+		ID:     resource.ID,
+		Name:   resource.Name,
+		Type:   resource.Type,
+		Active: resource.Active,
+	}
+}
+
+func toAppointmentResourceDtos(allocations []models.AppointmentResource) []dtos.AppointmentResourceDto {
+	resourceDtos := []dtos.AppointmentResourceDto{}
+	for _, allocation := range allocations {
+		resourceDtos = append(resourceDtos, dtos.AppointmentResourceDto{
+			ResourceID:   allocation.ResourceID,
+			ResourceName: allocation.Resource.Name,
+			ResourceType: allocation.Resource.Type,
+			Start:        allocation.StartTime.Format("15:04"),
+			End:          allocation.EndTime.Format("15:04"),
+		})
+	}
+	return resourceDtos
+}
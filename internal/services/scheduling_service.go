@@ -0,0 +1,470 @@
+package services
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"peluqueria/database"
+	"peluqueria/internal/dtos"
+	"peluqueria/internal/helpers"
+	"peluqueria/internal/models"
+	"peluqueria/logger"
+	"slices"
+	"strconv"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// Ventana hacia atrás usada para buscar turnos que podrían solaparse con uno nuevo.
+const conflictLookback = 24 * time.Hour
+
+// Granularidad por defecto de la agenda, en minutos.
+const defaultSlotMinutes = 15
+
+// ErrInvalidAvailabilityRequest indica que la consulta de disponibilidad no es válida.
+var ErrInvalidAvailabilityRequest = errors.New("consulta de disponibilidad inválida")
+
+// Estados de turno que no ocupan la agenda.
+var inactiveAppointmentStatuses = []string{models.AppointmentStatusCancelled, models.AppointmentStatusNoShow}
+
+// AppointmentConflictError indica que un turno se superpone con otro ya agendado.
+type AppointmentConflictError struct {
+	AppointmentID uint
+}
+
+func (e *AppointmentConflictError) Error() string {
+	return fmt.Sprintf("el turno se superpone con el turno ID %d", e.AppointmentID)
+}
+
+// busyBlock es un tramo, relativo al inicio del turno, en el que el estilista está ocupado.
+type busyBlock struct {
+	Offset time.Duration
+	Length time.Duration
+}
+
+// servicePlan encadena los servicios en orden: tiempo activo, espera y limpieza. Devuelve
+// los tramos en que el estilista está ocupado (durante la espera queda libre para otro
+// cliente) y la duración total.
+func servicePlan(services []models.Service) ([]busyBlock, time.Duration) {
+	var blocks []busyBlock
+	var offset time.Duration
+	for _, service := range services {
+		active := time.Duration(service.EstimatedTimeMinutes) * time.Minute
+		blocks = appendBusyBlock(blocks, offset, active)
+		offset += active + time.Duration(service.ProcessingTimeMinutes)*time.Minute
+
+		buffer := time.Duration(service.BufferTimeMinutes) * time.Minute
+		blocks = appendBusyBlock(blocks, offset, buffer)
+		offset += buffer
+	}
+	return blocks, offset
+}
+
+// appendBusyBlock agrega un tramo, uniéndolo al anterior si son contiguos.
+func appendBusyBlock(blocks []busyBlock, offset, length time.Duration) []busyBlock {
+	if length <= 0 {
+		return blocks
+	}
+	if n := len(blocks); n > 0 && blocks[n-1].Offset+blocks[n-1].Length == offset {
+		blocks[n-1].Length += length
+		return blocks
+	}
+	return append(blocks, busyBlock{Offset: offset, Length: length})
+}
+
+// appointmentPlan devuelve los tramos ocupados y la duración del turno. Con duración manual
+// el estilista queda ocupado todo el turno.
+func appointmentPlan(appointment models.Appointment) ([]busyBlock, time.Duration) {
+	if appointment.DurationOverride != nil && *appointment.DurationOverride > 0 {
+		duration := time.Duration(*appointment.DurationOverride) * time.Minute
+		return []busyBlock{{Offset: 0, Length: duration}}, duration
+	}
+
+	return servicePlan(appointmentServices(appointment))
+}
+
+// appointmentServices devuelve los servicios del turno con el tiempo activo registrado en
+// cada línea, que puede ser propio del estilista.
+func appointmentServices(appointment models.Appointment) []models.Service {
+	var services []models.Service
+	for _, appService := range appointment.AppointmentServices {
+		service := appService.Service
+		if appService.ActiveMinutes != nil {
+			service.EstimatedTimeMinutes = *appService.ActiveMinutes
+		}
+		services = append(services, service)
+	}
+	return services
+}
+
+// appointmentDuration es el tiempo total del turno, incluyendo esperas y limpieza.
+func appointmentDuration(appointment models.Appointment) time.Duration {
+	_, duration := appointmentPlan(appointment)
+	return duration
+}
+
+// appointmentBusyRanges devuelve los tramos absolutos en que el turno ocupa al estilista.
+// Un turno sin duración ocupa igual su horario de inicio.
+func appointmentBusyRanges(appointment models.Appointment) []timeRange {
+	blocks, _ := appointmentPlan(appointment)
+	return blockRanges(appointment.AppointmentDate, blocks)
+}
+
+func blockRanges(start time.Time, blocks []busyBlock) []timeRange {
+	if len(blocks) == 0 {
+		return []timeRange{{Start: start, End: start}}
+	}
+	ranges := make([]timeRange, 0, len(blocks))
+	for _, block := range blocks {
+		ranges = append(ranges, timeRange{Start: start.Add(block.Offset), End: start.Add(block.Offset + block.Length)})
+	}
+	return ranges
+}
+
+// blocksFree indica si todos los tramos ocupados de un turno que empieza en start están libres.
+func blocksFree(busy []timeRange, start time.Time, blocks []busyBlock) bool {
+	for _, r := range blockRanges(start, blocks) {
+		if !isFree(busy, r.Start, r.End) {
+			return false
+		}
+	}
+	return true
+}
+
+// overlaps indica si los intervalos [startA, endA) y [startB, endB) se superponen.
+func overlaps(startA, endA, startB, endB time.Time) bool {
+	if startA.Equal(startB) {
+		return true
+	}
+	return startA.Before(endB) && startB.Before(endA)
+}
+
+// lockStaffAgenda bloquea la fila del estilista dentro de la transacción para serializar
+// las reservas concurrentes sobre su agenda.
+func lockStaffAgenda(tx *gorm.DB, staffID uint) error {
+	if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).Select("id").First(&models.Staff{}, staffID).Error; err != nil {
+		logger.Log.Error("[SchedulingService][lockStaffAgenda] Error al bloquear agenda del estilista: ", err)
+		return errors.New("error al bloquear la agenda del estilista")
+	}
+	return nil
+}
+
+// validateAppointmentSchedule verifica que el turno caiga dentro del horario de atención y
+// del horario de trabajo del estilista, que no se superponga con otro turno activo suyo, y
+// le asigna los recursos que necesitan sus servicios. Debe llamarse dentro de la
+// transacción que guarda el turno.
+func validateAppointmentSchedule(tx *gorm.DB, appointmentID uint) error {
+	var appointment models.Appointment
+	if err := tx.Preload("AppointmentServices.Service.Resources").First(&appointment, appointmentID).Error; err != nil {
+		logger.Log.Error("[SchedulingService][validateAppointmentSchedule] Error al buscar turno: ", err)
+		return errors.New("error al verificar la agenda del turno")
+	}
+
+	if isInactiveStatus(appointment.Status) {
+		return nil
+	}
+
+	start := appointment.AppointmentDate
+	end := start.Add(appointmentDuration(appointment))
+
+	if err := checkBusinessHours(tx, start, end); err != nil {
+		return err
+	}
+
+	if appointment.StaffID != nil {
+		if err := checkStaffWorking(tx, *appointment.StaffID, start, end); err != nil {
+			return err
+		}
+		if err := checkStaffOverlap(tx, appointment, start, end); err != nil {
+			return err
+		}
+	}
+
+	return allocateResources(tx, appointment)
+}
+
+// checkStaffOverlap verifica que el turno no choque con otro turno activo de su estilista.
+func checkStaffOverlap(tx *gorm.DB, appointment models.Appointment, start, end time.Time) error {
+	var candidates []models.Appointment
+	if err := tx.Preload("AppointmentServices.Service").
+		Where("staff_id = ? AND id <> ?", *appointment.StaffID, appointment.ID).
+		Where("status NOT IN ?", inactiveAppointmentStatuses).
+		Where("appointment_date >= ? AND appointment_date <= ?", start.Add(-conflictLookback), end).
+		Find(&candidates).Error; err != nil {
+		logger.Log.Error("[SchedulingService][checkStaffOverlap] Error al buscar turnos del estilista: ", err)
+		return errors.New("error al verificar superposición de turnos")
+	}
+
+	// Solo chocan los tramos en que el estilista trabaja; las esperas quedan libres
+	ownRanges := appointmentBusyRanges(appointment)
+	for _, other := range candidates {
+		otherRanges := appointmentBusyRanges(other)
+		for _, own := range ownRanges {
+			if !isFree(otherRanges, own.Start, own.End) {
+				logger.Log.Warnf("[SchedulingService][checkStaffOverlap] Turno ID %d se superpone con turno ID %d", appointment.ID, other.ID)
+				return &AppointmentConflictError{AppointmentID: other.ID}
+			}
+		}
+	}
+	return nil
+}
+
+// timeRange representa un tramo horario, ocupado o de atención según el contexto.
+type timeRange struct {
+	Start time.Time
+	End   time.Time
+}
+
+// staffBusyIntervals devuelve los tramos ocupados por turnos activos de cada estilista
+// entre from y to.
+func staffBusyIntervals(db *gorm.DB, staffIDs []uint, from, to time.Time) (map[uint][]timeRange, error) {
+	var appointments []models.Appointment
+	if err := db.Preload("AppointmentServices.Service").
+		Where("staff_id IN ?", staffIDs).
+		Where("status NOT IN ?", inactiveAppointmentStatuses).
+		Where("appointment_date >= ? AND appointment_date <= ?", from.Add(-conflictLookback), to).
+		Find(&appointments).Error; err != nil {
+		logger.Log.Error("[SchedulingService][staffBusyIntervals] Error al obtener turnos: ", err)
+		return nil, errors.New("error al obtener turnos de la agenda")
+	}
+
+	busy := make(map[uint][]timeRange)
+	for _, appointment := range appointments {
+		busy[*appointment.StaffID] = append(busy[*appointment.StaffID], appointmentBusyRanges(appointment)...)
+	}
+	return busy, nil
+}
+
+// staffPlan es la ocupación que tendría un turno con un estilista determinado, relativa a
+// su inicio.
+type staffPlan struct {
+	blocks   []busyBlock
+	duration time.Duration
+	needs    []resourceNeed
+}
+
+// GetAvailability devuelve los horarios libres de un día para los servicios, combos,
+// variantes y adicionales solicitados. Si staffID es 0 se consideran todos los estilistas
+// activos.
+func GetAvailability(day string, request dtos.ServiceSelectionDto, staffID uint) (dtos.AvailabilityDto, error) {
+	logger.Log.Infof("[SchedulingService][GetAvailability] Buscando disponibilidad para el día %s", day)
+
+	date, err := helpers.ParseCustomDay(day)
+	if err != nil {
+		logger.Log.Warn("[SchedulingService][GetAvailability] Error al parsear fecha: ", err)
+		return dtos.AvailabilityDto{}, fmt.Errorf("%w: %v", ErrInvalidAvailabilityRequest, err)
+	}
+
+	if isEmptySelection(request) {
+		logger.Log.Warn("[SchedulingService][GetAvailability] Servicios faltantes")
+		return dtos.AvailabilityDto{}, fmt.Errorf("%w: debe indicar al menos un servicio o combo", ErrInvalidAvailabilityRequest)
+	}
+
+	// Las reservas online vencidas no deben ocupar horarios
+	expireBookingRequests()
+
+	selection, err := loadServiceSelection(database.DB, request)
+	if err != nil {
+		return dtos.AvailabilityDto{}, err
+	}
+	ordered := selection.Services
+	_, baseDuration := servicePlan(ordered)
+	minutes := uint(baseDuration / time.Minute)
+
+	// Estilistas candidatos
+	var staff []models.Staff
+	query := database.DB.Where("active = ?", true)
+	if staffID != 0 {
+		query = query.Where("id = ?", staffID)
+	}
+	if err := query.Find(&staff).Error; err != nil {
+		logger.Log.Error("[SchedulingService][GetAvailability] Error al buscar empleados: ", err)
+		return dtos.AvailabilityDto{}, errors.New("error al buscar empleados")
+	}
+	if len(staff) == 0 {
+		logger.Log.Warn("[SchedulingService][GetAvailability] No hay estilistas activos para la búsqueda")
+		return dtos.AvailabilityDto{}, fmt.Errorf("%w: no hay estilistas activos para la búsqueda", ErrInvalidAvailabilityRequest)
+	}
+
+	// Cada estilista tiene su propio tiempo por servicio; quedan afuera los que no los realizan
+	plans := make(map[uint]staffPlan)
+	var staffIDs []uint
+	var resourceTypes []string
+	longest := baseDuration
+	for _, member := range staff {
+		terms, err := selection.terms(database.DB, &member.ID)
+		if err != nil {
+			if errors.Is(err, ErrServiceNotOffered) && staffID == 0 {
+				continue
+			}
+			return dtos.AvailabilityDto{}, err
+		}
+		adjusted := withStaffTimes(ordered, terms)
+		blocks, duration := servicePlan(adjusted)
+		plan := staffPlan{blocks: blocks, duration: duration, needs: resourceNeeds(time.Time{}, adjusted)}
+		for _, need := range plan.needs {
+			if !slices.Contains(resourceTypes, need.Type) {
+				resourceTypes = append(resourceTypes, need.Type)
+			}
+		}
+		if duration > longest {
+			longest = duration
+		}
+		plans[member.ID] = plan
+		staffIDs = append(staffIDs, member.ID)
+	}
+	if len(staffIDs) == 0 {
+		logger.Log.Warn("[SchedulingService][GetAvailability] Ningún estilista realiza los servicios solicitados")
+		return dtos.AvailabilityDto{}, fmt.Errorf("%w: ningún estilista realiza los servicios solicitados", ErrInvalidAvailabilityRequest)
+	}
+
+	slot, err := slotDuration()
+	if err != nil {
+		logger.Log.Error("[SchedulingService][GetAvailability] Configuración de agenda inválida: ", err)
+		return dtos.AvailabilityDto{}, err
+	}
+
+	availability := dtos.AvailabilityDto{
+		Date:                 date.Format("02/01/2006"),
+		EstimatedTimeMinutes: minutes,
+		SlotMinutes:          uint(slot / time.Minute),
+		Slots:                []dtos.AvailableSlotDto{},
+	}
+
+	ranges, closure, err := openingRanges(database.DB, date)
+	if err != nil {
+		return dtos.AvailabilityDto{}, err
+	}
+	if closure != nil {
+		logger.Log.Infof("[SchedulingService][GetAvailability] Salón cerrado el día %s", day)
+		availability.Closed = true
+		availability.ClosedReason = closure.Reason
+		return availability, nil
+	}
+	if len(ranges) == 0 {
+		availability.Closed = true
+		return availability, nil
+	}
+
+	busy, err := staffBusyIntervals(database.DB, staffIDs, ranges[0].Start, ranges[len(ranges)-1].End)
+	if err != nil {
+		return dtos.AvailabilityDto{}, err
+	}
+
+	// Franjas de trabajo de cada estilista; sin horario cargado trabajan todo el día
+	working := make(map[uint][]timeRange)
+	restricted := make(map[uint]bool)
+	for _, id := range staffIDs {
+		if working[id], restricted[id], err = staffWorkingRanges(database.DB, id, date); err != nil {
+			return dtos.AvailabilityDto{}, err
+		}
+	}
+
+	// Recursos del salón que necesitan los servicios
+	var resourcesByType map[string][]uint
+	var resourceBusy map[uint][]timeRange
+	if len(resourceTypes) > 0 {
+		resourcesByType, resourceBusy, err = loadResourcePool(database.DB, resourceTypes, ranges[0].Start, ranges[len(ranges)-1].End.Add(longest), 0, false)
+		if err != nil {
+			return dtos.AvailabilityDto{}, err
+		}
+	}
+
+	now := time.Now()
+	for _, openRange := range ranges {
+		for start := openRange.Start; start.Before(openRange.End); start = start.Add(slot) {
+			if start.Before(now) {
+				continue
+			}
+			var free []uint
+			for _, id := range staffIDs {
+				plan := plans[id]
+				end := start.Add(plan.duration)
+				if end.After(openRange.End) {
+					continue
+				}
+				if restricted[id] && !withinRanges(working[id], start, end) {
+					continue
+				}
+				if !blocksFree(busy[id], start, plan.blocks) {
+					continue
+				}
+				if len(plan.needs) > 0 {
+					if _, failed := assignResources(shiftNeeds(plan.needs, start), resourcesByType, resourceBusy); failed >= 0 {
+						continue
+					}
+				}
+				free = append(free, id)
+			}
+			if len(free) > 0 {
+				availability.Slots = append(availability.Slots, dtos.AvailableSlotDto{
+					Time:     start.Format("15:04"),
+					StaffIDs: free,
+				})
+			}
+		}
+	}
+
+	logger.Log.Infof("[SchedulingService][GetAvailability] %d horarios libres para el día %s", len(availability.Slots), day)
+	return availability, nil
+}
+
+func isFree(intervals []timeRange, start, end time.Time) bool {
+	for _, interval := range intervals {
+		if overlaps(start, end, interval.Start, interval.End) {
+			return false
+		}
+	}
+	return true
+}
+
+// slotDuration lee la granularidad de la agenda desde la variable de entorno SLOT_MINUTES.
+func slotDuration() (time.Duration, error) {
+	slot, err := strconv.Atoi(envOrDefault("SLOT_MINUTES", strconv.Itoa(defaultSlotMinutes)))
+	if err != nil || slot <= 0 {
+		return 0, errors.New("SLOT_MINUTES debe ser un número positivo")
+	}
+	return time.Duration(slot) * time.Minute, nil
+}
+
+// parseClock convierte una hora HH:MM en el tiempo transcurrido desde la medianoche.
+func parseClock(value string) (time.Duration, error) {
+	parsed, err := time.Parse("15:04", value)
+	if err != nil {
+		return 0, fmt.Errorf("hora inválida '%s', debe ser HH:MM", value)
+	}
+	return time.Duration(parsed.Hour())*time.Hour + time.Duration(parsed.Minute())*time.Minute, nil
+}
+
+func envOrDefault(key, fallback string) string {
+	if value := os.Getenv(key); value != "" {
+		return value
+	}
+	return fallback
+}
+
+func uniqueIDs(ids []uint) []uint {
+	seen := make(map[uint]bool)
+	var unique []uint
+	for _, id := range ids {
+		if !seen[id] {
+			seen[id] = true
+			unique = append(unique, id)
+		}
+	}
+	return unique
+}
+
+// isScheduleError indica si el error proviene de una validación de agenda que debe
+// informarse tal cual al usuario.
+func isScheduleError(err error) bool {
+	var conflictErr *AppointmentConflictError
+	return errors.As(err, &conflictErr) || errors.Is(err, ErrSalonClosed) || errors.Is(err, ErrOutsideBusinessHours) ||
+		errors.Is(err, ErrStaffNotWorking) || errors.Is(err, ErrResourceUnavailable)
+}
+
+func isInactiveStatus(status string) bool {
+	return slices.Contains(inactiveAppointmentStatuses, status)
+}
@@ -0,0 +1,382 @@
+package services
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"peluqueria/database"
+	"peluqueria/internal/dtos"
+	"peluqueria/internal/models"
+	"peluqueria/logger"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+func CreateServiceBundle(dto dtos.ServiceBundleDto) error {
+	logger.Log.Infof("[ServiceBundleService][CreateServiceBundle] Intentando crear combo: %s", dto.Name)
+
+	if dto.Name == "" {
+		logger.Log.Warn("[ServiceBundleService][CreateServiceBundle] Nombre requerido")
+		return errors.New("el nombre del combo es requerido")
+	}
+	if err := database.DB.Where("name = ?", dto.Name).First(&models.ServiceBundle{}).Error; err == nil {
+		logger.Log.Warn("[ServiceBundleService][CreateServiceBundle] Combo existente")
+		return errors.New("el combo ya existe")
+	}
+
+	bundle := models.ServiceBundle{
+		Name:            dto.Name,
+		Description:     dto.Description,
+		Price:           dto.Price,
+		DurationMinutes: dto.DurationMinutes,
+		Active:          true,
+	}
+	if dto.Active != nil {
+		bundle.Active = *dto.Active
+	}
+
+	items, err := buildBundleItems(database.DB, dto.ServiceIDs)
+	if err != nil {
+		logger.Log.Warn("[ServiceBundleService][CreateServiceBundle] Servicios inválidos: ", err)
+		return err
+	}
+	if err := validateBundle(database.DB, &bundle, items); err != nil {
+		logger.Log.Warn("[ServiceBundleService][CreateServiceBundle] Combo inválido: ", err)
+		return err
+	}
+
+	err = database.DB.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(&bundle).Error; err != nil {
+			return err
+		}
+		for i := range items {
+			items[i].BundleID = bundle.ID
+		}
+		return tx.Omit("Service").Create(&items).Error
+	})
+	if err != nil {
+		logger.Log.Error("[ServiceBundleService][CreateServiceBundle] Error al crear combo: ", err)
+		return errors.New("error al crear combo")
+	}
+
+	logger.Log.Infof("[ServiceBundleService][CreateServiceBundle] Combo creado: %s", bundle.Name)
+	return nil
+}
+
+func GetAllServiceBundles(onlyActive bool) ([]dtos.GetServiceBundleDto, error) {
+	logger.Log.Info("[ServiceBundleService][GetAllServiceBundles] Obteniendo lista de combos")
+
+	query := preloadBundleItems(database.DB).Order("name")
+	if onlyActive {
+		query = query.Where("active = ?", true)
+	}
+	var bundles []models.ServiceBundle
+	if err := query.Find(&bundles).Error; err != nil {
+		logger.Log.Error("[ServiceBundleService][GetAllServiceBundles] Error al obtener combos: ", err)
+		return nil, errors.New("error al obtener combos")
+	}
+
+	bundleDtos := []dtos.GetServiceBundleDto{}
+	for _, bundle := range bundles {
+		bundleDtos = append(bundleDtos, toServiceBundleDto(bundle))
+	}
+
+	logger.Log.Infof("[ServiceBundleService][GetAllServiceBundles] %d combos obtenidos", len(bundleDtos))
+	return bundleDtos, nil
+}
+
+func GetServiceBundleByID(id uint) (dtos.GetServiceBundleDto, error) {
+	logger.Log.Infof("[ServiceBundleService][GetServiceBundleByID] Obteniendo combo con ID: %d", id)
+
+	var bundle models.ServiceBundle
+	if err := preloadBundleItems(database.DB).First(&bundle, id).Error; err != nil {
+		logger.Log.Error("[ServiceBundleService][GetServiceBundleByID] Error al obtener combo: ", err)
+		return dtos.GetServiceBundleDto{}, errors.New("error al obtener combo")
+	}
+
+	return toServiceBundleDto(bundle), nil
+}
+
+// UpdateServiceBundle modifica el combo. Los turnos ya agendados conservan los precios y
+// tiempos con los que se reservaron.
+func UpdateServiceBundle(id uint, dto dtos.ServiceBundleDto) error {
+	logger.Log.Infof("[ServiceBundleService][UpdateServiceBundle] Actualizando combo con ID: %d", id)
+
+	var bundle models.ServiceBundle
+	if err := preloadBundleItems(database.DB).First(&bundle, id).Error; err != nil {
+		logger.Log.Warn("[ServiceBundleService][UpdateServiceBundle] Combo no encontrado")
+		return errors.New("el combo no existe")
+	}
+
+	if dto.Name != "" && dto.Name != bundle.Name {
+		if err := database.DB.Where("name = ? AND id <> ?", dto.Name, id).First(&models.ServiceBundle{}).Error; err == nil {
+			logger.Log.Warn("[ServiceBundleService][UpdateServiceBundle] Nombre en uso")
+			return errors.New("ya existe un combo con ese nombre")
+		}
+		bundle.Name = dto.Name
+	}
+	if dto.Description != "" {
+		bundle.Description = dto.Description
+	}
+	if dto.Price > 0 {
+		bundle.Price = dto.Price
+	}
+	if dto.DurationMinutes > 0 {
+		bundle.DurationMinutes = dto.DurationMinutes
+	}
+	if dto.Active != nil {
+		bundle.Active = *dto.Active
+	}
+
+	items := bundle.Items
+	if dto.ServiceIDs != nil {
+		var err error
+		if items, err = buildBundleItems(database.DB, dto.ServiceIDs); err != nil {
+			logger.Log.Warn("[ServiceBundleService][UpdateServiceBundle] Servicios inválidos: ", err)
+			return err
+		}
+	}
+	if err := validateBundle(database.DB, &bundle, items); err != nil {
+		logger.Log.Warn("[ServiceBundleService][UpdateServiceBundle] Combo inválido: ", err)
+		return err
+	}
+
+	err := database.DB.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Omit("Items").Save(&bundle).Error; err != nil {
+			return err
+		}
+		if dto.ServiceIDs == nil {
+			return nil
+		}
+		if err := tx.Where("bundle_id = ?", bundle.ID).Delete(&models.ServiceBundleItem{}).Error; err != nil {
+			return err
+		}
+		for i := range items {
+			items[i].BundleID = bundle.ID
+		}
+		return tx.Omit("Service").Create(&items).Error
+	})
+	if err != nil {
+		logger.Log.Error("[ServiceBundleService][UpdateServiceBundle] Error al actualizar combo: ", err)
+		return errors.New("error al actualizar combo")
+	}
+
+	logger.Log.Infof("[ServiceBundleService][UpdateServiceBundle] Combo actualizado: %s", bundle.Name)
+	return nil
+}
+
+// DeleteServiceBundle da de baja el combo. Los turnos que lo usaron lo siguen mostrando.
+func DeleteServiceBundle(id uint) error {
+	logger.Log.Infof("[ServiceBundleService][DeleteServiceBundle] Eliminando combo con ID: %d", id)
+
+	result := database.DB.Delete(&models.ServiceBundle{}, id)
+	if result.Error != nil {
+		logger.Log.Error("[ServiceBundleService][DeleteServiceBundle] Error al eliminar combo: ", result.Error)
+		return errors.New("error al eliminar combo")
+	}
+	if result.RowsAffected == 0 {
+		logger.Log.Warnf("[ServiceBundleService][DeleteServiceBundle] Combo no encontrado: ID %d", id)
+		return errors.New("el combo no existe")
+	}
+
+	logger.Log.Infof("[ServiceBundleService][DeleteServiceBundle] Combo eliminado con éxito: ID %d", id)
+	return nil
+}
+
+func preloadBundleItems(db *gorm.DB) *gorm.DB {
+	return db.Preload("Items", func(db *gorm.DB) *gorm.DB { return db.Order("position, id") }).
+		Preload("Items.Service.Resources")
+}
+
+// buildBundleItems arma los servicios del combo en el orden recibido.
+func buildBundleItems(db *gorm.DB, serviceIDs []uint) ([]models.ServiceBundleItem, error) {
+	if len(serviceIDs) < 2 {
+		return nil, errors.New("el combo debe tener al menos dos servicios")
+	}
+	if len(uniqueIDs(serviceIDs)) != len(serviceIDs) {
+		return nil, errors.New("el combo no puede repetir servicios")
+	}
+
+	var services []models.Service
+	if err := db.Where("id IN ?", serviceIDs).Find(&services).Error; err != nil {
+		logger.Log.Error("[ServiceBundleService][buildBundleItems] Error al buscar servicios: ", err)
+		return nil, errors.New("error al buscar servicios")
+	}
+	servicesByID := make(map[uint]models.Service)
+	for _, service := range services {
+		servicesByID[service.ID] = service
+	}
+
+	items := make([]models.ServiceBundleItem, len(serviceIDs))
+	for i, serviceID := range serviceIDs {
+		service, ok := servicesByID[serviceID]
+		if !ok {
+			return nil, fmt.Errorf("el servicio ID %d no existe", serviceID)
+		}
+		items[i] = models.ServiceBundleItem{ServiceID: serviceID, Service: service, Position: uint(i)}
+	}
+	return items, nil
+}
+
+// validateBundle verifica que el combo sea un descuento sobre sus servicios con cualquier
+// estilista y que su duración alcance para las esperas y limpiezas. Sin duración se usa la
+// de los servicios.
+func validateBundle(db *gorm.DB, bundle *models.ServiceBundle, items []models.ServiceBundleItem) error {
+	var fixedMinutes uint
+	services := make([]models.Service, len(items))
+	for i, item := range items {
+		fixedMinutes += item.Service.ProcessingTimeMinutes + item.Service.BufferTimeMinutes
+		services[i] = item.Service
+	}
+
+	if bundle.Price <= 0 {
+		return errors.New("el precio del combo debe ser mayor a 0")
+	}
+	listPrice, err := bundleListPrice(db, services)
+	if err != nil {
+		return err
+	}
+	if bundle.Price > listPrice {
+		return fmt.Errorf("el precio del combo no puede superar la suma de sus servicios (%.2f)", listPrice)
+	}
+
+	if bundle.DurationMinutes == 0 {
+		_, total := servicePlan(services)
+		bundle.DurationMinutes = uint(total / time.Minute)
+	}
+	if minimum := fixedMinutes + uint(len(items)); bundle.DurationMinutes < minimum {
+		return fmt.Errorf("la duración del combo debe ser de al menos %d minutos", minimum)
+	}
+	return nil
+}
+
+// bundleListPrice devuelve lo que cuestan por separado los servicios del combo con el
+// estilista activo más económico de los que los realizan todos, con sus precios propios.
+// Sin estilistas que los realicen se usa el precio de cada servicio.
+func bundleListPrice(db *gorm.DB, services []models.Service) (float64, error) {
+	var staffIDs []uint
+	if err := db.Model(&models.Staff{}).Where("active = ?", true).Pluck("id", &staffIDs).Error; err != nil {
+		logger.Log.Error("[ServiceBundleService][bundleListPrice] Error al obtener estilistas: ", err)
+		return 0, errors.New("error al obtener estilistas")
+	}
+
+	listPrice := -1.0
+	for _, staffID := range staffIDs {
+		terms, err := staffServiceTerms(db, &staffID, services)
+		if errors.Is(err, ErrServiceNotOffered) {
+			continue
+		}
+		if err != nil {
+			return 0, err
+		}
+		var total float64
+		for _, term := range terms {
+			total += term.Price
+		}
+		if listPrice < 0 || total < listPrice {
+			listPrice = total
+		}
+	}
+
+	if listPrice < 0 {
+		listPrice = 0
+		for _, service := range services {
+			listPrice += service.Price
+		}
+	}
+	return roundCents(listPrice), nil
+}
+
+// applyBundleTerms reparte el precio y la duración del combo entre sus líneas.
+func applyBundleTerms(bundle *models.ServiceBundle, services []models.Service, terms []serviceTerms, lines []int) {
+	priceWeights := make([]float64, len(lines))
+	minuteWeights := make([]float64, len(lines))
+	var baseActive, fixedMinutes uint
+	for j, i := range lines {
+		priceWeights[j] = terms[i].ListPrice
+		minuteWeights[j] = float64(services[i].EstimatedTimeMinutes)
+		if terms[i].ActiveMinutes != nil {
+			minuteWeights[j] = float64(*terms[i].ActiveMinutes)
+		}
+		baseActive += services[i].EstimatedTimeMinutes
+		fixedMinutes += services[i].ProcessingTimeMinutes + services[i].BufferTimeMinutes
+	}
+
+	cents := distribute(int64(math.Round(bundle.Price*100)), priceWeights)
+	for j, i := range lines {
+		terms[i].Price = float64(cents[j]) / 100
+		bundleID := bundle.ID
+		terms[i].BundleID = &bundleID
+	}
+
+	// El tiempo activo del combo se reparte respetando el ritmo propio del estilista
+	if baseActive == 0 || bundle.DurationMinutes <= fixedMinutes {
+		return
+	}
+	var staffActive float64
+	for _, weight := range minuteWeights {
+		staffActive += weight
+	}
+	target := staffActive * float64(bundle.DurationMinutes-fixedMinutes) / float64(baseActive)
+	minutes := distribute(int64(math.Round(target)), minuteWeights)
+	for j, i := range lines {
+		active := uint(max(minutes[j], 1))
+		terms[i].ActiveMinutes = &active
+	}
+}
+
+// distribute reparte un total entero en proporción a los pesos. El redondeo se hace sobre
+// los acumulados para que las partes sumen exactamente el total. Sin pesos se reparte en
+// partes iguales.
+func distribute(total int64, weights []float64) []int64 {
+	var sum float64
+	for _, weight := range weights {
+		sum += weight
+	}
+	if sum <= 0 {
+		weights = make([]float64, len(weights))
+		for i := range weights {
+			weights[i] = 1
+		}
+		sum = float64(len(weights))
+	}
+
+	parts := make([]int64, len(weights))
+	var cumulative float64
+	var previous int64
+	for i, weight := range weights {
+		cumulative += weight
+		next := int64(math.Round(float64(total) * cumulative / sum))
+		if i == len(weights)-1 {
+			next = total
+		}
+		parts[i] = next - previous
+		previous = next
+	}
+	return parts
+}
+
+func toServiceBundleDto(bundle models.ServiceBundle) dtos.GetServiceBundleDto {
+	bundleDto := dtos.GetServiceBundleDto{
+		ID:              bundle.ID,
+		Name:            bundle.Name,
+		Description:     bundle.Description,
+		Price:           bundle.Price,
+		DurationMinutes: bundle.DurationMinutes,
+		Active:          bundle.Active,
+		Services:        []dtos.ServiceBundleItemDto{},
+	}
+	for _, item := range bundle.Items {
+		_, total := servicePlan([]models.Service{item.Service})
+		bundleDto.ListPrice += item.Service.Price
+		bundleDto.Services = append(bundleDto.Services, dtos.ServiceBundleItemDto{
+			ServiceID:   item.ServiceID,
+			ServiceName: item.Service.Name,
+			Price:       item.Service.Price,
+			TotalTime:   uint(total / time.Minute),
+		})
+	}
+	bundleDto.Savings = math.Round((bundleDto.ListPrice-bundle.Price)*100) / 100
+	return bundleDto
+}
@@ -0,0 +1,304 @@
+package services
+
+import (
+	"errors"
+	"fmt"
+	"peluqueria/internal/dtos"
+	"peluqueria/internal/models"
+	"peluqueria/logger"
+
+	"gorm.io/gorm"
+)
+
+// ErrInvalidSelection indica que los servicios, combos, variantes o adicionales pedidos no
+// existen o no se pueden combinar.
+var ErrInvalidSelection = errors.New("servicios inválidos")
+
+// serviceSelection son las líneas de servicio de un turno: el servicio, el combo del que
+// sale, la variante y los adicionales elegidos. Todas las listas tienen el mismo largo.
+type serviceSelection struct {
+	Services []models.Service
+	Bundles  []*models.ServiceBundle  // nil si el servicio se pidió suelto
+	Variants []*models.ServiceVariant // nil si no se eligió variante
+	Options  [][]models.ServiceOption
+}
+
+// appointmentSelection extrae los servicios pedidos de los datos del turno.
+func appointmentSelection(appointmentDto dtos.CreateAppointmentDto) dtos.ServiceSelectionDto {
+	return dtos.ServiceSelectionDto{
+		ServiceIDs: appointmentDto.ServiceIds,
+		BundleIDs:  appointmentDto.BundleIDs,
+		VariantIDs: appointmentDto.VariantIDs,
+		OptionIDs:  appointmentDto.OptionIDs,
+	}
+}
+
+// isEmptySelection indica si no se pidió ningún servicio.
+func isEmptySelection(request dtos.ServiceSelectionDto) bool {
+	return len(request.ServiceIDs) == 0 && len(request.BundleIDs) == 0 &&
+		len(request.VariantIDs) == 0 && len(request.OptionIDs) == 0
+}
+
+// loadServiceSelection arma las líneas pedidas: primero los servicios sueltos en el orden
+// recibido y después los servicios de cada combo. Cada variante y cada adicional se aplican
+// a la primera línea de su servicio que los admita, prefiriendo las sueltas; si no hay
+// ninguna, agregan una línea para su servicio.
+func loadServiceSelection(db *gorm.DB, request dtos.ServiceSelectionDto) (serviceSelection, error) {
+	var selection serviceSelection
+
+	if len(request.ServiceIDs) > 0 {
+		servicesByID, err := findServices(db, request.ServiceIDs)
+		if err != nil {
+			return serviceSelection{}, err
+		}
+		for _, id := range request.ServiceIDs {
+			selection.add(servicesByID[id], nil)
+		}
+	}
+
+	if len(request.BundleIDs) > 0 {
+		if len(uniqueIDs(request.BundleIDs)) != len(request.BundleIDs) {
+			return serviceSelection{}, fmt.Errorf("%w: no se puede reservar dos veces el mismo combo en un turno", ErrInvalidSelection)
+		}
+		var bundles []models.ServiceBundle
+		if err := preloadBundleItems(db).Where("id IN ? AND active = ?", request.BundleIDs, true).Find(&bundles).Error; err != nil {
+			logger.Log.Error("[ServiceSelectionService][loadServiceSelection] Error al buscar combos: ", err)
+			return serviceSelection{}, errors.New("error al buscar combos")
+		}
+		if len(bundles) != len(request.BundleIDs) {
+			logger.Log.Warn("[ServiceSelectionService][loadServiceSelection] Uno o más combos no existen o no están activos")
+			return serviceSelection{}, fmt.Errorf("%w: uno o más combos no existen o no están activos", ErrInvalidSelection)
+		}
+		bundlesByID := make(map[uint]*models.ServiceBundle)
+		for i := range bundles {
+			bundlesByID[bundles[i].ID] = &bundles[i]
+		}
+		for _, id := range request.BundleIDs {
+			bundle := bundlesByID[id]
+			for _, item := range bundle.Items {
+				selection.add(item.Service, bundle)
+			}
+		}
+	}
+
+	if len(request.VariantIDs) > 0 {
+		var variants []models.ServiceVariant
+		if err := db.Where("id IN ?", request.VariantIDs).Find(&variants).Error; err != nil {
+			logger.Log.Error("[ServiceSelectionService][loadServiceSelection] Error al buscar variantes: ", err)
+			return serviceSelection{}, errors.New("error al buscar variantes")
+		}
+		if len(variants) != len(uniqueIDs(request.VariantIDs)) {
+			logger.Log.Warn("[ServiceSelectionService][loadServiceSelection] Una o más variantes no existen")
+			return serviceSelection{}, fmt.Errorf("%w: una o más variantes no existen", ErrInvalidSelection)
+		}
+		variantsByID := make(map[uint]*models.ServiceVariant)
+		for i := range variants {
+			variantsByID[variants[i].ID] = &variants[i]
+		}
+		for _, id := range request.VariantIDs {
+			variant := variantsByID[id]
+			line := selection.lineFor(variant.ServiceID, func(i int) bool { return selection.Variants[i] == nil })
+			if line < 0 {
+				var err error
+				if line, err = selection.addService(db, variant.ServiceID); err != nil {
+					return serviceSelection{}, err
+				}
+			}
+			selection.Variants[line] = variant
+		}
+	}
+
+	if len(request.OptionIDs) > 0 {
+		var options []models.ServiceOption
+		if err := db.Where("id IN ?", request.OptionIDs).Find(&options).Error; err != nil {
+			logger.Log.Error("[ServiceSelectionService][loadServiceSelection] Error al buscar adicionales: ", err)
+			return serviceSelection{}, errors.New("error al buscar adicionales")
+		}
+		if len(options) != len(uniqueIDs(request.OptionIDs)) {
+			logger.Log.Warn("[ServiceSelectionService][loadServiceSelection] Uno o más adicionales no existen")
+			return serviceSelection{}, fmt.Errorf("%w: uno o más adicionales no existen", ErrInvalidSelection)
+		}
+		optionsByID := make(map[uint]models.ServiceOption)
+		for _, option := range options {
+			optionsByID[option.ID] = option
+		}
+		for _, id := range request.OptionIDs {
+			option := optionsByID[id]
+			line := selection.lineFor(option.ServiceID, func(i int) bool { return !hasOption(selection.Options[i], option.ID) })
+			if line < 0 {
+				var err error
+				if line, err = selection.addService(db, option.ServiceID); err != nil {
+					return serviceSelection{}, err
+				}
+			}
+			selection.Options[line] = append(selection.Options[line], option)
+		}
+	}
+
+	return selection, nil
+}
+
+func (s *serviceSelection) add(service models.Service, bundle *models.ServiceBundle) {
+	s.Services = append(s.Services, service)
+	s.Bundles = append(s.Bundles, bundle)
+	s.Variants = append(s.Variants, nil)
+	s.Options = append(s.Options, nil)
+}
+
+// addService agrega una línea suelta para el servicio y devuelve su posición.
+func (s *serviceSelection) addService(db *gorm.DB, serviceID uint) (int, error) {
+	servicesByID, err := findServices(db, []uint{serviceID})
+	if err != nil {
+		return -1, err
+	}
+	s.add(servicesByID[serviceID], nil)
+	return len(s.Services) - 1, nil
+}
+
+// lineFor devuelve la primera línea del servicio que cumple la condición, prefiriendo las
+// sueltas a las de un combo, o -1 si no hay ninguna.
+func (s *serviceSelection) lineFor(serviceID uint, accepts func(int) bool) int {
+	bundled := -1
+	for i, service := range s.Services {
+		if service.ID != serviceID || !accepts(i) {
+			continue
+		}
+		if s.Bundles[i] == nil {
+			return i
+		}
+		if bundled < 0 {
+			bundled = i
+		}
+	}
+	return bundled
+}
+
+func hasOption(options []models.ServiceOption, optionID uint) bool {
+	for _, option := range options {
+		if option.ID == optionID {
+			return true
+		}
+	}
+	return false
+}
+
+// findServices busca los servicios con sus recursos, indexados por ID.
+func findServices(db *gorm.DB, ids []uint) (map[uint]models.Service, error) {
+	var services []models.Service
+	if err := db.Preload("Resources").Where("id IN ?", ids).Find(&services).Error; err != nil {
+		logger.Log.Error("[ServiceSelectionService][findServices] Error al buscar servicios: ", err)
+		return nil, errors.New("error al buscar servicios")
+	}
+	if len(services) != len(uniqueIDs(ids)) {
+		logger.Log.Warn("[ServiceSelectionService][findServices] Uno o más servicios no existen")
+		return nil, fmt.Errorf("%w: uno o más servicios no existen", ErrInvalidSelection)
+	}
+	servicesByID := make(map[uint]models.Service)
+	for _, service := range services {
+		servicesByID[service.ID] = service
+	}
+	return servicesByID, nil
+}
+
+// lineSelection reconstruye la selección a partir de las líneas ya guardadas de un turno.
+// Las líneas deben traer precargados Service, Bundle, Variant y Options; los adicionales
+// conservan el precio con el que se reservaron.
+func lineSelection(lines []models.AppointmentService) serviceSelection {
+	var selection serviceSelection
+	for _, line := range lines {
+		selection.add(line.Service, line.Bundle)
+		i := len(selection.Services) - 1
+		selection.Variants[i] = line.Variant
+		for _, lineOption := range line.Options {
+			selection.Options[i] = append(selection.Options[i], models.ServiceOption{
+				ID:           lineOption.OptionID,
+				ServiceID:    line.ServiceID,
+				Name:         lineOption.Name,
+				Price:        lineOption.Price,
+				ExtraMinutes: lineOption.ExtraMinutes,
+			})
+		}
+	}
+	return selection
+}
+
+// terms resuelve precio y tiempo activo de cada línea para el estilista. La variante
+// reemplaza precio y tiempo del servicio; los servicios de un combo reparten su precio en
+// proporción al precio de lista de cada uno, de modo que cada línea registra lo que
+// efectivamente se cobró por ese servicio, y ajustan su tiempo activo para que el combo
+// dure lo indicado. Los adicionales se suman al final, fuera del precio del combo.
+func (s serviceSelection) terms(db *gorm.DB, staffID *uint) ([]serviceTerms, error) {
+	terms, err := staffServiceTerms(db, staffID, s.Services)
+	if err != nil {
+		return nil, err
+	}
+	for i := range terms {
+		if s.Variants[i] != nil {
+			applyVariantTerms(s.Services[i], s.Variants[i], &terms[i])
+		}
+		terms[i].ListPrice = terms[i].Price
+	}
+
+	done := make(map[uint]bool)
+	for _, bundle := range s.Bundles {
+		if bundle == nil || done[bundle.ID] {
+			continue
+		}
+		done[bundle.ID] = true
+
+		var lines []int
+		for i, lineBundle := range s.Bundles {
+			if lineBundle != nil && lineBundle.ID == bundle.ID {
+				lines = append(lines, i)
+			}
+		}
+		applyBundleTerms(bundle, s.Services, terms, lines)
+	}
+
+	for i := range terms {
+		applyOptionTerms(s.Services[i], s.Options[i], &terms[i])
+	}
+	return terms, nil
+}
+
+// serviceLines arma las líneas de servicio del turno con los términos resueltos.
+func serviceLines(appointmentID uint, staffID *uint, selection serviceSelection, terms []serviceTerms) []models.AppointmentService {
+	lines := make([]models.AppointmentService, len(selection.Services))
+	for i, service := range selection.Services {
+		lines[i] = models.AppointmentService{
+			AppointmentID: appointmentID,
+			ServiceID:     service.ID,
+			StaffID:       staffID,
+			Price:         terms[i].Price,
+			ListPrice:     terms[i].ListPrice,
+			ActiveMinutes: terms[i].ActiveMinutes,
+			BundleID:      terms[i].BundleID,
+		}
+		if variant := selection.Variants[i]; variant != nil {
+			lines[i].VariantID = &variant.ID
+		}
+		for _, option := range selection.Options[i] {
+			lines[i].Options = append(lines[i].Options, models.AppointmentServiceOption{
+				OptionID:     option.ID,
+				Name:         option.Name,
+				Price:        option.Price,
+				ExtraMinutes: option.ExtraMinutes,
+			})
+		}
+	}
+	return lines
+}
+
+// toAppointmentServiceOptionDtos devuelve los adicionales de una línea del turno.
+func toAppointmentServiceOptionDtos(options []models.AppointmentServiceOption) []dtos.AppointmentServiceOptionDto {
+	optionDtos := []dtos.AppointmentServiceOptionDto{}
+	for _, option := range options {
+		optionDtos = append(optionDtos, dtos.AppointmentServiceOptionDto{
+			OptionID:     option.OptionID,
+			Name:         option.Name,
+			Price:        option.Price,
+			ExtraMinutes: option.ExtraMinutes,
+		})
+	}
+	return optionDtos
+}
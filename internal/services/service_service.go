@@ -6,6 +6,10 @@ import (
 	"peluqueria/internal/dtos"
 	"peluqueria/internal/models"
 	"peluqueria/logger"
+	"strings"
+	"time"
+
+	"gorm.io/gorm"
 )
 
 func CreateService(serviceDto dtos.ServiceDto) error {
@@ -30,9 +34,34 @@ func CreateService(serviceDto dtos.ServiceDto) error {
 	service := models.Service{
 		Name:                 serviceDto.Name,
 		Description:          serviceDto.Description,
+		Category:             normalizeCategory(serviceDto.Category),
 		Price:                serviceDto.Price,
 		EstimatedTimeMinutes: serviceDto.EstimatedTimeMinutes + serviceDto.EstimatedTimeHours*60,
 	}
+	if serviceDto.ProcessingTimeMinutes != nil {
+		service.ProcessingTimeMinutes = *serviceDto.ProcessingTimeMinutes
+	}
+	if serviceDto.BufferTimeMinutes != nil {
+		service.BufferTimeMinutes = *serviceDto.BufferTimeMinutes
+	}
+
+	resources, err := buildServiceResources(serviceDto.Resources, service)
+	if err != nil {
+		logger.Log.Warn("[ServiceService][CreateService] Recursos inválidos: ", err)
+		return err
+	}
+	service.Resources = resources
+
+	if err := validateServiceVariants(serviceDto.Variants); err != nil {
+		logger.Log.Warn("[ServiceService][CreateService] Variantes inválidas: ", err)
+		return err
+	}
+	if err := validateServiceOptions(serviceDto.Options); err != nil {
+		logger.Log.Warn("[ServiceService][CreateService] Adicionales inválidos: ", err)
+		return err
+	}
+	service.Variants = buildServiceVariants(serviceDto.Variants)
+	service.Options = buildServiceOptions(serviceDto.Options)
 
 	if err := database.DB.Create(&service).Error; err != nil {
 		logger.Log.Error("[ServiceService][CreateService] Error al crear servicio: ", err)
@@ -47,20 +76,14 @@ func GetAllServices() ([]dtos.GetServiceDto, error) {
 	logger.Log.Info("[ServiceService][GetAllServices] Obteniendo lista de servicios")
 
 	var services []models.Service
-	if err := database.DB.Find(&services).Error; err != nil {
+	if err := preloadServiceCatalog(database.DB).Find(&services).Error; err != nil {
 		logger.Log.Error("[ServiceService][GetAllServices] Error al obtener servicios: ", err)
 		return nil, errors.New("error al obtener servicios")
 	}
 
 	var serviceDtos []dtos.GetServiceDto
 	for _, service := range services {
-		serviceDtos = append(serviceDtos, dtos.GetServiceDto{
-			ID:            service.ID,
-			Name:          service.Name,
-			Description:   service.Description,
-			Price:         service.Price,
-			EstimatedTime: service.EstimatedTimeMinutes,
-		})
+		serviceDtos = append(serviceDtos, toServiceDto(service))
 	}
 
 	logger.Log.Infof("[ServiceService][GetAllServices] %d servicios obtenidos", len(serviceDtos))
@@ -71,17 +94,12 @@ func GetServiceByID(id uint) (dtos.GetServiceDto, error) {
 	logger.Log.Infof("[ServiceService][GetServiceByID] Obteniendo servicio con ID: %d", id)
 
 	var service models.Service
-	if err := database.DB.Where("id = ?", id).First(&service).Error; err != nil {
+	if err := preloadServiceCatalog(database.DB).Where("id = ?", id).First(&service).Error; err != nil {
 		logger.Log.Error("[ServiceService][GetServiceByID] Error al obtener servicio: ", err)
 		return dtos.GetServiceDto{}, errors.New("error al obtener servicio")
 	}
 
-	serviceDto := dtos.GetServiceDto{
-		Name:          service.Name,
-		Description:   service.Description,
-		Price:         service.Price,
-		EstimatedTime: service.EstimatedTimeMinutes,
-	}
+	serviceDto := toServiceDto(service)
 
 	logger.Log.Infof("[ServiceService][GetServiceByID] Servicio obtenido: %s", service.Name)
 	return serviceDto, nil
@@ -102,14 +120,76 @@ func UpdateService(id uint, serviceDto dtos.ServiceDto) error {
 	if serviceDto.Description != "" {
 		service.Description = serviceDto.Description
 	}
+	if serviceDto.Category != "" {
+		service.Category = normalizeCategory(serviceDto.Category)
+	}
 	if serviceDto.Price > 0 {
 		service.Price = serviceDto.Price
 	}
 	if serviceDto.EstimatedTimeMinutes > 0 || serviceDto.EstimatedTimeHours > 0 {
 		service.EstimatedTimeMinutes = serviceDto.EstimatedTimeMinutes + serviceDto.EstimatedTimeHours*60
 	}
+	if serviceDto.ProcessingTimeMinutes != nil {
+		service.ProcessingTimeMinutes = *serviceDto.ProcessingTimeMinutes
+	}
+	if serviceDto.BufferTimeMinutes != nil {
+		service.BufferTimeMinutes = *serviceDto.BufferTimeMinutes
+	}
 
-	if err := database.DB.Save(&service).Error; err != nil {
+	var resources []models.ServiceResource
+	if serviceDto.Resources != nil {
+		var err error
+		if resources, err = buildServiceResources(serviceDto.Resources, service); err != nil {
+			logger.Log.Warn("[ServiceService][UpdateService] Recursos inválidos: ", err)
+			return err
+		}
+	}
+
+	if serviceDto.Variants != nil {
+		if err := validateServiceVariants(serviceDto.Variants); err != nil {
+			logger.Log.Warn("[ServiceService][UpdateService] Variantes inválidas: ", err)
+			return err
+		}
+	}
+	if serviceDto.Options != nil {
+		if err := validateServiceOptions(serviceDto.Options); err != nil {
+			logger.Log.Warn("[ServiceService][UpdateService] Adicionales inválidos: ", err)
+			return err
+		}
+	}
+
+	if err := checkServiceCatalogIDs(database.DB, service.ID, serviceDto.Variants, serviceDto.Options); err != nil {
+		logger.Log.Warn("[ServiceService][UpdateService] Variantes o adicionales inválidos: ", err)
+		return err
+	}
+
+	err := database.DB.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Save(&service).Error; err != nil {
+			return err
+		}
+		if serviceDto.Variants != nil {
+			if err := syncServiceVariants(tx, service.ID, serviceDto.Variants); err != nil {
+				return err
+			}
+		}
+		if serviceDto.Options != nil {
+			if err := syncServiceOptions(tx, service.ID, serviceDto.Options); err != nil {
+				return err
+			}
+		}
+		if serviceDto.Resources == nil {
+			return nil
+		}
+		// Los recursos declarados se reemplazan completos; los turnos ya agendados conservan su asignación
+		if err := tx.Where("service_id = ?", service.ID).Delete(&models.ServiceResource{}).Error; err != nil {
+			return err
+		}
+		if len(resources) == 0 {
+			return nil
+		}
+		return tx.Create(&resources).Error
+	})
+	if err != nil {
 		logger.Log.Error("[ServiceService][UpdateService] Error al actualizar servicio: ", err)
 		return errors.New("error al actualizar servicio")
 	}
@@ -132,6 +212,19 @@ func DeleteService(id uint) error {
 		return errors.New("el servicio está vinculado a turnos y no se puede eliminar")
 	}
 
+	// Verificar si el servicio forma parte de un combo
+	if err := database.DB.Model(&models.ServiceBundleItem{}).
+		Joins("JOIN service_bundles ON service_bundles.id = service_bundle_items.bundle_id AND service_bundles.deleted_at IS NULL").
+		Where("service_bundle_items.service_id = ?", id).
+		Count(&count).Error; err != nil {
+		logger.Log.Error("[ServiceService][DeleteService] Error al verificar combos: ", err)
+		return errors.New("error al verificar combos del servicio")
+	}
+	if count > 0 {
+		logger.Log.Warn("[ServiceService][DeleteService] Servicio incluido en combos, no se puede eliminar")
+		return errors.New("el servicio forma parte de un combo y no se puede eliminar")
+	}
+
 	if err := database.DB.Delete(&models.Service{}, id).Error; err != nil {
 		logger.Log.Error("[ServiceService][DeleteService] Error al eliminar servicio: ", err)
 		return errors.New("error al eliminar servicio")
@@ -140,3 +233,40 @@ func DeleteService(id uint) error {
 	logger.Log.Infof("[ServiceService][DeleteService] Servicio eliminado con éxito: ID %d", id)
 	return nil
 }
+
+func toServiceDto(service models.Service) dtos.GetServiceDto {
+	_, total := servicePlan([]models.Service{service})
+	resources := []dtos.ServiceResourceDto{}
+	for _, requirement := range service.Resources {
+		resources = append(resources, dtos.ServiceResourceDto{
+			ResourceType:    requirement.ResourceType,
+			OffsetMinutes:   requirement.OffsetMinutes,
+			DurationMinutes: requirement.DurationMinutes,
+		})
+	}
+	return dtos.GetServiceDto{
+		ID:             service.ID,
+		Name:           service.Name,
+		Description:    service.Description,
+		Category:       service.Category,
+		Price:          service.Price,
+		EstimatedTime:  service.EstimatedTimeMinutes,
+		ProcessingTime: service.ProcessingTimeMinutes,
+		BufferTime:     service.BufferTimeMinutes,
+		TotalTime:      uint(total / time.Minute),
+		Resources:      resources,
+		Variants:       toServiceVariantDtos(service),
+		Options:        toServiceOptionDtos(service.Options),
+	}
+}
+
+// normalizeCategory guarda las categorías en minúsculas para compararlas sin importar cómo
+// se escribieron.
+func normalizeCategory(category string) string {
+	return strings.ToLower(strings.TrimSpace(category))
+}
+
+// preloadServiceCatalog carga lo que se muestra de un servicio en el catálogo.
+func preloadServiceCatalog(db *gorm.DB) *gorm.DB {
+	return db.Preload("Resources").Preload("Variants").Preload("Options")
+}
@@ -0,0 +1,241 @@
+package services
+
+import (
+	"errors"
+	"fmt"
+	"peluqueria/internal/dtos"
+	"peluqueria/internal/models"
+	"strings"
+
+	"gorm.io/gorm"
+)
+
+// validateServiceVariants verifica nombre, precio y duración de cada variante.
+func validateServiceVariants(variantDtos []dtos.ServiceVariantDto) error {
+	seen := make(map[string]bool)
+	for _, variantDto := range variantDtos {
+		name := strings.ToLower(strings.TrimSpace(variantDto.Name))
+		if name == "" {
+			return errors.New("cada variante debe tener nombre")
+		}
+		if seen[name] {
+			return fmt.Errorf("la variante '%s' está repetida", variantDto.Name)
+		}
+		seen[name] = true
+		if variantDto.Price <= 0 {
+			return fmt.Errorf("el precio de la variante '%s' debe ser mayor a 0", variantDto.Name)
+		}
+		if variantDto.EstimatedTimeMinutes != nil && *variantDto.EstimatedTimeMinutes == 0 {
+			return fmt.Errorf("la duración de la variante '%s' debe ser mayor a 0", variantDto.Name)
+		}
+	}
+	return nil
+}
+
+// validateServiceOptions verifica nombre y precio de cada adicional.
+func validateServiceOptions(optionDtos []dtos.ServiceOptionDto) error {
+	seen := make(map[string]bool)
+	for _, optionDto := range optionDtos {
+		name := strings.ToLower(strings.TrimSpace(optionDto.Name))
+		if name == "" {
+			return errors.New("cada adicional debe tener nombre")
+		}
+		if seen[name] {
+			return fmt.Errorf("el adicional '%s' está repetido", optionDto.Name)
+		}
+		seen[name] = true
+		if optionDto.Price < 0 {
+			return fmt.Errorf("el precio del adicional '%s' no puede ser negativo", optionDto.Name)
+		}
+	}
+	return nil
+}
+
+// checkServiceCatalogIDs verifica que las variantes y adicionales a modificar pertenezcan
+// al servicio.
+func checkServiceCatalogIDs(db *gorm.DB, serviceID uint, variantDtos []dtos.ServiceVariantDto, optionDtos []dtos.ServiceOptionDto) error {
+	for _, variantDto := range variantDtos {
+		if variantDto.ID == 0 {
+			continue
+		}
+		if err := db.Select("id").Where("id = ? AND service_id = ?", variantDto.ID, serviceID).First(&models.ServiceVariant{}).Error; err != nil {
+			return fmt.Errorf("la variante ID %d no pertenece al servicio", variantDto.ID)
+		}
+	}
+	for _, optionDto := range optionDtos {
+		if optionDto.ID == 0 {
+			continue
+		}
+		if err := db.Select("id").Where("id = ? AND service_id = ?", optionDto.ID, serviceID).First(&models.ServiceOption{}).Error; err != nil {
+			return fmt.Errorf("el adicional ID %d no pertenece al servicio", optionDto.ID)
+		}
+	}
+	return nil
+}
+
+func buildServiceVariants(variantDtos []dtos.ServiceVariantDto) []models.ServiceVariant {
+	var variants []models.ServiceVariant
+	for _, variantDto := range variantDtos {
+		variants = append(variants, models.ServiceVariant{
+			Name:                 strings.TrimSpace(variantDto.Name),
+			Price:                variantDto.Price,
+			EstimatedTimeMinutes: variantDto.EstimatedTimeMinutes,
+		})
+	}
+	return variants
+}
+
+func buildServiceOptions(optionDtos []dtos.ServiceOptionDto) []models.ServiceOption {
+	var options []models.ServiceOption
+	for _, optionDto := range optionDtos {
+		options = append(options, models.ServiceOption{
+			Name:         strings.TrimSpace(optionDto.Name),
+			Price:        optionDto.Price,
+			ExtraMinutes: optionDto.ExtraMinutes,
+		})
+	}
+	return options
+}
+
+// syncServiceVariants deja las variantes del servicio como las recibidas: modifica las que
+// traen ID, crea las nuevas y da de baja las que no se enviaron. Los turnos ya agendados
+// conservan la variante con la que se reservaron.
+func syncServiceVariants(tx *gorm.DB, serviceID uint, variantDtos []dtos.ServiceVariantDto) error {
+	var existing []models.ServiceVariant
+	if err := tx.Where("service_id = ?", serviceID).Find(&existing).Error; err != nil {
+		return err
+	}
+	byID := make(map[uint]models.ServiceVariant)
+	for _, variant := range existing {
+		byID[variant.ID] = variant
+	}
+
+	kept := make(map[uint]bool)
+	for i, variant := range buildServiceVariants(variantDtos) {
+		variant.ServiceID = serviceID
+		if id := variantDtos[i].ID; id != 0 {
+			current, ok := byID[id]
+			if !ok {
+				return fmt.Errorf("la variante ID %d no pertenece al servicio", id)
+			}
+			variant.ID = id
+			variant.CreatedAt = current.CreatedAt
+			kept[id] = true
+		}
+		if err := tx.Save(&variant).Error; err != nil {
+			return err
+		}
+	}
+
+	for _, variant := range existing {
+		if kept[variant.ID] {
+			continue
+		}
+		if err := tx.Delete(&variant).Error; err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// syncServiceOptions aplica a los adicionales la misma regla que syncServiceVariants.
+func syncServiceOptions(tx *gorm.DB, serviceID uint, optionDtos []dtos.ServiceOptionDto) error {
+	var existing []models.ServiceOption
+	if err := tx.Where("service_id = ?", serviceID).Find(&existing).Error; err != nil {
+		return err
+	}
+	byID := make(map[uint]models.ServiceOption)
+	for _, option := range existing {
+		byID[option.ID] = option
+	}
+
+	kept := make(map[uint]bool)
+	for i, option := range buildServiceOptions(optionDtos) {
+		option.ServiceID = serviceID
+		if id := optionDtos[i].ID; id != 0 {
+			current, ok := byID[id]
+			if !ok {
+				return fmt.Errorf("el adicional ID %d no pertenece al servicio", id)
+			}
+			option.ID = id
+			option.CreatedAt = current.CreatedAt
+			kept[id] = true
+		}
+		if err := tx.Save(&option).Error; err != nil {
+			return err
+		}
+	}
+
+	for _, option := range existing {
+		if kept[option.ID] {
+			continue
+		}
+		if err := tx.Delete(&option).Error; err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// applyVariantTerms reemplaza precio y tiempo del servicio por los de la variante. Si el
+// estilista tiene precio o tiempo propios, conserva su diferencia respecto del servicio.
+func applyVariantTerms(service models.Service, variant *models.ServiceVariant, terms *serviceTerms) {
+	terms.Price = max(0, variant.Price+terms.Price-service.Price)
+	if variant.EstimatedTimeMinutes == nil {
+		return
+	}
+	staffActive := service.EstimatedTimeMinutes
+	if terms.ActiveMinutes != nil {
+		staffActive = *terms.ActiveMinutes
+	}
+	active := uint(max(1, int(*variant.EstimatedTimeMinutes)+int(staffActive)-int(service.EstimatedTimeMinutes)))
+	terms.ActiveMinutes = &active
+}
+
+// applyOptionTerms suma precio y minutos de los adicionales a la línea.
+func applyOptionTerms(service models.Service, options []models.ServiceOption, terms *serviceTerms) {
+	var extra uint
+	for _, option := range options {
+		terms.Price += option.Price
+		terms.ListPrice += option.Price
+		extra += option.ExtraMinutes
+	}
+	if extra == 0 {
+		return
+	}
+	active := service.EstimatedTimeMinutes + extra
+	if terms.ActiveMinutes != nil {
+		active = *terms.ActiveMinutes + extra
+	}
+	terms.ActiveMinutes = &active
+}
+
+func toServiceVariantDtos(service models.Service) []dtos.GetServiceVariantDto {
+	variantDtos := []dtos.GetServiceVariantDto{}
+	for _, variant := range service.Variants {
+		minutes := service.EstimatedTimeMinutes
+		if variant.EstimatedTimeMinutes != nil {
+			minutes = *variant.EstimatedTimeMinutes
+		}
+		variantDtos = append(variantDtos, dtos.GetServiceVariantDto{
+			ID:                   variant.ID,
+			Name:                 variant.Name,
+			Price:                variant.Price,
+			EstimatedTimeMinutes: minutes,
+		})
+	}
+	return variantDtos
+}
+
+func toServiceOptionDtos(options []models.ServiceOption) []dtos.GetServiceOptionDto {
+	optionDtos := []dtos.GetServiceOptionDto{}
+	for _, option := range options {
+		optionDtos = append(optionDtos, dtos.GetServiceOptionDto{
+			ID:           option.ID,
+			Name:         option.Name,
+			Price:        option.Price,
+			ExtraMinutes: option.ExtraMinutes,
+		})
+	}
+	return optionDtos
+}
@@ -0,0 +1,741 @@
+package services
+
+import (
+	"errors"
+	"fmt"
+	"peluqueria/database"
+	"peluqueria/internal/dtos"
+	"peluqueria/internal/helpers"
+	"peluqueria/internal/models"
+	"peluqueria/logger"
+	"slices"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// ErrStaffNotWorking indica que el turno cae fuera del horario de trabajo del estilista.
+var ErrStaffNotWorking = errors.New("el estilista no trabaja en el horario solicitado")
+
+// ErrTimeOffNotAllowed indica que el usuario no puede gestionar licencias de otro empleado.
+var ErrTimeOffNotAllowed = errors.New("solo puede solicitar o modificar sus propias licencias")
+
+var timeOffKinds = []string{"vacaciones", "enfermedad", "otro"}
+
+func GetStaffShifts(staffID uint) ([]dtos.GetStaffShiftDto, error) {
+	logger.Log.Infof("[StaffScheduleService][GetStaffShifts] Obteniendo franjas del empleado ID %d", staffID)
+
+	var shifts []models.StaffShift
+	if err := database.DB.Where("staff_id = ?", staffID).Order("weekday, start_time").Find(&shifts).Error; err != nil {
+		logger.Log.Error("[StaffScheduleService][GetStaffShifts] Error al obtener franjas: ", err)
+		return nil, errors.New("error al obtener franjas de trabajo")
+	}
+
+	shiftDtos := []dtos.GetStaffShiftDto{}
+	for _, shift := range shifts {
+		shiftDtos = append(shiftDtos, dtos.GetStaffShiftDto{
+			ID:          shift.ID,
+			StaffID:     shift.StaffID,
+			Weekday:     shift.Weekday,
+			WeekdayName: weekdayNames[shift.Weekday],
+			StartTime:   shift.StartTime,
+			EndTime:     shift.EndTime,
+		})
+	}
+	return shiftDtos, nil
+}
+
+func CreateStaffShift(staffID uint, dto dtos.StaffShiftDto) error {
+	logger.Log.Infof("[StaffScheduleService][CreateStaffShift] Creando franja para el empleado ID %d", staffID)
+
+	if _, err := findActiveStaff(database.DB, staffID); err != nil {
+		return err
+	}
+	if err := validateStaffShift(staffID, dto, 0); err != nil {
+		return err
+	}
+
+	shift := models.StaffShift{
+		StaffID:   staffID,
+		Weekday:   dto.Weekday,
+		StartTime: dto.StartTime,
+		EndTime:   dto.EndTime,
+	}
+	if err := database.DB.Create(&shift).Error; err != nil {
+		logger.Log.Error("[StaffScheduleService][CreateStaffShift] Error al crear franja: ", err)
+		return errors.New("error al crear franja de trabajo")
+	}
+
+	logger.Log.Infof("[StaffScheduleService][CreateStaffShift] Franja creada: ID %d", shift.ID)
+	return nil
+}
+
+func UpdateStaffShift(id uint, dto dtos.StaffShiftDto) error {
+	logger.Log.Infof("[StaffScheduleService][UpdateStaffShift] Actualizando franja con ID: %d", id)
+
+	var shift models.StaffShift
+	if err := database.DB.First(&shift, id).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			logger.Log.Warnf("[StaffScheduleService][UpdateStaffShift] Franja no encontrada: ID %d", id)
+			return errors.New("la franja de trabajo no existe")
+		}
+		logger.Log.Error("[StaffScheduleService][UpdateStaffShift] Error al buscar franja: ", err)
+		return errors.New("error al buscar franja de trabajo")
+	}
+
+	if err := validateStaffShift(shift.StaffID, dto, id); err != nil {
+		return err
+	}
+
+	shift.Weekday = dto.Weekday
+	shift.StartTime = dto.StartTime
+	shift.EndTime = dto.EndTime
+	if err := database.DB.Save(&shift).Error; err != nil {
+		logger.Log.Error("[StaffScheduleService][UpdateStaffShift] Error al actualizar franja: ", err)
+		return errors.New("error al actualizar franja de trabajo")
+	}
+
+	logger.Log.Infof("[StaffScheduleService][UpdateStaffShift] Franja actualizada: ID %d", id)
+	return nil
+}
+
+func DeleteStaffShift(id uint) error {
+	logger.Log.Infof("[StaffScheduleService][DeleteStaffShift] Eliminando franja con ID: %d", id)
+
+	if err := database.DB.Delete(&models.StaffShift{}, id).Error; err != nil {
+		logger.Log.Error("[StaffScheduleService][DeleteStaffShift] Error al eliminar franja: ", err)
+		return errors.New("error al eliminar franja de trabajo")
+	}
+
+	logger.Log.Infof("[StaffScheduleService][DeleteStaffShift] Franja eliminada: ID %d", id)
+	return nil
+}
+
+func GetStaffScheduleExceptions(staffID uint, from, to string) ([]dtos.GetStaffScheduleExceptionDto, error) {
+	logger.Log.Infof("[StaffScheduleService][GetStaffScheduleExceptions] Obteniendo excepciones del empleado ID %d", staffID)
+
+	query := database.DB.Where("staff_id = ?", staffID).Order("date, start_time")
+	if from != "" {
+		fromDate, err := helpers.ParseCustomDay(from)
+		if err != nil {
+			return nil, err
+		}
+		query = query.Where("date >= ?", fromDate.Format("2006-01-02"))
+	}
+	if to != "" {
+		toDate, err := helpers.ParseCustomDay(to)
+		if err != nil {
+			return nil, err
+		}
+		query = query.Where("date <= ?", toDate.Format("2006-01-02"))
+	}
+
+	var exceptions []models.StaffScheduleException
+	if err := query.Find(&exceptions).Error; err != nil {
+		logger.Log.Error("[StaffScheduleService][GetStaffScheduleExceptions] Error al obtener excepciones: ", err)
+		return nil, errors.New("error al obtener excepciones de horario")
+	}
+
+	exceptionDtos := []dtos.GetStaffScheduleExceptionDto{}
+	for _, exception := range exceptions {
+		exceptionDtos = append(exceptionDtos, toStaffScheduleExceptionDto(exception, nil))
+	}
+	return exceptionDtos, nil
+}
+
+// CreateStaffScheduleException guarda la excepción y devuelve los turnos ya agendados que
+// quedan fuera del nuevo horario del día.
+func CreateStaffScheduleException(staffID uint, dto dtos.StaffScheduleExceptionDto) (dtos.GetStaffScheduleExceptionDto, error) {
+	logger.Log.Infof("[StaffScheduleService][CreateStaffScheduleException] Creando excepción para el empleado ID %d", staffID)
+
+	if _, err := findActiveStaff(database.DB, staffID); err != nil {
+		return dtos.GetStaffScheduleExceptionDto{}, err
+	}
+	exception, err := staffScheduleExceptionFromDto(staffID, dto, 0)
+	if err != nil {
+		return dtos.GetStaffScheduleExceptionDto{}, err
+	}
+
+	if err := database.DB.Create(&exception).Error; err != nil {
+		logger.Log.Error("[StaffScheduleService][CreateStaffScheduleException] Error al crear excepción: ", err)
+		return dtos.GetStaffScheduleExceptionDto{}, errors.New("error al crear excepción de horario")
+	}
+
+	conflicts, err := staffScheduleConflicts(database.DB, staffID, exception.Date, exception.Date)
+	if err != nil {
+		return dtos.GetStaffScheduleExceptionDto{}, err
+	}
+
+	logger.Log.Infof("[StaffScheduleService][CreateStaffScheduleException] Excepción creada: ID %d, %d turnos en conflicto", exception.ID, len(conflicts))
+	return toStaffScheduleExceptionDto(exception, conflicts), nil
+}
+
+func UpdateStaffScheduleException(id uint, dto dtos.StaffScheduleExceptionDto) (dtos.GetStaffScheduleExceptionDto, error) {
+	logger.Log.Infof("[StaffScheduleService][UpdateStaffScheduleException] Actualizando excepción con ID: %d", id)
+
+	var existing models.StaffScheduleException
+	if err := database.DB.First(&existing, id).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			logger.Log.Warnf("[StaffScheduleService][UpdateStaffScheduleException] Excepción no encontrada: ID %d", id)
+			return dtos.GetStaffScheduleExceptionDto{}, errors.New("la excepción de horario no existe")
+		}
+		logger.Log.Error("[StaffScheduleService][UpdateStaffScheduleException] Error al buscar excepción: ", err)
+		return dtos.GetStaffScheduleExceptionDto{}, errors.New("error al buscar excepción de horario")
+	}
+
+	exception, err := staffScheduleExceptionFromDto(existing.StaffID, dto, id)
+	if err != nil {
+		return dtos.GetStaffScheduleExceptionDto{}, err
+	}
+	exception.ID = existing.ID
+	exception.CreatedAt = existing.CreatedAt
+
+	if err := database.DB.Save(&exception).Error; err != nil {
+		logger.Log.Error("[StaffScheduleService][UpdateStaffScheduleException] Error al actualizar excepción: ", err)
+		return dtos.GetStaffScheduleExceptionDto{}, errors.New("error al actualizar excepción de horario")
+	}
+
+	conflicts, err := staffScheduleConflicts(database.DB, exception.StaffID, exception.Date, exception.Date)
+	if err != nil {
+		return dtos.GetStaffScheduleExceptionDto{}, err
+	}
+
+	logger.Log.Infof("[StaffScheduleService][UpdateStaffScheduleException] Excepción actualizada: ID %d", id)
+	return toStaffScheduleExceptionDto(exception, conflicts), nil
+}
+
+func DeleteStaffScheduleException(id uint) error {
+	logger.Log.Infof("[StaffScheduleService][DeleteStaffScheduleException] Eliminando excepción con ID: %d", id)
+
+	if err := database.DB.Delete(&models.StaffScheduleException{}, id).Error; err != nil {
+		logger.Log.Error("[StaffScheduleService][DeleteStaffScheduleException] Error al eliminar excepción: ", err)
+		return errors.New("error al eliminar excepción de horario")
+	}
+
+	logger.Log.Infof("[StaffScheduleService][DeleteStaffScheduleException] Excepción eliminada: ID %d", id)
+	return nil
+}
+
+// RequestTimeOff registra una solicitud de licencia pendiente de aprobación.
+// RequestTimeOff registra una licencia pendiente. Sin permiso para aprobar licencias, el
+// usuario solo puede pedirlas para el empleado vinculado a él.
+func RequestTimeOff(dto dtos.TimeOffDto, userID uint, canApprove bool) (dtos.GetTimeOffDto, error) {
+	logger.Log.Infof("[StaffScheduleService][RequestTimeOff] Solicitando licencia para el empleado ID %d", dto.StaffID)
+
+	staff, err := findActiveStaff(database.DB, dto.StaffID)
+	if err != nil {
+		return dtos.GetTimeOffDto{}, err
+	}
+	if err := checkTimeOffOwner(staff, userID, canApprove); err != nil {
+		return dtos.GetTimeOffDto{}, err
+	}
+
+	timeOff, err := timeOffFromDto(dto)
+	if err != nil {
+		return dtos.GetTimeOffDto{}, err
+	}
+	timeOff.Status = models.TimeOffStatusPending
+	if userID != 0 {
+		timeOff.RequestedByID = &userID
+	}
+
+	if err := database.DB.Create(&timeOff).Error; err != nil {
+		logger.Log.Error("[StaffScheduleService][RequestTimeOff] Error al registrar licencia: ", err)
+		return dtos.GetTimeOffDto{}, errors.New("error al registrar la licencia")
+	}
+	timeOff.Staff = staff
+
+	conflicts, err := timeOffConflicts(database.DB, timeOff)
+	if err != nil {
+		return dtos.GetTimeOffDto{}, err
+	}
+
+	logger.Log.Infof("[StaffScheduleService][RequestTimeOff] Licencia registrada: ID %d", timeOff.ID)
+	return toTimeOffDto(timeOff, conflicts), nil
+}
+
+func GetAllTimeOff(staffID, status string) ([]dtos.GetTimeOffDto, error) {
+	logger.Log.Info("[StaffScheduleService][GetAllTimeOff] Obteniendo licencias")
+
+	query := database.DB.Preload("Staff").Order("start_date DESC")
+	if staffID != "" {
+		query = query.Where("staff_id = ?", staffID)
+	}
+	if status != "" {
+		query = query.Where("status = ?", status)
+	}
+
+	var timeOffs []models.TimeOff
+	if err := query.Find(&timeOffs).Error; err != nil {
+		logger.Log.Error("[StaffScheduleService][GetAllTimeOff] Error al obtener licencias: ", err)
+		return nil, errors.New("error al obtener licencias")
+	}
+
+	timeOffDtos := []dtos.GetTimeOffDto{}
+	for _, timeOff := range timeOffs {
+		timeOffDtos = append(timeOffDtos, toTimeOffDto(timeOff, nil))
+	}
+
+	logger.Log.Infof("[StaffScheduleService][GetAllTimeOff] Licencias obtenidas: %d", len(timeOffDtos))
+	return timeOffDtos, nil
+}
+
+// GetTimeOffByID devuelve la licencia junto con los turnos del empleado que caen dentro de ella.
+func GetTimeOffByID(id uint) (dtos.GetTimeOffDto, error) {
+	logger.Log.Infof("[StaffScheduleService][GetTimeOffByID] Obteniendo licencia con ID: %d", id)
+
+	timeOff, err := findTimeOff(database.DB, id)
+	if err != nil {
+		return dtos.GetTimeOffDto{}, err
+	}
+
+	conflicts, err := timeOffConflicts(database.DB, timeOff)
+	if err != nil {
+		return dtos.GetTimeOffDto{}, err
+	}
+	return toTimeOffDto(timeOff, conflicts), nil
+}
+
+// UpdateTimeOff modifica una licencia que todavía no fue revisada.
+// UpdateTimeOff modifica una licencia pendiente, con la misma restricción que RequestTimeOff.
+func UpdateTimeOff(id uint, dto dtos.TimeOffDto, userID uint, canApprove bool) error {
+	logger.Log.Infof("[StaffScheduleService][UpdateTimeOff] Actualizando licencia con ID: %d", id)
+
+	existing, err := findTimeOff(database.DB, id)
+	if err != nil {
+		return err
+	}
+	if err := checkTimeOffOwner(existing.Staff, userID, canApprove); err != nil {
+		return err
+	}
+	if existing.Status != models.TimeOffStatusPending {
+		logger.Log.Warnf("[StaffScheduleService][UpdateTimeOff] Licencia ID %d ya revisada (%s)", id, existing.Status)
+		return errors.New("solo se pueden modificar licencias pendientes")
+	}
+
+	dto.StaffID = existing.StaffID
+	timeOff, err := timeOffFromDto(dto)
+	if err != nil {
+		return err
+	}
+	existing.Kind = timeOff.Kind
+	existing.StartDate = timeOff.StartDate
+	existing.EndDate = timeOff.EndDate
+	existing.Reason = timeOff.Reason
+
+	if err := database.DB.Omit("Staff").Save(&existing).Error; err != nil {
+		logger.Log.Error("[StaffScheduleService][UpdateTimeOff] Error al actualizar licencia: ", err)
+		return errors.New("error al actualizar la licencia")
+	}
+
+	logger.Log.Infof("[StaffScheduleService][UpdateTimeOff] Licencia actualizada: ID %d", id)
+	return nil
+}
+
+func DeleteTimeOff(id uint) error {
+	logger.Log.Infof("[StaffScheduleService][DeleteTimeOff] Eliminando licencia con ID: %d", id)
+
+	if err := database.DB.Delete(&models.TimeOff{}, id).Error; err != nil {
+		logger.Log.Error("[StaffScheduleService][DeleteTimeOff] Error al eliminar licencia: ", err)
+		return errors.New("error al eliminar la licencia")
+	}
+
+	logger.Log.Infof("[StaffScheduleService][DeleteTimeOff] Licencia eliminada: ID %d", id)
+	return nil
+}
+
+// ApproveTimeOff aprueba la licencia y devuelve los turnos ya agendados que quedan dentro
+// de ella, para que se reasignen o reprogramen.
+func ApproveTimeOff(id uint, dto dtos.ReviewTimeOffDto, userID uint) (dtos.GetTimeOffDto, error) {
+	return reviewTimeOff(id, models.TimeOffStatusApproved, dto.Note, userID)
+}
+
+func RejectTimeOff(id uint, dto dtos.ReviewTimeOffDto, userID uint) (dtos.GetTimeOffDto, error) {
+	return reviewTimeOff(id, models.TimeOffStatusRejected, dto.Note, userID)
+}
+
+func reviewTimeOff(id uint, status, note string, userID uint) (dtos.GetTimeOffDto, error) {
+	logger.Log.Infof("[StaffScheduleService][reviewTimeOff] Licencia ID %d -> %s", id, status)
+
+	var timeOff models.TimeOff
+	err := database.DB.Transaction(func(tx *gorm.DB) error {
+		var err error
+		if timeOff, err = findTimeOff(tx, id); err != nil {
+			return err
+		}
+		if timeOff.Status != models.TimeOffStatusPending {
+			logger.Log.Warnf("[StaffScheduleService][reviewTimeOff] Licencia ID %d ya revisada (%s)", id, timeOff.Status)
+			return errors.New("la licencia ya fue revisada")
+		}
+
+		now := time.Now()
+		timeOff.Status = status
+		timeOff.ReviewNote = note
+		timeOff.ReviewedAt = &now
+		if userID != 0 {
+			timeOff.ReviewedByID = &userID
+		}
+		if err := tx.Omit("Staff").Save(&timeOff).Error; err != nil {
+			logger.Log.Error("[StaffScheduleService][reviewTimeOff] Error al guardar licencia: ", err)
+			return errors.New("error al revisar la licencia")
+		}
+		return nil
+	})
+	if err != nil {
+		return dtos.GetTimeOffDto{}, err
+	}
+
+	var conflicts []dtos.StaffScheduleConflictDto
+	if status == models.TimeOffStatusApproved {
+		if conflicts, err = timeOffConflicts(database.DB, timeOff); err != nil {
+			return dtos.GetTimeOffDto{}, err
+		}
+		if len(conflicts) > 0 {
+			logger.Log.Warnf("[StaffScheduleService][reviewTimeOff] Licencia ID %d aprobada con %d turnos en conflicto", id, len(conflicts))
+		}
+	}
+
+	logger.Log.Infof("[StaffScheduleService][reviewTimeOff] Licencia ID %d %s", id, status)
+	return toTimeOffDto(timeOff, conflicts), nil
+}
+
+// staffWorkingRanges devuelve las franjas en que el empleado trabaja el día indicado
+// (medianoche en la zona horaria del salón). Una licencia aprobada deja el día sin franjas y
+// una excepción reemplaza las franjas semanales. restricted es false si el empleado no
+// tiene horario cargado: en ese caso trabaja en todo el horario del salón.
+func staffWorkingRanges(db *gorm.DB, staffID uint, day time.Time) (ranges []timeRange, restricted bool, err error) {
+	dayKey := day.Format("2006-01-02")
+
+	var leaves int64
+	if err := db.Model(&models.TimeOff{}).
+		Where("staff_id = ? AND status = ?", staffID, models.TimeOffStatusApproved).
+		Where("start_date <= ? AND end_date >= ?", dayKey, dayKey).
+		Count(&leaves).Error; err != nil {
+		logger.Log.Error("[StaffScheduleService][staffWorkingRanges] Error al obtener licencias: ", err)
+		return nil, false, errors.New("error al obtener licencias del empleado")
+	}
+	if leaves > 0 {
+		return nil, true, nil
+	}
+
+	var exceptions []models.StaffScheduleException
+	if err := db.Where("staff_id = ? AND date = ?", staffID, dayKey).Order("start_time").Find(&exceptions).Error; err != nil {
+		logger.Log.Error("[StaffScheduleService][staffWorkingRanges] Error al obtener excepciones: ", err)
+		return nil, false, errors.New("error al obtener excepciones de horario")
+	}
+	if len(exceptions) > 0 {
+		for _, exception := range exceptions {
+			if exception.StartTime == "" {
+				continue
+			}
+			r, err := clockRange(day, exception.StartTime, exception.EndTime)
+			if err != nil {
+				return nil, false, err
+			}
+			ranges = append(ranges, r)
+		}
+		return ranges, true, nil
+	}
+
+	var shifts []models.StaffShift
+	if err := db.Where("staff_id = ?", staffID).Order("start_time").Find(&shifts).Error; err != nil {
+		logger.Log.Error("[StaffScheduleService][staffWorkingRanges] Error al obtener franjas: ", err)
+		return nil, false, errors.New("error al obtener franjas de trabajo")
+	}
+	if len(shifts) == 0 {
+		return nil, false, nil
+	}
+	for _, shift := range shifts {
+		if shift.Weekday != int(day.Weekday()) {
+			continue
+		}
+		r, err := clockRange(day, shift.StartTime, shift.EndTime)
+		if err != nil {
+			return nil, false, err
+		}
+		ranges = append(ranges, r)
+	}
+	return ranges, true, nil
+}
+
+// checkStaffWorking valida que el tramo [start, end) caiga dentro de una franja de trabajo
+// del estilista.
+func checkStaffWorking(db *gorm.DB, staffID uint, start, end time.Time) error {
+	loc, err := helpers.SalonLocation()
+	if err != nil {
+		return err
+	}
+	local := start.In(loc)
+	day := time.Date(local.Year(), local.Month(), local.Day(), 0, 0, 0, 0, loc)
+
+	ranges, restricted, err := staffWorkingRanges(db, staffID, day)
+	if err != nil {
+		return err
+	}
+	if !restricted || withinRanges(ranges, start, end) {
+		return nil
+	}
+
+	logger.Log.Warnf("[StaffScheduleService][checkStaffWorking] Empleado ID %d no trabaja el %s", staffID, local.Format("02/01/2006 15:04"))
+	return ErrStaffNotWorking
+}
+
+// staffScheduleConflicts devuelve los turnos activos del empleado entre los días from y to
+// (inclusive) que quedan fuera de su horario de trabajo.
+func staffScheduleConflicts(db *gorm.DB, staffID uint, from, to time.Time) ([]dtos.StaffScheduleConflictDto, error) {
+	appointments, err := staffAppointmentsBetween(db, staffID, from, to)
+	if err != nil {
+		return nil, err
+	}
+
+	conflicts := []dtos.StaffScheduleConflictDto{}
+	for _, appointment := range appointments {
+		start := appointment.AppointmentDate
+		end := start.Add(appointmentDuration(appointment))
+		if err := checkStaffWorking(db, staffID, start, end); err != nil {
+			if !errors.Is(err, ErrStaffNotWorking) {
+				return nil, err
+			}
+			conflicts = append(conflicts, toStaffScheduleConflictDto(appointment))
+		}
+	}
+	return conflicts, nil
+}
+
+// timeOffConflicts devuelve los turnos activos del empleado que caen dentro de la licencia.
+func timeOffConflicts(db *gorm.DB, timeOff models.TimeOff) ([]dtos.StaffScheduleConflictDto, error) {
+	appointments, err := staffAppointmentsBetween(db, timeOff.StaffID, timeOff.StartDate, timeOff.EndDate)
+	if err != nil {
+		return nil, err
+	}
+
+	conflicts := []dtos.StaffScheduleConflictDto{}
+	for _, appointment := range appointments {
+		conflicts = append(conflicts, toStaffScheduleConflictDto(appointment))
+	}
+	return conflicts, nil
+}
+
+// staffAppointmentsBetween devuelve los turnos activos del empleado entre los días from y to
+// (inclusive), tomando los días en la zona horaria del salón.
+func staffAppointmentsBetween(db *gorm.DB, staffID uint, from, to time.Time) ([]models.Appointment, error) {
+	loc, err := helpers.SalonLocation()
+	if err != nil {
+		return nil, err
+	}
+	start := time.Date(from.Year(), from.Month(), from.Day(), 0, 0, 0, 0, loc)
+	end := time.Date(to.Year(), to.Month(), to.Day(), 0, 0, 0, 0, loc).AddDate(0, 0, 1)
+
+	var appointments []models.Appointment
+	if err := db.Preload("Client").Preload("AppointmentServices.Service").
+		Where("staff_id = ?", staffID).
+		Where("status NOT IN ?", inactiveAppointmentStatuses).
+		Where("status <> ?", models.AppointmentStatusFinished).
+		Where("appointment_date >= ? AND appointment_date < ?", start, end).
+		Order("appointment_date").
+		Find(&appointments).Error; err != nil {
+		logger.Log.Error("[StaffScheduleService][staffAppointmentsBetween] Error al obtener turnos: ", err)
+		return nil, errors.New("error al obtener turnos del empleado")
+	}
+	return appointments, nil
+}
+
+func validateStaffShift(staffID uint, dto dtos.StaffShiftDto, id uint) error {
+	if dto.Weekday < 0 || dto.Weekday > 6 {
+		logger.Log.Warnf("[StaffScheduleService][validateStaffShift] Día inválido: %d", dto.Weekday)
+		return errors.New("el día de la semana debe estar entre 0 (domingo) y 6 (sábado)")
+	}
+
+	start, end, err := parseClockRange(dto.StartTime, dto.EndTime)
+	if err != nil {
+		return err
+	}
+
+	// Las franjas de un mismo día no pueden superponerse
+	var sameDay []models.StaffShift
+	if err := database.DB.Where("staff_id = ? AND weekday = ? AND id <> ?", staffID, dto.Weekday, id).Find(&sameDay).Error; err != nil {
+		logger.Log.Error("[StaffScheduleService][validateStaffShift] Error al obtener franjas del día: ", err)
+		return errors.New("error al validar franja de trabajo")
+	}
+	for _, other := range sameDay {
+		otherStart, _ := parseClock(other.StartTime)
+		otherEnd, _ := parseClock(other.EndTime)
+		if start < otherEnd && otherStart < end {
+			logger.Log.Warnf("[StaffScheduleService][validateStaffShift] Franja superpuesta con ID %d", other.ID)
+			return fmt.Errorf("la franja se superpone con %s-%s", other.StartTime, other.EndTime)
+		}
+	}
+	return nil
+}
+
+func staffScheduleExceptionFromDto(staffID uint, dto dtos.StaffScheduleExceptionDto, id uint) (models.StaffScheduleException, error) {
+	date, err := helpers.ParseCustomDay(dto.Date)
+	if err != nil {
+		return models.StaffScheduleException{}, err
+	}
+
+	exception := models.StaffScheduleException{
+		StaffID: staffID,
+		Date:    dateOnly(date),
+		Reason:  dto.Reason,
+	}
+	if dto.StartTime == "" && dto.EndTime == "" {
+		return exception, nil
+	}
+
+	start, end, err := parseClockRange(dto.StartTime, dto.EndTime)
+	if err != nil {
+		return models.StaffScheduleException{}, err
+	}
+
+	var sameDay []models.StaffScheduleException
+	if err := database.DB.Where("staff_id = ? AND date = ? AND id <> ?", staffID, date.Format("2006-01-02"), id).Find(&sameDay).Error; err != nil {
+		logger.Log.Error("[StaffScheduleService][staffScheduleExceptionFromDto] Error al obtener excepciones del día: ", err)
+		return models.StaffScheduleException{}, errors.New("error al validar excepción de horario")
+	}
+	for _, other := range sameDay {
+		if other.StartTime == "" {
+			return models.StaffScheduleException{}, errors.New("el empleado ya tiene franco ese día")
+		}
+		otherStart, _ := parseClock(other.StartTime)
+		otherEnd, _ := parseClock(other.EndTime)
+		if start < otherEnd && otherStart < end {
+			return models.StaffScheduleException{}, fmt.Errorf("la franja se superpone con %s-%s", other.StartTime, other.EndTime)
+		}
+	}
+
+	exception.StartTime = dto.StartTime
+	exception.EndTime = dto.EndTime
+	return exception, nil
+}
+
+func timeOffFromDto(dto dtos.TimeOffDto) (models.TimeOff, error) {
+	if !slices.Contains(timeOffKinds, dto.Kind) {
+		logger.Log.Warnf("[StaffScheduleService][timeOffFromDto] Tipo de licencia inválido: %s", dto.Kind)
+		return models.TimeOff{}, errors.New("el tipo de licencia debe ser 'vacaciones', 'enfermedad' u 'otro'")
+	}
+
+	startDate, err := helpers.ParseCustomDay(dto.StartDate)
+	if err != nil {
+		return models.TimeOff{}, err
+	}
+	endDate := startDate
+	if dto.EndDate != "" {
+		if endDate, err = helpers.ParseCustomDay(dto.EndDate); err != nil {
+			return models.TimeOff{}, err
+		}
+	}
+	if endDate.Before(startDate) {
+		logger.Log.Warn("[StaffScheduleService][timeOffFromDto] Fecha de fin anterior a la de inicio")
+		return models.TimeOff{}, errors.New("la fecha de fin debe ser posterior o igual a la de inicio")
+	}
+
+	return models.TimeOff{
+		StaffID:   dto.StaffID,
+		Kind:      dto.Kind,
+		StartDate: dateOnly(startDate),
+		EndDate:   dateOnly(endDate),
+		Reason:    dto.Reason,
+	}, nil
+}
+
+// checkTimeOffOwner verifica que el usuario pueda aprobar licencias o sea el empleado.
+func checkTimeOffOwner(staff models.Staff, userID uint, canApprove bool) error {
+	if canApprove || (staff.UserID != nil && *staff.UserID == userID) {
+		return nil
+	}
+	logger.Log.Warnf("[StaffScheduleService][checkTimeOffOwner] Usuario ID %d sin permiso sobre licencias del empleado ID %d", userID, staff.ID)
+	return ErrTimeOffNotAllowed
+}
+
+func findTimeOff(db *gorm.DB, id uint) (models.TimeOff, error) {
+	var timeOff models.TimeOff
+	if err := db.Preload("Staff").First(&timeOff, id).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			logger.Log.Warnf("[StaffScheduleService][findTimeOff] Licencia no encontrada: ID %d", id)
+			return models.TimeOff{}, errors.New("licencia no encontrada")
+		}
+		logger.Log.Error("[StaffScheduleService][findTimeOff] Error al buscar licencia: ", err)
+		return models.TimeOff{}, errors.New("error al buscar la licencia")
+	}
+	return timeOff, nil
+}
+
+// parseClockRange valida un par de horas HH:MM con el fin posterior al inicio.
+func parseClockRange(startTime, endTime string) (time.Duration, time.Duration, error) {
+	start, err := parseClock(startTime)
+	if err != nil {
+		return 0, 0, err
+	}
+	end, err := parseClock(endTime)
+	if err != nil {
+		return 0, 0, err
+	}
+	if end <= start {
+		return 0, 0, errors.New("el horario de fin debe ser posterior al de inicio")
+	}
+	return start, end, nil
+}
+
+func clockRange(day time.Time, startTime, endTime string) (timeRange, error) {
+	start, err := parseClock(startTime)
+	if err != nil {
+		return timeRange{}, err
+	}
+	end, err := parseClock(endTime)
+	if err != nil {
+		return timeRange{}, err
+	}
+	return timeRange{Start: day.Add(start), End: day.Add(end)}, nil
+}
+
+// withinRanges indica si el tramo [start, end) cae completo dentro de alguna franja.
+func withinRanges(ranges []timeRange, start, end time.Time) bool {
+	for _, r := range ranges {
+		if !start.Before(r.Start) && !end.After(r.End) {
+			return true
+		}
+	}
+	return false
+}
+
+func toStaffScheduleExceptionDto(exception models.StaffScheduleException, conflicts []dtos.StaffScheduleConflictDto) dtos.GetStaffScheduleExceptionDto {
+	return dtos.GetStaffScheduleExceptionDto{
+		ID:        exception.ID,
+		StaffID:   exception.StaffID,
+		Date:      exception.Date.Format("02/01/2006"),
+		StartTime: exception.StartTime,
+		EndTime:   exception.EndTime,
+		Reason:    exception.Reason,
+		Conflicts: conflicts,
+	}
+}
+
+func toTimeOffDto(timeOff models.TimeOff, conflicts []dtos.StaffScheduleConflictDto) dtos.GetTimeOffDto {
+	reviewedAt := ""
+	if timeOff.ReviewedAt != nil {
+		reviewedAt = timeOff.ReviewedAt.Format("02/01/2006 15:04")
+	}
+	return dtos.GetTimeOffDto{
+		ID:         timeOff.ID,
+		StaffID:    timeOff.StaffID,
+		StaffName:  staffFullName(&timeOff.Staff),
+		Kind:       timeOff.Kind,
+		StartDate:  timeOff.StartDate.Format("02/01/2006"),
+		EndDate:    timeOff.EndDate.Format("02/01/2006"),
+		Reason:     timeOff.Reason,
+		Status:     timeOff.Status,
+		ReviewedAt: reviewedAt,
+		ReviewNote: timeOff.ReviewNote,
+		Conflicts:  conflicts,
+	}
+}
+
+func toStaffScheduleConflictDto(appointment models.Appointment) dtos.StaffScheduleConflictDto {
+	return dtos.StaffScheduleConflictDto{
+		AppointmentID:   appointment.ID,
+		AppointmentDate: appointment.AppointmentDate.Format("02/01/2006 15:04"),
+		ClientName:      fmt.Sprintf("%s %s", appointment.Client.Name, appointment.Client.LastName),
+		Status:          appointment.Status,
+	}
+}
@@ -0,0 +1,212 @@
+package services
+
+import (
+	"errors"
+	"peluqueria/database"
+	"peluqueria/internal/dtos"
+	"peluqueria/internal/models"
+	"peluqueria/logger"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+var ErrStaffNotFound = errors.New("empleado no encontrado")
+
+func CreateStaff(staffDto dtos.StaffDto) error {
+	logger.Log.Infof("[StaffService][CreateStaff] Intentando crear empleado: %s %s", staffDto.Name, staffDto.LastName)
+
+	if staffDto.Name == "" {
+		logger.Log.Warn("[StaffService][CreateStaff] Nombre requerido")
+		return errors.New("el nombre del empleado es obligatorio")
+	}
+
+	if staffDto.UserID != nil {
+		if err := validateStaffUser(*staffDto.UserID, 0); err != nil {
+			return err
+		}
+	}
+
+	staff := models.Staff{
+		Name:     staffDto.Name,
+		LastName: staffDto.LastName,
+		Phone:    staffDto.Phone,
+		UserID:   staffDto.UserID,
+		Active:   true,
+	}
+	if staffDto.Active != nil {
+		staff.Active = *staffDto.Active
+	}
+
+	if err := database.DB.Create(&staff).Error; err != nil {
+		logger.Log.Error("[StaffService][CreateStaff] Error al crear empleado: ", err)
+		return errors.New("error al crear empleado")
+	}
+
+	logger.Log.Infof("[StaffService][CreateStaff] Empleado creado con éxito: ID %d", staff.ID)
+	return nil
+}
+
+func GetAllStaff() ([]dtos.GetStaffDto, error) {
+	logger.Log.Info("[StaffService][GetAllStaff] Obteniendo empleados")
+
+	var staff []models.Staff
+	if err := database.DB.Preload("User").Find(&staff).Error; err != nil {
+		logger.Log.Error("[StaffService][GetAllStaff] Error al obtener empleados: ", err)
+		return nil, errors.New("error al obtener empleados")
+	}
+
+	var staffDtos []dtos.GetStaffDto
+	for _, member := range staff {
+		staffDtos = append(staffDtos, toStaffDto(member))
+	}
+
+	logger.Log.Infof("[StaffService][GetAllStaff] Empleados obtenidos: %d", len(staffDtos))
+	return staffDtos, nil
+}
+
+func GetStaffByID(id uint) (dtos.GetStaffDto, error) {
+	logger.Log.Infof("[StaffService][GetStaffByID] Obteniendo empleado con ID: %d", id)
+
+	var staff models.Staff
+	if err := database.DB.Preload("User").First(&staff, id).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			logger.Log.Warnf("[StaffService][GetStaffByID] Empleado no encontrado: ID %d", id)
+			return dtos.GetStaffDto{}, errors.New("empleado no encontrado")
+		}
+		logger.Log.Error("[StaffService][GetStaffByID] Error al obtener empleado: ", err)
+		return dtos.GetStaffDto{}, errors.New("error al obtener empleado")
+	}
+
+	logger.Log.Infof("[StaffService][GetStaffByID] Empleado obtenido con éxito: ID %d", id)
+	return toStaffDto(staff), nil
+}
+
+func UpdateStaff(id uint, staffDto dtos.StaffDto) error {
+	logger.Log.Infof("[StaffService][UpdateStaff] Actualizando empleado con ID: %d", id)
+
+	var staff models.Staff
+	if err := database.DB.First(&staff, id).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			logger.Log.Warnf("[StaffService][UpdateStaff] Empleado no encontrado: ID %d", id)
+			return errors.New("el empleado no existe")
+		}
+		logger.Log.Error("[StaffService][UpdateStaff] Error al buscar empleado: ", err)
+		return err
+	}
+
+	if staffDto.Name != "" {
+		staff.Name = staffDto.Name
+	}
+	if staffDto.LastName != "" {
+		staff.LastName = staffDto.LastName
+	}
+	if staffDto.Phone != "" {
+		staff.Phone = staffDto.Phone
+	}
+	if staffDto.UserID != nil {
+		if err := validateStaffUser(*staffDto.UserID, id); err != nil {
+			return err
+		}
+		staff.UserID = staffDto.UserID
+	}
+	if staffDto.Active != nil {
+		staff.Active = *staffDto.Active
+	}
+
+	if err := database.DB.Save(&staff).Error; err != nil {
+		logger.Log.Error("[StaffService][UpdateStaff] Error al actualizar empleado: ", err)
+		return errors.New("error al actualizar empleado")
+	}
+
+	logger.Log.Infof("[StaffService][UpdateStaff] Empleado actualizado con éxito: ID %d", id)
+	return nil
+}
+
+func DeleteStaff(id uint) error {
+	logger.Log.Infof("[StaffService][DeleteStaff] Eliminando empleado con ID: %d", id)
+
+	err := database.DB.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).Select("id").First(&models.Staff{}, id).Error; err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				logger.Log.Warnf("[StaffService][DeleteStaff] Empleado no encontrado: ID %d", id)
+				return ErrStaffNotFound
+			}
+			logger.Log.Error("[StaffService][DeleteStaff] Error al buscar empleado: ", err)
+			return errors.New("error al buscar empleado")
+		}
+
+		// Verificar si el empleado tiene turnos asignados, como estilista del turno o de alguno de sus servicios
+		lines := tx.Model(&models.AppointmentService{}).Select("appointment_id").Where("staff_id = ?", id)
+		var count int64
+		if err := tx.Model(&models.Appointment{}).Where("staff_id = ? OR id IN (?)", id, lines).Count(&count).Error; err != nil {
+			logger.Log.Error("[StaffService][DeleteStaff] Error al verificar turnos del empleado: ", err)
+			return errors.New("error al verificar turnos del empleado")
+		}
+		if count > 0 {
+			logger.Log.Warnf("[StaffService][DeleteStaff] El empleado tiene %d turnos asignados", count)
+			return errors.New("no se puede eliminar un empleado con turnos asignados, desactívelo en su lugar")
+		}
+
+		if err := tx.Delete(&models.Staff{}, id).Error; err != nil {
+			logger.Log.Error("[StaffService][DeleteStaff] Error al eliminar empleado: ", err)
+			return errors.New("error al eliminar empleado")
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	logger.Log.Infof("[StaffService][DeleteStaff] Empleado eliminado con éxito: ID %d", id)
+	return nil
+}
+
+// findActiveStaff valida que el empleado exista y esté activo.
+func findActiveStaff(tx *gorm.DB, id uint) (models.Staff, error) {
+	var staff models.Staff
+	if err := tx.First(&staff, id).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			logger.Log.Warnf("[StaffService][findActiveStaff] Empleado no encontrado: ID %d", id)
+			return models.Staff{}, errors.New("empleado no encontrado")
+		}
+		logger.Log.Error("[StaffService][findActiveStaff] Error al buscar empleado: ", err)
+		return models.Staff{}, errors.New("error al buscar empleado")
+	}
+	if !staff.Active {
+		logger.Log.Warnf("[StaffService][findActiveStaff] Empleado inactivo: ID %d", id)
+		return models.Staff{}, errors.New("el empleado no está activo")
+	}
+	return staff, nil
+}
+
+// validateStaffUser verifica que el usuario exista y no esté vinculado a otro empleado.
+func validateStaffUser(userID uint, staffID uint) error {
+	if err := database.DB.Select("id").First(&models.User{}, userID).Error; err != nil {
+		logger.Log.Warnf("[StaffService][validateStaffUser] Usuario no encontrado: ID %d", userID)
+		return errors.New("el usuario asociado no existe")
+	}
+
+	var existing models.Staff
+	if err := database.DB.Where("user_id = ? AND id <> ?", userID, staffID).First(&existing).Error; err == nil {
+		logger.Log.Warnf("[StaffService][validateStaffUser] Usuario ya vinculado al empleado ID %d", existing.ID)
+		return errors.New("el usuario ya está vinculado a otro empleado")
+	}
+	return nil
+}
+
+func toStaffDto(staff models.Staff) dtos.GetStaffDto {
+	username := ""
+	if staff.User != nil {
+		username = staff.User.Username
+	}
+	return dtos.GetStaffDto{
+		ID:       staff.ID,
+		Name:     staff.Name,
+		LastName: staff.LastName,
+		Phone:    staff.Phone,
+		UserID:   staff.UserID,
+		Username: username,
+		Active:   staff.Active,
+	}
+}
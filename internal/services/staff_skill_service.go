@@ -0,0 +1,180 @@
+package services
+
+import (
+	"errors"
+	"fmt"
+	"peluqueria/database"
+	"peluqueria/internal/dtos"
+	"peluqueria/internal/models"
+	"peluqueria/logger"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// ErrServiceNotOffered indica que el estilista asignado no realiza alguno de los servicios.
+var ErrServiceNotOffered = errors.New("el estilista no realiza el servicio")
+
+// serviceTerms es el precio y el tiempo activo de un servicio para un estilista.
+type serviceTerms struct {
+	Price         float64
+	ListPrice     float64 // Precio antes del descuento del combo
+	ActiveMinutes *uint   // nil usa el tiempo del servicio
+	BundleID      *uint   // Combo del que sale el servicio
+}
+
+func GetStaffSkills(staffID uint) ([]dtos.GetStaffSkillDto, error) {
+	logger.Log.Infof("[StaffSkillService][GetStaffSkills] Obteniendo servicios del empleado ID %d", staffID)
+
+	var skills []models.StaffService
+	if err := database.DB.Preload("Service").Where("staff_id = ?", staffID).Find(&skills).Error; err != nil {
+		logger.Log.Error("[StaffSkillService][GetStaffSkills] Error al obtener servicios del empleado: ", err)
+		return nil, errors.New("error al obtener servicios del empleado")
+	}
+
+	skillDtos := []dtos.GetStaffSkillDto{}
+	for _, skill := range skills {
+		skillDtos = append(skillDtos, toStaffSkillDto(skill))
+	}
+	return skillDtos, nil
+}
+
+// SetStaffSkill habilita al empleado para un servicio o actualiza su precio y duración propios.
+func SetStaffSkill(staffID, serviceID uint, dto dtos.StaffSkillDto) error {
+	logger.Log.Infof("[StaffSkillService][SetStaffSkill] Asignando servicio ID %d al empleado ID %d", serviceID, staffID)
+
+	if err := database.DB.Select("id").First(&models.Staff{}, staffID).Error; err != nil {
+		logger.Log.Warnf("[StaffSkillService][SetStaffSkill] Empleado no encontrado: ID %d", staffID)
+		return errors.New("empleado no encontrado")
+	}
+	if err := database.DB.Select("id").First(&models.Service{}, serviceID).Error; err != nil {
+		logger.Log.Warnf("[StaffSkillService][SetStaffSkill] Servicio no encontrado: ID %d", serviceID)
+		return errors.New("servicio no encontrado")
+	}
+	if dto.Price != nil && *dto.Price <= 0 {
+		return errors.New("el precio del estilista debe ser mayor a 0")
+	}
+	if dto.EstimatedTimeMinutes != nil && *dto.EstimatedTimeMinutes == 0 {
+		return errors.New("la duración del estilista debe ser mayor a 0")
+	}
+
+	skill := models.StaffService{
+		StaffID:              staffID,
+		ServiceID:            serviceID,
+		Price:                dto.Price,
+		EstimatedTimeMinutes: dto.EstimatedTimeMinutes,
+	}
+	if err := database.DB.Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "staff_id"}, {Name: "service_id"}},
+		DoUpdates: clause.AssignmentColumns([]string{"price", "estimated_time_minutes", "updated_at"}),
+	}).Create(&skill).Error; err != nil {
+		logger.Log.Error("[StaffSkillService][SetStaffSkill] Error al asignar servicio: ", err)
+		return errors.New("error al asignar servicio al empleado")
+	}
+
+	logger.Log.Infof("[StaffSkillService][SetStaffSkill] Servicio ID %d asignado al empleado ID %d", serviceID, staffID)
+	return nil
+}
+
+func DeleteStaffSkill(staffID, serviceID uint) error {
+	logger.Log.Infof("[StaffSkillService][DeleteStaffSkill] Quitando servicio ID %d al empleado ID %d", serviceID, staffID)
+
+	result := database.DB.Where("staff_id = ? AND service_id = ?", staffID, serviceID).Delete(&models.StaffService{})
+	if result.Error != nil {
+		logger.Log.Error("[StaffSkillService][DeleteStaffSkill] Error al quitar servicio: ", result.Error)
+		return errors.New("error al quitar servicio al empleado")
+	}
+	if result.RowsAffected == 0 {
+		return errors.New("el empleado no tiene asignado ese servicio")
+	}
+	return nil
+}
+
+// staffServiceTerms resuelve precio y tiempo activo de cada servicio para el estilista y
+// valida que los realice. Sin estilista, o si el estilista no tiene servicios cargados, se
+// usan los valores de cada servicio.
+func staffServiceTerms(db *gorm.DB, staffID *uint, services []models.Service) ([]serviceTerms, error) {
+	terms := make([]serviceTerms, len(services))
+	for i, service := range services {
+		terms[i].Price = service.Price
+	}
+	if staffID == nil {
+		return terms, nil
+	}
+
+	var skills []models.StaffService
+	if err := db.Where("staff_id = ?", *staffID).Find(&skills).Error; err != nil {
+		logger.Log.Error("[StaffSkillService][staffServiceTerms] Error al obtener servicios del estilista: ", err)
+		return nil, errors.New("error al obtener servicios del estilista")
+	}
+	if len(skills) == 0 {
+		return terms, nil
+	}
+
+	skillsByService := make(map[uint]models.StaffService)
+	for _, skill := range skills {
+		skillsByService[skill.ServiceID] = skill
+	}
+	for i, service := range services {
+		skill, ok := skillsByService[service.ID]
+		if !ok {
+			logger.Log.Warnf("[StaffSkillService][staffServiceTerms] Estilista ID %d no realiza el servicio ID %d", *staffID, service.ID)
+			return nil, fmt.Errorf("%w '%s'", ErrServiceNotOffered, service.Name)
+		}
+		if skill.Price != nil {
+			terms[i].Price = *skill.Price
+		}
+		if skill.EstimatedTimeMinutes != nil {
+			minutes := *skill.EstimatedTimeMinutes
+			terms[i].ActiveMinutes = &minutes
+		}
+	}
+	return terms, nil
+}
+
+// withStaffTimes devuelve copias de los servicios con el tiempo activo del estilista.
+func withStaffTimes(services []models.Service, terms []serviceTerms) []models.Service {
+	adjusted := make([]models.Service, len(services))
+	for i, service := range services {
+		if terms[i].ActiveMinutes != nil {
+			service.EstimatedTimeMinutes = *terms[i].ActiveMinutes
+		}
+		adjusted[i] = service
+	}
+	return adjusted
+}
+
+// lineActiveMinutes es el tiempo activo registrado en una línea de servicio del turno.
+func lineActiveMinutes(appService models.AppointmentService) uint {
+	if appService.ActiveMinutes != nil {
+		return *appService.ActiveMinutes
+	}
+	return appService.Service.EstimatedTimeMinutes
+}
+
+// lineListPrice es el precio de lista de una línea de servicio. Las líneas anteriores a los
+// combos no lo registraban y se cobraron a precio de lista.
+func lineListPrice(appService models.AppointmentService) float64 {
+	if appService.ListPrice == 0 {
+		return appService.Price
+	}
+	return appService.ListPrice
+}
+
+func toStaffSkillDto(skill models.StaffService) dtos.GetStaffSkillDto {
+	skillDto := dtos.GetStaffSkillDto{
+		ServiceID:            skill.ServiceID,
+		ServiceName:          skill.Service.Name,
+		Price:                skill.Service.Price,
+		EstimatedTimeMinutes: skill.Service.EstimatedTimeMinutes,
+		PriceOverride:        skill.Price,
+		DurationOverride:     skill.EstimatedTimeMinutes,
+	}
+	if skill.Price != nil {
+		skillDto.Price = *skill.Price
+	}
+	if skill.EstimatedTimeMinutes != nil {
+		skillDto.EstimatedTimeMinutes = *skill.EstimatedTimeMinutes
+	}
+	return skillDto
+}
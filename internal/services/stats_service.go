@@ -2,11 +2,15 @@ package services
 
 import (
 	"errors"
+	"math"
 	"peluqueria/database"
 	"peluqueria/internal/dtos"
 	"peluqueria/internal/helpers"
 	"peluqueria/internal/models"
 	"peluqueria/logger"
+	"time"
+
+	"gorm.io/gorm"
 )
 
 func GetMonthlyStatistics(month string) (dtos.MonthlyStatisticsDto, error) {
@@ -21,7 +25,7 @@ func GetMonthlyStatistics(month string) (dtos.MonthlyStatisticsDto, error) {
 
 	var statistics dtos.MonthlyStatisticsDto
 
-	// Calcular ingresos
+	// Calcular ingresos: lo cobrado por cada servicio, con combos y promociones descontados
 	var income float64
 	if err := database.DB.
 		Model(&models.AppointmentService{}).
@@ -34,6 +38,19 @@ func GetMonthlyStatistics(month string) (dtos.MonthlyStatisticsDto, error) {
 	}
 	statistics.Incomes = income
 
+	// Calcular descuentos de promociones, ya restados de los ingresos
+	var discounts float64
+	if err := database.DB.
+		Model(&models.AppointmentService{}).
+		Select("COALESCE(SUM(discount), 0)").
+		Joins("JOIN appointments ON appointments.id = appointment_services.appointment_id").
+		Where("appointments.status = 'finalizado' AND appointments.appointment_date BETWEEN ? AND ?", startDate, endDate).
+		Scan(&discounts).Error; err != nil {
+		logger.Log.Error("[StatisticsService][GetMonthlyStatistics] Error al calcular descuentos: ", err)
+		return dtos.MonthlyStatisticsDto{}, errors.New("error al calcular descuentos")
+	}
+	statistics.Discounts = roundCents(discounts)
+
 	// Calcular egresos (compras de stock)
 	var expenses float64
 	if err := database.DB.
@@ -46,39 +63,12 @@ func GetMonthlyStatistics(month string) (dtos.MonthlyStatisticsDto, error) {
 	}
 	statistics.Expenses = expenses
 
-	// Agregar las variables para almacenar los totales de cada método de pago
-	var debitCount, cashCount, transferCount int64
-
-	// Contar los turnos pagados con débito
-	if err := database.DB.Model(&models.Appointment{}).
-		Where("payment_method = ? AND status = ? AND appointment_date BETWEEN ? AND ?", "debito", "finalizado", startDate, endDate).
-		Count(&debitCount).Error; err != nil {
-		logger.Log.Error("[StatisticsService][GetMonthlyStatistics] Error al contar turnos con débito: ", err)
-		return dtos.MonthlyStatisticsDto{}, errors.New("error al contar turnos con débito")
-	}
-
-	// Contar los turnos pagados en efectivo
-	if err := database.DB.Model(&models.Appointment{}).
-		Where("payment_method = ? AND status = ? AND appointment_date BETWEEN ? AND ?", "efectivo", "finalizado", startDate, endDate).
-		Count(&cashCount).Error; err != nil {
-		logger.Log.Error("[StatisticsService][GetMonthlyStatistics] Error al contar turnos con efectivo: ", err)
-		return dtos.MonthlyStatisticsDto{}, errors.New("error al contar turnos con efectivo")
-	}
-
-	// Contar los turnos pagados por transferencia
-	if err := database.DB.Model(&models.Appointment{}).
-		Where("payment_method = ? AND status = ? AND appointment_date BETWEEN ? AND ?", "transferencia", "finalizado", startDate, endDate).
-		Count(&transferCount).Error; err != nil {
-		logger.Log.Error("[StatisticsService][GetMonthlyStatistics] Error al contar turnos con transferencia: ", err)
-		return dtos.MonthlyStatisticsDto{}, errors.New("error al contar turnos con transferencia")
-	}
-
-	// Asignar los resultados al DTO
-	statistics.PaymentMethodBreakdown = dtos.PaymentMethodBreakdownDto{
-		Debit:    debitCount,
-		Cash:     cashCount,
-		Transfer: transferCount,
+	// Sumar lo cobrado por cada medio de pago
+	paymentMethods, err := paymentMethodStatistics(startDate, endDate)
+	if err != nil {
+		return dtos.MonthlyStatisticsDto{}, err
 	}
+	statistics.PaymentMethods = paymentMethods
 
 	// Contar cantidad de turnos realizados
 	var appointmentsCount int64
@@ -106,3 +96,198 @@ func GetMonthlyStatistics(month string) (dtos.MonthlyStatisticsDto, error) {
 	logger.Log.Infof("[StatisticsService][GetMonthlyStatistics] Estadísticas generadas para el mes: %s", month)
 	return statistics, nil
 }
+
+// paymentMethodStatistics suma los pagos de los turnos finalizados del período por medio de
+// pago. Los medios activos sin pagos se informan en cero.
+func paymentMethodStatistics(startDate, endDate time.Time) ([]dtos.PaymentMethodStatDto, error) {
+	var rows []struct {
+		MethodID uint
+		Count    int64
+		Amount   float64
+	}
+	if err := database.DB.
+		Model(&models.Payment{}).
+		Select("payments.method_id, COUNT(*) AS count, COALESCE(SUM(payments.amount), 0) AS amount").
+		Joins("JOIN appointments ON appointments.id = payments.appointment_id").
+		Where("appointments.status = ? AND appointments.appointment_date BETWEEN ? AND ?", models.AppointmentStatusFinished, startDate, endDate).
+		Group("payments.method_id").
+		Scan(&rows).Error; err != nil {
+		logger.Log.Error("[StatisticsService][paymentMethodStatistics] Error al sumar pagos: ", err)
+		return nil, errors.New("error al sumar pagos por medio de pago")
+	}
+	totals := make(map[uint]dtos.PaymentMethodStatDto)
+	for _, row := range rows {
+		totals[row.MethodID] = dtos.PaymentMethodStatDto{Count: row.Count, Amount: roundCents(row.Amount)}
+	}
+
+	var methods []models.PaymentMethod
+	if err := database.DB.Unscoped().Order("name").Find(&methods).Error; err != nil {
+		logger.Log.Error("[StatisticsService][paymentMethodStatistics] Error al obtener medios de pago: ", err)
+		return nil, errors.New("error al obtener medios de pago")
+	}
+
+	stats := []dtos.PaymentMethodStatDto{}
+	for _, method := range methods {
+		stat, used := totals[method.ID]
+		if !used && (!method.Active || method.DeletedAt.Valid) {
+			continue
+		}
+		stat.Method = method.Code
+		stat.Name = method.Name
+		stats = append(stats, stat)
+	}
+	return stats, nil
+}
+
+// timeStat acumula minutos estimados y reales de un servicio o de un estilista.
+type timeStat struct {
+	Name      string
+	Count     int64
+	Estimated float64
+	Actual    float64
+	Delay     float64 // Atraso al iniciar, sumado por turno
+	Wait      float64 // Espera del cliente, sumada por turno
+	Turns     int64
+}
+
+// GetTimeStatistics compara la duración real de los turnos finalizados del mes con la
+// estimada, por servicio y por estilista. En los turnos con varios servicios el tiempo
+// real se reparte en proporción al estimado de cada uno. La limpieza no se cuenta, porque
+// el turno se finaliza cuando el cliente se va.
+func GetTimeStatistics(month string) (dtos.TimeStatisticsDto, error) {
+	logger.Log.Infof("[StatisticsService][GetTimeStatistics] Generando estadísticas de tiempos para el mes: %s", month)
+
+	startDate, endDate, err := helpers.ParseMonthFilter(month)
+	if err != nil {
+		logger.Log.Warn("[StatisticsService][GetTimeStatistics] Error al parsear mes: ", err)
+		return dtos.TimeStatisticsDto{}, err
+	}
+
+	var appointments []models.Appointment
+	if err := database.DB.
+		Preload("Staff").
+		Preload("AppointmentServices.Service", func(db *gorm.DB) *gorm.DB { return db.Unscoped() }).
+		Preload("AppointmentServices.Staff").
+		Where("status = ? AND appointment_date BETWEEN ? AND ?", models.AppointmentStatusFinished, startDate, endDate).
+		Where("started_at IS NOT NULL AND finished_at IS NOT NULL").
+		Find(&appointments).Error; err != nil {
+		logger.Log.Error("[StatisticsService][GetTimeStatistics] Error al obtener turnos: ", err)
+		return dtos.TimeStatisticsDto{}, errors.New("error al obtener turnos del mes")
+	}
+
+	byService := make(map[uint]*timeStat)
+	byStaff := make(map[uint]*timeStat)
+	var serviceOrder, staffOrder []uint
+	statFor := func(stats map[uint]*timeStat, order *[]uint, id uint, name string) *timeStat {
+		stat, ok := stats[id]
+		if !ok {
+			stat = &timeStat{Name: name}
+			stats[id] = stat
+			*order = append(*order, id)
+		}
+		return stat
+	}
+
+	statistics := dtos.TimeStatisticsDto{
+		Month:     month,
+		ByService: []dtos.ServiceTimeStatDto{},
+		ByStaff:   []dtos.StaffTimeStatDto{},
+	}
+	for _, appointment := range appointments {
+		estimates := make([]float64, len(appointment.AppointmentServices))
+		var totalEstimated float64
+		for i, appService := range appointment.AppointmentServices {
+			estimates[i] = float64(lineActiveMinutes(appService) + appService.Service.ProcessingTimeMinutes)
+			totalEstimated += estimates[i]
+		}
+		if totalEstimated == 0 {
+			continue
+		}
+		// Con duración manual, lo estimado es esa duración repartida entre los servicios
+		if appointment.DurationOverride != nil && *appointment.DurationOverride > 0 {
+			scale := float64(*appointment.DurationOverride) / totalEstimated
+			for i := range estimates {
+				estimates[i] *= scale
+			}
+			totalEstimated = float64(*appointment.DurationOverride)
+		}
+		actual := appointment.FinishedAt.Sub(*appointment.StartedAt).Minutes()
+		statistics.Appointments++
+
+		for i, appService := range appointment.AppointmentServices {
+			lineActual := actual * estimates[i] / totalEstimated
+
+			stat := statFor(byService, &serviceOrder, appService.ServiceID, appService.Service.Name)
+			stat.Count++
+			stat.Estimated += estimates[i]
+			stat.Actual += lineActual
+
+			staff := appService.Staff
+			if staff == nil {
+				staff = appointment.Staff
+			}
+			if staff == nil {
+				continue
+			}
+			stat = statFor(byStaff, &staffOrder, staff.ID, staffFullName(staff))
+			stat.Count++
+			stat.Estimated += estimates[i]
+			stat.Actual += lineActual
+		}
+
+		if appointment.Staff != nil {
+			stat := statFor(byStaff, &staffOrder, appointment.Staff.ID, staffFullName(appointment.Staff))
+			stat.Turns++
+			stat.Delay += appointment.StartedAt.Sub(appointment.AppointmentDate).Minutes()
+			if appointment.CheckedInAt != nil {
+				stat.Wait += appointment.StartedAt.Sub(*appointment.CheckedInAt).Minutes()
+			}
+		}
+	}
+
+	for _, id := range serviceOrder {
+		stat := byService[id]
+		statistics.ByService = append(statistics.ByService, dtos.ServiceTimeStatDto{
+			ServiceID:           id,
+			ServiceName:         stat.Name,
+			Count:               stat.Count,
+			AvgEstimatedMinutes: roundOneDecimal(stat.Estimated / float64(stat.Count)),
+			AvgActualMinutes:    roundOneDecimal(stat.Actual / float64(stat.Count)),
+			AvgDeviationMinutes: roundOneDecimal((stat.Actual - stat.Estimated) / float64(stat.Count)),
+			AvgDeviationPercent: deviationPercent(*stat),
+		})
+	}
+	for _, id := range staffOrder {
+		stat := byStaff[id]
+		staffStat := dtos.StaffTimeStatDto{
+			StaffID:             id,
+			StaffName:           stat.Name,
+			Count:               stat.Count,
+			AvgDeviationPercent: deviationPercent(*stat),
+		}
+		if stat.Count > 0 {
+			staffStat.AvgEstimatedMinutes = roundOneDecimal(stat.Estimated / float64(stat.Count))
+			staffStat.AvgActualMinutes = roundOneDecimal(stat.Actual / float64(stat.Count))
+			staffStat.AvgDeviationMinutes = roundOneDecimal((stat.Actual - stat.Estimated) / float64(stat.Count))
+		}
+		if stat.Turns > 0 {
+			staffStat.AvgStartDelayMinutes = roundOneDecimal(stat.Delay / float64(stat.Turns))
+			staffStat.AvgClientWaitMinutes = roundOneDecimal(stat.Wait / float64(stat.Turns))
+		}
+		statistics.ByStaff = append(statistics.ByStaff, staffStat)
+	}
+
+	logger.Log.Infof("[StatisticsService][GetTimeStatistics] Estadísticas de tiempos generadas sobre %d turnos", statistics.Appointments)
+	return statistics, nil
+}
+
+func deviationPercent(stat timeStat) float64 {
+	if stat.Estimated == 0 {
+		return 0
+	}
+	return roundOneDecimal((stat.Actual - stat.Estimated) / stat.Estimated * 100)
+}
+
+func roundOneDecimal(value float64) float64 {
+	return math.Round(value*10) / 10
+}
@@ -0,0 +1,182 @@
+package services
+
+import (
+	"errors"
+	"fmt"
+	"peluqueria/database"
+	"peluqueria/internal/dtos"
+	"peluqueria/internal/helpers"
+	"peluqueria/internal/models"
+	"peluqueria/logger"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// buildTips arma las propinas del turno. Sin estilista indicado la propina es para el
+// estilista del turno.
+func buildTips(tx *gorm.DB, appointment models.Appointment, tipDtos []dtos.TipDto, userID uint) ([]models.Tip, error) {
+	now := time.Now()
+	var tips []models.Tip
+	for _, tipDto := range tipDtos {
+		amount := roundCents(tipDto.Amount)
+		if amount <= 0 {
+			return nil, fmt.Errorf("%w: el monto de cada propina debe ser mayor a 0", ErrInvalidPayment)
+		}
+
+		staffID := appointment.StaffID
+		if tipDto.StaffID != nil {
+			staffID = tipDto.StaffID
+		}
+		if staffID == nil {
+			return nil, fmt.Errorf("%w: debe indicar el estilista de la propina", ErrInvalidPayment)
+		}
+		if err := tx.Select("id").First(&models.Staff{}, *staffID).Error; err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return nil, fmt.Errorf("%w: el estilista ID %d de la propina no existe", ErrInvalidPayment, *staffID)
+			}
+			logger.Log.Error("[TipService][buildTips] Error al buscar estilista: ", err)
+			return nil, errors.New("error al buscar estilista")
+		}
+
+		method, err := findActivePaymentMethod(tx, tipDto.Method)
+		if err != nil {
+			return nil, err
+		}
+
+		tips = append(tips, models.Tip{
+			AppointmentID: appointment.ID,
+			StaffID:       *staffID,
+			MethodID:      method.ID,
+			Method:        method,
+			Amount:        amount,
+			PaidAt:        now,
+			UserID:        optionalUserID(userID),
+		})
+	}
+	return tips, nil
+}
+
+// appointmentTips devuelve las propinas de los turnos, indexadas por turno.
+func appointmentTips(db *gorm.DB, appointmentIDs []uint) (map[uint][]dtos.GetTipDto, error) {
+	var tips []models.Tip
+	if err := db.
+		Preload("Staff", func(db *gorm.DB) *gorm.DB { return db.Unscoped() }).
+		Preload("Method", func(db *gorm.DB) *gorm.DB { return db.Unscoped() }).
+		Where("appointment_id IN ?", appointmentIDs).
+		Order("paid_at, id").
+		Find(&tips).Error; err != nil {
+		logger.Log.Error("[TipService][appointmentTips] Error al obtener propinas: ", err)
+		return nil, errors.New("error al obtener propinas del turno")
+	}
+
+	byAppointment := make(map[uint][]dtos.GetTipDto)
+	for _, tip := range tips {
+		byAppointment[tip.AppointmentID] = append(byAppointment[tip.AppointmentID], dtos.GetTipDto{
+			ID:         tip.ID,
+			StaffID:    tip.StaffID,
+			StaffName:  staffFullName(&tip.Staff),
+			Method:     tip.Method.Code,
+			MethodName: tip.Method.Name,
+			Amount:     tip.Amount,
+			PaidAt:     tip.PaidAt.Format("02/01/2006 15:04"),
+		})
+	}
+	return byAppointment, nil
+}
+
+// GetTipReport suma las propinas cobradas entre dos días, ambos incluidos, por estilista y
+// por medio de pago, para repartirlas al cierre de la semana.
+func GetTipReport(from, to string) (dtos.TipReportDto, error) {
+	logger.Log.Infof("[TipService][GetTipReport] Generando reporte de propinas del %s al %s", from, to)
+
+	fromDate, err := helpers.ParseCustomDay(from)
+	if err != nil {
+		return dtos.TipReportDto{}, err
+	}
+	toDate, err := helpers.ParseCustomDay(to)
+	if err != nil {
+		return dtos.TipReportDto{}, err
+	}
+	if toDate.Before(fromDate) {
+		return dtos.TipReportDto{}, errors.New("la fecha de fin no puede ser anterior a la de inicio")
+	}
+
+	var rows []struct {
+		StaffID  uint
+		MethodID uint
+		Count    int64
+		Amount   float64
+	}
+	if err := database.DB.
+		Model(&models.Tip{}).
+		Select("tips.staff_id, tips.method_id, COUNT(*) AS count, COALESCE(SUM(tips.amount), 0) AS amount").
+		Joins("JOIN appointments ON appointments.id = tips.appointment_id AND appointments.deleted_at IS NULL").
+		Where("tips.paid_at >= ? AND tips.paid_at < ?", fromDate, toDate.AddDate(0, 0, 1)).
+		Group("tips.staff_id, tips.method_id").
+		Order("tips.staff_id, tips.method_id").
+		Scan(&rows).Error; err != nil {
+		logger.Log.Error("[TipService][GetTipReport] Error al sumar propinas: ", err)
+		return dtos.TipReportDto{}, errors.New("error al sumar propinas")
+	}
+
+	report := dtos.TipReportDto{
+		From:    fromDate.Format("02/01/2006"),
+		To:      toDate.Format("02/01/2006"),
+		ByStaff: []dtos.StaffTipDto{},
+	}
+	if len(rows) == 0 {
+		return report, nil
+	}
+
+	var staffIDs, methodIDs []uint
+	for _, row := range rows {
+		staffIDs = append(staffIDs, row.StaffID)
+		methodIDs = append(methodIDs, row.MethodID)
+	}
+	var staff []models.Staff
+	if err := database.DB.Unscoped().Where("id IN ?", uniqueIDs(staffIDs)).Find(&staff).Error; err != nil {
+		logger.Log.Error("[TipService][GetTipReport] Error al obtener estilistas: ", err)
+		return dtos.TipReportDto{}, errors.New("error al obtener estilistas")
+	}
+	staffByID := make(map[uint]*models.Staff)
+	for i := range staff {
+		staffByID[staff[i].ID] = &staff[i]
+	}
+	var methods []models.PaymentMethod
+	if err := database.DB.Unscoped().Where("id IN ?", uniqueIDs(methodIDs)).Find(&methods).Error; err != nil {
+		logger.Log.Error("[TipService][GetTipReport] Error al obtener medios de pago: ", err)
+		return dtos.TipReportDto{}, errors.New("error al obtener medios de pago")
+	}
+	methodsByID := make(map[uint]models.PaymentMethod)
+	for _, method := range methods {
+		methodsByID[method.ID] = method
+	}
+
+	// Las filas vienen ordenadas por estilista
+	var total float64
+	for _, row := range rows {
+		last := len(report.ByStaff) - 1
+		if last < 0 || report.ByStaff[last].StaffID != row.StaffID {
+			report.ByStaff = append(report.ByStaff, dtos.StaffTipDto{
+				StaffID:   row.StaffID,
+				StaffName: staffFullName(staffByID[row.StaffID]),
+				ByMethod:  []dtos.TipMethodDto{},
+			})
+			last++
+		}
+		staffTips := &report.ByStaff[last]
+		staffTips.Count += row.Count
+		staffTips.Amount = roundCents(staffTips.Amount + row.Amount)
+		staffTips.ByMethod = append(staffTips.ByMethod, dtos.TipMethodDto{
+			Method: methodsByID[row.MethodID].Code,
+			Name:   methodsByID[row.MethodID].Name,
+			Amount: roundCents(row.Amount),
+		})
+		total += row.Amount
+	}
+	report.Total = roundCents(total)
+
+	logger.Log.Infof("[TipService][GetTipReport] Reporte generado para %d estilistas", len(report.ByStaff))
+	return report, nil
+}
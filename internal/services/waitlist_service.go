@@ -0,0 +1,444 @@
+package services
+
+import (
+	"errors"
+	"fmt"
+	"peluqueria/database"
+	"peluqueria/internal/dtos"
+	"peluqueria/internal/events"
+	"peluqueria/internal/helpers"
+	"peluqueria/internal/models"
+	"peluqueria/logger"
+	"slices"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// freedSlot es el horario que dejó libre un turno cancelado, movido o eliminado.
+type freedSlot struct {
+	AppointmentID uint
+	StaffID       *uint
+	Start         time.Time
+	End           time.Time
+}
+
+// movedFrom indica si el turno ya no ocupa el horario: cambió su inicio, su fin o su estilista.
+func (s freedSlot) movedFrom(updated freedSlot) bool {
+	if !s.Start.Equal(updated.Start) || !s.End.Equal(updated.End) {
+		return true
+	}
+	if s.StaffID == nil || updated.StaffID == nil {
+		return s.StaffID != updated.StaffID
+	}
+	return *s.StaffID != *updated.StaffID
+}
+
+func CreateWaitlistEntry(dto dtos.WaitlistEntryDto) error {
+	logger.Log.Infof("[WaitlistService][CreateWaitlistEntry] Agregando cliente ID %d a la lista de espera", dto.ClientID)
+
+	entry := models.WaitlistEntry{Status: models.WaitlistStatusActive}
+	services, err := fillWaitlistEntry(&entry, dto)
+	if err != nil {
+		return err
+	}
+
+	err = database.DB.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(&entry).Error; err != nil {
+			return err
+		}
+		return tx.Model(&entry).Association("Services").Replace(services)
+	})
+	if err != nil {
+		logger.Log.Error("[WaitlistService][CreateWaitlistEntry] Error al crear entrada: ", err)
+		return errors.New("error al agregar a la lista de espera")
+	}
+
+	logger.Log.Infof("[WaitlistService][CreateWaitlistEntry] Entrada creada: ID %d", entry.ID)
+	return nil
+}
+
+func GetAllWaitlistEntries(status string) ([]dtos.GetWaitlistEntryDto, error) {
+	logger.Log.Info("[WaitlistService][GetAllWaitlistEntries] Obteniendo lista de espera")
+
+	var entries []models.WaitlistEntry
+	query := database.DB.Preload("Client").Preload("Staff").Preload("Services").Order("created_at")
+	if status != "" {
+		query = query.Where("status = ?", status)
+	}
+	if err := query.Find(&entries).Error; err != nil {
+		logger.Log.Error("[WaitlistService][GetAllWaitlistEntries] Error al obtener lista de espera: ", err)
+		return nil, errors.New("error al obtener lista de espera")
+	}
+
+	var entryDtos []dtos.GetWaitlistEntryDto
+	for _, entry := range entries {
+		entryDtos = append(entryDtos, toWaitlistEntryDto(entry))
+	}
+
+	logger.Log.Infof("[WaitlistService][GetAllWaitlistEntries] Entradas obtenidas: %d", len(entryDtos))
+	return entryDtos, nil
+}
+
+func GetWaitlistEntryByID(id uint) (dtos.GetWaitlistEntryDto, error) {
+	logger.Log.Infof("[WaitlistService][GetWaitlistEntryByID] Obteniendo entrada ID: %d", id)
+
+	var entry models.WaitlistEntry
+	if err := database.DB.Preload("Client").Preload("Staff").Preload("Services").First(&entry, id).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			logger.Log.Warnf("[WaitlistService][GetWaitlistEntryByID] Entrada no encontrada: ID %d", id)
+			return dtos.GetWaitlistEntryDto{}, errors.New("entrada no encontrada")
+		}
+		logger.Log.Error("[WaitlistService][GetWaitlistEntryByID] Error al obtener entrada: ", err)
+		return dtos.GetWaitlistEntryDto{}, errors.New("error al obtener entrada")
+	}
+
+	return toWaitlistEntryDto(entry), nil
+}
+
+func UpdateWaitlistEntry(id uint, dto dtos.WaitlistEntryDto) error {
+	logger.Log.Infof("[WaitlistService][UpdateWaitlistEntry] Actualizando entrada ID: %d", id)
+
+	var entry models.WaitlistEntry
+	if err := database.DB.Preload("Services").First(&entry, id).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			logger.Log.Warnf("[WaitlistService][UpdateWaitlistEntry] Entrada no encontrada: ID %d", id)
+			return errors.New("la entrada no existe")
+		}
+		logger.Log.Error("[WaitlistService][UpdateWaitlistEntry] Error al buscar entrada: ", err)
+		return errors.New("error al buscar entrada")
+	}
+
+	// Completar con los valores actuales lo que no se envía
+	if dto.ClientID == 0 {
+		dto.ClientID = entry.ClientID
+	}
+	if dto.StaffID == 0 && entry.StaffID != nil {
+		dto.StaffID = *entry.StaffID
+	}
+	if len(dto.ServiceIds) == 0 {
+		for _, service := range entry.Services {
+			dto.ServiceIds = append(dto.ServiceIds, service.ID)
+		}
+	}
+	if dto.DesiredFrom == "" {
+		dto.DesiredFrom = entry.DesiredFrom.Format("02/01/2006")
+	}
+	if dto.DesiredTo == "" {
+		dto.DesiredTo = entry.DesiredTo.Format("02/01/2006")
+	}
+	if dto.PreferredStartTime == "" {
+		dto.PreferredStartTime = entry.PreferredStartTime
+	}
+	if dto.PreferredEndTime == "" {
+		dto.PreferredEndTime = entry.PreferredEndTime
+	}
+	if dto.Notes == "" {
+		dto.Notes = entry.Notes
+	}
+
+	services, err := fillWaitlistEntry(&entry, dto)
+	if err != nil {
+		return err
+	}
+	if dto.Status != "" {
+		if !slices.Contains([]string{models.WaitlistStatusActive, models.WaitlistStatusServed, models.WaitlistStatusCancelled}, dto.Status) {
+			logger.Log.Warnf("[WaitlistService][UpdateWaitlistEntry] Estado inválido: %s", dto.Status)
+			return errors.New("el estado debe ser 'activa', 'atendida' o 'cancelada'")
+		}
+		entry.Status = dto.Status
+	}
+
+	err = database.DB.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Omit("Services").Save(&entry).Error; err != nil {
+			return err
+		}
+		return tx.Model(&entry).Association("Services").Replace(services)
+	})
+	if err != nil {
+		logger.Log.Error("[WaitlistService][UpdateWaitlistEntry] Error al actualizar entrada: ", err)
+		return errors.New("error al actualizar la entrada")
+	}
+
+	logger.Log.Infof("[WaitlistService][UpdateWaitlistEntry] Entrada actualizada: ID %d", id)
+	return nil
+}
+
+func DeleteWaitlistEntry(id uint) error {
+	logger.Log.Infof("[WaitlistService][DeleteWaitlistEntry] Eliminando entrada ID: %d", id)
+
+	if err := database.DB.Delete(&models.WaitlistEntry{}, id).Error; err != nil {
+		logger.Log.Error("[WaitlistService][DeleteWaitlistEntry] Error al eliminar entrada: ", err)
+		return errors.New("error al eliminar la entrada")
+	}
+
+	logger.Log.Infof("[WaitlistService][DeleteWaitlistEntry] Entrada eliminada: ID %d", id)
+	return nil
+}
+
+// GetWaitlistMatches devuelve los horarios liberados aún vigentes con los clientes de la
+// lista de espera a los que les sirven.
+func GetWaitlistMatches() ([]dtos.WaitlistMatchDto, error) {
+	logger.Log.Info("[WaitlistService][GetWaitlistMatches] Obteniendo candidatos de la lista de espera")
+
+	var matches []models.WaitlistMatch
+	if err := database.DB.
+		Preload("WaitlistEntry.Client").
+		Preload("WaitlistEntry.Staff").
+		Preload("WaitlistEntry.Services").
+		Joins("JOIN waitlist_entries ON waitlist_entries.id = waitlist_matches.waitlist_entry_id").
+		Where("waitlist_entries.status = ? AND waitlist_entries.deleted_at IS NULL", models.WaitlistStatusActive).
+		Where("waitlist_matches.dismissed = ? AND waitlist_matches.slot_start > ?", false, time.Now()).
+		Order("waitlist_matches.slot_start").
+		Find(&matches).Error; err != nil {
+		logger.Log.Error("[WaitlistService][GetWaitlistMatches] Error al obtener candidatos: ", err)
+		return nil, errors.New("error al obtener candidatos de la lista de espera")
+	}
+
+	matchDtos := []dtos.WaitlistMatchDto{}
+	for _, match := range matches {
+		matchDtos = append(matchDtos, toWaitlistMatchDto(match))
+	}
+
+	logger.Log.Infof("[WaitlistService][GetWaitlistMatches] Candidatos obtenidos: %d", len(matchDtos))
+	return matchDtos, nil
+}
+
+func DismissWaitlistMatch(id uint) error {
+	logger.Log.Infof("[WaitlistService][DismissWaitlistMatch] Descartando candidato ID: %d", id)
+
+	result := database.DB.Model(&models.WaitlistMatch{}).Where("id = ?", id).Update("dismissed", true)
+	if result.Error != nil {
+		logger.Log.Error("[WaitlistService][DismissWaitlistMatch] Error al descartar candidato: ", result.Error)
+		return errors.New("error al descartar candidato")
+	}
+	if result.RowsAffected == 0 {
+		logger.Log.Warnf("[WaitlistService][DismissWaitlistMatch] Candidato no encontrado: ID %d", id)
+		return errors.New("candidato no encontrado")
+	}
+	return nil
+}
+
+// captureFreedSlot obtiene el horario que ocupa un turno antes de cancelarlo o moverlo.
+func captureFreedSlot(tx *gorm.DB, appointmentID uint) (freedSlot, error) {
+	var appointment models.Appointment
+	if err := tx.Preload("AppointmentServices.Service").First(&appointment, appointmentID).Error; err != nil {
+		logger.Log.Error("[WaitlistService][captureFreedSlot] Error al buscar turno: ", err)
+		return freedSlot{}, errors.New("error al buscar turno")
+	}
+	return freedSlot{
+		AppointmentID: appointment.ID,
+		StaffID:       appointment.StaffID,
+		Start:         appointment.AppointmentDate,
+		End:           appointment.AppointmentDate.Add(appointmentDuration(appointment)),
+	}, nil
+}
+
+// matchWaitlist busca entradas activas de la lista de espera que entren en los horarios
+// liberados. Se ejecuta después de confirmar la transacción; los errores solo se registran.
+func matchWaitlist(slots []freedSlot) {
+	for _, slot := range slots {
+		if err := matchWaitlistSlot(slot); err != nil {
+			logger.Log.Error("[WaitlistService][matchWaitlist] Error al buscar candidatos para turno ID ", slot.AppointmentID, ": ", err)
+		}
+	}
+}
+
+func matchWaitlistSlot(slot freedSlot) error {
+	if !slot.Start.After(time.Now()) {
+		return nil
+	}
+
+	loc, err := helpers.SalonLocation()
+	if err != nil {
+		return err
+	}
+	local := slot.Start.In(loc)
+	dayKey := local.Format("2006-01-02")
+
+	query := database.DB.Preload("Client").Preload("Staff").Preload("Services").
+		Where("status = ? AND desired_from <= ? AND desired_to >= ?", models.WaitlistStatusActive, dayKey, dayKey)
+	if slot.StaffID != nil {
+		query = query.Where("staff_id IS NULL OR staff_id = ?", *slot.StaffID)
+	} else {
+		query = query.Where("staff_id IS NULL")
+	}
+
+	var entries []models.WaitlistEntry
+	if err := query.Order("created_at").Find(&entries).Error; err != nil {
+		return err
+	}
+	if len(entries) == 0 {
+		return nil
+	}
+
+	var busy []timeRange
+	if slot.StaffID != nil {
+		busyByStaff, err := staffBusyIntervals(database.DB, []uint{*slot.StaffID}, slot.Start, slot.End.Add(conflictLookback))
+		if err != nil {
+			return err
+		}
+		busy = busyByStaff[*slot.StaffID]
+	}
+
+	clock := time.Duration(local.Hour())*time.Hour + time.Duration(local.Minute())*time.Minute
+	for _, entry := range entries {
+		blocks, duration := servicePlan(entry.Services)
+		end := slot.Start.Add(duration)
+
+		if !withinPreferredWindow(entry, clock) || !blocksFree(busy, slot.Start, blocks) {
+			continue
+		}
+		if err := checkBusinessHours(database.DB, slot.Start, end); err != nil {
+			continue
+		}
+
+		// La entrada ya fue ofrecida para este horario
+		duplicate := database.DB.Model(&models.WaitlistMatch{}).Where("waitlist_entry_id = ? AND slot_start = ?", entry.ID, slot.Start)
+		if slot.StaffID != nil {
+			duplicate = duplicate.Where("staff_id = ?", *slot.StaffID)
+		} else {
+			duplicate = duplicate.Where("staff_id IS NULL")
+		}
+		var count int64
+		if err := duplicate.Count(&count).Error; err != nil {
+			return err
+		}
+		if count > 0 {
+			continue
+		}
+
+		match := models.WaitlistMatch{
+			WaitlistEntryID:    entry.ID,
+			FreedAppointmentID: slot.AppointmentID,
+			StaffID:            slot.StaffID,
+			SlotStart:          slot.Start,
+			SlotEnd:            end,
+		}
+		if err := database.DB.Create(&match).Error; err != nil {
+			return err
+		}
+		match.WaitlistEntry = entry
+
+		logger.Log.Infof("[WaitlistService][matchWaitlistSlot] Entrada ID %d coincide con el horario liberado por el turno ID %d", entry.ID, slot.AppointmentID)
+		events.Publish(events.WaitlistMatchFound, toWaitlistMatchDto(match))
+	}
+	return nil
+}
+
+func withinPreferredWindow(entry models.WaitlistEntry, clock time.Duration) bool {
+	if entry.PreferredStartTime != "" {
+		if start, err := parseClock(entry.PreferredStartTime); err == nil && clock < start {
+			return false
+		}
+	}
+	if entry.PreferredEndTime != "" {
+		if end, err := parseClock(entry.PreferredEndTime); err == nil && clock > end {
+			return false
+		}
+	}
+	return true
+}
+
+// fillWaitlistEntry valida el DTO y completa la entrada; devuelve los servicios deseados.
+func fillWaitlistEntry(entry *models.WaitlistEntry, dto dtos.WaitlistEntryDto) ([]models.Service, error) {
+	if err := database.DB.Select("id").First(&models.Client{}, dto.ClientID).Error; err != nil {
+		logger.Log.Warnf("[WaitlistService][fillWaitlistEntry] Cliente no encontrado: ID %d", dto.ClientID)
+		return nil, errors.New("cliente no encontrado")
+	}
+
+	entry.StaffID = nil
+	if dto.StaffID != 0 {
+		staff, err := findActiveStaff(database.DB, dto.StaffID)
+		if err != nil {
+			return nil, err
+		}
+		entry.StaffID = &staff.ID
+	}
+
+	if len(dto.ServiceIds) == 0 {
+		logger.Log.Warn("[WaitlistService][fillWaitlistEntry] Servicios faltantes")
+		return nil, errors.New("debe indicar al menos un servicio")
+	}
+	var services []models.Service
+	if err := database.DB.Where("id IN ?", dto.ServiceIds).Find(&services).Error; err != nil {
+		logger.Log.Error("[WaitlistService][fillWaitlistEntry] Error al buscar servicios: ", err)
+		return nil, errors.New("error al buscar servicios")
+	}
+	if len(services) != len(uniqueIDs(dto.ServiceIds)) {
+		logger.Log.Warn("[WaitlistService][fillWaitlistEntry] Uno o más servicios no existen")
+		return nil, errors.New("uno o más servicios no existen")
+	}
+
+	desiredFrom, err := helpers.ParseCustomDay(dto.DesiredFrom)
+	if err != nil {
+		return nil, err
+	}
+	desiredTo, err := helpers.ParseCustomDay(dto.DesiredTo)
+	if err != nil {
+		return nil, err
+	}
+	if desiredTo.Before(desiredFrom) {
+		return nil, errors.New("la fecha hasta debe ser posterior o igual a la fecha desde")
+	}
+
+	for _, value := range []string{dto.PreferredStartTime, dto.PreferredEndTime} {
+		if value == "" {
+			continue
+		}
+		if _, err := parseClock(value); err != nil {
+			return nil, fmt.Errorf("horario preferido inválido: %w", err)
+		}
+	}
+
+	entry.ClientID = dto.ClientID
+	entry.DesiredFrom = dateOnly(desiredFrom)
+	entry.DesiredTo = dateOnly(desiredTo)
+	entry.PreferredStartTime = dto.PreferredStartTime
+	entry.PreferredEndTime = dto.PreferredEndTime
+	entry.Notes = dto.Notes
+	return services, nil
+}
+
+func toWaitlistEntryDto(entry models.WaitlistEntry) dtos.GetWaitlistEntryDto {
+	var services []dtos.AppointmentServiceDto
+	for _, service := range entry.Services {
+		services = append(services, dtos.AppointmentServiceDto{
+			ServiceID:            service.ID,
+			ServiceName:          service.Name,
+			Price:                service.Price,
+			EstimatedTimeMinutes: service.EstimatedTimeMinutes,
+		})
+	}
+
+	return dtos.GetWaitlistEntryDto{
+		ID:                 entry.ID,
+		ClientID:           entry.ClientID,
+		ClientName:         fmt.Sprintf("%s %s", entry.Client.Name, entry.Client.LastName),
+		ClientPhone:        entry.Client.Phone,
+		StaffID:            entry.StaffID,
+		StaffName:          staffFullName(entry.Staff),
+		Services:           services,
+		DesiredFrom:        entry.DesiredFrom.Format("02/01/2006"),
+		DesiredTo:          entry.DesiredTo.Format("02/01/2006"),
+		PreferredStartTime: entry.PreferredStartTime,
+		PreferredEndTime:   entry.PreferredEndTime,
+		Notes:              entry.Notes,
+		Status:             entry.Status,
+	}
+}
+
+func toWaitlistMatchDto(match models.WaitlistMatch) dtos.WaitlistMatchDto {
+	loc, err := helpers.SalonLocation()
+	if err != nil {
+		loc = time.Local
+	}
+	return dtos.WaitlistMatchDto{
+		ID:                 match.ID,
+		FreedAppointmentID: match.FreedAppointmentID,
+		StaffID:            match.StaffID,
+		SlotStart:          match.SlotStart.In(loc).Format("02/01/2006 15:04"),
+		SlotEnd:            match.SlotEnd.In(loc).Format("02/01/2006 15:04"),
+		Entry:              toWaitlistEntryDto(match.WaitlistEntry),
+	}
+}
@@ -0,0 +1,482 @@
+package services
+
+import (
+	"errors"
+	"fmt"
+	"peluqueria/database"
+	"peluqueria/internal/dtos"
+	"peluqueria/internal/events"
+	"peluqueria/internal/helpers"
+	"peluqueria/internal/models"
+	"peluqueria/logger"
+	"slices"
+	"sort"
+	"strings"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// activeWalkInStatuses son los tickets que siguen en la fila.
+var activeWalkInStatuses = []string{models.WalkInStatusWaiting, models.WalkInStatusCalled}
+
+// EnqueueWalkIn saca un ticket para un cliente sin turno. Si no se indica el cliente se
+// busca por teléfono o se crea con el nombre recibido.
+func EnqueueWalkIn(dto dtos.WalkInDto) (dtos.GetWalkInDto, error) {
+	logger.Log.Info("[WalkInService][EnqueueWalkIn] Agregando cliente a la fila")
+
+	if len(dto.ServiceIds) == 0 {
+		logger.Log.Warn("[WalkInService][EnqueueWalkIn] Servicios faltantes")
+		return dtos.GetWalkInDto{}, errors.New("debe indicar al menos un servicio")
+	}
+	var services []models.Service
+	if err := database.DB.Where("id IN ?", dto.ServiceIds).Find(&services).Error; err != nil {
+		logger.Log.Error("[WalkInService][EnqueueWalkIn] Error al buscar servicios: ", err)
+		return dtos.GetWalkInDto{}, errors.New("error al buscar servicios")
+	}
+	if len(services) != len(uniqueIDs(dto.ServiceIds)) {
+		logger.Log.Warn("[WalkInService][EnqueueWalkIn] Uno o más servicios no existen")
+		return dtos.GetWalkInDto{}, errors.New("uno o más servicios no existen")
+	}
+
+	walkIn := models.WalkIn{Status: models.WalkInStatusWaiting}
+	if dto.StaffID != 0 {
+		staff, err := findActiveStaff(database.DB, dto.StaffID)
+		if err != nil {
+			return dtos.GetWalkInDto{}, err
+		}
+		walkIn.StaffID = &staff.ID
+	}
+
+	if dto.ClientID != 0 {
+		if err := database.DB.Select("id").First(&models.Client{}, dto.ClientID).Error; err != nil {
+			logger.Log.Warnf("[WalkInService][EnqueueWalkIn] Cliente no encontrado: ID %d", dto.ClientID)
+			return dtos.GetWalkInDto{}, errors.New("cliente no encontrado")
+		}
+		walkIn.ClientID = dto.ClientID
+	} else {
+		if strings.TrimSpace(dto.Name) == "" {
+			logger.Log.Warn("[WalkInService][EnqueueWalkIn] Cliente o nombre faltante")
+			return dtos.GetWalkInDto{}, errors.New("debe indicar el cliente o su nombre")
+		}
+		client, err := findClientByPhone(dto.Phone)
+		if err != nil {
+			return dtos.GetWalkInDto{}, err
+		}
+		if client == nil {
+			created, err := createContactClient(database.DB, dto.Name, dto.LastName, dto.Phone, "")
+			if err != nil {
+				return dtos.GetWalkInDto{}, err
+			}
+			client = &created
+		}
+		walkIn.ClientID = client.ID
+	}
+
+	today, err := salonToday()
+	if err != nil {
+		return dtos.GetWalkInDto{}, err
+	}
+	walkIn.QueueDate = today
+
+	err = database.DB.Transaction(func(tx *gorm.DB) error {
+		// Bloquear la numeración del día para numerar sin repetir; la fila se crea con el
+		// primer ticket del día y las llegadas simultáneas esperan a que se confirme
+		sequence := models.WalkInSequence{QueueDate: today}
+		if err := tx.Clauses(clause.OnConflict{DoNothing: true}).Create(&sequence).Error; err != nil {
+			return err
+		}
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+			Where("queue_date = ?", today.Format("2006-01-02")).First(&sequence).Error; err != nil {
+			return err
+		}
+		sequence.LastNumber++
+		if err := tx.Model(&models.WalkInSequence{}).Where("queue_date = ?", today.Format("2006-01-02")).
+			Update("last_number", sequence.LastNumber).Error; err != nil {
+			return err
+		}
+		walkIn.TicketNumber = sequence.LastNumber
+
+		var maxPosition int
+		if err := tx.Model(&models.WalkIn{}).
+			Where("queue_date = ? AND status IN ?", today.Format("2006-01-02"), activeWalkInStatuses).
+			Select("COALESCE(MAX(position), 0)").Scan(&maxPosition).Error; err != nil {
+			return err
+		}
+		walkIn.Position = maxPosition + 1
+
+		if err := tx.Omit("Services").Create(&walkIn).Error; err != nil {
+			return err
+		}
+		return tx.Model(&walkIn).Association("Services").Replace(services)
+	})
+	if err != nil {
+		logger.Log.Error("[WalkInService][EnqueueWalkIn] Error al crear ticket: ", err)
+		return dtos.GetWalkInDto{}, errors.New("error al agregar a la fila")
+	}
+
+	logger.Log.Infof("[WalkInService][EnqueueWalkIn] Ticket %d creado para cliente ID %d", walkIn.TicketNumber, walkIn.ClientID)
+	return findWalkInInQueue(walkIn.ID)
+}
+
+// GetWalkInQueue devuelve la fila del día en orden con la espera estimada de cada ticket.
+func GetWalkInQueue() ([]dtos.GetWalkInDto, error) {
+	logger.Log.Info("[WalkInService][GetWalkInQueue] Obteniendo fila")
+
+	queue, err := loadWalkInQueue()
+	if err != nil {
+		return nil, err
+	}
+	waits, err := estimateWalkInWaits(queue, time.Now())
+	if err != nil {
+		return nil, err
+	}
+
+	queueDtos := []dtos.GetWalkInDto{}
+	for _, walkIn := range queue {
+		queueDtos = append(queueDtos, toWalkInDto(walkIn, waits[walkIn.ID]))
+	}
+	return queueDtos, nil
+}
+
+// ReorderWalkIn mueve un ticket a la posición indicada (1 es el primero).
+func ReorderWalkIn(id uint, position int) error {
+	logger.Log.Infof("[WalkInService][ReorderWalkIn] Moviendo ticket ID %d a la posición %d", id, position)
+	if position < 1 {
+		return errors.New("la posición debe ser mayor a cero")
+	}
+
+	today, err := salonToday()
+	if err != nil {
+		return err
+	}
+
+	return database.DB.Transaction(func(tx *gorm.DB) error {
+		var queue []models.WalkIn
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+			Where("queue_date = ? AND status IN ?", today.Format("2006-01-02"), activeWalkInStatuses).
+			Order("position").Find(&queue).Error; err != nil {
+			logger.Log.Error("[WalkInService][ReorderWalkIn] Error al obtener fila: ", err)
+			return errors.New("error al obtener la fila")
+		}
+
+		index := -1
+		for i, walkIn := range queue {
+			if walkIn.ID == id {
+				index = i
+			}
+		}
+		if index == -1 {
+			logger.Log.Warnf("[WalkInService][ReorderWalkIn] Ticket no encontrado en la fila: ID %d", id)
+			return errors.New("el ticket no está en la fila")
+		}
+
+		moved := queue[index]
+		queue = append(queue[:index], queue[index+1:]...)
+		if position > len(queue)+1 {
+			position = len(queue) + 1
+		}
+		queue = append(queue[:position-1], append([]models.WalkIn{moved}, queue[position-1:]...)...)
+
+		for i := range queue {
+			if err := tx.Model(&queue[i]).Update("position", i+1).Error; err != nil {
+				logger.Log.Error("[WalkInService][ReorderWalkIn] Error al actualizar posición: ", err)
+				return errors.New("error al reordenar la fila")
+			}
+		}
+		return nil
+	})
+}
+
+// SkipWalkIn saca de la fila a un ticket que no respondió al llamado.
+func SkipWalkIn(id uint) error {
+	logger.Log.Infof("[WalkInService][SkipWalkIn] Salteando ticket ID %d", id)
+
+	result := database.DB.Model(&models.WalkIn{}).
+		Where("id = ? AND status IN ?", id, activeWalkInStatuses).
+		Update("status", models.WalkInStatusSkipped)
+	if result.Error != nil {
+		logger.Log.Error("[WalkInService][SkipWalkIn] Error al saltear ticket: ", result.Error)
+		return errors.New("error al saltear el ticket")
+	}
+	if result.RowsAffected == 0 {
+		logger.Log.Warnf("[WalkInService][SkipWalkIn] Ticket no encontrado en la fila: ID %d", id)
+		return errors.New("el ticket no está en la fila")
+	}
+	return nil
+}
+
+// CallNextWalkIn llama al primer ticket en espera. Con staffID solo se consideran los
+// tickets sin preferencia o que prefieren a ese estilista.
+func CallNextWalkIn(staffID uint) (dtos.GetWalkInDto, error) {
+	logger.Log.Infof("[WalkInService][CallNextWalkIn] Llamando al siguiente de la fila (estilista ID %d)", staffID)
+
+	today, err := salonToday()
+	if err != nil {
+		return dtos.GetWalkInDto{}, err
+	}
+
+	var next models.WalkIn
+	err = database.DB.Transaction(func(tx *gorm.DB) error {
+		query := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+			Where("queue_date = ? AND status = ?", today.Format("2006-01-02"), models.WalkInStatusWaiting)
+		if staffID != 0 {
+			query = query.Where("staff_id IS NULL OR staff_id = ?", staffID)
+		}
+		if err := query.Order("position").First(&next).Error; err != nil {
+			return err
+		}
+
+		now := time.Now()
+		next.Status = models.WalkInStatusCalled
+		next.CalledAt = &now
+		return tx.Omit("Services").Save(&next).Error
+	})
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			logger.Log.Info("[WalkInService][CallNextWalkIn] No hay clientes esperando")
+			return dtos.GetWalkInDto{}, errors.New("no hay clientes esperando")
+		}
+		logger.Log.Error("[WalkInService][CallNextWalkIn] Error al llamar al siguiente: ", err)
+		return dtos.GetWalkInDto{}, errors.New("error al llamar al siguiente de la fila")
+	}
+
+	logger.Log.Infof("[WalkInService][CallNextWalkIn] Ticket %d llamado", next.TicketNumber)
+	return findWalkInInQueue(next.ID)
+}
+
+// ServeWalkIn atiende un ticket: crea el turno para ahora con sus servicios y lo inicia. El
+// ticket queda bloqueado durante toda la operación, así no se atiende dos veces.
+func ServeWalkIn(id uint, dto dtos.ServeWalkInDto, userID uint) (dtos.GetWalkInDto, error) {
+	logger.Log.Infof("[WalkInService][ServeWalkIn] Atendiendo ticket ID %d", id)
+
+	var walkIn models.WalkIn
+	var appointment models.Appointment
+	err := database.DB.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).Preload("Services").First(&walkIn, id).Error; err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				logger.Log.Warnf("[WalkInService][ServeWalkIn] Ticket no encontrado: ID %d", id)
+				return errors.New("ticket no encontrado")
+			}
+			logger.Log.Error("[WalkInService][ServeWalkIn] Error al buscar ticket: ", err)
+			return errors.New("error al buscar ticket")
+		}
+		if !slices.Contains(activeWalkInStatuses, walkIn.Status) {
+			logger.Log.Warnf("[WalkInService][ServeWalkIn] Ticket en estado %s", walkIn.Status)
+			return fmt.Errorf("%w: el ticket ya está %s", ErrInvalidStatusTransition, walkIn.Status)
+		}
+
+		staffID := walkIn.StaffID
+		if dto.StaffID != 0 {
+			staffID = &dto.StaffID
+		}
+		if staffID != nil {
+			staff, err := findActiveStaff(tx, *staffID)
+			if err != nil {
+				return err
+			}
+			staffID = &staff.ID
+		}
+
+		var serviceIDs []uint
+		for _, service := range walkIn.Services {
+			serviceIDs = append(serviceIDs, service.ID)
+		}
+		selection, err := loadServiceSelection(tx, dtos.ServiceSelectionDto{ServiceIDs: serviceIDs})
+		if err != nil {
+			return err
+		}
+		if _, err := staffServiceTerms(tx, staffID, selection.Services); err != nil {
+			return err
+		}
+
+		// El cliente llegó cuando sacó el ticket
+		checkedInAt := walkIn.CreatedAt
+		appointment = models.Appointment{
+			ClientID:        walkIn.ClientID,
+			StaffID:         staffID,
+			AppointmentDate: time.Now().Truncate(time.Minute),
+			Status:          models.AppointmentStatusPending,
+			CheckedInAt:     &checkedInAt,
+		}
+		if err := createAppointmentTx(tx, &appointment, selection, userID); err != nil {
+			if isScheduleError(err) {
+				return err
+			}
+			logger.Log.Error("[WalkInService][ServeWalkIn] Error al crear turno: ", err)
+			return errors.New("error al crear turno")
+		}
+		if err := changeAppointmentStatus(tx, &appointment, models.AppointmentStatusInProgress, "", userID); err != nil {
+			return err
+		}
+
+		walkIn.Status = models.WalkInStatusServed
+		walkIn.AppointmentID = &appointment.ID
+		walkIn.StaffID = staffID
+		if err := tx.Omit("Services").Save(&walkIn).Error; err != nil {
+			logger.Log.Error("[WalkInService][ServeWalkIn] Error al actualizar ticket: ", err)
+			return errors.New("error al actualizar el ticket")
+		}
+		return nil
+	})
+	if err != nil {
+		logger.Log.Warn("[WalkInService][ServeWalkIn] No se pudo atender el ticket: ", err)
+		return dtos.GetWalkInDto{}, err
+	}
+
+	logger.Log.Infof("[WalkInService][ServeWalkIn] Ticket %d atendido con turno ID %d", walkIn.TicketNumber, appointment.ID)
+	publishAppointmentEvent(events.AppointmentCreated, appointment.ID)
+	if err := database.DB.Preload("Client").Preload("Staff").Preload("Services").First(&walkIn, id).Error; err != nil {
+		return dtos.GetWalkInDto{}, errors.New("error al buscar ticket")
+	}
+	return toWalkInDto(walkIn, 0), nil
+}
+
+// estimateWalkInWaits reparte la fila, en orden, entre los estilistas activos según
+// cuándo se libera cada uno (turnos en curso y agendados) y la duración de los servicios.
+func estimateWalkInWaits(queue []models.WalkIn, now time.Time) (map[uint]time.Duration, error) {
+	waits := make(map[uint]time.Duration)
+
+	var staffIDs []uint
+	if err := database.DB.Model(&models.Staff{}).Where("active = ?", true).Pluck("id", &staffIDs).Error; err != nil {
+		logger.Log.Error("[WalkInService][estimateWalkInWaits] Error al obtener estilistas: ", err)
+		return nil, errors.New("error al obtener estilistas")
+	}
+
+	if len(staffIDs) == 0 {
+		// Sin estilistas cargados se atiende de a uno
+		var ahead time.Duration
+		for _, walkIn := range queue {
+			waits[walkIn.ID] = ahead
+			ahead += walkInDuration(walkIn)
+		}
+		return waits, nil
+	}
+
+	busy, err := staffBusyIntervals(database.DB, staffIDs, now, now.Add(24*time.Hour))
+	if err != nil {
+		return nil, err
+	}
+	freeAt := make(map[uint]time.Time)
+	for _, staffID := range staffIDs {
+		sort.Slice(busy[staffID], func(i, j int) bool { return busy[staffID][i].Start.Before(busy[staffID][j].Start) })
+		freeAt[staffID] = now
+	}
+
+	for _, walkIn := range queue {
+		duration := walkInDuration(walkIn)
+		candidates := staffIDs
+		if walkIn.StaffID != nil {
+			if _, ok := freeAt[*walkIn.StaffID]; ok {
+				candidates = []uint{*walkIn.StaffID}
+			}
+		}
+
+		var bestStaff uint
+		var bestStart time.Time
+		for _, staffID := range candidates {
+			start := earliestFit(busy[staffID], freeAt[staffID], duration)
+			if bestStart.IsZero() || start.Before(bestStart) {
+				bestStaff, bestStart = staffID, start
+			}
+		}
+
+		waits[walkIn.ID] = bestStart.Sub(now)
+		freeAt[bestStaff] = bestStart.Add(duration)
+	}
+	return waits, nil
+}
+
+// earliestFit devuelve el primer inicio desde from en el que entra un servicio de la
+// duración indicada sin pisar los tramos ocupados (ordenados por inicio).
+func earliestFit(busy []timeRange, from time.Time, duration time.Duration) time.Time {
+	start := from
+	for _, interval := range busy {
+		if !interval.End.After(start) {
+			continue
+		}
+		if !start.Add(duration).After(interval.Start) {
+			break
+		}
+		start = interval.End
+	}
+	return start
+}
+
+// walkInDuration calcula la duración igual que la de los turnos.
+func walkInDuration(walkIn models.WalkIn) time.Duration {
+	_, duration := servicePlan(walkIn.Services)
+	return duration
+}
+
+func loadWalkInQueue() ([]models.WalkIn, error) {
+	today, err := salonToday()
+	if err != nil {
+		return nil, err
+	}
+
+	var queue []models.WalkIn
+	if err := database.DB.Preload("Client").Preload("Staff").Preload("Services").
+		Where("queue_date = ? AND status IN ?", today.Format("2006-01-02"), activeWalkInStatuses).
+		Order("position").Find(&queue).Error; err != nil {
+		logger.Log.Error("[WalkInService][loadWalkInQueue] Error al obtener fila: ", err)
+		return nil, errors.New("error al obtener la fila")
+	}
+	return queue, nil
+}
+
+// findWalkInInQueue devuelve un ticket activo con su espera estimada.
+func findWalkInInQueue(id uint) (dtos.GetWalkInDto, error) {
+	queue, err := GetWalkInQueue()
+	if err != nil {
+		return dtos.GetWalkInDto{}, err
+	}
+	for _, walkIn := range queue {
+		if walkIn.ID == id {
+			return walkIn, nil
+		}
+	}
+	return dtos.GetWalkInDto{}, errors.New("el ticket no está en la fila")
+}
+
+// salonToday devuelve la fecha de hoy en la zona horaria del salón.
+func salonToday() (time.Time, error) {
+	loc, err := helpers.SalonLocation()
+	if err != nil {
+		return time.Time{}, err
+	}
+	return dateOnly(time.Now().In(loc)), nil
+}
+
+func toWalkInDto(walkIn models.WalkIn, wait time.Duration) dtos.GetWalkInDto {
+	var services []dtos.AppointmentServiceDto
+	for _, service := range walkIn.Services {
+		services = append(services, dtos.AppointmentServiceDto{
+			ServiceID:            service.ID,
+			ServiceName:          service.Name,
+			Price:                service.Price,
+			EstimatedTimeMinutes: service.EstimatedTimeMinutes,
+		})
+	}
+
+	loc, err := helpers.SalonLocation()
+	if err != nil {
+		loc = time.Local
+	}
+
+	return dtos.GetWalkInDto{
+		ID:                   walkIn.ID,
+		TicketNumber:         walkIn.TicketNumber,
+		Position:             walkIn.Position,
+		ClientID:             walkIn.ClientID,
+		ClientName:           strings.TrimSpace(fmt.Sprintf("%s %s", walkIn.Client.Name, walkIn.Client.LastName)),
+		StaffID:              walkIn.StaffID,
+		StaffName:            staffFullName(walkIn.Staff),
+		Services:             services,
+		Status:               walkIn.Status,
+		EstimatedWaitMinutes: uint(wait.Minutes()),
+		AppointmentID:        walkIn.AppointmentID,
+		CreatedAt:            walkIn.CreatedAt.In(loc).Format("02/01/2006 15:04"),
+	}
+}
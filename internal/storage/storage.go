@@ -0,0 +1,28 @@
+package storage
+
+import (
+	"context"
+	"errors"
+	"io"
+	"os"
+)
+
+// ErrNotFound indica que no existe un archivo con esa clave.
+var ErrNotFound = errors.New("archivo no encontrado")
+
+// Storage guarda archivos bajo una clave relativa, como "turnos/12/foto.jpg".
+type Storage interface {
+	Save(ctx context.Context, key string, r io.Reader) error
+	Open(ctx context.Context, key string) (io.ReadCloser, error)
+	Delete(ctx context.Context, key string) error
+}
+
+// FromEnv arma el almacenamiento configurado. Por ahora solo hay disco local, en
+// STORAGE_DIR o en "uploads" si no está definido.
+func FromEnv() Storage {
+	root := os.Getenv("STORAGE_DIR")
+	if root == "" {
+		root = "uploads"
+	}
+	return &LocalStorage{Root: root}
+}
@@ -0,0 +1,24 @@
+package middlewares
+
+import (
+	"net/http"
+	"peluqueria/logger"
+
+	"github.com/labstack/echo/v4"
+)
+
+// BodyLimit rechaza las solicitudes que declaran un cuerpo mayor a maxBytes antes de
+// leerlo, y corta la lectura de las que lo superan sin declararlo.
+func BodyLimit(maxBytes int64) echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			req := c.Request()
+			if req.ContentLength > maxBytes {
+				logger.Log.Warnf("Cuerpo de la solicitud demasiado grande: %d bytes", req.ContentLength)
+				return respondError(c, http.StatusRequestEntityTooLarge, "La solicitud supera el tamaño permitido")
+			}
+			req.Body = http.MaxBytesReader(c.Response(), req.Body, maxBytes)
+			return next(c)
+		}
+	}
+}
@@ -81,6 +81,20 @@ func JWTMiddleware(next echo.HandlerFunc) echo.HandlerFunc {
 	}
 }
 
+// EventStreamTokenMiddleware acepta el token JWT en el parámetro access_token, porque
+// EventSource no permite enviar headers. Se usa solo en el flujo de eventos, antes de
+// JWTMiddleware.
+func EventStreamTokenMiddleware(next echo.HandlerFunc) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		if c.Request().Header.Get("Authorization") == "" {
+			if token := c.QueryParam("access_token"); token != "" {
+				c.Request().Header.Set("Authorization", "Bearer "+token)
+			}
+		}
+		return next(c)
+	}
+}
+
 // PermissionMiddleware verifica si el rol del usuario tiene el permiso requerido.
 func PermissionMiddleware(permission string) echo.MiddlewareFunc {
 	return func(next echo.HandlerFunc) echo.HandlerFunc {
@@ -88,7 +102,7 @@ func PermissionMiddleware(permission string) echo.MiddlewareFunc {
 			roleID := c.Get("role_id").(uint)
 
 			// Verificar si el rol tiene el permiso
-			allowed, err := hasPermission(roleID, permission)
+			allowed, err := HasPermission(roleID, permission)
 			if err != nil {
 				logger.Log.Error("Error al verificar permisos para RoleID: ", roleID, ", Permission: ", permission, ", Error: ", err)
 				return respondError(c, http.StatusInternalServerError, "Error al verificar permisos")
@@ -105,8 +119,8 @@ func PermissionMiddleware(permission string) echo.MiddlewareFunc {
 	}
 }
 
-// hasPermission verifica si un rol tiene un permiso específico.
-func hasPermission(roleID uint, permission string) (bool, error) {
+// HasPermission verifica si un rol tiene un permiso específico.
+func HasPermission(roleID uint, permission string) (bool, error) {
 	var count int64
 	err := database.DB.Table("role_permissions").
 		Joins("JOIN permissions ON role_permissions.permission_id = permissions.id").
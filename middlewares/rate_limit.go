@@ -0,0 +1,56 @@
+package middlewares
+
+import (
+	"net/http"
+	"peluqueria/logger"
+	"sync"
+	"time"
+
+	"github.com/labstack/echo/v4"
+)
+
+type rateWindow struct {
+	start time.Time
+	count int
+}
+
+// RateLimiter limita la cantidad de solicitudes por IP dentro de cada ventana de tiempo.
+// El conteo se guarda en memoria, por instancia del servidor.
+func RateLimiter(limit int, window time.Duration) echo.MiddlewareFunc {
+	var mu sync.Mutex
+	clients := make(map[string]*rateWindow)
+	lastCleanup := time.Now()
+
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			ip := c.RealIP()
+			now := time.Now()
+
+			mu.Lock()
+			// Descartar las ventanas vencidas para que el mapa no crezca sin límite
+			if now.Sub(lastCleanup) > window {
+				for key, w := range clients {
+					if now.Sub(w.start) > window {
+						delete(clients, key)
+					}
+				}
+				lastCleanup = now
+			}
+
+			w, ok := clients[ip]
+			if !ok || now.Sub(w.start) > window {
+				w = &rateWindow{start: now}
+				clients[ip] = w
+			}
+			w.count++
+			allowed := w.count <= limit
+			mu.Unlock()
+
+			if !allowed {
+				logger.Log.Warnf("Límite de solicitudes excedido para IP: %s", ip)
+				return respondError(c, http.StatusTooManyRequests, "Demasiadas solicitudes, intente nuevamente en unos minutos")
+			}
+			return next(c)
+		}
+	}
+}